@@ -7,9 +7,10 @@ import (
 
 	config "backend-service/config/core_backend"
 
+	"backend-service/internal/core_backend/infrastructure/blockchain"
 	"backend-service/internal/core_backend/infrastructure/callers"
 	"backend-service/internal/core_backend/infrastructure/connections"
-	"backend-service/internal/core_backend/infrastructure/firebase"
+	"backend-service/internal/core_backend/infrastructure/identity"
 	"backend-service/internal/core_backend/infrastructure/router"
 	"backend-service/internal/core_backend/infrastructure/storage"
 	"backend-service/internal/core_backend/registry"
@@ -21,17 +22,29 @@ func main() {
 	mongo := connections.NewMongo()
 	v := validator.New()
 	c := callers.NewCaller()
-	fb, err := firebase.NewFirebaseClient(config.C.Firebase.FirebaseProjectID)
+	ip, err := identity.NewProvider()
 	if err != nil {
-		log.Fatalln("Failed to Initialize Firebase")
+		log.Fatalln("Failed to Initialize Identity Provider: " + err.Error())
 	}
 	gs := storage.NewGCPClient()
-	rg := registry.NewInteractor(mongo, v, c, fb, gs)
+	chains, err := blockchain.NewChainPool(config.Chains)
+	if err != nil {
+		log.Fatalln("Failed to Initialize Chains: " + err.Error())
+	}
+	rg := registry.NewInteractor(mongo, v, c, ip, gs, chains)
+	if err = rg.EnsureIndexes(); err != nil {
+		log.Fatalln("Failed to Create Indexes: " + err.Error())
+	}
 	mdw := rg.NewMiddlewareServices()
 	h := rg.NewAppHandler()
 
-	// nftService := rg.NewNFTGlobalService()
-	// go nftService.DeployContract()
+	nftService := rg.NewNFTGlobalService()
+	if err = nftService.VerifyCollectionChains(); err != nil {
+		log.Fatalln(err)
+	}
+	go nftService.ResumePendingDeployments()
+	go rg.NewWalletGlobalService().RunReconciliation()
+	go rg.NewStoryGlobalService().RunStoryPublisher()
 	// go nftService.ListenEvent()
 
 	router.Initialize(h, mdw)
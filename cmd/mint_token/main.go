@@ -0,0 +1,95 @@
+// Command mint_token prints an ID token accepted by the local identity provider (IDENTITY_PROVIDER=local),
+// signed with LOCAL_JWT_SECRET, to call the API without Firebase.
+//
+// Roles are only granted by role bindings, the role claim of a token is a copy of them. With -role the
+// binding of the user is also stored in the database, which is how the first SUPER_ADMIN is bootstrapped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/connections"
+	"backend-service/internal/core_backend/infrastructure/identity"
+	"backend-service/internal/core_backend/infrastructure/repository"
+)
+
+func main() {
+	userID := flag.String("user", "local-user", "user ID, the subject of the token")
+	email := flag.String("email", "", "email claim")
+	name := flag.String("name", "", "name claim")
+	role := flag.String("role", "", "role granted to the user, e.g. SUPER_ADMIN or ORG_ADMIN, stored as a role binding")
+	org := flag.String("org", "", "organization the role is granted in, the name tag of the organization, every organization when empty")
+	ttl := flag.Duration("ttl", time.Hour, "validity of the token")
+	flag.Parse()
+
+	config.LoadConfig()
+	provider, err := identity.NewLocalProviderFromConfig()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if *role != "" {
+		if err = grantRole(*userID, *role, *org); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	token, err := provider.MintToken(identity.Token{
+		UserID:        *userID,
+		Email:         *email,
+		EmailVerified: *email != "",
+		Name:          *name,
+		Role:          *role,
+		Organization:  *org,
+	}, *ttl)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Println(token)
+}
+
+// grantRole stores the role binding of the user, granting it again is a no-op
+func grantRole(userID string, roleName string, orgTagName string) error {
+	db := connections.NewMongo()
+
+	var orgID primitive.ObjectID
+	if orgTagName != "" {
+		org, err := repository.NewOrganizationRepository(db).GetOrgByTagName(&orgTagName)
+		if err != nil {
+			return err
+		}
+		if org == nil {
+			return fmt.Errorf("organization %q not found", orgTagName)
+		}
+		orgID = org.ID
+	}
+
+	roles := repository.NewRoleRepository(db)
+	if _, ok := entity.SystemRoles[roleName]; !ok {
+		custom, err := roles.GetRoleByName(orgID, &roleName)
+		if err != nil {
+			return err
+		}
+		if custom == nil {
+			return fmt.Errorf("role %q not found", roleName)
+		}
+	}
+	if roleName == string(entity.SUPER_ADMIN_ROLE) && !orgID.IsZero() {
+		return fmt.Errorf("%s is granted in every organization, drop -org", roleName)
+	}
+
+	binding, err := roles.UpsertRoleBinding(&entity.RoleBinding{UserID: userID, OrganizationID: orgID, RoleName: roleName})
+	if err != nil {
+		return err
+	}
+	log.Printf("Role %s granted to %s (binding %s)", roleName, userID, binding.ID.Hex())
+
+	return nil
+}
@@ -1,13 +1,23 @@
 package config
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 
 	"backend-service/internal/core_backend/common/logger"
 )
 
+// EnvProd the APP_ENV of production deployments
+const EnvProd = "production"
+
 type config struct {
+	App struct {
+		// ENV the environment deployed to, e.g. dev or production
+		ENV string `env:"APP_ENV"`
+	}
 	Server struct {
 		SimultaneousConnection int `env:"SIMULTANEOUS_CONNECTION" envDefault:"50"`
 		SessionTimeoutInSecond int `env:"SESSION_TIMEOUT_IN_SECOND" envDefault:"10"`
@@ -22,39 +32,132 @@ type config struct {
 		AuthenticateNFCDomain string `env:"AUTHENTICATE_NFC_DOMAIN"`
 		WebpageDomain         string `env:"WEBPAGE_DOMAIN"`
 		ScanErrorPage         string `env:"SCAN_ERROR_PAGE"`
+		BackendDomain         string `env:"BACKEND_DOMAIN"`
 	}
 	Firebase struct {
 		FirebaseProjectID string `env:"FIREBASE_PROJECT_ID"`
 	}
+	Identity struct {
+		// IDENTITY_PROVIDER firebase, or local to accept JWTs signed with LOCAL_JWT_SECRET (HS256) or a key of LOCAL_JWT_JWKS (RS256).
+		// local is refused when APP_ENV is production.
+		IDENTITY_PROVIDER string `env:"IDENTITY_PROVIDER" env-default:"firebase"`
+		// LOCAL_JWT_SECRET at least 32 bytes
+		LOCAL_JWT_SECRET string `env:"LOCAL_JWT_SECRET"`
+		// LOCAL_JWT_JWKS path or URL of the JSON Web Key Set, read once at startup
+		LOCAL_JWT_JWKS     string `env:"LOCAL_JWT_JWKS"`
+		LOCAL_JWT_ISSUER   string `env:"LOCAL_JWT_ISSUER"`
+		LOCAL_JWT_AUDIENCE string `env:"LOCAL_JWT_AUDIENCE"`
+	}
+	Account struct {
+		// DELETION_CLAIM_POLICY what happens to the items a deleted user claimed: release makes them claimable again,
+		// reassign gives them to the owner of the item's organization
+		DELETION_CLAIM_POLICY string `env:"ACCOUNT_DELETION_CLAIM_POLICY" env-default:"release"`
+	}
+	Siwe struct {
+		// DOMAIN the host (and port) wallets sign the EIP-4361 message for, e.g. app.example.com
+		DOMAIN string `env:"SIWE_DOMAIN"`
+		// URI the origin the message URI must be on, e.g. https://app.example.com
+		URI string `env:"SIWE_URI"`
+		// CHAIN_IDS the comma separated chain IDs wallets may sign the message on
+		CHAIN_IDS           []int64 `env:"SIWE_CHAIN_IDS" env-separator:","`
+		NONCE_TTL_IN_SECOND int     `env:"SIWE_NONCE_TTL_IN_SECOND" env-default:"300"`
+	}
 	GCP struct {
 		StorageBucketName string `env:"STORAGE_BUCKET_NAME"`
 		StorageProjectID  string `env:"STORAGE_PROJECT_ID"`
 		StorageDomain     string `env:"GCP_STORAGE_DOMAIN"`
 		StorageImagePath  string `env:"GCP_STORAGE_IMAGE_PATH"`
 	}
+	Story struct {
+		// STORAGE_PATH the folder of the bucket stories are exported to, <path>/<product item id>/<language>.json and .html
+		STORAGE_PATH               string `env:"STORY_STORAGE_PATH" env-default:"stories"`
+		REBUILD_INTERVAL_IN_SECOND int    `env:"STORY_REBUILD_INTERVAL_IN_SECOND" env-default:"60"`
+		BATCH_SIZE                 int    `env:"STORY_BATCH_SIZE" env-default:"200"`
+		CACHE_MAX_AGE              int    `env:"STORY_CACHE_MAX_AGE" env-default:"300"`
+		// CHANGE_OVERLAP_IN_SECOND changes are looked for again this long before the last detection, to catch writes
+		// committed late or stamped by a clock behind. Their stories may be built once more.
+		CHANGE_OVERLAP_IN_SECOND int `env:"STORY_CHANGE_OVERLAP_IN_SECOND" env-default:"30"`
+		// REDIRECT_SCANS scans of items whose story is exported redirect to the exported HTML page
+		REDIRECT_SCANS bool `env:"STORY_REDIRECT_SCANS" env-default:"false"`
+	}
 	Pubsub struct {
-		AuthenToken string `env:"PUBSUB_AUTHTOKEN_TOKEN"`
+		// Push requests carry an OIDC token of PUBSUB_OIDC_SERVICE_ACCOUNT_EMAIL issued for PUBSUB_OIDC_AUDIENCE,
+		// or an HMAC-SHA256 of the body with PUBSUB_HMAC_SECRET in the X-Pubsub-Signature header
+		OIDC_AUDIENCE              string `env:"PUBSUB_OIDC_AUDIENCE"`
+		OIDC_SERVICE_ACCOUNT_EMAIL string `env:"PUBSUB_OIDC_SERVICE_ACCOUNT_EMAIL"`
+		OIDC_ISSUERS               string `env:"PUBSUB_OIDC_ISSUERS" env-default:"https://accounts.google.com,accounts.google.com"`
+		OIDC_JWKS_URL              string `env:"PUBSUB_OIDC_JWKS_URL" env-default:"https://www.googleapis.com/oauth2/v3/certs"`
+		HMAC_SECRET                string `env:"PUBSUB_HMAC_SECRET"`
+		// REPLAY_WINDOW_IN_SECOND messages published earlier are refused, message IDs are remembered this long
+		REPLAY_WINDOW_IN_SECOND int `env:"PUBSUB_REPLAY_WINDOW_IN_SECOND" env-default:"86400"`
 	}
 	Cheat struct {
 		ProductID string `env:"PRODUCT_ID"`
 	}
 	NFT struct {
 		BASE_NET_URL                    string `env:"BASE_NET_URL"`
-		PRIVATE_KEY                     string `env:"PRIVATE_KEY"`
+		CHAINS_CONFIG_FILE              string `env:"CHAINS_CONFIG_FILE"`
+		CONTRACT_ARTIFACTS_DIR          string `env:"CONTRACT_ARTIFACTS_DIR" env-default:"internal/core_backend/contracts/artifacts"`
 		WATCH_TRANSACTION_MAX_RETRY     int    `env:"WATCH_TRANSACTION_MAX_RETRY"`
 		WATCH_TRANSACTION_INTERVAL_TIME int    `env:"WATCH_TRANSACTION_INTERVAL_TIME"`
+		METADATA_CACHE_MAX_AGE          int    `env:"METADATA_CACHE_MAX_AGE" env-default:"3600"`
+		UNREVEALED_CACHE_MAX_AGE        int    `env:"UNREVEALED_CACHE_MAX_AGE" env-default:"60"`
+	}
+	Signer struct {
+		// SIGNERS_CONFIG_FILE the signers collections can be deployed with, by name
+		SIGNERS_CONFIG_FILE      string `env:"SIGNERS_CONFIG_FILE"`
+		KEYSTORE_DIR             string `env:"SIGNER_KEYSTORE_DIR"`
+		DEFAULT_KEYSTORE_FILE    string `env:"SIGNER_DEFAULT_KEYSTORE_FILE"`
+		DEFAULT_PASSPHRASE_FILE  string `env:"SIGNER_DEFAULT_PASSPHRASE_FILE"`
+		REMOTE_AUTH_TOKEN        string `env:"SIGNER_REMOTE_AUTH_TOKEN"`
+		REMOTE_TIMEOUT_IN_SECOND int    `env:"SIGNER_REMOTE_TIMEOUT_IN_SECOND" env-default:"10"`
 	}
 	Wallet struct {
-		WALLET_CLIENT_ID   string `env:"WALLET_CLIENT_ID"`
-		WALLET_CLIENT_KEY  string `env:"WALLET_CLIENT_KEY"`
-		WALLET_DOMAIN_V1   string `env:"WALLET_DOMAIN_V1"`
-		WALLET_API_TIMEOUT int    `env:"WALLET_API_TIMEOUT"`
+		WALLET_PROVIDER                     string `env:"WALLET_PROVIDER" env-default:"http"`
+		WALLET_CLIENT_ID                    string `env:"WALLET_CLIENT_ID"`
+		WALLET_CLIENT_KEY                   string `env:"WALLET_CLIENT_KEY"`
+		WALLET_DOMAIN_V1                    string `env:"WALLET_DOMAIN_V1"`
+		WALLET_API_TIMEOUT                  int    `env:"WALLET_API_TIMEOUT" env-default:"10"`
+		WALLET_API_MAX_RETRY                int    `env:"WALLET_API_MAX_RETRY" env-default:"3"`
+		WALLET_API_RETRY_DELAY_IN_MS        int    `env:"WALLET_API_RETRY_DELAY_IN_MS" env-default:"500"`
+		WALLET_RECONCILE_INTERVAL_IN_SECOND int    `env:"WALLET_RECONCILE_INTERVAL_IN_SECOND" env-default:"0"`
+		WALLET_RECONCILE_BATCH_SIZE         int    `env:"WALLET_RECONCILE_BATCH_SIZE" env-default:"50"`
+		WALLET_RECONCILE_MAX_ATTEMPTS       int    `env:"WALLET_RECONCILE_MAX_ATTEMPTS" env-default:"5"`
 	}
 }
 
+// ChainConfig configuration of one EVM network, listed in the CHAINS_CONFIG_FILE
+type ChainConfig struct {
+	ChainID       int64  `yaml:"chain_id"`
+	Name          string `yaml:"name"`
+	RPCURL        string `yaml:"rpc_url"`
+	Confirmations uint64 `yaml:"confirmations"`
+	// EIP1559 sends dynamic fee transactions, otherwise legacy gas price transactions
+	EIP1559            bool    `yaml:"eip1559"`
+	GasPriceMultiplier float64 `yaml:"gas_price_multiplier"`
+	MaxPriorityFeeGwei float64 `yaml:"max_priority_fee_gwei"`
+}
+
+// SignerConfig a signer holding a minting key, listed in the SIGNERS_CONFIG_FILE. Deployments name the signer
+// they use, its keystore files and endpoint never come from a request.
+type SignerConfig struct {
+	Name           string `yaml:"name"`
+	Type           string `yaml:"type"`
+	Address        string `yaml:"address"`
+	KeystoreFile   string `yaml:"keystore_file"`
+	PassphraseFile string `yaml:"passphrase_file"`
+	RemoteURL      string `yaml:"remote_url"`
+}
+
 // C config struct
 var C config
 
+// Chains configured EVM networks
+var Chains []ChainConfig
+
+// Signers configured signers, collections without one use the default keystore
+var Signers []SignerConfig
+
 // LoadConfig load config from environment and parse to struct
 func LoadConfig() {
 	err := godotenv.Load()
@@ -72,5 +175,85 @@ func LoadConfig() {
 		return
 	}
 
+	// A broken chains or signers file would otherwise leave the service minting with the wrong configuration
+	if Chains, err = loadChains(); err != nil {
+		log.Fatalln("Chains config parsing failed: " + err.Error())
+	}
+	if Signers, err = loadSigners(); err != nil {
+		log.Fatalln("Signers config parsing failed: " + err.Error())
+	}
+
 	logger.LogSuccess("Load Config Successfully!")
 }
+
+// loadChains reads the chains file, or falls back to the single BASE_NET_URL network whose chain ID is discovered at startup
+func loadChains() ([]ChainConfig, error) {
+	if C.NFT.CHAINS_CONFIG_FILE == "" {
+		return []ChainConfig{{
+			Name:               "default",
+			RPCURL:             C.NFT.BASE_NET_URL,
+			GasPriceMultiplier: 1,
+		}}, nil
+	}
+
+	return parseChains(C.NFT.CHAINS_CONFIG_FILE)
+}
+
+// parseChains reads and checks a chains file, every chain needs a name, an RPC URL and a chain ID of its own
+func parseChains(path string) ([]ChainConfig, error) {
+	var chainsFile struct {
+		Chains []ChainConfig `yaml:"chains"`
+	}
+	if err := cleanenv.ReadConfig(path, &chainsFile); err != nil {
+		return nil, err
+	}
+	if len(chainsFile.Chains) == 0 {
+		return nil, fmt.Errorf("%s lists no chains", path)
+	}
+
+	seen := map[int64]bool{}
+	for i, chain := range chainsFile.Chains {
+		switch {
+		case chain.Name == "":
+			return nil, fmt.Errorf("chain %d has no name", i+1)
+		case chain.RPCURL == "":
+			return nil, fmt.Errorf("chain %s has no rpc_url", chain.Name)
+		case chain.ChainID <= 0:
+			return nil, fmt.Errorf("chain %s has no chain_id", chain.Name)
+		case seen[chain.ChainID]:
+			return nil, fmt.Errorf("chain ID %d is configured twice", chain.ChainID)
+		case chain.GasPriceMultiplier < 0 || chain.MaxPriorityFeeGwei < 0:
+			return nil, fmt.Errorf("chain %s has a negative gas price setting", chain.Name)
+		}
+		seen[chain.ChainID] = true
+	}
+
+	return chainsFile.Chains, nil
+}
+
+// loadSigners reads the signers file, without one only the default keystore signs
+func loadSigners() ([]SignerConfig, error) {
+	if C.Signer.SIGNERS_CONFIG_FILE == "" {
+		return nil, nil
+	}
+
+	var signersFile struct {
+		Signers []SignerConfig `yaml:"signers"`
+	}
+	if err := cleanenv.ReadConfig(C.Signer.SIGNERS_CONFIG_FILE, &signersFile); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for i, signer := range signersFile.Signers {
+		if signer.Name == "" {
+			return nil, fmt.Errorf("signer %d has no name", i+1)
+		}
+		if seen[signer.Name] {
+			return nil, fmt.Errorf("signer %s is configured twice", signer.Name)
+		}
+		seen[signer.Name] = true
+	}
+
+	return signersFile.Signers, nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeChains(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "chains.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestParseChainsExample(t *testing.T) {
+	chains, err := parseChains("chains.example.yml")
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+
+	assert.Equal(t, ChainConfig{
+		ChainID:            80001,
+		Name:               "mumbai",
+		RPCURL:             "wss://polygon-mumbai.example/ws",
+		Confirmations:      3,
+		EIP1559:            true,
+		MaxPriorityFeeGwei: 30,
+	}, chains[0])
+	assert.Equal(t, int64(56), chains[1].ChainID)
+	assert.Equal(t, 1.1, chains[1].GasPriceMultiplier)
+	assert.False(t, chains[1].EIP1559)
+}
+
+func TestParseChainsRejectsBadFiles(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		err     string
+	}{
+		"no chains":     {"chains: []\n", "lists no chains"},
+		"no name":       {"chains:\n  - chain_id: 1\n    rpc_url: https://rpc.example\n", "chain 1 has no name"},
+		"no rpc url":    {"chains:\n  - chain_id: 1\n    name: main\n", "chain main has no rpc_url"},
+		"no chain id":   {"chains:\n  - name: main\n    rpc_url: https://rpc.example\n", "chain main has no chain_id"},
+		"negative fees": {"chains:\n  - chain_id: 1\n    name: main\n    rpc_url: https://rpc.example\n    gas_price_multiplier: -1\n", "negative gas price"},
+		"duplicate chain id": {
+			"chains:\n  - chain_id: 1\n    name: main\n    rpc_url: https://a.example\n  - chain_id: 1\n    name: copy\n    rpc_url: https://b.example\n",
+			"chain ID 1 is configured twice",
+		},
+		"not yaml": {"chains: [\n", ""},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseChains(writeChains(t, c.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), c.err)
+		})
+	}
+
+	_, err := parseChains(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+}
+
+func TestLoadChainsFallsBackToBaseNet(t *testing.T) {
+	previous := C
+	t.Cleanup(func() { C = previous })
+	C.NFT.CHAINS_CONFIG_FILE = ""
+	C.NFT.BASE_NET_URL = "https://rpc.example"
+
+	chains, err := loadChains()
+	require.NoError(t, err)
+	assert.Equal(t, []ChainConfig{{Name: "default", RPCURL: "https://rpc.example", GasPriceMultiplier: 1}}, chains)
+}
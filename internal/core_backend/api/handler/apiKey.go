@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/api/presenter"
+	"backend-service/internal/core_backend/entity"
+	validation "backend-service/internal/core_backend/infrastructure/validator"
+	"backend-service/internal/core_backend/usecase/apiKey"
+)
+
+// APIKeyHandler interface
+type APIKeyHandler interface {
+	GetAPIKeys(*gin.Context) APIResponse
+	CreateAPIKey(*gin.Context) APIResponse
+	RevokeAPIKey(*gin.Context) APIResponse
+}
+
+// apiKeyHandler struct
+type apiKeyHandler struct {
+	APIKeyService apiKey.UseCase
+	Validator     validation.CustomValidator
+}
+
+// NewAPIKeyHandler create handler
+func NewAPIKeyHandler(as apiKey.UseCase, v validation.CustomValidator) APIKeyHandler {
+	return &apiKeyHandler{
+		APIKeyService: as,
+		Validator:     v,
+	}
+}
+
+// GetAPIKeys	godoc
+// GetAPIKeys	API
+//
+//	@Summary		Get API keys
+//	@Description	List the API keys of the organizations the admin manages, revoked ones included. Key values are never returned.
+//	@Tags			api-key
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/api-key [get]
+//	@Success		200	{object}	APIResponse{result=[]entity.APIKey}
+//	@Failure		500	{object}	APIResponse
+func (h *apiKeyHandler) GetAPIKeys(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+
+	keys, code, err := h.APIKeyService.GetAPIKeys(scope)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", keys)
+}
+
+// CreateAPIKey	godoc
+// CreateAPIKey	API
+//
+//	@Summary		Create API key
+//	@Description	Create an API key of an organization for server to server integrations. Send it in the X-API-Key header. The key value is only returned by this call.
+//	@Tags			api-key
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/api-key [post]
+//	@Param			request	body		request.CreateAPIKeyRequest	true	"Create API Key Request"
+//	@Success		200		{object}	APIResponse{result=presenter.CreateAPIKeyResponse}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+func (h *apiKeyHandler) CreateAPIKey(c *gin.Context) APIResponse {
+	var req request.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if err := h.Validator.Validate(req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	user, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+	grants, err := GetRoleGrantsFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+	orgID, code, err := ResolveOrganizationID(scope, req.OrganizationID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	key, plain, code, err := h.APIKeyService.CreateAPIKey(grants, &entity.APIKey{
+		OrganizationID:  orgID,
+		Name:            req.Name,
+		Permissions:     req.Permissions,
+		CreatedByUserID: user.ID,
+		ExpiresAt:       req.ExpiresAt,
+	})
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", presenter.CreateAPIKeyResponse{APIKey: key, Key: plain})
+}
+
+// RevokeAPIKey	godoc
+// RevokeAPIKey	API
+//
+//	@Summary		Revoke API key
+//	@Description	Revoke an API key, requests using it are refused from then on
+//	@Tags			api-key
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/api-key/{api_key_id} [delete]
+//	@Param			api_key_id	path		string	true	"API Key ID"
+//	@Success		200			{object}	APIResponse{result=bool}
+//	@Failure		400			{object}	APIResponse
+//	@Failure		404			{object}	APIResponse
+func (h *apiKeyHandler) RevokeAPIKey(c *gin.Context) APIResponse {
+	req := request.RevokeAPIKeyRequest{APIKeyID: c.Param("api_key_id")}
+	if err := h.Validator.Validate(req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+
+	ok, code, err := h.APIKeyService.RevokeAPIKey(scope, &req.APIKeyID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", ok)
+}
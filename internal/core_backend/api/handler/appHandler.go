@@ -7,8 +7,10 @@ import (
 	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/common/logger"
 	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // AppHandler app handler
@@ -23,11 +25,17 @@ type AppHandler struct {
 	TemplateHandler
 	WebPageHandler
 	ProductHandler
+	ProductTypeHandler
 	OrganizationHandler
 	SessionHandler
 	UploadHandler
 	PubsubHandler
 	AuthorHandler
+	RoleHandler
+	APIKeyHandler
+	SiteHandler
+	StoryHandler
+	ClaimHandler
 }
 
 func CreateResponse(err error, code int, xRequestID string, errorMessage string, result interface{}) APIResponse {
@@ -80,21 +88,80 @@ func getMessageFromCode(code int) string {
 	}
 }
 
-func GetRoleFromGinContext(c *gin.Context) (string, error) {
+func GetUserFromGinContext(c *gin.Context) (*entity.User, error) {
 	decodeToken, isExisted := c.Get("userInfo")
 	if !isExisted {
-		return "", errors.New("userInfo (set at middleware) doesn't exist in Gin Context")
+		return nil, errors.New("userInfo (set at middleware) doesn't exist in Gin Context")
 	}
 
-	info := decodeToken.(*entity.User)
-	return info.Role, nil
+	return decodeToken.(*entity.User), nil
 }
 
-func GetUserRoleAndOrgTagNameFromGinContext(c *gin.Context) (string, string, error) {
-	decodeToken, isExisted := c.Get("userInfo")
+func GetRoleGrantsFromGinContext(c *gin.Context) ([]entity.RoleGrant, error) {
+	grants, isExisted := c.Get("roleGrants")
 	if !isExisted {
-		return "", "", errors.New("userInfo (set at middleware) doesn't exist in Gin Context")
+		return nil, errors.New("roleGrants (set at middleware) doesn't exist in Gin Context")
 	}
-	info := decodeToken.(*entity.User)
-	return info.Role, info.Organization, nil
+
+	return grants.([]entity.RoleGrant), nil
+}
+
+func GetAccessScopeFromGinContext(c *gin.Context) (*entity.AccessScope, error) {
+	scope, isExisted := c.Get("accessScope")
+	if !isExisted {
+		return nil, errors.New("accessScope (set at middleware) doesn't exist in Gin Context")
+	}
+
+	return scope.(*entity.AccessScope), nil
+}
+
+// LocalizeResult writes the localized texts of the result in the languages of the lang query, ?lang=fr or ?lang=fr,en,
+// then those of the Accept-Language header and the default languages. Without the lang query every language is kept.
+func LocalizeResult(c *gin.Context, result any) any {
+	lang, ok := c.GetQuery("lang")
+	if !ok {
+		return result
+	}
+	translation.Translate(result, translation.NewFallbackChain(lang, c.GetHeader("Accept-Language")))
+
+	return result
+}
+
+// CheckOrganizationAccess makes sure the organization is in the access scope of the request.
+// Organizations out of scope are reported as not found so that they cannot be discovered.
+func CheckOrganizationAccess(c *gin.Context, orgID primitive.ObjectID) (int, error) {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !scope.CanAccess(orgID) {
+		return http.StatusNotFound, errors.New(common.MessageErrorOrgNotFound)
+	}
+
+	return http.StatusOK, nil
+}
+
+// ResolveOrganizationID the organization a new resource belongs to. It defaults to the only organization
+// of the scope; admins of all organizations create resources shared by every organization when it is empty.
+func ResolveOrganizationID(scope *entity.AccessScope, orgID string) (primitive.ObjectID, int, error) {
+	if orgID == "" {
+		switch {
+		case scope.AllOrganizations:
+			return primitive.NilObjectID, http.StatusOK, nil
+		case len(scope.OrganizationIDs) == 1:
+			return scope.OrganizationIDs[0], http.StatusOK, nil
+		default:
+			return primitive.NilObjectID, http.StatusBadRequest, errors.New(common.MessageErrorOrganizationRequired)
+		}
+	}
+
+	oID, err := primitive.ObjectIDFromHex(orgID)
+	if err != nil {
+		return primitive.NilObjectID, http.StatusBadRequest, err
+	}
+	if !scope.CanAccess(oID) {
+		return primitive.NilObjectID, http.StatusNotFound, errors.New(common.MessageErrorOrgNotFound)
+	}
+
+	return oID, http.StatusOK, nil
 }
@@ -39,7 +39,7 @@ func NewAuthorHandler(tuc author.UseCase, puc product.UseCase, pap presenter.Con
 // CreateAuthor	API
 //
 //	@Summary		Create Author
-//	@Description	Create author
+//	@Description	Create an author of an organization, admins of all organizations create shared authors without one
 //	@Tags			author
 //	@Accept      	json
 //	@Security		ApiKeyAuth
@@ -47,6 +47,7 @@ func NewAuthorHandler(tuc author.UseCase, puc product.UseCase, pap presenter.Con
 //	@Router			/admin/author [post]
 //	@Param			request body entity.Author	true	"Create an author"
 //	@Success		200					{object}	APIResponse{result=bool}
+//	@Failure		404					{object}	APIResponse
 //	@Failure		500					{object}	APIResponse
 func (h *authorHandler) CreateAuthor(c *gin.Context) APIResponse {
 	var requestAuthor entity.Author
@@ -54,6 +55,20 @@ func (h *authorHandler) CreateAuthor(c *gin.Context) APIResponse {
 		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
 
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	var orgID string
+	if !requestAuthor.OrganizationID.IsZero() {
+		orgID = requestAuthor.OrganizationID.Hex()
+	}
+	orgObjectID, code, err := ResolveOrganizationID(scope, orgID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	requestAuthor.OrganizationID = orgObjectID
+
 	authorInserted, code, err := h.AuthorService.CreateAuthor(&requestAuthor)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
@@ -66,7 +81,7 @@ func (h *authorHandler) CreateAuthor(c *gin.Context) APIResponse {
 // GetListAuthor	API
 //
 //	@Summary		Get List of Author
-//	@Description	Get List of author
+//	@Description	Get the shared authors and the authors of the organizations of the admin
 //	@Tags			author
 //	@Security		ApiKeyAuth
 //	@Produce		json
@@ -74,7 +89,12 @@ func (h *authorHandler) CreateAuthor(c *gin.Context) APIResponse {
 //	@Success		200					{object}	APIResponse{result=bool}
 //	@Failure		500					{object}	APIResponse
 func (h *authorHandler) GetListAuthor(c *gin.Context) APIResponse {
-	listAuthors, code, err := h.AuthorService.GetListAuthor()
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	listAuthors, code, err := h.AuthorService.GetListAuthor(scope)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -92,6 +112,7 @@ func (h *authorHandler) GetListAuthor(c *gin.Context) APIResponse {
 //	@Produce		json
 //	@Router			/author/{author_id} [get]
 //	@Param			author_id	path		string	true	"Author ID"
+//	@Param			lang		query		string	false	"Languages to write localized texts in, e.g. fr,en"
 //	@Success		200					{object}	APIResponse{result=bool}
 //	@Failure		500					{object}	APIResponse
 func (h *authorHandler) GetDetailAuthor(c *gin.Context) APIResponse {
@@ -112,7 +133,7 @@ func (h *authorHandler) GetDetailAuthor(c *gin.Context) APIResponse {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
-	return HandlerResponse(code, "", "", h.AuthorPresenter.ResponseAuthorDetail(author, listProduct))
+	return HandlerResponse(code, "", "", LocalizeResult(c, h.AuthorPresenter.ResponseAuthorDetail(author, listProduct)))
 }
 
 // UpdateAuthor	godoc
@@ -128,6 +149,8 @@ func (h *authorHandler) GetDetailAuthor(c *gin.Context) APIResponse {
 //	@Param			author_id	path		string	true	"Author ID"
 //	@Param			request body entity.Author	true	"Update an author"
 //	@Success		200					{object}	APIResponse{result=entity.Author}
+//	@Failure		403					{object}	APIResponse
+//	@Failure		404					{object}	APIResponse
 //	@Failure		500					{object}	APIResponse
 func (h *authorHandler) UpdateAuthor(c *gin.Context) APIResponse {
 	var req request.AuthorRequest
@@ -140,7 +163,12 @@ func (h *authorHandler) UpdateAuthor(c *gin.Context) APIResponse {
 		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
 
-	updatedAuthor, code, err := h.AuthorService.UpdateAuthor(&req.AuthorID, &requestAuthor)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	updatedAuthor, code, err := h.AuthorService.UpdateAuthor(scope, &req.AuthorID, &requestAuthor)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
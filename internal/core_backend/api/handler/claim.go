@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
+	validation "backend-service/internal/core_backend/infrastructure/validator"
+	"backend-service/internal/core_backend/usecase/claim"
+	"backend-service/internal/core_backend/usecase/user"
+)
+
+// ClaimHandler interface
+type ClaimHandler interface {
+	ChangeClaimState(*gin.Context) APIResponse
+	GetClaimEvents(*gin.Context) APIResponse
+	ClaimItem(*gin.Context) APIResponse
+}
+
+// claimHandler struct
+type claimHandler struct {
+	ClaimService claim.UseCase
+	UserService  user.UseCase
+	Validator    validation.CustomValidator
+}
+
+// NewClaimHandler create handler
+func NewClaimHandler(cuc claim.UseCase, uuc user.UseCase, v validation.CustomValidator) ClaimHandler {
+	return &claimHandler{
+		ClaimService: cuc,
+		UserService:  uuc,
+		Validator:    v,
+	}
+}
+
+// ChangeClaimState	godoc
+// ChangeClaimState	API
+//
+//	@Summary		Change Claim State Of Product Item
+//	@Description	Move the product item to unclaimable, claimable, claimed or locked for a reason code, the change is recorded in its claim events.
+//	@Description	Making it claimable opens the claim window of its product, making it claimable or unclaimable releases its owner.
+//	@Tags			claim
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-item/{product_item_id}/claim-state [put]
+//	@Param			product_item_id		path		string						true	"Product Item ID"
+//	@Param			claim_state_request	body		request.ClaimStateRequest	true	"Claim State Request"
+//	@Success		200					{object}	APIResponse{result=entity.ClaimEvent}
+//	@Failure		400					{object}	APIResponse
+//	@Failure		404					{object}	APIResponse
+//	@Failure		409					{object}	APIResponse
+//	@Failure		500					{object}	APIResponse
+func (h *claimHandler) ChangeClaimState(c *gin.Context) APIResponse {
+	var req request.ClaimStateRequest
+	if err := c.ShouldBind(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productItemID := c.Param("product_item_id")
+	event, code, err := h.ClaimService.ChangeClaimState(scope, &productItemID, &req, scope.UserID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", event)
+}
+
+// GetClaimEvents	godoc
+// GetClaimEvents	API
+//
+//	@Summary		Get Claim Events Of Product Item
+//	@Description	Get the changes of the claim state of the product item, newest first
+//	@Tags			claim
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-item/{product_item_id}/claim-events [get]
+//	@Param			product_item_id	path		string	true	"Product Item ID"
+//	@Success		200				{object}	APIResponse{result=[]entity.ClaimEvent}
+//	@Failure		404				{object}	APIResponse
+//	@Failure		500				{object}	APIResponse
+func (h *claimHandler) GetClaimEvents(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productItemID := c.Param("product_item_id")
+	events, code, err := h.ClaimService.GetClaimEvents(scope, &productItemID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", events)
+}
+
+// ClaimItem	godoc
+// ClaimItem	API
+//
+//	@Summary		Claim Product Item
+//	@Description	Claim product item while it is claimable
+//	@Tags			product-item user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/product-item/{product_item_id}/claim [put]
+//	@Param			product_item_id	path		string	true	"Product Item ID"
+//	@Success		200				{object}	APIResponse{result=bool}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		409				{object}	APIResponse
+func (h *claimHandler) ClaimItem(c *gin.Context) APIResponse {
+	req := request.SetOwnerRequest{
+		Token:         c.GetHeader("Authorization"),
+		ProductItemID: c.Param("product_item_id"),
+	}
+
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
+	}
+
+	user, code, err := h.UserService.TokenToUser(&req.Token)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if user == nil {
+		return CreateResponse(nil, http.StatusBadRequest, "", common.MessageErrorNotFoundUser, nil)
+	}
+
+	req.OwnerID = user.ID
+	result, code, err := h.ClaimService.ClaimItem(&req)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
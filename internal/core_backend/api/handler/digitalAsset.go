@@ -1,19 +1,26 @@
 package handler
 
 import (
+	config "backend-service/config/core_backend"
 	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
 	validation "backend-service/internal/core_backend/infrastructure/validator"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"backend-service/internal/core_backend/api/handler/request"
 	"backend-service/internal/core_backend/api/presenter"
 	"backend-service/internal/core_backend/usecase/digitalAsset"
 	"backend-service/internal/core_backend/usecase/digitalAssetCollection"
 	"backend-service/internal/core_backend/usecase/mapping"
+	"backend-service/internal/core_backend/usecase/metadataTemplate"
+	"backend-service/internal/core_backend/usecase/nft"
 	"backend-service/internal/core_backend/usecase/organization"
 	"backend-service/internal/core_backend/usecase/product"
 	"backend-service/internal/core_backend/usecase/productItem"
@@ -27,6 +34,16 @@ type DigitalAssetHandler interface {
 	GetDigitalAssets(*gin.Context) APIResponse
 	GetDigitalMetadataWithID(*gin.Context) APIResponse
 	SyncDigitalAssetsMetadata(*gin.Context) APIResponse
+	SyncAllDigitalAssetsMetadata(*gin.Context) APIResponse
+	GetMetadataTemplate(*gin.Context) APIResponse
+	UpsertMetadataTemplate(*gin.Context) APIResponse
+	PreviewMetadataTemplate(*gin.Context) APIResponse
+	GetTokenMetadata(*gin.Context) APIResponse
+	GetContractMetadata(*gin.Context) APIResponse
+	UpdateCollectionMetadata(*gin.Context) APIResponse
+	GetContractTemplates(*gin.Context) APIResponse
+	DeployCollection(*gin.Context) APIResponse
+	GetContractDeployment(*gin.Context) APIResponse
 }
 
 // digitalAssetHandler struct
@@ -39,12 +56,14 @@ type digitalAssetHandler struct {
 	ProductService                product.UseCase
 	OrganizationService           organization.UseCase
 	TemplateService               template.Usecase
+	MetadataTemplateService       metadataTemplate.UseCase
+	NFTService                    nft.UseCase
 	DigitalAssetPresenter         presenter.ConvertDigitalAsset
 	Validator                     validation.CustomValidator
 }
 
 // NewDigitalAssetHandler create handler
-func NewDigitalAssetHandler(dcs digitalAssetCollection.UseCase, ds digitalAsset.UseCase, ms mapping.UseCase, us user.UseCase, pis productItem.UseCase, ps product.UseCase, os organization.UseCase, ts template.Usecase, dp presenter.ConvertDigitalAsset, v validation.CustomValidator) DigitalAssetHandler {
+func NewDigitalAssetHandler(dcs digitalAssetCollection.UseCase, ds digitalAsset.UseCase, ms mapping.UseCase, us user.UseCase, pis productItem.UseCase, ps product.UseCase, os organization.UseCase, ts template.Usecase, mts metadataTemplate.UseCase, nft nft.UseCase, dp presenter.ConvertDigitalAsset, v validation.CustomValidator) DigitalAssetHandler {
 	return &digitalAssetHandler{
 		DigitalAssetService:           ds,
 		DigitalAssetCollectionService: dcs,
@@ -54,6 +73,8 @@ func NewDigitalAssetHandler(dcs digitalAssetCollection.UseCase, ds digitalAsset.
 		ProductService:                ps,
 		OrganizationService:           os,
 		TemplateService:               ts,
+		MetadataTemplateService:       mts,
+		NFTService:                    nft,
 		DigitalAssetPresenter:         dp,
 		Validator:                     v,
 	}
@@ -87,8 +108,10 @@ func (h *digitalAssetHandler) GetDigitalAssetsByCollection(c *gin.Context) APIRe
 //	@Router			/digital-asset/{org_tag_name}/{token_id} [get]
 //	@Param			org_tag_name	path		string	true	"organization tag name"
 //	@Param			token_id		path		string	true	"Token ID"
-//	@Success		200				{object}	APIResponse{result=entity.Metadata}
+//	@Success		200				{object}	presenter.TokenMetadataResponse
+//	@Success		304
 //	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
 //	@Failure		500				{object}	APIResponse
 func (h *digitalAssetHandler) GetDigitalMetadataWithID(c *gin.Context) APIResponse {
 	var request = request.GetDigitalMetadataWithIDRequest{
@@ -117,20 +140,7 @@ func (h *digitalAssetHandler) GetDigitalMetadataWithID(c *gin.Context) APIRespon
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
-	// Get Digital Asset by Token ID and Collection
-	collectionID := collection.ID.Hex()
-	tokenID := request.TokenIDToInt()
-	digitalAsset, code, err := h.DigitalAssetService.GetDigitalAssetByTokenID(&collectionID, &tokenID)
-	if err != nil {
-		return CreateResponse(err, code, "", err.Error(), nil)
-	}
-
-	if digitalAsset == nil {
-		err := errors.New("Couldn't find corresponding digital asset (given token_id might not exist)")
-		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
-	}
-
-	return APIResponse{Code: code, Result: digitalAsset.Metadata}
+	return h.tokenMetadataResponse(c, collection, request.TokenIDToInt())
 }
 
 // GetDigitalAssets	godoc
@@ -147,6 +157,10 @@ func (h *digitalAssetHandler) GetDigitalMetadataWithID(c *gin.Context) APIRespon
 //	@Failure		400				{object}	APIResponse
 //	@Failure		500				{object}	APIResponse
 func (h *digitalAssetHandler) GetDigitalAssets(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
 	org_tag_name, exist := c.GetQuery("org_tag_name")
 	var digitalAssets *[]entity.DigitalAsset
 	if exist {
@@ -155,6 +169,9 @@ func (h *digitalAssetHandler) GetDigitalAssets(c *gin.Context) APIResponse {
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
+		if code, err := CheckOrganizationAccess(c, org.ID); err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
 
 		// Get Collection by Org
 		oID := org.ID.Hex()
@@ -168,15 +185,28 @@ func (h *digitalAssetHandler) GetDigitalAssets(c *gin.Context) APIResponse {
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
-	} else {
-		var (
-			code int
-			err  error
-		)
+	} else if scope.AllOrganizations {
+		var code int
 		digitalAssets, code, err = h.DigitalAssetService.GetAllActiveDigitalAssets()
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
+	} else {
+		// Only the assets of the collections in the caller's organizations
+		collections, code, err := h.DigitalAssetCollectionService.GetCollectionsInScope(scope)
+		if err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+		assets := []entity.DigitalAsset{}
+		for _, collection := range *collections {
+			cID := collection.ID.Hex()
+			collectionAssets, code, err := h.DigitalAssetService.GetActiveDigitalAssetByCollectionID(&cID)
+			if err != nil {
+				return CreateResponse(err, code, "", err.Error(), nil)
+			}
+			assets = append(assets, *collectionAssets...)
+		}
+		digitalAssets = &assets
 	}
 
 	var (
@@ -216,32 +246,516 @@ func (h *digitalAssetHandler) GetDigitalAssets(c *gin.Context) APIResponse {
 // SyncDigitalAssetsMetadata	API
 //
 //	@Summary		Sync Digital Assets Metadata
-//	@Description	Sync Digital Assets Metadata (As metadata format can change so use this API to update the correct one to the DB)
+//	@Description	Re-render the metadata of every digital asset in the collection from the collection's metadata template
+//	@Tags			digital-asset
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/collection/{collection_id}/sync-metadata [put]
+//	@Param			collection_id	path		string	true	"Collection ID"
+//	@Success		200				{object}	APIResponse{result=bool}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+//	@Failure		500				{object}	APIResponse
+func (h *digitalAssetHandler) SyncDigitalAssetsMetadata(c *gin.Context) APIResponse {
+	var request = request.CollectionMetadataTemplateRequest{
+		CollectionID: c.Param("collection_id"),
+	}
+	if e := h.Validator.Validate(request); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	if _, code, err := h.DigitalAssetCollectionService.GetCollectionInScope(scope, &request.CollectionID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	template, code, err := h.MetadataTemplateService.GetTemplateByCollectionID(&request.CollectionID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	if code, err := h.syncCollectionMetadata(template, &request.CollectionID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return APIResponse{Code: http.StatusOK, Result: true}
+}
+
+// SyncAllDigitalAssetsMetadata	godoc
+// SyncAllDigitalAssetsMetadata	API
+//
+//	@Summary		Sync Digital Assets Metadata Of Every Collection
+//	@Description	Re-render the metadata of the digital assets of every collection in scope that has a metadata template. Kept for the clients of the former sync-metadata API.
 //	@Tags			digital-asset
 //	@Security		ApiKeyAuth
 //	@Produce		json
 //	@Router			/admin/digital-asset/sync-metadata [put]
 //	@Success		200	{object}	APIResponse{result=bool}
-//	@Failure		400	{object}	APIResponse
 //	@Failure		500	{object}	APIResponse
-func (h *digitalAssetHandler) SyncDigitalAssetsMetadata(c *gin.Context) APIResponse {
-	aggregations, code, err := h.DigitalAssetService.GetDigitalAssetsProductAggregate()
+func (h *digitalAssetHandler) SyncAllDigitalAssetsMetadata(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	collections, code, err := h.DigitalAssetCollectionService.GetCollectionsInScope(scope)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
+	for _, collection := range *collections {
+		collectionID := collection.ID.Hex()
+		template, code, err := h.MetadataTemplateService.GetTemplateByCollectionID(&collectionID)
+		// Collections without a template have no metadata to sync
+		if code == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+		if code, err := h.syncCollectionMetadata(template, &collectionID); err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+	}
+
+	return APIResponse{Code: http.StatusOK, Result: true}
+}
+
+// syncCollectionMetadata renders the template for every digital asset of the collection and stores the result
+func (h *digitalAssetHandler) syncCollectionMetadata(template *entity.MetadataTemplate, collectionID *string) (int, error) {
+	aggregations, code, err := h.DigitalAssetService.GetDigitalAssetsProductAggregate(collectionID)
+	if err != nil {
+		return code, err
+	}
+
 	for _, aggregation := range *aggregations {
-		metadata := h.DigitalAssetService.ConstructMetadata(aggregation.ItemIndex, &aggregation.OrgTagName, &aggregation.Product)
+		metadata := h.DigitalAssetService.ConstructMetadata(template, aggregation.MetadataSource())
 		daID := aggregation.ID.Hex()
 		ok, code, err := h.DigitalAssetService.UpdateDigitalAssetMetadata(&daID, metadata)
 		if err != nil {
-			return CreateResponse(err, code, "", err.Error(), nil)
+			return code, err
 		}
 		if !ok {
-			err = errors.New("Error update digital asset metadata")
-			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+			return http.StatusInternalServerError, errors.New("Error update digital asset metadata")
 		}
 	}
 
-	return APIResponse{Code: http.StatusOK, Result: true}
+	return http.StatusOK, nil
+}
+
+// GetMetadataTemplate	godoc
+// GetMetadataTemplate	API
+//
+//	@Summary		Get Metadata Template
+//	@Description	Get the metadata template of a digital asset collection
+//	@Tags			digital-asset
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/collection/{collection_id}/metadata-template [get]
+//	@Param			collection_id	path		string	true	"Collection ID"
+//	@Success		200				{object}	APIResponse{result=entity.MetadataTemplate}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *digitalAssetHandler) GetMetadataTemplate(c *gin.Context) APIResponse {
+	var request = request.CollectionMetadataTemplateRequest{
+		CollectionID: c.Param("collection_id"),
+	}
+	if e := h.Validator.Validate(request); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	if _, code, err := h.DigitalAssetCollectionService.GetCollectionInScope(scope, &request.CollectionID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	template, code, err := h.MetadataTemplateService.GetTemplateByCollectionID(&request.CollectionID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", template)
+}
+
+// UpsertMetadataTemplate	godoc
+// UpsertMetadataTemplate	API
+//
+//	@Summary		Create Or Update Metadata Template
+//	@Description	Create or replace the metadata template of a digital asset collection. String fields accept placeholders such as {{product.product_name}}, {{item.item_index}}, {{author.name.en}} or {{attribute.farm_name}}
+//	@Tags			digital-asset
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/collection/{collection_id}/metadata-template [put]
+//	@Param			collection_id	path		string									true	"Collection ID"
+//	@Param			request			body		request.UpsertMetadataTemplateRequest	true	"Metadata template"
+//	@Success		200				{object}	APIResponse{result=entity.MetadataTemplate}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		500				{object}	APIResponse
+func (h *digitalAssetHandler) UpsertMetadataTemplate(c *gin.Context) APIResponse {
+	var collectionRequest = request.CollectionMetadataTemplateRequest{
+		CollectionID: c.Param("collection_id"),
+	}
+	if e := h.Validator.Validate(collectionRequest); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	var templateRequest request.UpsertMetadataTemplateRequest
+	if err := c.ShouldBindJSON(&templateRequest); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(templateRequest); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	collection, code, err := h.DigitalAssetCollectionService.GetCollectionInScope(scope, &collectionRequest.CollectionID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	template, code, err := h.MetadataTemplateService.UpsertTemplate(templateRequest.ToEntity(collection.ID))
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", template)
+}
+
+// PreviewMetadataTemplate	godoc
+// PreviewMetadataTemplate	API
+//
+//	@Summary		Preview Metadata Template
+//	@Description	Render the metadata of a product item with the given template, or with the collection's stored template when none is given. Nothing is saved.
+//	@Tags			digital-asset
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/collection/{collection_id}/metadata-template/preview [post]
+//	@Param			collection_id	path		string									true	"Collection ID"
+//	@Param			request			body		request.PreviewMetadataTemplateRequest	true	"Product item and optional template"
+//	@Success		200				{object}	APIResponse{result=entity.Metadata}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *digitalAssetHandler) PreviewMetadataTemplate(c *gin.Context) APIResponse {
+	var collectionRequest = request.CollectionMetadataTemplateRequest{
+		CollectionID: c.Param("collection_id"),
+	}
+	if e := h.Validator.Validate(collectionRequest); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	var previewRequest request.PreviewMetadataTemplateRequest
+	if err := c.ShouldBindJSON(&previewRequest); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(previewRequest); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	collection, code, err := h.DigitalAssetCollectionService.GetCollectionInScope(scope, &collectionRequest.CollectionID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	// The product item must belong to the collection's organization
+	item, code, err := h.ProductItemService.GetDetailProductItem(&previewRequest.ProductItemID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if item == nil {
+		err := errors.New(common.MessageErrorProductItemNotFound)
+		return CreateResponse(err, http.StatusNotFound, "", err.Error(), nil)
+	}
+	productID := item.ProductID.Hex()
+	product, code, err := h.ProductService.GetProductInScope(scope, &productID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if product.OrganizationID != collection.OrganizationID {
+		err := errors.New(common.MessageErrorProductItemNotFound)
+		return CreateResponse(err, http.StatusNotFound, "", err.Error(), nil)
+	}
+
+	var template *entity.MetadataTemplate
+	if previewRequest.Template != nil {
+		template = previewRequest.Template.ToEntity(primitive.NilObjectID)
+	} else {
+		template, code, err = h.MetadataTemplateService.GetTemplateByCollectionID(&collectionRequest.CollectionID)
+		if err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+	}
+
+	source, code, err := h.DigitalAssetService.GetMetadataSourceByProductItemID(&previewRequest.ProductItemID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(http.StatusOK, "", "", h.DigitalAssetService.ConstructMetadata(template, source))
+}
+
+// GetTokenMetadata	godoc
+// GetTokenMetadata	API
+//
+//	@Summary		Get Token Metadata
+//	@Description	ERC-721 token metadata (tokenURI) of a collection. Unrevealed collections serve their placeholder metadata. Responses carry ETag and Cache-Control headers.
+//	@Tags			digital-asset
+//	@Produce		json
+//	@Router			/digital-asset/collection/{collection_id}/token/{token_id} [get]
+//	@Param			collection_id	path		string	true	"Collection ID"
+//	@Param			token_id		path		string	true	"Token ID"
+//	@Success		200				{object}	presenter.TokenMetadataResponse
+//	@Success		304
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *digitalAssetHandler) GetTokenMetadata(c *gin.Context) APIResponse {
+	var request = request.GetTokenMetadataRequest{
+		CollectionID: c.Param("collectionID"),
+		TokenID:      c.Param("token_id"),
+	}
+	if e := h.Validator.Validate(request); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	tokenID, err := request.TokenIDToInt()
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+
+	collection, code, err := h.DigitalAssetCollectionService.GetCollectionByID(&request.CollectionID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return h.tokenMetadataResponse(c, collection, tokenID)
+}
+
+// GetContractMetadata	godoc
+// GetContractMetadata	API
+//
+//	@Summary		Get Contract Metadata
+//	@Description	Collection level metadata (contractURI) of a collection. Responses carry ETag and Cache-Control headers.
+//	@Tags			digital-asset
+//	@Produce		json
+//	@Router			/digital-asset/collection/{collection_id}/contract [get]
+//	@Param			collection_id	path		string	true	"Collection ID"
+//	@Success		200				{object}	presenter.ContractMetadataResponse
+//	@Success		304
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *digitalAssetHandler) GetContractMetadata(c *gin.Context) APIResponse {
+	var request = request.GetAssetByCollectionRequest{
+		CollectionID: c.Param("collectionID"),
+	}
+	if e := h.Validator.Validate(request); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	collection, code, err := h.DigitalAssetCollectionService.GetCollectionByID(&request.CollectionID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	result := h.DigitalAssetPresenter.ResponseContractMetadata(collection)
+
+	return cachedResponse(c, result, config.C.NFT.METADATA_CACHE_MAX_AGE)
+}
+
+// UpdateCollectionMetadata	godoc
+// UpdateCollectionMetadata	API
+//
+//	@Summary		Update Collection Metadata Settings
+//	@Description	Update the reveal flag, the placeholder metadata served while unrevealed and the contract level metadata of a collection
+//	@Tags			digital-asset
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/collection/{collection_id}/metadata-settings [put]
+//	@Param			collection_id	path		string									true	"Collection ID"
+//	@Param			request			body		request.UpdateCollectionMetadataRequest	true	"Metadata settings"
+//	@Success		200				{object}	APIResponse{result=bool}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *digitalAssetHandler) UpdateCollectionMetadata(c *gin.Context) APIResponse {
+	var collectionRequest = request.CollectionMetadataTemplateRequest{
+		CollectionID: c.Param("collection_id"),
+	}
+	if e := h.Validator.Validate(collectionRequest); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	var settingsRequest request.UpdateCollectionMetadataRequest
+	if err := c.ShouldBindJSON(&settingsRequest); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(settingsRequest); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	collection, code, err := h.DigitalAssetCollectionService.GetCollectionInScope(scope, &collectionRequest.CollectionID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	collection.Revealed = settingsRequest.Revealed
+	collection.PlaceholderMetadata = settingsRequest.PlaceholderMetadata
+	collection.ContractMetadata = settingsRequest.ContractMetadata
+	ok, code, err := h.DigitalAssetCollectionService.UpdateCollectionMetadata(collection)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", ok)
+}
+
+// GetContractTemplates	godoc
+// GetContractTemplates	API
+//
+//	@Summary		Get Contract Templates
+//	@Description	List the contract templates a collection can be deployed from. Only configurable templates take the royalty as constructor arguments. Templates that are not available are not built on this server and cannot be deployed.
+//	@Tags			digital-asset
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/contract-templates [get]
+//	@Success		200	{object}	APIResponse{result=[]contracts.Template}
+func (h *digitalAssetHandler) GetContractTemplates(c *gin.Context) APIResponse {
+	return HandlerResponse(http.StatusOK, "", "", h.NFTService.ListContractTemplates())
+}
+
+// DeployCollection	godoc
+// DeployCollection	API
+//
+//	@Summary		Deploy Collection
+//	@Description	Deploy the collection contract of an organization from a template. The base URI defaults to the token metadata endpoint of the new collection. The collection is created once the deployment transaction is confirmed. An organization has a single collection, a second deployment is refused while one is pending.
+//	@Tags			digital-asset
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/collection/deploy [post]
+//	@Param			request	body		request.DeployCollectionRequest	true	"Deployment"
+//	@Success		200		{object}	APIResponse{result=entity.ContractDeployment}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+//	@Failure		500		{object}	APIResponse
+func (h *digitalAssetHandler) DeployCollection(c *gin.Context) APIResponse {
+	var deployRequest request.DeployCollectionRequest
+	if err := c.ShouldBindJSON(&deployRequest); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(deployRequest); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	if _, code, err := h.OrganizationService.GetDetailOrganization(&deployRequest.OrgID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	orgID, _ := primitive.ObjectIDFromHex(deployRequest.OrgID)
+	if code, err := CheckOrganizationAccess(c, orgID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	// An organization owns a single collection
+	_, code, err := h.DigitalAssetCollectionService.GetCollectionByOrgID(&deployRequest.OrgID)
+	if err == nil {
+		err = errors.New(common.MessageErrorOrganizationHasCollection)
+		return CreateResponse(err, http.StatusConflict, "", err.Error(), nil)
+	}
+	if code != http.StatusNotFound {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	deployment, code, err := h.NFTService.DeployCollection(deployRequest.ToEntity())
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", deployment)
+}
+
+// GetContractDeployment	godoc
+// GetContractDeployment	API
+//
+//	@Summary		Get Contract Deployment
+//	@Description	Get the status of a collection deployment. The collection_id is usable once the status is Active.
+//	@Tags			digital-asset
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/digital-asset/collection/deployment/{deployment_id} [get]
+//	@Param			deployment_id	path		string	true	"Deployment ID"
+//	@Success		200				{object}	APIResponse{result=entity.ContractDeployment}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *digitalAssetHandler) GetContractDeployment(c *gin.Context) APIResponse {
+	var request = request.GetContractDeploymentRequest{
+		DeploymentID: c.Param("deployment_id"),
+	}
+	if e := h.Validator.Validate(request); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	deployment, code, err := h.NFTService.GetContractDeploymentByID(&request.DeploymentID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if code, err := CheckOrganizationAccess(c, deployment.OrganizationID); err != nil {
+		err := errors.New(common.MessageErrorContractDeploymentNotFound)
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", deployment)
+}
+
+// tokenMetadataResponse serves the metadata of a minted token, or the collection's placeholder while unrevealed
+func (h *digitalAssetHandler) tokenMetadataResponse(c *gin.Context, collection *entity.DigitalAssetCollection, tokenID int) APIResponse {
+	collectionID := collection.ID.Hex()
+	digitalAsset, code, err := h.DigitalAssetService.GetDigitalAssetByTokenID(&collectionID, &tokenID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if digitalAsset == nil {
+		err := errors.New(common.MessageErrorTokenNotMinted)
+		return CreateResponse(err, http.StatusNotFound, "", err.Error(), nil)
+	}
+
+	if !collection.IsRevealed() {
+		result := h.DigitalAssetPresenter.ResponseTokenMetadata(collection.RenderPlaceholderMetadata(tokenID))
+		return cachedResponse(c, result, config.C.NFT.UNREVEALED_CACHE_MAX_AGE)
+	}
+
+	result := h.DigitalAssetPresenter.ResponseTokenMetadata(&digitalAsset.Metadata)
+
+	return cachedResponse(c, result, config.C.NFT.METADATA_CACHE_MAX_AGE)
+}
+
+// cachedResponse sets ETag and Cache-Control headers, answering 304 when the client already holds the same body
+func cachedResponse(c *gin.Context, result any, maxAge int) APIResponse {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	if c.GetHeader("If-None-Match") == etag {
+		return APIResponse{Code: http.StatusNotModified}
+	}
+
+	return APIResponse{Code: http.StatusOK, Result: result}
 }
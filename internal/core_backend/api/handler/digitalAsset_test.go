@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/api/presenter"
+	"backend-service/internal/core_backend/entity"
+	validation "backend-service/internal/core_backend/infrastructure/validator"
+	"backend-service/internal/core_backend/usecase/digitalAsset"
+	"backend-service/internal/core_backend/usecase/digitalAssetCollection"
+)
+
+// oneCollection serves a single collection by its ID
+type oneCollection struct {
+	digitalAssetCollection.UseCase
+	collection *entity.DigitalAssetCollection
+}
+
+func (s *oneCollection) GetCollectionByID(cID *string) (*entity.DigitalAssetCollection, int, error) {
+	if *cID != s.collection.ID.Hex() {
+		return nil, http.StatusNotFound, nil
+	}
+	return s.collection, http.StatusOK, nil
+}
+
+// mintedTokens serves the digital assets of the minted token IDs
+type mintedTokens struct {
+	digitalAsset.UseCase
+	assets map[int]entity.DigitalAsset
+}
+
+func (s *mintedTokens) GetDigitalAssetByTokenID(collectionID *string, tokenID *int) (*entity.DigitalAsset, int, error) {
+	asset, ok := s.assets[*tokenID]
+	if !ok {
+		return nil, http.StatusOK, nil
+	}
+	return &asset, http.StatusOK, nil
+}
+
+func TestGetTokenMetadata(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	previous := config.C
+	t.Cleanup(func() { config.C = previous })
+	config.C.NFT.METADATA_CACHE_MAX_AGE = 3600
+	config.C.NFT.UNREVEALED_CACHE_MAX_AGE = 60
+
+	revealed := false
+	collection := &entity.DigitalAssetCollection{
+		Name:                "Sculptures",
+		Revealed:            &revealed,
+		PlaceholderMetadata: entity.Metadata{Name: "Mystery #{{token_id}}", Image: "https://example.com/hidden.png"},
+	}
+	collection.ID = primitive.NewObjectID()
+	tokens := &mintedTokens{assets: map[int]entity.DigitalAsset{7: {Metadata: entity.Metadata{
+		Name:       "Chiếc Nón",
+		Image:      "https://example.com/non.png",
+		Attributes: []entity.MetadataAttribute{{TraitType: "Height", Value: "150", DisplayType: "number"}},
+	}}}}
+	h := NewDigitalAssetHandler(&oneCollection{collection: collection}, tokens, nil, nil, nil, nil, nil, nil, nil, nil,
+		presenter.NewPresenterDigitalAsset(), validation.NewCustomValidator(validator.New()))
+
+	engine := gin.New()
+	engine.GET("/collection/:collectionID/token/:token_id", func(c *gin.Context) {
+		result := h.GetTokenMetadata(c)
+		c.JSON(result.Code, result.Result)
+	})
+	get := func(tokenID, etag string) (*httptest.ResponseRecorder, presenter.TokenMetadataResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/collection/"+collection.ID.Hex()+"/token/"+tokenID, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		var metadata presenter.TokenMetadataResponse
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &metadata); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rec, metadata
+	}
+
+	// Unrevealed collections serve the placeholder, cached briefly
+	rec, metadata := get("7", "")
+	if rec.Code != http.StatusOK || metadata.Name != "Mystery #7" || metadata.Image != "https://example.com/hidden.png" {
+		t.Fatalf("unrevealed: got %d %+v", rec.Code, metadata)
+	}
+	if rec.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Errorf("unrevealed Cache-Control = %q", rec.Header().Get("Cache-Control"))
+	}
+	placeholderETag := rec.Header().Get("ETag")
+	if placeholderETag == "" {
+		t.Fatal("no ETag")
+	}
+
+	// The same body is not sent again
+	if rec, _ := get("7", placeholderETag); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("matching If-None-Match: got %d %q", rec.Code, rec.Body.String())
+	}
+
+	// Revealing changes the body and its ETag
+	revealed = true
+	rec, metadata = get("7", placeholderETag)
+	if rec.Code != http.StatusOK || metadata.Name != "Chiếc Nón" || len(metadata.Attributes) != 1 || metadata.Attributes[0].Value != float64(150) {
+		t.Fatalf("revealed: got %d %+v", rec.Code, metadata)
+	}
+	if rec.Header().Get("Cache-Control") != "public, max-age=3600" {
+		t.Errorf("revealed Cache-Control = %q", rec.Header().Get("Cache-Control"))
+	}
+	if etag := rec.Header().Get("ETag"); etag == "" || etag == placeholderETag {
+		t.Errorf("revealed ETag = %q, placeholder ETag %q", etag, placeholderETag)
+	}
+
+	// Tokens not minted yet are not found, even while unrevealed
+	revealed = false
+	if rec, _ := get("8", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("token not minted: got %d", rec.Code)
+	}
+	for _, tokenID := range []string{"x", "-7", "+7", "7.0", "99999999999999999999"} {
+		if rec, _ := get(tokenID, ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("invalid token ID %q: got %d", tokenID, rec.Code)
+		}
+	}
+}
@@ -87,6 +87,15 @@ func (h *mappingHandler) UpdateMapping(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
 
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	mapping, code, err := h.MappingService.GetMappingWithTagIDInScope(scope, &tagID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
 	if request.ProductItemID != nil {
 		// Check if given product item is already mapped
 		pItemID := request.ProductItemID.Hex()
@@ -101,11 +110,26 @@ func (h *mappingHandler) UpdateMapping(c *gin.Context) APIResponse {
 			return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 		}
 
-		// Check if given mapping is already mapped
-		mapping, code, err := h.MappingService.GetMappingWithTagID(&tagID)
+		// The product item must belong to a product of the tag's organization
+		item, code, err := h.ProductItemService.GetDetailProductItem(&pItemID)
+		if err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+		if item == nil {
+			err := errors.New(common.MessageErrorProductItemNotFound)
+			return CreateResponse(err, http.StatusNotFound, "", err.Error(), nil)
+		}
+		productID := item.ProductID.Hex()
+		product, code, err := h.ProductService.GetProductInScope(scope, &productID)
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
+		if product.OrganizationID != mapping.OrganizationID {
+			err := errors.New(common.MessageErrorProductItemNotFound)
+			return CreateResponse(err, http.StatusNotFound, "", err.Error(), nil)
+		}
+
+		// Check if given mapping is already mapped
 		if !mapping.ProductItemID.IsZero() {
 			err := errors.New("Tag ID is already mapped with some product item")
 			return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
@@ -146,8 +170,13 @@ func (h *mappingHandler) Unmap(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
 
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
 	// Check if given mapping is already mapped
-	mapping, code, err := h.MappingService.GetMappingWithTagID(&tagID)
+	mapping, code, err := h.MappingService.GetMappingWithTagIDInScope(scope, &tagID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -173,7 +202,7 @@ func (h *mappingHandler) Unmap(c *gin.Context) APIResponse {
 // GetAllMapping	API
 //
 //	@Summary		Get All Mapping (Of 1 Org/All Orgs)
-//	@Description	Get all mapping (of 1 org or all orgs (required super admin, leave org tag name empty))
+//	@Description	Get all mapping (of 1 org, or of every org in the caller's scope when org tag name is empty)
 //	@Tags			mapping
 //	@Security		ApiKeyAuth
 //	@Produce		json
@@ -184,32 +213,25 @@ func (h *mappingHandler) Unmap(c *gin.Context) APIResponse {
 //	@Failure		400				{object}	APIResponse
 //	@Failure		500				{object}	APIResponse
 func (h *mappingHandler) GetAllMapping(c *gin.Context) APIResponse {
-	decodeToken, isExisted := c.Get("userInfo")
-	if !isExisted {
-		return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
 
-	info := decodeToken.(*entity.User)
 	org_tag_name, exist := c.GetQuery("org_tag_name")
 	var orgID string
 	if exist {
-		if info.Role == string(entity.ORG_ADMIN_ROLE) && info.Organization != org_tag_name {
-			err := errors.New("Unauthorized: You can't get mappings that are NOT in your organization!")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
 		org, code, err := h.OrganizationService.GetOrgByTagName(&org_tag_name)
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
-		orgID = org.ID.Hex()
-	} else {
-		if info.Role != string(entity.SUPER_ADMIN_ROLE) {
-			err := errors.New("Missing org tag name query, only super admin can view all mappings")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+		if code, err := CheckOrganizationAccess(c, org.ID); err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
 		}
+		orgID = org.ID.Hex()
 	}
 
-	mappingsRaw, code, err := h.MappingService.GetAllMappingInOrg(&orgID)
+	mappingsRaw, code, err := h.MappingService.GetMappings(scope, &orgID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -266,33 +288,16 @@ func (h *mappingHandler) GetAllMapping(c *gin.Context) APIResponse {
 //	@Failure		400			{object}	APIResponse
 //	@Failure		500			{object}	APIResponse
 func (h *mappingHandler) GetAllMappingForProduct(c *gin.Context) APIResponse {
-	decodeToken, isExisted := c.Get("userInfo")
-	if !isExisted {
-		return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
-
-	info := decodeToken.(*entity.User)
 	productID := c.Param("product_id")
-	product, code, err := h.ProductService.GetProductByID(&productID)
+	product, code, err := h.ProductService.GetProductInScope(scope, &productID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 	oID := product.OrganizationID.Hex()
-	switch info.Role {
-	case string(entity.ORG_ADMIN_ROLE):
-		org, code, err := h.OrganizationService.GetDetailOrganization(&oID)
-		if err != nil {
-			return CreateResponse(err, code, "", err.Error(), nil)
-		}
-		if info.Organization != org.NameTag {
-			err = errors.New("Unauthorized: Given product is NOT in your organization!")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
-	case string(entity.SUPER_ADMIN_ROLE):
-	default:
-		err := errors.New("Unidentified user role in token")
-		return CreateResponse(err, code, "", err.Error(), nil)
-	}
 	mappingsRaw, code, err := h.MappingService.GetAllMappingForProduct(&productID, &oID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
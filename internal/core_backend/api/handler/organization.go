@@ -53,15 +53,14 @@ func NewOrganizationHandler(ouc organization.UseCase, dp presenter.ConvertOrgani
 //	@Success		200							{object}	APIResponse{result=presenter.OrganizationResponse}
 //	@Failure		500							{object}	APIResponse
 func (h *organizationHandler) CreateOrganization(c *gin.Context) APIResponse {
-	//Check user role
-	userRole, err := GetRoleFromGinContext(c)
+	// Organizations can only be created by admins granted in every organization
+	scope, err := GetAccessScopeFromGinContext(c)
 	if err != nil {
 		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
-
-	if userRole != string(entity.SUPER_ADMIN_ROLE) {
-		err = errors.New("Unauthorized: only super admin can create organization")
-		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	if !scope.AllOrganizations {
+		err = errors.New(common.MessageErrorForbidden + ": only global admins can create organization")
+		return CreateResponse(err, http.StatusForbidden, "", err.Error(), nil)
 	}
 
 	// Process request
@@ -105,41 +104,32 @@ func (h *organizationHandler) CreateOrganization(c *gin.Context) APIResponse {
 //	@Success		200	{object}	APIResponse{result=presenter.OrganizationListResponse}
 //	@Failure		500	{object}	APIResponse
 func (h *organizationHandler) GetAllOrganizations(c *gin.Context) APIResponse {
-	// Get Org from UserInfo
-	decodeToken, isExisted := c.Get("userInfo")
-	if !isExisted {
-		return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
-	info := decodeToken.(*entity.User)
-
-	switch info.Role {
-	// Org Admin
-	case string(entity.ORG_ADMIN_ROLE):
-		org, code, err := h.OrganizationService.GetOrgByTagName(&info.Organization)
-		if err != nil {
-			return CreateResponse(err, code, "", err.Error(), nil)
-		}
-
-		orgs := []entity.Organization{*org}
-		result := h.OrganizationPresenter.ResponseAllOrganization(&orgs)
 
-		return HandlerResponse(code, "", "", result)
-
-	// Super Admin
-	case string(entity.SUPER_ADMIN_ROLE):
+	if scope.AllOrganizations {
 		allOrgs, code, err := h.OrganizationService.GetAllOrganizations()
 		if err != nil {
 			return CreateResponse(err, http.StatusBadRequest, "", "", nil)
 		}
 
-		result := h.OrganizationPresenter.ResponseAllOrganization(allOrgs)
-
-		return HandlerResponse(code, "", "", result)
+		return HandlerResponse(code, "", "", h.OrganizationPresenter.ResponseAllOrganization(allOrgs))
+	}
 
-	// Otherwise
-	default:
-		return CreateResponse(errors.New("Invalid user role"), http.StatusUnauthorized, "", "Invalid user role", nil)
+	// Only the organizations the admin is granted in
+	orgs := []entity.Organization{}
+	for _, id := range scope.OrganizationIDs {
+		orgID := id.Hex()
+		org, code, err := h.OrganizationService.GetDetailOrganization(&orgID)
+		if err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+		orgs = append(orgs, *org)
 	}
+
+	return HandlerResponse(http.StatusOK, "", "", h.OrganizationPresenter.ResponseAllOrganization(&orgs))
 }
 
 // UpdateOrganization	godoc
@@ -157,26 +147,22 @@ func (h *organizationHandler) GetAllOrganizations(c *gin.Context) APIResponse {
 //	@Success		200							{object}	APIResponse{result=bool}
 //	@Failure		500							{object}	APIResponse
 func (h *organizationHandler) UpdateOrganization(c *gin.Context) APIResponse {
-	// Get OrgTagName from UserInfo
-	decodeToken, isExisted := c.Get("userInfo")
-	if !isExisted {
-		return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
-	info := decodeToken.(*entity.User)
 
 	orgID := c.Param("org_id")
 
-	// Check if org_admin has the same org_id
-	switch info.Role {
-	case string(entity.ORG_ADMIN_ROLE):
+	// Check the admin is granted in the organization
+	if !scope.AllOrganizations {
 		org, code, err := h.OrganizationService.GetDetailOrganization(&orgID)
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
 
-		if org.NameTag != info.Organization {
-			err := errors.New("Unauthorized: You do not have access to this organization")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+		if code, err := CheckOrganizationAccess(c, org.ID); err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
 		}
 	}
 
@@ -221,27 +207,7 @@ func (h *organizationHandler) UpdateOrganization(c *gin.Context) APIResponse {
 //	@Success		200				{object}	APIResponse{result=presenter.OrganizationResponse}
 //	@Failure		500				{object}	APIResponse
 func (h *organizationHandler) GetOrganization(c *gin.Context) APIResponse {
-	userRole, err := GetRoleFromGinContext(c)
-	if err != nil {
-		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-	}
-
 	orgTagName := c.Param("org_tag_name")
-
-	//Check if org_admin has the same org_tag_name
-	if userRole == string(entity.ORG_ADMIN_ROLE) {
-		//Get OrgTagName from UserInfo
-		decodeToken, isExisted := c.Get("userInfo")
-		if !isExisted {
-			return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
-		}
-		info := decodeToken.(*entity.User)
-		if orgTagName != info.Organization {
-			err = errors.New("Unauthorized: You do not have access to this organization")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
-	}
-
 	org, code, err := h.OrganizationService.GetOrgByTagName(&orgTagName)
 
 	if err != nil {
@@ -258,6 +224,11 @@ func (h *organizationHandler) GetOrganization(c *gin.Context) APIResponse {
 		)
 	}
 
+	//Check the admin is granted in the organization
+	if code, err := CheckOrganizationAccess(c, org.ID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
 	result := h.OrganizationPresenter.OrganizationResponse(org)
 
 	return HandlerResponse(code, "", "", result)
@@ -16,6 +16,7 @@ import (
 	"backend-service/internal/core_backend/usecase/organization"
 	"backend-service/internal/core_backend/usecase/product"
 	"backend-service/internal/core_backend/usecase/productItem"
+	"backend-service/internal/core_backend/usecase/productType"
 	"backend-service/internal/core_backend/usecase/template"
 	webpage "backend-service/internal/core_backend/usecase/webPage"
 )
@@ -24,11 +25,20 @@ import (
 type ProductHandler interface {
 	CreateProduct(*gin.Context) APIResponse
 	GetAllProducts(*gin.Context) APIResponse
+	SearchProducts(*gin.Context) APIResponse
 	GetProductDetail(c *gin.Context) APIResponse
 	UpdateProductDetail(c *gin.Context) APIResponse
 	DeteleProductByID(c *gin.Context) APIResponse
 	GetProductByTagID(c *gin.Context) APIResponse
 	CloneProductByID(c *gin.Context) APIResponse
+	DeepCloneProduct(c *gin.Context) APIResponse
+	GetProductDraft(c *gin.Context) APIResponse
+	DiscardProductDraft(c *gin.Context) APIResponse
+	PublishProductDraft(c *gin.Context) APIResponse
+	GetProductVersions(c *gin.Context) APIResponse
+	GetProductVersion(c *gin.Context) APIResponse
+	DiffProductVersions(c *gin.Context) APIResponse
+	RollbackProduct(c *gin.Context) APIResponse
 }
 
 // productHandler struct
@@ -38,19 +48,21 @@ type productHandler struct {
 	WebpageService      webpage.UseCase
 	ProductService      product.UseCase
 	ProductItemService  productItem.UseCase
+	ProductTypeService  productType.UseCase
 	TemplateService     template.Usecase
 	ProductPresenter    presenter.ConvertProduct
 	Validator           validation.CustomValidator
 }
 
 // NewProductHandler create handler
-func NewProductHandler(muc mapping.UseCase, ouc organization.UseCase, wb webpage.UseCase, ds product.UseCase, piuc productItem.UseCase, ts template.Usecase, dp presenter.ConvertProduct, v validation.CustomValidator) ProductHandler {
+func NewProductHandler(muc mapping.UseCase, ouc organization.UseCase, wb webpage.UseCase, ds product.UseCase, piuc productItem.UseCase, ptuc productType.UseCase, ts template.Usecase, dp presenter.ConvertProduct, v validation.CustomValidator) ProductHandler {
 	return &productHandler{
 		MappingService:      muc,
 		OrganizationService: ouc,
 		WebpageService:      wb,
 		ProductService:      ds,
 		ProductItemService:  piuc,
+		ProductTypeService:  ptuc,
 		TemplateService:     ts,
 		ProductPresenter:    dp,
 		Validator:           v,
@@ -61,7 +73,7 @@ func NewProductHandler(muc mapping.UseCase, ouc organization.UseCase, wb webpage
 // CreateProduct	API
 //
 //	@Summary		Create Product
-//	@Description	Create product
+//	@Description	Create product, its attribute must match the schema of its product type
 //	@Tags			product
 //	@Accept			multipart/form-data
 //	@Security		ApiKeyAuth
@@ -92,18 +104,25 @@ func (h *productHandler) CreateProduct(c *gin.Context) APIResponse {
 		err = errors.New("Cannot get organization entity from the given org id")
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	decodeToken, isExisted := c.Get("userInfo")
-	if !isExisted {
-		return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
+	if code, err := CheckOrganizationAccess(c, org.ID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	info := decodeToken.(*entity.User)
-	switch info.Role {
-	case string(entity.ORG_ADMIN_ROLE):
-		if org.NameTag != info.Organization {
-			err := errors.New("Unauthorized: You do not have access to this organization")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	if !request.TemplateID.IsZero() {
+		scope, err := GetAccessScopeFromGinContext(c)
+		if err != nil {
+			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+		}
+		tID := request.TemplateID.Hex()
+		if _, code, err := h.TemplateService.GetTemplateInScope(scope, &tID); err != nil {
+			if code == http.StatusNotFound {
+				return CreateResponse(errors.New(common.MessageErrorInvalidTemplateID), http.StatusBadRequest, "", common.MessageErrorInvalidTemplateID, nil)
+			}
+			return CreateResponse(err, code, "", err.Error(), nil)
 		}
 	}
+	if code, err := h.ProductTypeService.ValidateProduct(&request); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
 	product, code, err := h.ProductService.CreateProduct(&request)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
@@ -136,14 +155,17 @@ func (h *productHandler) CreateProduct(c *gin.Context) APIResponse {
 //	@Failure		400			{object}	APIResponse
 //	@Failure		500			{object}	APIResponse
 func (h *productHandler) GetProductDetail(c *gin.Context) APIResponse {
-	// TODO: Check organization of user correspond to mapping or not
 	var request request.InteractProductDetailRequest
 	request.ProductID = c.Param("product_id")
 	if e := h.Validator.Validate(request); e != nil {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
 
-	product, code, err := h.ProductService.GetProductDetail(&request)
+	product, code, err := h.ProductService.GetProductInScope(scope, &request.ProductID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -157,17 +179,6 @@ func (h *productHandler) GetProductDetail(c *gin.Context) APIResponse {
 		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
 
-	role, orgTagName, err := GetUserRoleAndOrgTagNameFromGinContext(c)
-	if err != nil {
-		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-	}
-	if role == string(entity.ORG_ADMIN_ROLE) {
-		if orgTagName != organization.NameTag {
-			err := errors.New("Unauthorized: You do not have access to this organization")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
-	}
-
 	result := h.ProductPresenter.ResponseGetProductDetail(product, organization)
 	return HandlerResponse(code, "", "", result)
 }
@@ -186,38 +197,25 @@ func (h *productHandler) GetProductDetail(c *gin.Context) APIResponse {
 //	@Failure		203				{object}	APIResponse
 //	@Failure		500				{object}	APIResponse
 func (h *productHandler) GetAllProducts(c *gin.Context) APIResponse {
-	decodeToken, isExisted := c.Get("userInfo")
-	if !isExisted {
-		return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
-	}
-	organizationTagName := c.Query("org_tag_name")
-	info := decodeToken.(*entity.User)
-	if organizationTagName == "" {
-		if info.Role == string(entity.ORG_ADMIN_ROLE) {
-			organizationTagName = info.Organization
-		}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
 	var orgID string
-	if info.Role == string(entity.ORG_ADMIN_ROLE) {
-		if organizationTagName != info.Organization {
-			err := errors.New("Unauthorized: You do not have access to this organization")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
-	}
+	organizationTagName := c.Query("org_tag_name")
 	if organizationTagName != "" {
 		org, code, err := h.OrganizationService.GetOrgByTagName(&organizationTagName)
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
-		if org == nil {
+		if org == nil || !scope.CanAccess(org.ID) {
 			err = errors.New("Invalid org tag name")
-			return CreateResponse(err, code, "", err.Error(), nil)
+			return CreateResponse(err, http.StatusNotFound, "", err.Error(), nil)
 		}
-		// TODO: Check if organization of user is the same with the org of the queried product
 		orgID = org.ID.Hex()
 	}
 
-	products, code, err := h.ProductService.GetAllProductsInOrg(&orgID)
+	products, code, err := h.ProductService.GetProducts(scope, &orgID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -240,11 +238,60 @@ func (h *productHandler) GetAllProducts(c *gin.Context) APIResponse {
 	return HandlerResponse(code, "", "", result)
 }
 
+// SearchProducts	godoc
+// SearchProducts	API
+//
+//	@Summary		Search Products
+//	@Description	Search the product catalog of the organizations the admin manages by words of the name, origin or tags, type, author, organization and status. Pages are read with the next_cursor of the previous page, or by page number.
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/search [get]
+//	@Param			q				query		string	false	"Words of the name, origin or tags"
+//	@Param			type			query		string	false	"Product type"
+//	@Param			author_id		query		string	false	"Author ID"
+//	@Param			org_id			query		string	false	"Organization ID"
+//	@Param			status			query		string	false	"Active or Inactive, Active by default"
+//	@Param			order_by		query		string	false	"created_at, updated_at, product_name, total_item or relevance, relevance by default with q and created_at without"
+//	@Param			order_direction	query		string	false	"asc or desc, desc by default"
+//	@Param			limit			query		int		false	"Products per page, 50 at most and by default"
+//	@Param			page			query		int		false	"Page, from 1, when there is no cursor"
+//	@Param			cursor			query		string	false	"next_cursor of the previous page"
+//	@Success		200				{object}	APIResponse{result=entity.ProductPage}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *productHandler) SearchProducts(c *gin.Context) APIResponse {
+	var req request.SearchProductsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	filter := req.ToFilter()
+	if !filter.OrganizationID.IsZero() {
+		if code, err := CheckOrganizationAccess(c, filter.OrganizationID); err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+	}
+
+	page, code, err := h.ProductService.SearchProducts(scope, filter, req.ToPagination())
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", page)
+}
+
 // UpdateProductDetail	godoc
 // UpdateProductDetail	API
 //
 //	@Summary		Update Product Detail
-//	@Description	Update product detail
+//	@Description	Save the product detail as the draft of the product, its attribute must match the current schema of its product type. Scans keep showing the published version until the draft is published.
 //	@Tags			product
 //	@Accept			multipart/form-data
 //	@Security		ApiKeyAuth
@@ -252,8 +299,9 @@ func (h *productHandler) GetAllProducts(c *gin.Context) APIResponse {
 //	@Router			/admin/product/{product_id} [put]
 //	@Param			product_id				path		string			true	"Product ID"
 //	@Param			update_product_detail	body		entity.Product	true	"Update Product Request"
-//	@Success		200						{object}	APIResponse{result=bool}
+//	@Success		200						{object}	APIResponse{result=entity.ProductVersion}
 //	@Failure		400						{object}	APIResponse
+//	@Failure		404						{object}	APIResponse
 //	@Failure		500						{object}	APIResponse
 func (h *productHandler) UpdateProductDetail(c *gin.Context) APIResponse {
 	var request entity.Product
@@ -266,47 +314,41 @@ func (h *productHandler) UpdateProductDetail(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
 
-	role, orgTagName, err := GetUserRoleAndOrgTagNameFromGinContext(c)
-	if role == string(entity.ORG_ADMIN_ROLE) {
-		product, code, err := h.ProductService.GetProductByID(&productID)
-		if err != nil {
-			return CreateResponse(err, code, "", err.Error(), nil)
-		}
-		orgIDOfProduct := product.OrganizationID.Hex()
-		organization, code, err := h.OrganizationService.GetDetailOrganization(&orgIDOfProduct)
-		if err != nil {
-			return CreateResponse(err, code, "", err.Error(), nil)
-		}
-		if organization == nil {
-			err = errors.New("Cannot get organization entity from product")
-			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-		}
-		if orgTagName != organization.NameTag {
-			err := errors.New("Unauthorized: You do not have access to this organization")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
 
-	//Check updated Template exists
-	if request.TemplateID.Hex() != "" {
-		tID := request.TemplateID.Hex()
-		isExisted, code, err := h.TemplateService.CheckExistedTemplate(&tID)
+	if _, code, err := h.ProductService.GetProductInScope(scope, &productID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if code, err := h.ProductTypeService.ValidateProduct(&request); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
 
+	//Check updated Template exists and is usable by the admin
+	if !request.TemplateID.IsZero() {
+		tID := request.TemplateID.Hex()
+		_, code, err := h.TemplateService.GetTemplateInScope(scope, &tID)
+		if code == http.StatusNotFound {
+			return CreateResponse(errors.New(common.MessageErrorInvalidTemplateID), http.StatusBadRequest, "", common.MessageErrorInvalidTemplateID, nil)
+		}
 		if err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
+	}
 
-		if !isExisted {
-			return CreateResponse(errors.New(common.MessageErrorInvalidTemplateID), http.StatusBadRequest, "", common.MessageErrorInvalidTemplateID, nil)
-		}
+	user, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
 
-	success, code, err := h.ProductService.UpdateProductDetail(&request, &productID)
+	draft, code, err := h.ProductService.SaveProductDraft(scope, &request, &productID, user.ID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
-	return HandlerResponse(code, "", "", success)
+	return HandlerResponse(code, "", "", draft)
 }
 
 // DeteleProductByID	godoc
@@ -329,32 +371,12 @@ func (h *productHandler) DeteleProductByID(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
 
-	role, orgTagName, err := GetUserRoleAndOrgTagNameFromGinContext(c)
-	if role == string(entity.ORG_ADMIN_ROLE) {
-		product, code, err := h.ProductService.GetProductByID(&request.ProductID)
-		if err != nil {
-			return CreateResponse(err, code, "", err.Error(), nil)
-		}
-		orgIDOfProduct := product.OrganizationID.Hex()
-		organization, code, err := h.OrganizationService.GetDetailOrganization(&orgIDOfProduct)
-		if err != nil {
-			return CreateResponse(err, code, "", err.Error(), nil)
-		}
-		if organization == nil {
-			err = errors.New("Cannot get organization entity from product")
-			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-		}
-
-		if err != nil {
-			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-		}
-		if orgTagName != organization.NameTag {
-			err := errors.New("Unauthorized: You do not have access to this organization")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
 
-	success, code, err := h.ProductService.DeteleProductByID(&request)
+	success, code, err := h.ProductService.DeteleProductByID(scope, &request)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -431,10 +453,58 @@ func (h *productHandler) CloneProductByID(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
 	}
 
-	prod, code, err := h.ProductService.CloneProductByID(&req.ProductID)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	prod, code, err := h.ProductService.CloneProductByID(scope, &req.ProductID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
 	return HandlerResponse(http.StatusOK, "", "", prod)
 }
+
+// DeepCloneProduct	godoc
+// DeepCloneProduct	API
+//
+//	@Summary		Deep Clone Product
+//	@Description	Clone the product as a new product at version 1, in another organization the admin manages when org_id is given. With clone_template the template and all its webpages are cloned too and the clone references the copies, otherwise it keeps the template, which must then be usable by its organization. total_item items are created for the clone. Nothing is created when a copy fails. id_map gives the ID of the copy of each document by ID of the original.
+//	@Tags			product
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/clone [post]
+//	@Param			product_id					path		string							true	"Product ID"
+//	@Param			deep_clone_product_request	body		request.DeepCloneProductRequest	true	"Deep Clone Product Request"
+//	@Success		200							{object}	APIResponse{result=entity.ProductCloneResult}
+//	@Failure		400							{object}	APIResponse
+//	@Failure		404							{object}	APIResponse
+func (h *productHandler) DeepCloneProduct(c *gin.Context) APIResponse {
+	var req request.DeepCloneProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	options := req.ToOptions()
+	if !options.OrganizationID.IsZero() {
+		if code, err := CheckOrganizationAccess(c, options.OrganizationID); err != nil {
+			return CreateResponse(err, code, "", err.Error(), nil)
+		}
+	}
+
+	productID := c.Param("product_id")
+	result, code, err := h.ProductService.DeepCloneProduct(scope, &productID, options)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
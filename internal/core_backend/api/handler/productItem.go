@@ -3,9 +3,9 @@ package handler
 import (
 	"backend-service/internal/core_backend/usecase/author"
 	"errors"
-	"math/big"
 	"net/http"
 	"strconv"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -20,6 +20,7 @@ import (
 	"backend-service/internal/core_backend/usecase/digitalAsset"
 	"backend-service/internal/core_backend/usecase/digitalAssetCollection"
 	"backend-service/internal/core_backend/usecase/mapping"
+	"backend-service/internal/core_backend/usecase/metadataTemplate"
 	"backend-service/internal/core_backend/usecase/nft"
 	"backend-service/internal/core_backend/usecase/organization"
 	"backend-service/internal/core_backend/usecase/product"
@@ -34,12 +35,9 @@ type ProductItemHandler interface {
 	CreateProductItem(*gin.Context) APIResponse
 	CreateMultipleProductItems(*gin.Context) APIResponse
 	GetDetailProductItem(*gin.Context) APIResponse
-	GetMetadata(*gin.Context) APIResponse
 	GetAllProductItem(c *gin.Context) APIResponse
 	GetAllProductItemInOrg(c *gin.Context) APIResponse
-	ClaimItem(*gin.Context) APIResponse
 	GetStoryByTagID(*gin.Context) APIResponse
-	ToggleClaimableItem(*gin.Context) APIResponse
 	LikeProductItem(*gin.Context) APIResponse
 	GetGalleryOfProductItemsInOrg(*gin.Context) APIResponse
 	GetGalleryOfProductItemsInOrgV2(*gin.Context) APIResponse
@@ -61,10 +59,11 @@ type productItemHandler struct {
 	DigitalAssetCollectionService digitalAssetCollection.UseCase
 	NFTService                    nft.UseCase
 	AuthorService                 author.UseCase
+	MetadataTemplateService       metadataTemplate.UseCase
 }
 
 // NewItemHandler create handler
-func NewProductItemHandler(uuc user.UseCase, puc product.UseCase, piuc productItem.UseCase, dp presenter.ConvertProductItem, m mapping.UseCase, o organization.UseCase, t template.Usecase, w webpage.UseCase, v validation.CustomValidator, duc digitalAsset.UseCase, cuc digitalAssetCollection.UseCase, nft nft.UseCase, author author.UseCase) ProductItemHandler {
+func NewProductItemHandler(uuc user.UseCase, puc product.UseCase, piuc productItem.UseCase, dp presenter.ConvertProductItem, m mapping.UseCase, o organization.UseCase, t template.Usecase, w webpage.UseCase, v validation.CustomValidator, duc digitalAsset.UseCase, cuc digitalAssetCollection.UseCase, nft nft.UseCase, author author.UseCase, mt metadataTemplate.UseCase) ProductItemHandler {
 	return &productItemHandler{
 		UserService:                   uuc,
 		ProductService:                puc,
@@ -79,6 +78,7 @@ func NewProductItemHandler(uuc user.UseCase, puc product.UseCase, piuc productIt
 		DigitalAssetCollectionService: cuc,
 		NFTService:                    nft,
 		AuthorService:                 author,
+		MetadataTemplateService:       mt,
 	}
 }
 
@@ -96,7 +96,6 @@ func NewProductItemHandler(uuc user.UseCase, puc product.UseCase, piuc productIt
 //	@Success		200							{object}	APIResponse{result=presenter.ProductItemDetailResponse}
 //	@Failure		400							{object}	APIResponse
 func (h *productItemHandler) CreateProductItem(c *gin.Context) APIResponse {
-	// TODO: Will be check owner of the product and organzation ID
 	var req request.CreateProductItemRequest
 	if err := c.ShouldBind(&req); err != nil {
 		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
@@ -106,14 +105,16 @@ func (h *productItemHandler) CreateProductItem(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
 	}
 
-	item, code, err := h.ProductItemService.CreateProductItem(&req)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	product, code, err := h.ProductService.GetProductInScope(scope, &req.ProductID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
-	var reqProduct request.InteractProductDetailRequest
-	reqProduct.ProductID = item.ProductID.Hex()
-	product, code, err := h.ProductService.GetProductDetail(&reqProduct)
+	item, code, err := h.ProductItemService.CreateProductItem(&req)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -137,7 +138,6 @@ func (h *productItemHandler) CreateProductItem(c *gin.Context) APIResponse {
 //	@Success		200										{object}	APIResponse{result=bool}
 //	@Failure		400										{object}	APIResponse
 func (h *productItemHandler) CreateMultipleProductItems(c *gin.Context) APIResponse {
-	// TODO: Will be check owner of the product and organzation ID
 	var req request.CreateMultipleProductItemsRequest
 	if err := c.ShouldBind(&req); err != nil {
 		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
@@ -146,7 +146,11 @@ func (h *productItemHandler) CreateMultipleProductItems(c *gin.Context) APIRespo
 	if e := h.Validator.Validate(req); e != nil {
 		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
 	}
-	product, code, err := h.ProductService.GetProductByID(&req.ProductID)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	product, code, err := h.ProductService.GetProductInScope(scope, &req.ProductID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -223,7 +227,11 @@ func (h *productItemHandler) GetAllProductItem(c *gin.Context) APIResponse {
 		err := errors.New("Required product_id query")
 		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
-	product, code, err := h.ProductService.GetProductByID(&productID)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	product, code, err := h.ProductService.GetProductInScope(scope, &productID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -274,21 +282,6 @@ func (h *productItemHandler) GetAllProductItem(c *gin.Context) APIResponse {
 	return HandlerResponse(code, "", "", result)
 }
 
-func (h *productItemHandler) GetMetadata(c *gin.Context) APIResponse {
-	tokenId := c.Param("token_id")
-	tId := new(big.Int)
-
-	tId, success := tId.SetString(tokenId, 10)
-	if !success {
-		err := errors.New("Require token_id")
-		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
-	}
-
-	result := h.ProductItemPresenter.ResponseGetMetadata(tId)
-
-	return HandlerResponse(http.StatusOK, "", "", result)
-}
-
 // GetAllProductItemInOrg	godoc
 // GetAllProductItemInOrg	API
 //
@@ -307,24 +300,10 @@ func (h *productItemHandler) GetAllProductItemInOrg(c *gin.Context) APIResponse
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	oID := org.ID.Hex()
-	decodeToken, isExisted := c.Get("userInfo")
-	if !isExisted {
-		return CreateResponse(errors.New(common.MessageErrorFailDetectUser), http.StatusNonAuthoritativeInfo, "", common.MessageErrorFailDetectUser, nil)
-	}
-
-	info := decodeToken.(*entity.User)
-	switch info.Role {
-	case string(entity.ORG_ADMIN_ROLE):
-		if info.Organization != orgTagName {
-			err = errors.New("Unauthorized: You can't get product items that are NOT in your organization!")
-			return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-		}
-	case string(entity.SUPER_ADMIN_ROLE):
-	default:
-		err := errors.New("Unidentified user role in token")
+	if code, err := CheckOrganizationAccess(c, org.ID); err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
+	oID := org.ID.Hex()
 	productItems, code, err := h.ProductItemService.GetProductItemsInOrg(&oID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
@@ -363,45 +342,6 @@ func (h *productItemHandler) GetAllProductItemInOrg(c *gin.Context) APIResponse
 	return HandlerResponse(code, "", "", result)
 }
 
-// ClaimItem	godoc
-// ClaimItem	API
-//
-//	@Summary		Claim Product Item
-//	@Description	Claim product item
-//	@Tags			product-item user
-//	@Security		ApiKeyAuth
-//	@Produce		json
-//	@Router			/product-item/{product_item_id}/claim [put]
-//	@Param			product_item_id	path		string	true	"Product Item ID"
-//	@Success		200				{object}	APIResponse{result=bool}
-//	@Failure		400				{object}	APIResponse
-func (h *productItemHandler) ClaimItem(c *gin.Context) APIResponse {
-	req := request.SetOwnerRequest{
-		Token:         c.GetHeader("Authorization"),
-		ProductItemID: c.Param("product_item_id"),
-	}
-
-	if e := h.Validator.Validate(req); e != nil {
-		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
-	}
-
-	user, code, err := h.UserService.TokenToUser(&req.Token)
-	if err != nil {
-		return CreateResponse(err, code, "", err.Error(), nil)
-	}
-	if user == nil {
-		return CreateResponse(nil, http.StatusBadRequest, "", common.MessageErrorNotFoundUser, nil)
-	}
-
-	req.OwnerID = user.ID
-	result, code, err := h.ProductItemService.SetOnwerForItem(&req)
-	if err != nil {
-		return CreateResponse(err, code, "", err.Error(), nil)
-	}
-
-	return HandlerResponse(code, "", "", result)
-}
-
 // GetStoryByTagID	godoc
 // GetStoryByTagID	API
 //
@@ -411,6 +351,7 @@ func (h *productItemHandler) ClaimItem(c *gin.Context) APIResponse {
 //	@Produce		json
 //	@Router			/product-item/story [get]
 //	@Param			tag_id	query		string	true	"Tag ID Query"
+//	@Param			lang	query		string	false	"Languages to write localized texts in, e.g. fr,en"
 //	@Success		200		{object}	APIResponse{result=presenter.StoryDetailResponse}
 //	@Failure		400		{object}	APIResponse
 //	@Failure		500		{object}	APIResponse
@@ -480,14 +421,9 @@ func (h *productItemHandler) GetStoryByTagID(c *gin.Context) APIResponse {
 		}
 	}
 
-	for _, page := range template.Pages {
-		if page.Type == "home" {
-			homepage = &page
-			break
-		}
-	}
+	homepage = template.HomePage()
 	if homepage == nil {
-		err = errors.New("Template doesn't have 'home' in pages (template_id = " + template.ID.Hex() + " )")
+		err = entity.ErrTemplateWithoutHome
 		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
 
@@ -506,47 +442,22 @@ func (h *productItemHandler) GetStoryByTagID(c *gin.Context) APIResponse {
 	}
 	result := h.ProductItemPresenter.ResponseGetStoryDetail(mapping, product, productItem, owner, template, homepage, organization, da, dac, at)
 
-	return HandlerResponse(http.StatusOK, "", "", result)
-}
-
-// ToggleClaimableItem	godoc
-// ToggleClaimableItem	API
-//
-//	@Summary		Toggle Claimable Item
-//	@Description	Toggle Claimable Item
-//	@Tags			product-item user
-//	@Produce		json
-//	@Router			/product-item/{product_item_id}/toggle-claimable [put]
-//	@Param			product_item_id	path		string	true	"Product Item ID"
-//	@Success		200				{object}	APIResponse{result=bool}
-//	@Failure		400				{object}	APIResponse
-//	@Failure		500				{object}	APIResponse
-func (h *productItemHandler) ToggleClaimableItem(c *gin.Context) APIResponse {
-	var req request.ProductItemInteractionRequest
-	req.ProductItemID = c.Param("product_item_id")
-	if e := h.Validator.Validate(req); e != nil {
-		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
-	}
-
-	success, code, err := h.ProductItemService.ToggleClaimable(&req)
-	if err != nil {
-		return CreateResponse(err, code, "", err.Error(), nil)
-	}
-
-	return HandlerResponse(code, "", "", success)
+	return HandlerResponse(http.StatusOK, "", "", LocalizeResult(c, result))
 }
 
 // LikeProductItem	godoc
 // LikeProductItem	API
 //
 //	@Summary		Like Product Item
-//	@Description	Like Product Item
+//	@Description	Like Product Item, users like an item once. Returns false when the user already liked it.
 //	@Tags			product-item user
+//	@Security		ApiKeyAuth
 //	@Produce		json
 //	@Router			/product-item/{product_item_id}/like [post]
 //	@Param			product_item_id	path		string	true	"Product Item ID"
 //	@Success		200				{object}	APIResponse{result=bool}
 //	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
 //	@Failure		500				{object}	APIResponse
 func (h *productItemHandler) LikeProductItem(c *gin.Context) APIResponse {
 	var req request.ProductItemLikeRequest
@@ -555,8 +466,13 @@ func (h *productItemHandler) LikeProductItem(c *gin.Context) APIResponse {
 	if e := h.Validator.Validate(req); e != nil {
 		return CreateResponse(e, http.StatusBadRequest, "", e.Error(), nil)
 	}
+	user, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	req.UserID = user.ID
 
-	ok, code, err := h.ProductItemService.UpdateTotalLike(&req.ProductItemID)
+	ok, code, err := h.ProductItemService.LikeProductItem(&req)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -573,6 +489,7 @@ func (h *productItemHandler) LikeProductItem(c *gin.Context) APIResponse {
 //	@Produce		json
 //	@Router			/competition/{org_tag_name} [get]
 //	@Param			org_tag_name	path		string	true	"Organization Tag Name (Competition Name)"
+//	@Param			lang			query		string	false	"Languages to write localized texts in, e.g. fr,en"
 //	@Success		200				{array}		APIResponse{result=presenter.GalleryProductItemsListResponse}
 //	@Failure		203				{object}	APIResponse
 //	@Failure		400				{object}	APIResponse
@@ -631,19 +548,17 @@ func (h *productItemHandler) GetGalleryOfProductItemsInOrg(c *gin.Context) APIRe
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
 
-		var homepage, craftsmen entity.WebPage
+		homepage := template.HomePage()
+		if homepage == nil {
+			err = entity.ErrTemplateWithoutHome
+			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+		}
+		var craftsmen entity.WebPage
 		for _, page := range template.Pages {
-			if page.Type == "home" {
-				homepage = page
-			}
 			if page.Type == "craftsmen" {
 				craftsmen = page
 			}
 		}
-		if homepage.ID.IsZero() {
-			err = errors.New("Template doesn't have 'home' in pages (template_id = " + template.ID.Hex() + " )")
-			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-		}
 
 		if !craftsmen.ID.IsZero() {
 			craftsmens = append(craftsmens, craftsmen)
@@ -672,11 +587,11 @@ func (h *productItemHandler) GetGalleryOfProductItemsInOrg(c *gin.Context) APIRe
 		mappings = append(mappings, mapping)
 		products = append(products, *product)
 		totalLikes = append(totalLikes, productItem.TotalLike)
-		homepages = append(homepages, homepage)
+		homepages = append(homepages, *homepage)
 		templates = append(templates, *template)
 	}
 	result := h.ProductItemPresenter.ResponseGalleryProductItems(&org.OrganizationName, totalLikes, &mappings, &products, &homepages, &craftsmens, &templates, &das, &dacs)
-	return HandlerResponse(code, "", "", result)
+	return HandlerResponse(code, "", "", LocalizeResult(c, result))
 }
 
 // GetGalleryOfProductItemsInOrgV2	godoc
@@ -688,6 +603,7 @@ func (h *productItemHandler) GetGalleryOfProductItemsInOrg(c *gin.Context) APIRe
 //	@Produce		json
 //	@Router			/competition/v2/{org_tag_name} [get]
 //	@Param			org_tag_name	path		string	true	"Organization Tag Name (Competition Name)"
+//	@Param			lang			query		string	false	"Languages to write localized texts in, e.g. fr,en"
 //	@Success		200				{array}		APIResponse{result=presenter.GalleryProductItemsListResponse}
 //	@Failure		203				{object}	APIResponse
 //	@Failure		400				{object}	APIResponse
@@ -784,7 +700,7 @@ func (h *productItemHandler) GetGalleryOfProductItemsInOrgV2(c *gin.Context) API
 		orgs = append(orgs, org)
 	}
 	result := h.ProductItemPresenter.ResponseGalleryProductItemsV2(&mappings, &products, &productItems, &owners, &templates, &orgs, &das, &dacs)
-	return HandlerResponse(code, "", "", result)
+	return HandlerResponse(code, "", "", LocalizeResult(c, result))
 }
 
 // MintProductItem	godoc
@@ -800,15 +716,20 @@ func (h *productItemHandler) GetGalleryOfProductItemsInOrgV2(c *gin.Context) API
 //	@Param			product_item_id	path		string	true	"Product Item ID"
 //	@Success		200				{object}	APIResponse{result=bool}
 //	@Failure		400				{object}	APIResponse
+//	@Failure		409				{object}	APIResponse
 func (h *productItemHandler) MintProductItem(c *gin.Context) APIResponse {
 	pItemID := c.Param("product_item_id")
-	mapping, code, err := h.MappingService.GetMappingWithProductItemID(&pItemID)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	mapping, code, err := h.MappingService.GetMappingWithProductItemIDInScope(scope, &pItemID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	if mapping == nil {
-		err := errors.New("Mapping not found with the given product item")
-		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	if mapping.ClaimStateAt(time.Now()) == entity.ClaimStateLocked {
+		err := errors.New(common.MessageErrorItemLocked)
+		return CreateResponse(err, http.StatusConflict, "", err.Error(), nil)
 	}
 	if mapping.OwnerID == "" {
 		err := errors.New("OwnerID not found in mapping with given product item. Only mint when there is a valid OwnerID.")
@@ -823,16 +744,25 @@ func (h *productItemHandler) MintProductItem(c *gin.Context) APIResponse {
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	contractAddr := collection.ContractAddress
-	txHash, code, err := h.NFTService.Mint(&contractAddr, &owner.WalletAddress)
+	collectionID := collection.ID.Hex()
+	mTemplate, code, err := h.MetadataTemplateService.GetTemplateByCollectionID(&collectionID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	pItemProductOrgAggregate, code, err := h.ProductItemService.GetProductItemProductOrgAggregate(&pItemID)
+	metadataSource, code, err := h.DigitalAssetService.GetMetadataSourceByProductItemID(&pItemID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if owner == nil || owner.MintAddress() == "" {
+		err := errors.New("Owner has no wallet to mint to")
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	mintAddress := owner.MintAddress()
+	txHash, code, err := h.NFTService.Mint(collection, &mintAddress)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	metadata := h.DigitalAssetService.ConstructMetadata(pItemProductOrgAggregate.ItemIndex, &pItemProductOrgAggregate.OrgTagName, &pItemProductOrgAggregate.Product)
+	metadata := h.DigitalAssetService.ConstructMetadata(mTemplate, metadataSource)
 	da := &entity.DigitalAsset{
 		CollectionID: collection.ID,
 		BaseModel: entity.BaseModel{
@@ -856,7 +786,7 @@ func (h *productItemHandler) MintProductItem(c *gin.Context) APIResponse {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
-	go h.NFTService.WatchTransaction(&txHash)
+	go h.NFTService.WatchTransaction(int64(collection.ChainID), &txHash)
 
 	return HandlerResponse(code, "", "", ok)
 }
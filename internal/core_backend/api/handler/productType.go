@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/entity"
+	validation "backend-service/internal/core_backend/infrastructure/validator"
+	"backend-service/internal/core_backend/usecase/productType"
+)
+
+// ProductTypeHandler interface
+type ProductTypeHandler interface {
+	GetProductTypes(*gin.Context) APIResponse
+	GetProductType(*gin.Context) APIResponse
+	GetProductTypeSchemas(*gin.Context) APIResponse
+	CreateProductType(*gin.Context) APIResponse
+	UpdateProductType(*gin.Context) APIResponse
+	DeleteProductType(*gin.Context) APIResponse
+}
+
+// productTypeHandler struct
+type productTypeHandler struct {
+	ProductTypeService productType.UseCase
+	Validator          validation.CustomValidator
+}
+
+// NewProductTypeHandler create handler
+func NewProductTypeHandler(ps productType.UseCase, v validation.CustomValidator) ProductTypeHandler {
+	return &productTypeHandler{
+		ProductTypeService: ps,
+		Validator:          v,
+	}
+}
+
+// GetProductTypes	godoc
+// GetProductTypes	API
+//
+//	@Summary		Get product types
+//	@Description	List the product types with the JSON Schema the attribute of their products must match
+//	@Tags			product-type
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-type [get]
+//	@Success		200	{object}	APIResponse{result=[]entity.ProductType}
+//	@Failure		500	{object}	APIResponse
+func (h *productTypeHandler) GetProductTypes(c *gin.Context) APIResponse {
+	productTypes, code, err := h.ProductTypeService.GetProductTypes()
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", productTypes)
+}
+
+// GetProductType	godoc
+// GetProductType	API
+//
+//	@Summary		Get product type
+//	@Description	Get a product type with the current version of its schema
+//	@Tags			product-type
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-type/{name} [get]
+//	@Param			name	path		string	true	"Product type name"
+//	@Success		200		{object}	APIResponse{result=entity.ProductType}
+//	@Failure		404		{object}	APIResponse
+func (h *productTypeHandler) GetProductType(c *gin.Context) APIResponse {
+	name := c.Param("name")
+	result, code, err := h.ProductTypeService.GetProductType(&name)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
+
+// GetProductTypeSchemas	godoc
+// GetProductTypeSchemas	API
+//
+//	@Summary		Get product type schemas
+//	@Description	List every version of the schema of a product type, newest first. Products record the version they were validated against in type_version.
+//	@Tags			product-type
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-type/{name}/versions [get]
+//	@Param			name	path		string	true	"Product type name"
+//	@Success		200		{object}	APIResponse{result=[]entity.ProductTypeSchema}
+//	@Failure		404		{object}	APIResponse
+func (h *productTypeHandler) GetProductTypeSchemas(c *gin.Context) APIResponse {
+	name := c.Param("name")
+	schemas, code, err := h.ProductTypeService.GetProductTypeSchemas(&name)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", schemas)
+}
+
+// CreateProductType	godoc
+// CreateProductType	API
+//
+//	@Summary		Create product type
+//	@Description	Create a product type, only admins of all organizations can. The schema supports type, enum, const, properties, required, additionalProperties, items, length, size and range bounds, pattern, allOf, anyOf, oneOf and $ref to $defs.
+//	@Tags			product-type
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-type [post]
+//	@Param			request	body		request.CreateProductTypeRequest	true	"Create Product Type Request"
+//	@Success		200		{object}	APIResponse{result=entity.ProductType}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+func (h *productTypeHandler) CreateProductType(c *gin.Context) APIResponse {
+	var req request.CreateProductTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if err := h.Validator.Validate(req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	result, code, err := h.ProductTypeService.CreateProductType(scope, &entity.ProductType{
+		Name:        req.Name,
+		DisplayName: req.DisplayName,
+		Schema:      req.Schema,
+	})
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
+
+// UpdateProductType	godoc
+// UpdateProductType	API
+//
+//	@Summary		Update product type
+//	@Description	Change the display name or the schema of a product type, only admins of all organizations can. A new schema is a new version, existing products are validated against it when they are next updated.
+//	@Tags			product-type
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-type/{name} [put]
+//	@Param			name	path		string								true	"Product type name"
+//	@Param			request	body		request.UpdateProductTypeRequest	true	"Update Product Type Request"
+//	@Success		200		{object}	APIResponse{result=entity.ProductType}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+func (h *productTypeHandler) UpdateProductType(c *gin.Context) APIResponse {
+	var req request.UpdateProductTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	name := c.Param("name")
+	result, code, err := h.ProductTypeService.UpdateProductType(scope, &name, &entity.ProductType{
+		DisplayName: req.DisplayName,
+		Schema:      req.Schema,
+	})
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
+
+// DeleteProductType	godoc
+// DeleteProductType	API
+//
+//	@Summary		Delete product type
+//	@Description	Delete a product type and its schema versions, only admins of all organizations can. Types used by products cannot be deleted.
+//	@Tags			product-type
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-type/{name} [delete]
+//	@Param			name	path		string	true	"Product type name"
+//	@Success		200		{object}	APIResponse{result=bool}
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+func (h *productTypeHandler) DeleteProductType(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	name := c.Param("name")
+	ok, code, err := h.ProductTypeService.DeleteProductType(scope, &name)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", ok)
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// GetProductDraft	godoc
+// GetProductDraft	API
+//
+//	@Summary		Get Product Draft
+//	@Description	Get the draft of the product, the content saved for its next version
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/draft [get]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Success		200			{object}	APIResponse{result=entity.ProductVersion}
+//	@Failure		404			{object}	APIResponse
+func (h *productHandler) GetProductDraft(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	draft, code, err := h.ProductService.GetProductDraft(scope, &productID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", draft)
+}
+
+// DiscardProductDraft	godoc
+// DiscardProductDraft	API
+//
+//	@Summary		Discard Product Draft
+//	@Description	Delete the draft of the product, the published version is unchanged
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/draft [delete]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Success		200			{object}	APIResponse{result=bool}
+//	@Failure		404			{object}	APIResponse
+func (h *productHandler) DiscardProductDraft(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	ok, code, err := h.ProductService.DiscardProductDraft(scope, &productID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", ok)
+}
+
+// PublishProductDraft	godoc
+// PublishProductDraft	API
+//
+//	@Summary		Publish Product Draft
+//	@Description	Make the draft of the product live as its next version. The attribute is validated again against the current schema of the product type. A draft saved before another version was published has to be saved again first.
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/publish [post]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Success		200			{object}	APIResponse{result=entity.ProductVersion}
+//	@Failure		400			{object}	APIResponse
+//	@Failure		404			{object}	APIResponse
+//	@Failure		409			{object}	APIResponse
+func (h *productHandler) PublishProductDraft(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	user, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	draft, code, err := h.ProductService.GetProductDraft(scope, &productID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if code, err := h.ProductTypeService.ValidateProduct(&draft.Content); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	version, code, err := h.ProductService.PublishProductDraft(scope, draft, user.ID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", version)
+}
+
+// GetProductVersions	godoc
+// GetProductVersions	API
+//
+//	@Summary		Get Product Versions
+//	@Description	List the published versions of the product, newest first
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/versions [get]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Success		200			{object}	APIResponse{result=[]entity.ProductVersion}
+//	@Failure		404			{object}	APIResponse
+func (h *productHandler) GetProductVersions(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	versions, code, err := h.ProductService.GetProductVersions(scope, &productID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", versions)
+}
+
+// GetProductVersion	godoc
+// GetProductVersion	API
+//
+//	@Summary		Get Product Version
+//	@Description	Get a version of the product, draft for its draft
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/versions/{version} [get]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Param			version		path		string	true	"Version number or draft"
+//	@Success		200			{object}	APIResponse{result=entity.ProductVersion}
+//	@Failure		400			{object}	APIResponse
+//	@Failure		404			{object}	APIResponse
+func (h *productHandler) GetProductVersion(c *gin.Context) APIResponse {
+	version, err := parseProductVersion(c.Param("version"))
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	result, code, err := h.ProductService.GetProductVersion(scope, &productID, version)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
+
+// DiffProductVersions	godoc
+// DiffProductVersions	API
+//
+//	@Summary		Diff Product Versions
+//	@Description	List the fields of the content of the product that differ between two versions, by JSON path. Compares the published version to the draft by default.
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/diff [get]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Param			from		query		string	false	"Version number or draft, the published version by default"
+//	@Param			to			query		string	false	"Version number or draft, the draft by default"
+//	@Success		200			{object}	APIResponse{result=entity.ProductDiff}
+//	@Failure		400			{object}	APIResponse
+//	@Failure		404			{object}	APIResponse
+func (h *productHandler) DiffProductVersions(c *gin.Context) APIResponse {
+	var from, to *int
+	for query, version := range map[string]**int{"from": &from, "to": &to} {
+		value, ok := c.GetQuery(query)
+		if !ok {
+			continue
+		}
+		number, err := parseProductVersion(value)
+		if err != nil {
+			return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+		}
+		*version = &number
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	diff, code, err := h.ProductService.DiffProductVersions(scope, &productID, from, to)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", diff)
+}
+
+// RollbackProduct	godoc
+// RollbackProduct	API
+//
+//	@Summary		Rollback Product
+//	@Description	Publish the content of a previous version again as the next version. The draft is kept, it has to be saved again to be published.
+//	@Tags			product
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/versions/{version}/rollback [post]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Param			version		path		int		true	"Version number"
+//	@Success		200			{object}	APIResponse{result=entity.ProductVersion}
+//	@Failure		400			{object}	APIResponse
+//	@Failure		404			{object}	APIResponse
+//	@Failure		409			{object}	APIResponse
+func (h *productHandler) RollbackProduct(c *gin.Context) APIResponse {
+	version, err := parseProductVersion(c.Param("version"))
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	user, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	result, code, err := h.ProductService.RollbackProduct(scope, &productID, version, user.ID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
+
+// parseProductVersion a version number, or entity.DraftVersion for draft
+func parseProductVersion(value string) (int, error) {
+	if value == "draft" {
+		return entity.DraftVersion, nil
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, errors.New("version must be a version number or draft")
+	}
+
+	return version, nil
+}
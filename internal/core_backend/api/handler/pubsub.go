@@ -1,19 +1,16 @@
 package handler
 
 import (
-	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"time"
 
 	"google.golang.org/api/pubsub/v1"
 
-	config "backend-service/config/core_backend"
-	"backend-service/internal/core_backend/api/presenter"
-	"backend-service/internal/core_backend/infrastructure/callers"
+	"backend-service/internal/core_backend/common/logger"
 	validation "backend-service/internal/core_backend/infrastructure/validator"
+	"backend-service/internal/core_backend/usecase/pubsubMessage"
 	"backend-service/internal/core_backend/usecase/user"
+	"backend-service/internal/core_backend/usecase/wallet"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,15 +22,19 @@ type PubsubHandler interface {
 
 // pubsubHandler struct
 type pubsubHandler struct {
-	UserService user.UseCase
-	Validator   validation.CustomValidator
+	UserService          user.UseCase
+	WalletService        wallet.UseCase
+	PubsubMessageService pubsubMessage.UseCase
+	Validator            validation.CustomValidator
 }
 
 // NewPubsubHandler create handler
-func NewPubsubHandler(uc user.UseCase, v validation.CustomValidator) PubsubHandler {
+func NewPubsubHandler(uc user.UseCase, wu wallet.UseCase, pu pubsubMessage.UseCase, v validation.CustomValidator) PubsubHandler {
 	return &pubsubHandler{
-		UserService: uc,
-		Validator:   v,
+		UserService:          uc,
+		WalletService:        wu,
+		PubsubMessageService: pu,
+		Validator:            v,
 	}
 }
 
@@ -41,7 +42,7 @@ func NewPubsubHandler(uc user.UseCase, v validation.CustomValidator) PubsubHandl
 // UpsertUser	API
 //
 //	@Summary		Upsert User
-//	@Description	Upsert user
+//	@Description	Upsert user. A message redelivered within the replay window is not processed again, the stored user is returned.
 //	@Tags			pubsub
 //	@Accept			json
 //	@Security		ApiKeyAuth
@@ -64,32 +65,34 @@ func (h *pubsubHandler) UpsertUser(c *gin.Context) APIResponse {
 	if userID == "" || &userID == nil {
 		return CreateResponse(errors.New("userId is required"), http.StatusBadRequest, "", "", nil)
 	}
+	first, code, err := h.PubsubMessageService.ClaimMessage(msg.Message)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
 	user, code, err := h.UserService.GetUserByID(&userID)
 	if err != nil {
+		h.releaseMessage(first, msg.Message)
 		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
+	// Redelivered message, it was processed already
+	if !first {
+		return APIResponse{
+			Code:   code,
+			Result: user,
+		}
+	}
 	if user == nil {
 		user, code, err = h.UserService.UpsertUserFromFireBase(&userID)
 		if err != nil {
+			h.releaseMessage(first, msg.Message)
 			return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 		}
 	}
-	// responseData, err := h.requestUserWallet(&user.Email)
-	// if err != nil {
-	// 	return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-	// }
-	//
-	// updateReq := request.UpdateUserDetailsRequest{
-	// 	WalletAddress: &responseData.Result.WalletAddress,
-	// }
-	// ok, code, err := h.UserService.UpdateUserDetails(&user.ID, &updateReq)
-	// if err != nil {
-	// 	return CreateResponse(err, code, "", err.Error(), nil)
-	// }
-	// if !ok {
-	// 	err = errors.New("Couldn't update user wallet address")
-	// 	return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-	// }
+	// Provisioned in the background, a failure is retried by the wallet reconciliation job
+	if user.WalletAddress == "" {
+		go h.WalletService.ProvisionUserWallet(user)
+	}
 
 	return APIResponse{
 		Code:   code,
@@ -97,38 +100,12 @@ func (h *pubsubHandler) UpsertUser(c *gin.Context) APIResponse {
 	}
 }
 
-func (h *pubsubHandler) requestUserWallet(email *string) (*presenter.CreateWalletResponse, error) {
-	data := map[string]interface{}{
-		"client_id": config.C.Wallet.WALLET_CLIENT_ID,
-		"email":     *email,
-	}
-
-	req, err := callers.CreateRequest(config.C.Wallet.WALLET_DOMAIN_V1+"/wallets", "POST", data)
-	if err != nil {
-		return nil, err
+// releaseMessage lets Pub/Sub redeliver a message this request claimed but failed to process
+func (h *pubsubHandler) releaseMessage(claimed bool, msg *pubsub.PubsubMessage) {
+	if !claimed {
+		return
 	}
-
-	req.Header.Set("x-client-key", config.C.Wallet.WALLET_CLIENT_KEY)
-
-	client := http.Client{
-		Timeout: time.Duration(config.C.Wallet.WALLET_API_TIMEOUT) * time.Second,
-	}
-
-	resp, err := callers.SendRequest(&client, req)
-	defer resp.Body.Close()
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("Received non-OK response: %s", resp.Status)
-		return nil, err
-	}
-	responseData := presenter.CreateWalletResponse{}
-
-	// Decode JSON response into responseData
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&responseData); err != nil {
-		return nil, err
+	if _, _, err := h.PubsubMessageService.ReleaseMessage(&msg.MessageId); err != nil {
+		logger.LogError("Error when releasing pubsub message " + msg.MessageId + ": " + err.Error())
 	}
-	return &responseData, nil
 }
@@ -0,0 +1,18 @@
+package request
+
+import (
+	"time"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+type CreateAPIKeyRequest struct {
+	OrganizationID string              `json:"org_id" validate:"omitempty,mongodb"`
+	Name           string              `json:"name" validate:"required,max=64"`
+	Permissions    []entity.Permission `json:"permissions" validate:"required,min=1"`
+	ExpiresAt      *time.Time          `json:"expires_at"`
+}
+
+type RevokeAPIKeyRequest struct {
+	APIKeyID string `validate:"required,mongodb"`
+}
@@ -1,6 +1,12 @@
 package request
 
-import "strconv"
+import (
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
 
 type GetAssetByCollectionRequest struct {
 	CollectionID string
@@ -16,3 +22,84 @@ func (r *GetDigitalMetadataWithIDRequest) TokenIDToInt() int {
 
 	return tokenIDInInt
 }
+
+type CollectionMetadataTemplateRequest struct {
+	CollectionID string `validate:"required,mongodb"`
+}
+
+type UpsertMetadataTemplateRequest struct {
+	Name         string                             `json:"name" validate:"required"`
+	Description  string                             `json:"description"`
+	Image        string                             `json:"image" validate:"required"`
+	AnimationURL string                             `json:"animation_url"`
+	ExternalURL  string                             `json:"external_url"`
+	Attributes   []entity.MetadataTemplateAttribute `json:"attributes" validate:"dive"`
+}
+
+// ToEntity converts the request into the metadata template of the given collection
+func (r *UpsertMetadataTemplateRequest) ToEntity(collectionID primitive.ObjectID) *entity.MetadataTemplate {
+	return &entity.MetadataTemplate{
+		CollectionID: collectionID,
+		Name:         r.Name,
+		Description:  r.Description,
+		Image:        r.Image,
+		AnimationURL: r.AnimationURL,
+		ExternalURL:  r.ExternalURL,
+		Attributes:   r.Attributes,
+	}
+}
+
+type PreviewMetadataTemplateRequest struct {
+	ProductItemID string                         `json:"product_item_id" validate:"required,mongodb"`
+	Template      *UpsertMetadataTemplateRequest `json:"template"`
+}
+
+type GetTokenMetadataRequest struct {
+	CollectionID string `validate:"required,mongodb"`
+	TokenID      string `validate:"required,number"`
+}
+
+// TokenIDToInt fails for token IDs out of the int range
+func (r *GetTokenMetadataRequest) TokenIDToInt() (int, error) {
+	return strconv.Atoi(r.TokenID)
+}
+
+type UpdateCollectionMetadataRequest struct {
+	Revealed            *bool                   `json:"revealed" validate:"required"`
+	PlaceholderMetadata entity.Metadata         `json:"placeholder_metadata"`
+	ContractMetadata    entity.ContractMetadata `json:"contract_metadata"`
+}
+
+type DeployCollectionRequest struct {
+	OrgID               string `json:"org_id" validate:"required,mongodb"`
+	Template            string `json:"template" validate:"required"`
+	ChainID             int64  `json:"chain_id" validate:"required"`
+	Name                string `json:"name" validate:"required"`
+	Symbol              string `json:"symbol" validate:"required,alphanum,max=11"`
+	BaseURI             string `json:"base_uri" validate:"omitempty,url"`
+	RoyaltyReceiver     string `json:"royalty_receiver" validate:"required_with=RoyaltyFeeNumerator,omitempty,eth_addr"`
+	RoyaltyFeeNumerator uint16 `json:"royalty_fee_numerator" validate:"max=10000"`
+	SignerName          string `json:"signer_name" validate:"omitempty,max=64"`
+}
+
+// ToEntity converts the request into a pending contract deployment
+func (r *DeployCollectionRequest) ToEntity() *entity.ContractDeployment {
+	orgID, _ := primitive.ObjectIDFromHex(r.OrgID)
+	deployment := entity.ContractDeployment{
+		OrganizationID:      orgID,
+		Template:            r.Template,
+		ChainID:             r.ChainID,
+		Name:                r.Name,
+		Symbol:              r.Symbol,
+		BaseURI:             r.BaseURI,
+		RoyaltyReceiver:     r.RoyaltyReceiver,
+		RoyaltyFeeNumerator: r.RoyaltyFeeNumerator,
+		SignerName:          r.SignerName,
+	}
+
+	return &deployment
+}
+
+type GetContractDeploymentRequest struct {
+	DeploymentID string `validate:"required,mongodb"`
+}
@@ -4,7 +4,6 @@ import "go.mongodb.org/mongo-driver/bson/primitive"
 
 type UpdateMappingRequest struct {
 	ProductItemID  *primitive.ObjectID `json:"product_item_id,omitempty" bson:"product_item_id,omitempty"`
-	Claimable      *bool               `json:"claimable,omitempty" bson:"claimable,omitempty"`
 	ExternalURL    *string             `json:"external_url,omitempty" bson:"external_url,omitempty"`
 	OrgID          *primitive.ObjectID `json:"org_id,omitempty" bson:"org_id,omitempty"`
 	DigitalAssetID *primitive.ObjectID `json:"digital_asset_id,omitempty" bson:"digital_asset_id,omitempty"`
@@ -19,3 +18,10 @@ type MultipleMappingWithSingleProduct struct {
 type UnmapRequest struct {
 	ProductItemID string `json:"product_item_id" validate:"required"`
 }
+
+// ClaimStateRequest the claim state an admin moves an item to and why
+type ClaimStateRequest struct {
+	State      string `json:"state" validate:"required,oneof=unclaimable claimable claimed locked"`
+	ReasonCode string `json:"reason_code" validate:"required,oneof=sale owner_request dispute fraud correction"`
+	Note       string `json:"note" validate:"max=500"`
+}
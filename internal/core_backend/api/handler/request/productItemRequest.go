@@ -21,5 +21,5 @@ type SetOwnerRequest struct {
 
 type ProductItemLikeRequest struct {
 	ProductItemID string `form:"product_item_id" validate:"required"`
-	// UserID        string `form:"user_id" validate:"required"`
+	UserID        string
 }
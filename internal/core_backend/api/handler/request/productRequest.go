@@ -1,5 +1,69 @@
 package request
 
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/pagination"
+)
+
 type InteractProductDetailRequest struct {
 	ProductID string `json:"product_id" validate:"mongodb"`
 }
+
+type DeepCloneProductRequest struct {
+	// OrgID the organization of the clone, the organization of the product by default
+	OrgID         string `json:"org_id" validate:"omitempty,mongodb"`
+	CloneTemplate bool   `json:"clone_template"`
+	// TotalItem the number of items created for the clone
+	TotalItem int `json:"total_item" validate:"min=0,max=10000"`
+}
+
+// ToOptions what the clone copies
+func (r *DeepCloneProductRequest) ToOptions() *entity.ProductCloneOptions {
+	orgID, _ := primitive.ObjectIDFromHex(r.OrgID)
+	return &entity.ProductCloneOptions{
+		OrganizationID: orgID,
+		CloneTemplate:  r.CloneTemplate,
+		TotalItem:      r.TotalItem,
+	}
+}
+
+type SearchProductsRequest struct {
+	// Query words of the name, origin or tags of the product
+	Query    string `form:"q"`
+	Type     string `form:"type"`
+	AuthorID string `form:"author_id" validate:"omitempty,mongodb"`
+	OrgID    string `form:"org_id" validate:"omitempty,mongodb"`
+	Status   string `form:"status" validate:"omitempty,oneof=Active Inactive"`
+	// OrderBy relevance needs a query, it is the default order of a query
+	OrderBy        string `form:"order_by" validate:"omitempty,oneof=created_at updated_at product_name total_item relevance"`
+	OrderDirection string `form:"order_direction" validate:"omitempty,oneof=asc desc"`
+	Limit          int64  `form:"limit" validate:"omitempty,min=1,max=50"`
+	Page           int64  `form:"page" validate:"omitempty,min=1"`
+	Cursor         string `form:"cursor"`
+}
+
+// ToFilter the search criteria
+func (r *SearchProductsRequest) ToFilter() *entity.ProductFilter {
+	authorID, _ := primitive.ObjectIDFromHex(r.AuthorID)
+	orgID, _ := primitive.ObjectIDFromHex(r.OrgID)
+	return &entity.ProductFilter{
+		Query:          r.Query,
+		Type:           r.Type,
+		AuthorID:       authorID,
+		OrganizationID: orgID,
+		Status:         r.Status,
+	}
+}
+
+// ToPagination the page asked for
+func (r *SearchProductsRequest) ToPagination() *pagination.Pagination {
+	return &pagination.Pagination{
+		OrderBy:        r.OrderBy,
+		OrderDirection: r.OrderDirection,
+		Limit:          r.Limit,
+		Page:           r.Page,
+		Cursor:         r.Cursor,
+	}
+}
@@ -0,0 +1,17 @@
+package request
+
+import "encoding/json"
+
+type CreateProductTypeRequest struct {
+	// Name the value of the type of its products: a-z first, then a-z, 0-9, - or _
+	Name        string `json:"name" validate:"required"`
+	DisplayName string `json:"display_name" validate:"required"`
+	// Schema the JSON Schema the attribute of the products must match
+	Schema json.RawMessage `json:"schema" validate:"required" swaggertype:"object"`
+}
+
+type UpdateProductTypeRequest struct {
+	DisplayName string `json:"display_name"`
+	// Schema a new schema is a new version of the type, omit it to keep the current one
+	Schema json.RawMessage `json:"schema" swaggertype:"object"`
+}
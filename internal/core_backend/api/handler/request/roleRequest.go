@@ -0,0 +1,51 @@
+package request
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+type CreateRoleRequest struct {
+	OrganizationID string              `json:"org_id" validate:"omitempty,mongodb"`
+	RoleName       string              `json:"role_name" validate:"required,max=64"`
+	Description    string              `json:"description"`
+	Permissions    []entity.Permission `json:"permissions" validate:"required,min=1"`
+}
+
+// ToEntity an empty organization creates a global role
+func (r *CreateRoleRequest) ToEntity() *entity.Role {
+	orgID, _ := primitive.ObjectIDFromHex(r.OrganizationID)
+	return &entity.Role{
+		OrganizationID: orgID,
+		RoleName:       r.RoleName,
+		Description:    r.Description,
+		Permissions:    r.Permissions,
+	}
+}
+
+type UpdateRolePermissionsRequest struct {
+	RoleID      string              `json:"-" validate:"required,mongodb" swaggerignore:"true"`
+	Description string              `json:"description"`
+	Permissions []entity.Permission `json:"permissions" validate:"required,min=1"`
+}
+
+type AssignRoleRequest struct {
+	UserID         string `json:"user_id" validate:"required"`
+	OrganizationID string `json:"org_id" validate:"omitempty,mongodb"`
+	RoleName       string `json:"role_name" validate:"required"`
+}
+
+// ToEntity an empty organization binds the role in every organization
+func (r *AssignRoleRequest) ToEntity() *entity.RoleBinding {
+	orgID, _ := primitive.ObjectIDFromHex(r.OrganizationID)
+	return &entity.RoleBinding{
+		UserID:         r.UserID,
+		OrganizationID: orgID,
+		RoleName:       r.RoleName,
+	}
+}
+
+type RevokeRoleRequest struct {
+	BindingID string `validate:"required,mongodb"`
+}
@@ -7,5 +7,5 @@ type CreateTagRequest struct {
 	EncryptMode    string `form:"encrypt_mode"`
 	RawData        string `form:"raw_data"`
 	ScanCounter    int    `form:"scan_counter"`
-	OrganizationID string `form:"org_id" validate:"required"`
+	OrganizationID string `form:"org_id" validate:"required,mongodb"`
 }
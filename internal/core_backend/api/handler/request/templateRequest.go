@@ -1,5 +1,7 @@
 package request
 
+import "backend-service/pkg/common/translation"
+
 type TemplateRequest struct {
 	TemplateID string `json:"template_id" binding:"required"`
 }
@@ -9,21 +11,17 @@ type TemplatePagesRequest struct {
 }
 
 type TemplateMenuRequest struct {
-	Title  TemplateMenuTitleRequest `json:"title"`
-	PageID string                   `json:"page_id"`
-}
-
-type TemplateMenuTitleRequest struct {
-	VI string `json:"vi"`
-	EN string `json:"en"`
+	Title  translation.LocalizedString `json:"title" swaggertype:"object,string"`
+	PageID string                      `json:"page_id"`
 }
 
 type CreateTemplateRequest struct {
-	Name      string                 `json:"name,omitempty"`
-	Category  string                 `json:"category,omitempty"`
-	Languages []string               `json:"languages"`
-	Pages     []TemplatePagesRequest `json:"pages,omitempty"`
-	Menu      []TemplateMenuRequest  `json:"menu,omitempty"`
+	OrganizationID string                 `json:"org_id" validate:"omitempty,mongodb"`
+	Name           string                 `json:"name,omitempty"`
+	Category       string                 `json:"category,omitempty"`
+	Languages      []string               `json:"languages"`
+	Pages          []TemplatePagesRequest `json:"pages,omitempty"`
+	Menu           []TemplateMenuRequest  `json:"menu,omitempty"`
 }
 
 type UpdateTemplateRequest struct {
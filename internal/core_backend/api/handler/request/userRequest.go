@@ -1,16 +1,15 @@
 package request
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
 
 type CreateUserRequest struct {
 	Token string `form:"token" validate:"required"`
 }
 
-type UpdateRoleRequest struct {
-	UserID  string `form:"user_id" validate:"required"`
-	NewRole string `form:"new_role" validate:"required"`
-}
-
 type UpdateOrgRequest struct {
 	UserID        string             `form:"user_id" validate:"required"`
 	NewOrgTagName string             `form:"new_org_tag_name" validate:"required"`
@@ -18,7 +17,61 @@ type UpdateOrgRequest struct {
 }
 
 type UpdateUserDetailsRequest struct {
-	Name          *string `json:"full_name" bson:"full_name,omitempty"`
-	Picture       *string `json:"picture" bson:"picture,omitempty"`
-	WalletAddress *string `json:"wallet_address" bson:"wallet_address"`
+	Name    *string `json:"full_name" bson:"full_name,omitempty"`
+	Picture *string `json:"picture" bson:"picture,omitempty"`
+}
+
+type LinkWalletRequest struct {
+	// Message the EIP-4361 message signed by the wallet, built with the nonce from /user/wallet/nonce
+	Message   string `json:"message" validate:"required"`
+	Signature string `json:"signature" validate:"required,hexadecimal"`
+}
+
+type WalletAddressRequest struct {
+	Address string `json:"address" validate:"required,eth_addr"`
+}
+
+type DeleteAccountRequest struct {
+	// Confirm must be true, deleting an account cannot be undone
+	Confirm bool `json:"confirm"`
+}
+
+type SearchUsersRequest struct {
+	// Email part of the email, case insensitive
+	Email        string `form:"email"`
+	OrgID        string `form:"org_id" validate:"omitempty,mongodb"`
+	RoleName     string `form:"role"`
+	WalletStatus string `form:"wallet_status" validate:"omitempty,oneof=none custodial linked"`
+	Status       string `form:"status" validate:"omitempty,oneof=Active Inactive Deleted"`
+	Page         int    `form:"page" validate:"omitempty,min=1"`
+	Limit        int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ToFilter the search criteria
+func (r *SearchUsersRequest) ToFilter() *entity.UserFilter {
+	orgID, _ := primitive.ObjectIDFromHex(r.OrgID)
+	return &entity.UserFilter{
+		Email:          r.Email,
+		OrganizationID: orgID,
+		RoleName:       r.RoleName,
+		WalletStatus:   r.WalletStatus,
+		Status:         r.Status,
+	}
+}
+
+type CollectionRequest struct {
+	Page  int `form:"page" validate:"omitempty,min=1"`
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type InviteUserRequest struct {
+	Email          string `json:"email" validate:"required,email"`
+	Name           string `json:"full_name"`
+	OrganizationID string `json:"org_id" validate:"required,mongodb"`
+	// RoleName the role granted in the organization, ORG_ADMIN by default
+	RoleName string `json:"role_name"`
+}
+
+type UserIDRequest struct {
+	UserID string `validate:"required"`
 }
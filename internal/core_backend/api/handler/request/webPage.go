@@ -1,11 +1,15 @@
 package request
 
+import "backend-service/internal/core_backend/entity"
+
 type CreateWebpageRequest struct {
-	Name       string                 `json:"name,omitempty"`
-	URLLink    string                 `json:"url_link"`
-	Type       string                 `json:"type"`
-	Category   string                 `json:"category"`
-	Attributes map[string]interface{} `json:"attributes"`
+	OrganizationID string                 `json:"org_id" validate:"omitempty,mongodb"`
+	Name           string                 `json:"name,omitempty"`
+	URLLink        string                 `json:"url_link"`
+	Type           string                 `json:"type"`
+	Category       string                 `json:"category"`
+	Attributes     map[string]interface{} `json:"attributes"`
+	Blocks         []entity.WebPageBlock  `json:"blocks"`
 }
 
 type UpdateWebpageRequest struct {
@@ -15,4 +19,5 @@ type UpdateWebpageRequest struct {
 	Type       string                 `json:"type,omitempty"`
 	Category   string                 `json:"category,omitempty"`
 	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Blocks     []entity.WebPageBlock  `json:"blocks,omitempty"`
 }
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	validation "backend-service/internal/core_backend/infrastructure/validator"
+	"backend-service/internal/core_backend/usecase/role"
+	"backend-service/internal/core_backend/usecase/user"
+)
+
+// RoleHandler interface
+type RoleHandler interface {
+	GetRoles(*gin.Context) APIResponse
+	CreateRole(*gin.Context) APIResponse
+	UpdateRolePermissions(*gin.Context) APIResponse
+	AssignRole(*gin.Context) APIResponse
+	RevokeRole(*gin.Context) APIResponse
+}
+
+// roleHandler struct
+type roleHandler struct {
+	RoleService role.UseCase
+	UserService user.UseCase
+	Validator   validation.CustomValidator
+}
+
+// NewRoleHandler create handler
+func NewRoleHandler(rs role.UseCase, us user.UseCase, v validation.CustomValidator) RoleHandler {
+	return &roleHandler{
+		RoleService: rs,
+		UserService: us,
+		Validator:   v,
+	}
+}
+
+// GetRoles	godoc
+// GetRoles	API
+//
+//	@Summary		Get roles
+//	@Description	List the system roles and the custom roles of the organizations the admin can manage
+//	@Tags			role
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/role [get]
+//	@Success		200					{object}	APIResponse{result=[]entity.Role}
+//	@Failure		500					{object}	APIResponse
+func (h *roleHandler) GetRoles(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+
+	roles, code, err := h.RoleService.GetRoles(scope)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", roles)
+}
+
+// CreateRole	godoc
+// CreateRole	API
+//
+//	@Summary		Create role
+//	@Description	Create a custom role in an organization, or a global one when org_id is empty
+//	@Tags			role
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/role [post]
+//	@Param			request	body		request.CreateRoleRequest	true	"Create Role Request"
+//	@Success		200		{object}	APIResponse{result=entity.Role}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+func (h *roleHandler) CreateRole(c *gin.Context) APIResponse {
+	var req request.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if err := h.Validator.Validate(req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if code, err := checkRoleOrganization(c, req.OrganizationID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	grants, err := GetRoleGrantsFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+
+	role, code, err := h.RoleService.CreateRole(grants, req.ToEntity())
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", role)
+}
+
+// UpdateRolePermissions	godoc
+// UpdateRolePermissions	API
+//
+//	@Summary		Update role permissions
+//	@Description	Replace the description and permissions of a custom role
+//	@Tags			role
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/role/{role_id} [put]
+//	@Param			role_id	path		string									true	"Role ID"
+//	@Param			request	body		request.UpdateRolePermissionsRequest	true	"Update Role Request"
+//	@Success		200		{object}	APIResponse{result=entity.Role}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+func (h *roleHandler) UpdateRolePermissions(c *gin.Context) APIResponse {
+	var req request.UpdateRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	req.RoleID = c.Param("role_id")
+	if err := h.Validator.Validate(req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	grants, err := GetRoleGrantsFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+
+	role, code, err := h.RoleService.UpdateRole(grants, scope, &req.RoleID, req.Description, req.Permissions)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", role)
+}
+
+// AssignRole	godoc
+// AssignRole	API
+//
+//	@Summary		Assign role
+//	@Description	Bind a role to a user in an organization, or in every organization when org_id is empty. The role and organization claims of the user are synced with the role bindings.
+//	@Tags			role
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/role/binding [post]
+//	@Param			request	body		request.AssignRoleRequest	true	"Assign Role Request"
+//	@Success		200		{object}	APIResponse{result=entity.RoleBinding}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+func (h *roleHandler) AssignRole(c *gin.Context) APIResponse {
+	var req request.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if err := h.Validator.Validate(req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if code, err := checkRoleOrganization(c, req.OrganizationID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	grants, err := GetRoleGrantsFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+
+	binding, code, err := h.RoleService.AssignRole(grants, req.ToEntity())
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if _, code, err = h.UserService.SyncUserClaims(&binding.UserID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", binding)
+}
+
+// RevokeRole	godoc
+// RevokeRole	API
+//
+//	@Summary		Revoke role
+//	@Description	Delete a role binding and sync the role and organization claims of the user with the remaining bindings
+//	@Tags			role
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/role/binding/{binding_id} [delete]
+//	@Param			binding_id	path		string	true	"Role binding ID"
+//	@Success		200			{object}	APIResponse{result=bool}
+//	@Failure		403			{object}	APIResponse
+//	@Failure		404			{object}	APIResponse
+func (h *roleHandler) RevokeRole(c *gin.Context) APIResponse {
+	req := request.RevokeRoleRequest{BindingID: c.Param("binding_id")}
+	if err := h.Validator.Validate(req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	grants, err := GetRoleGrantsFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	}
+
+	binding, code, err := h.RoleService.RevokeRole(grants, &req.BindingID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	// The claims of the user must not grant the revoked role any longer
+	if _, code, err = h.UserService.SyncUserClaims(&binding.UserID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", true)
+}
+
+// checkRoleOrganization hides the organizations out of the admin's scope, an empty organization is global
+func checkRoleOrganization(c *gin.Context, orgID string) (int, error) {
+	if orgID == "" {
+		return http.StatusOK, nil
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	_, code, err := ResolveOrganizationID(scope, orgID)
+
+	return code, err
+}
@@ -17,9 +17,11 @@ import (
 	"backend-service/internal/core_backend/usecase/productItem"
 	"backend-service/internal/core_backend/usecase/scan"
 	"backend-service/internal/core_backend/usecase/session"
+	"backend-service/internal/core_backend/usecase/story"
 	"backend-service/internal/core_backend/usecase/tag"
 	"backend-service/internal/core_backend/usecase/template"
 	"backend-service/internal/core_backend/usecase/verification"
+	"backend-service/pkg/common/translation"
 )
 
 // ScanHandler interface
@@ -39,12 +41,13 @@ type scanHandler struct {
 	ProductService      product.UseCase
 	ProductItemService  productItem.UseCase
 	TemplateService     template.Usecase
+	StoryService        story.UseCase
 	ScanPresenter       presenter.ConvertScan
 	Validator           validation.CustomValidator
 }
 
 // NewScanHandler create handler
-func NewScanHandler(ssuc session.UseCase, ouc organization.UseCase, vuc verification.UseCase, puc product.UseCase, piuc productItem.UseCase, cuc tag.UseCase, suc scan.UseCase, muc mapping.UseCase, tuc template.Usecase, dp presenter.ConvertScan, v validation.CustomValidator) ScanHandler {
+func NewScanHandler(ssuc session.UseCase, ouc organization.UseCase, vuc verification.UseCase, puc product.UseCase, piuc productItem.UseCase, cuc tag.UseCase, suc scan.UseCase, muc mapping.UseCase, tuc template.Usecase, stuc story.UseCase, dp presenter.ConvertScan, v validation.CustomValidator) ScanHandler {
 	return &scanHandler{
 		SessionService:      ssuc,
 		OrganizationService: ouc,
@@ -55,6 +58,7 @@ func NewScanHandler(ssuc session.UseCase, ouc organization.UseCase, vuc verifica
 		ScanService:         suc,
 		MappingService:      muc,
 		TemplateService:     tuc,
+		StoryService:        stuc,
 		ScanPresenter:       dp,
 		Validator:           v,
 	}
@@ -129,6 +133,13 @@ func (h *scanHandler) DecodeScan(c *gin.Context) RedirectResponse {
 		return RedirectResponse{StatusCode: http.StatusSeeOther, URL: errorPageURL}
 	}
 
+	if config.C.Story.REDIRECT_SCANS {
+		chain := translation.NewFallbackChain(c.GetHeader("Accept-Language"))
+		if url, ok := h.StoryService.GetStoryURL(mapping.ProductItemID, chain); ok {
+			return RedirectResponse{StatusCode: http.StatusFound, URL: url}
+		}
+	}
+
 	piID := mapping.ProductItemID.Hex()
 	item, _, err := h.ProductItemService.GetDetailProductItem(&piID)
 	if err != nil || item == nil || item.ProductID.IsZero() {
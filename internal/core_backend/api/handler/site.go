@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"backend-service/internal/core_backend/usecase/site"
+	"backend-service/pkg/common/translation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SiteHandler interface
+type SiteHandler interface {
+	GetItemSite(*gin.Context) APIResponse
+	GetTagSite(*gin.Context) APIResponse
+}
+
+// siteHandler struct
+type siteHandler struct {
+	SiteService site.UseCase
+}
+
+// NewSiteHandler create handler
+func NewSiteHandler(suc site.UseCase) SiteHandler {
+	return &siteHandler{
+		SiteService: suc,
+	}
+}
+
+// siteChain the languages of the lang query, then those of the Accept-Language header and the default languages
+func siteChain(c *gin.Context) translation.FallbackChain {
+	return translation.NewFallbackChain(c.Query("lang"), c.GetHeader("Accept-Language"))
+}
+
+// GetItemSite	godoc
+// GetItemSite	API
+//
+//	@Summary		Get Site Of Product Item
+//	@Description	Get the site of a product item rendered from the template of its product, placeholders substituted
+//	@Tags			site user
+//	@Produce		json
+//	@Router			/site/product-item/{product_item_id} [get]
+//	@Param			product_item_id	path		string	true	"Product Item ID"
+//	@Param			lang			query		string	false	"Languages to write localized texts in, e.g. fr,en"
+//	@Success		200				{object}	APIResponse{result=entity.Site}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+//	@Failure		500				{object}	APIResponse
+func (h *siteHandler) GetItemSite(c *gin.Context) APIResponse {
+	productItemID := c.Param("product_item_id")
+	result, code, err := h.SiteService.RenderItemSite(&productItemID, siteChain(c))
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
+
+// GetTagSite	godoc
+// GetTagSite	API
+//
+//	@Summary		Get Site Of Tag
+//	@Description	Get the site of the product item a tag is mapped to, rendered from the template of its product
+//	@Tags			site user
+//	@Produce		json
+//	@Router			/site/tag/{tag_id} [get]
+//	@Param			tag_id	path		string	true	"Tag ID"
+//	@Param			lang	query		string	false	"Languages to write localized texts in, e.g. fr,en"
+//	@Success		200		{object}	APIResponse{result=entity.Site}
+//	@Failure		404		{object}	APIResponse
+//	@Failure		500		{object}	APIResponse
+func (h *siteHandler) GetTagSite(c *gin.Context) APIResponse {
+	tagID := c.Param("tag_id")
+	result, code, err := h.SiteService.RenderTagSite(&tagID, siteChain(c))
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", result)
+}
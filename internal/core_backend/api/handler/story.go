@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/usecase/product"
+	"backend-service/internal/core_backend/usecase/productItem"
+	"backend-service/internal/core_backend/usecase/story"
+)
+
+// StoryHandler interface
+type StoryHandler interface {
+	PublishProductStories(*gin.Context) APIResponse
+	GetStoryArtifact(*gin.Context) APIResponse
+}
+
+// storyHandler struct
+type storyHandler struct {
+	StoryService       story.UseCase
+	ProductService     product.UseCase
+	ProductItemService productItem.UseCase
+}
+
+// NewStoryHandler create handler
+func NewStoryHandler(suc story.UseCase, puc product.UseCase, piuc productItem.UseCase) StoryHandler {
+	return &storyHandler{
+		StoryService:       suc,
+		ProductService:     puc,
+		ProductItemService: piuc,
+	}
+}
+
+// PublishProductStories	godoc
+// PublishProductStories	API
+//
+//	@Summary		Publish Product Stories
+//	@Description	Queue the stories of every item of the product to be exported again to static JSON and HTML files, returns the number queued
+//	@Tags			story
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product/{product_id}/story/publish [post]
+//	@Param			product_id	path		string	true	"Product ID"
+//	@Success		200			{object}	APIResponse{result=int}
+//	@Failure		404			{object}	APIResponse
+//	@Failure		500			{object}	APIResponse
+func (h *storyHandler) PublishProductStories(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productID := c.Param("product_id")
+	product, code, err := h.ProductService.GetProductInScope(scope, &productID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	queued, code, err := h.StoryService.PublishProductStories(product.ID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", queued)
+}
+
+// GetStoryArtifact	godoc
+// GetStoryArtifact	API
+//
+//	@Summary		Get Story Of Product Item
+//	@Description	Get the exported files of the story of the product item and the state of its export
+//	@Tags			story
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/product-item/{product_item_id}/story [get]
+//	@Param			product_item_id	path		string	true	"Product Item ID"
+//	@Success		200				{object}	APIResponse{result=entity.StoryArtifact}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+//	@Failure		500				{object}	APIResponse
+func (h *storyHandler) GetStoryArtifact(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	productItemID := c.Param("product_item_id")
+	item, code, err := h.ProductItemService.GetDetailProductItem(&productItemID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	if item == nil {
+		err = errors.New(common.MessageErrorProductItemNotFound)
+		return CreateResponse(err, http.StatusNotFound, "", err.Error(), nil)
+	}
+	productID := item.ProductID.Hex()
+	if _, code, err = h.ProductService.GetProductInScope(scope, &productID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	artifact, code, err := h.StoryService.GetStoryArtifact(&productItemID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", artifact)
+}
@@ -10,6 +10,7 @@ import (
 	"backend-service/internal/core_backend/usecase/tag"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // TagHandler interface
@@ -57,6 +58,11 @@ func (h *tagHandler) CreateTag(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
 
+	orgID, _ := primitive.ObjectIDFromHex(request.OrganizationID)
+	if code, err := CheckOrganizationAccess(c, orgID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
 	ok, code, err := h.TagService.CreateTag(&request)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
@@ -16,6 +16,7 @@ type TemplateHandler interface {
 	GetAllTemplates(*gin.Context) APIResponse
 	GetTemplate(*gin.Context) APIResponse
 	UpdateTemplate(*gin.Context) APIResponse
+	GetDanglingReferences(*gin.Context) APIResponse
 }
 
 type templateHandler struct {
@@ -57,7 +58,16 @@ func (h *templateHandler) CreateTemplate(c *gin.Context) APIResponse {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
 
-	ok, code, err := h.TemplateService.CreateTemplate(&request)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	orgID, code, err := ResolveOrganizationID(scope, request.OrganizationID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	ok, code, err := h.TemplateService.CreateTemplate(scope, orgID, &request)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -78,11 +88,16 @@ func (h *templateHandler) CreateTemplate(c *gin.Context) APIResponse {
 //	@Router			/admin/template/{template_id} [get]
 //	@Param			template_id	path		string	true	"Template ID"
 //	@Success		200			{object}	APIResponse{result=presenter.TemplateWebpagesResponse}
+//	@Failure		404			{object}	APIResponse
 //	@Failure		500			{object}	APIResponse
 func (h *templateHandler) GetTemplate(c *gin.Context) APIResponse {
 	templateID := c.Param("template_id")
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
 
-	templateWebpages, code, err := h.TemplateService.GetTemplateWebpages(&templateID)
+	templateWebpages, code, err := h.TemplateService.GetTemplateWebpagesInScope(scope, &templateID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -103,7 +118,12 @@ func (h *templateHandler) GetTemplate(c *gin.Context) APIResponse {
 //	@Success		200	{object}	APIResponse{result=presenter.ListTemplateResponse}
 //	@Failure		500	{object}	APIResponse
 func (h *templateHandler) GetAllTemplates(c *gin.Context) APIResponse {
-	templates, code, err := h.TemplateService.GetAllTemplates()
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	templates, code, err := h.TemplateService.GetAllTemplates(scope)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -125,6 +145,7 @@ func (h *templateHandler) GetAllTemplates(c *gin.Context) APIResponse {
 //	@Param			update_template_request	body		request.UpdateTemplateRequest	true	"Update Template Request"
 //	@Success		200						{object}	APIResponse{result=bool}
 //	@Failure		400						{object}	APIResponse
+//	@Failure		404						{object}	APIResponse
 //	@Failure		500						{object}	APIResponse
 func (h *templateHandler) UpdateTemplate(c *gin.Context) APIResponse {
 	var request request.UpdateTemplateRequest
@@ -137,7 +158,12 @@ func (h *templateHandler) UpdateTemplate(c *gin.Context) APIResponse {
 	if e := h.Validator.Validate(request); e != nil {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
-	ok, code, err := h.TemplateService.UpdateTemplate(&request)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	ok, code, err := h.TemplateService.UpdateTemplate(scope, &request)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -153,10 +179,39 @@ func (h *templateHandler) CloneTemplate(c *gin.Context) APIResponse {
 		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
 
-	newTemplate, code, err := h.TemplateService.CloneTemplate(&req.TemplateID)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	newTemplate, code, err := h.TemplateService.CloneTemplate(scope, &req.TemplateID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
 	return HandlerResponse(code, "", "", newTemplate)
 }
+
+// GetDanglingReferences	godoc
+// GetDanglingReferences	API
+//
+//	@Summary		Get Dangling References
+//	@Description	List the template pages and menu entries targeting deleted webpages and the products of deleted templates
+//	@Tags			template
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/template/dangling-references [get]
+//	@Success		200	{object}	APIResponse{result=[]entity.DanglingReference}
+//	@Failure		500	{object}	APIResponse
+func (h *templateHandler) GetDanglingReferences(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	references, code, err := h.TemplateService.GetDanglingReferences(scope)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	return HandlerResponse(http.StatusOK, "", "", references)
+}
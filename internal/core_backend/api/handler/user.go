@@ -1,46 +1,61 @@
 package handler
 
 import (
-	config "backend-service/config/core_backend"
 	"backend-service/internal/core_backend/api/handler/request"
 	"backend-service/internal/core_backend/api/presenter"
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
-	"backend-service/internal/core_backend/infrastructure/callers"
 	validation "backend-service/internal/core_backend/infrastructure/validator"
 	"backend-service/internal/core_backend/usecase/organization"
+	"backend-service/internal/core_backend/usecase/role"
 	"backend-service/internal/core_backend/usecase/user"
-	"encoding/json"
+	"backend-service/internal/core_backend/usecase/wallet"
 	"errors"
-	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // UserHandler interface
 type UserHandler interface {
 	UserSignUp(*gin.Context) APIResponse
-	UpdateRole(*gin.Context) APIResponse
 	UpdateOrg(*gin.Context) APIResponse
 	UpdateUserDetails(*gin.Context) APIResponse
 	GetUserDetails(*gin.Context) APIResponse
 	SyncWalletAddress(*gin.Context) APIResponse
+	IssueWalletNonce(*gin.Context) APIResponse
+	LinkWallet(*gin.Context) APIResponse
+	UnlinkWallet(*gin.Context) APIResponse
+	SetMintWallet(*gin.Context) APIResponse
+	ExportUserData(*gin.Context) APIResponse
+	DeleteAccount(*gin.Context) APIResponse
+	SearchUsers(*gin.Context) APIResponse
+	InviteUser(*gin.Context) APIResponse
+	DeactivateUser(*gin.Context) APIResponse
+	ReactivateUser(*gin.Context) APIResponse
+	SyncUserClaims(*gin.Context) APIResponse
+	GetCollection(*gin.Context) APIResponse
+	GetCollectionItem(*gin.Context) APIResponse
 }
 
 // userHandler struct
 type userHandler struct {
 	UserService         user.UseCase
 	OrganizationService organization.UseCase
+	WalletService       wallet.UseCase
+	RoleService         role.UseCase
 	UserPresenter       presenter.ConvertUser
 	Validator           validation.CustomValidator
 }
 
 // NewUserHandler create handler
-func NewUserHandler(uc user.UseCase, ou organization.UseCase, pr presenter.ConvertUser, v validation.CustomValidator) UserHandler {
+func NewUserHandler(uc user.UseCase, ou organization.UseCase, wu wallet.UseCase, ru role.UseCase, pr presenter.ConvertUser, v validation.CustomValidator) UserHandler {
 	return &userHandler{
 		UserService:         uc,
 		OrganizationService: ou,
+		WalletService:       wu,
+		RoleService:         ru,
 		UserPresenter:       pr,
 		Validator:           v,
 	}
@@ -65,44 +80,6 @@ func (h *userHandler) UserSignUp(c *gin.Context) APIResponse {
 	}
 }
 
-// UpdateRole	godoc
-// UpdateRole	API
-//
-//	@Summary		Update User Role To Firebase and DB
-//	@Description	Update User Role To Firebase and DB
-//	@Tags			user
-//	@Accept			multipart/form-data
-//	@Security		ApiKeyAuth
-//	@Produce		json
-//	@Router			/admin/user/role [put]
-//	@Param			update_role_request	formData	request.UpdateRoleRequest	true	"Update Role Request"
-//	@Success		200					{object}	APIResponse{result=bool}
-//	@Failure		400					{object}	APIResponse
-func (h *userHandler) UpdateRole(c *gin.Context) APIResponse {
-	userRole, err := GetRoleFromGinContext(c)
-	if err != nil {
-		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-	}
-	if userRole != string(entity.SUPER_ADMIN_ROLE) {
-		err = errors.New("Unauthorized: only super admin can update user role")
-		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
-	}
-	var req request.UpdateRoleRequest
-	if err := c.ShouldBind(&req); err != nil {
-		return CreateResponse(err, http.StatusBadRequest, "", "", nil)
-	}
-
-	if e := h.Validator.Validate(req); e != nil {
-		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
-	}
-
-	success, code, err := h.UserService.UpdateRole(&req)
-	if err != nil {
-		return CreateResponse(err, code, "", err.Error(), nil)
-	}
-	return HandlerResponse(code, "", "", success)
-}
-
 // UpdateOrg	godoc
 // UpdateOrg	API
 //
@@ -210,90 +187,482 @@ func (h *userHandler) GetUserDetails(c *gin.Context) APIResponse {
 // SyncWalletAddress	API
 //
 //	@Summary		Sync Wallet Address Of Users Who Haven't Had
-//	@Description	Sync Wallet Address Of Users Who Haven't Had
+//	@Description	Create the custodial wallets of the users who have none, in batches, and report the outcome of each user. The same reconciliation runs in the background every WALLET_RECONCILE_INTERVAL_IN_SECOND.
 //	@Tags			user
 //	@Security		ApiKeyAuth
 //	@Produce		json
 //	@Router			/admin/user/sync-wallet-address [put]
-//	@Success		200					{object}	APIResponse{result=bool}
+//	@Success		200					{object}	APIResponse{result=entity.WalletReconciliation}
 //	@Failure		400					{object}	APIResponse
 func (h *userHandler) SyncWalletAddress(c *gin.Context) APIResponse {
-	userRole, err := GetRoleFromGinContext(c)
+	scope, err := GetAccessScopeFromGinContext(c)
 	if err != nil {
 		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
 	}
-	if userRole != string(entity.SUPER_ADMIN_ROLE) {
-		err = errors.New("Unauthorized: only super admin can sync user wallet addresses")
-		return CreateResponse(err, http.StatusUnauthorized, "", err.Error(), nil)
+	if !scope.AllOrganizations {
+		err = errors.New(common.MessageErrorForbidden + ": only global admins can sync user wallet addresses")
+		return CreateResponse(err, http.StatusForbidden, "", err.Error(), nil)
+	}
+
+	report, code, err := h.WalletService.ReconcileWallets()
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", report)
+}
+
+// IssueWalletNonce	godoc
+// IssueWalletNonce	API
+//
+//	@Summary		Issue Wallet Nonce
+//	@Description	Issue the single use nonce of the Sign-In with Ethereum (EIP-4361) message the user signs to link a wallet. The message must use the returned domain and version and expire before expiration_time.
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user/wallet/nonce [post]
+//	@Success		200	{object}	APIResponse{result=presenter.WalletNonceResponse}
+//	@Failure		500	{object}	APIResponse
+func (h *userHandler) IssueWalletNonce(c *gin.Context) APIResponse {
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
-	usersNoWallet, code, err := h.UserService.GetUserWithNoWallet()
+
+	nonce, code, err := h.UserService.IssueWalletNonce(&info.ID)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	if len(*usersNoWallet) == 0 {
-		return HandlerResponse(code, "", "", true)
+
+	return HandlerResponse(code, "", "", h.UserPresenter.ResponseWalletNonce(nonce))
+}
+
+// LinkWallet	godoc
+// LinkWallet	API
+//
+//	@Summary		Link Wallet
+//	@Description	Link a self-custodied wallet to the user by verifying a signed Sign-In with Ethereum (EIP-4361) message. Linking an already linked wallet again is a no-op.
+//	@Tags			user
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user/wallet/link [post]
+//	@Param			request	body		request.LinkWalletRequest	true	"Signed SIWE message"
+//	@Success		200		{object}	APIResponse{result=entity.User}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		401		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+func (h *userHandler) LinkWallet(c *gin.Context) APIResponse {
+	var req request.LinkWalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
-	emailToID := make(map[string]string)
-	var emails []string
-	for _, user := range *usersNoWallet {
-		emails = append(emails, user.Email)
-		emailToID[user.Email] = user.ID
+
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
-	responseData, err := h.requestUserWalletBulk(&emails)
+
+	user, code, err := h.UserService.LinkWallet(&info.ID, &req)
 	if err != nil {
-		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
-	// Update wallet_address for each user
-	for _, userWallet := range responseData.Result.ListWallet {
-		updateReq := request.UpdateUserDetailsRequest{
-			WalletAddress: &userWallet.Wallet.WalletAddress,
-		}
-		userID := emailToID[userWallet.Email]
-		ok, code, err := h.UserService.UpdateUserDetails(&userID, &updateReq)
-		if err != nil {
+	return HandlerResponse(code, "", "", user)
+}
+
+// UnlinkWallet	godoc
+// UnlinkWallet	API
+//
+//	@Summary		Unlink Wallet
+//	@Description	Unlink a wallet from the user. If it was the mint wallet, claimed items are minted to the custodial wallet again.
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user/wallet/{address} [delete]
+//	@Param			address	path		string	true	"Wallet address"
+//	@Success		200		{object}	APIResponse{result=bool}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+func (h *userHandler) UnlinkWallet(c *gin.Context) APIResponse {
+	var req = request.WalletAddressRequest{
+		Address: c.Param("address"),
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+
+	ok, code, err := h.UserService.UnlinkWallet(&info.ID, &req.Address)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", ok)
+}
+
+// SetMintWallet	godoc
+// SetMintWallet	API
+//
+//	@Summary		Set Mint Wallet
+//	@Description	Choose the wallet claimed items are minted to, either a linked wallet or the custodial wallet
+//	@Tags			user
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user/wallet/mint-address [put]
+//	@Param			request	body		request.WalletAddressRequest	true	"Wallet address"
+//	@Success		200		{object}	APIResponse{result=bool}
+//	@Failure		400		{object}	APIResponse
+func (h *userHandler) SetMintWallet(c *gin.Context) APIResponse {
+	var req request.WalletAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+
+	ok, code, err := h.UserService.SetMintWallet(&info.ID, &req.Address)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", ok)
+}
+
+// ExportUserData	godoc
+// ExportUserData	API
+//
+//	@Summary		Export User Data
+//	@Description	Export everything stored about the user as JSON: profile and wallet addresses, claimed items and their tokens, roles and custodial wallet requests
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user/export [get]
+//	@Success		200	{object}	APIResponse{result=entity.UserDataExport}
+//	@Failure		404	{object}	APIResponse
+func (h *userHandler) ExportUserData(c *gin.Context) APIResponse {
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+
+	export, code, err := h.UserService.ExportUserData(&info.ID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	c.Header("Content-Disposition", "attachment; filename=\"user-data.json\"")
+
+	return HandlerResponse(code, "", "", export)
+}
+
+// DeleteAccount	godoc
+// DeleteAccount	API
+//
+//	@Summary		Delete Account
+//	@Description	Delete the account of the user: personal data is erased, claimed items are released or reassigned to the owner of their organization following ACCOUNT_DELETION_CLAIM_POLICY, and the sign in account is deleted. Owners of organizations must transfer them first.
+//	@Tags			user
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user [delete]
+//	@Param			request	body		request.DeleteAccountRequest	true	"Confirmation"
+//	@Success		200		{object}	APIResponse{result=entity.AccountDeletion}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+func (h *userHandler) DeleteAccount(c *gin.Context) APIResponse {
+	var req request.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if !req.Confirm {
+		err := errors.New(common.MessageErrorAccountDeletionConfirm)
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+
+	deletion, code, err := h.UserService.DeleteAccount(&info.ID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", deletion)
+}
+
+// SearchUsers	godoc
+// SearchUsers	API
+//
+//	@Summary		Search users
+//	@Description	List the users of the organizations the admin manages, with their role bindings. Users are searched by part of their email, organization, role, wallet status and status.
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/user [get]
+//	@Param			email			query		string	false	"Part of the email"
+//	@Param			org_id			query		string	false	"Organization ID"
+//	@Param			role			query		string	false	"Role name"
+//	@Param			wallet_status	query		string	false	"none, custodial or linked"
+//	@Param			status			query		string	false	"Active, Inactive or Deleted"
+//	@Param			page			query		int		false	"Page, from 1"
+//	@Param			limit			query		int		false	"Users per page, 20 by default"
+//	@Success		200				{object}	APIResponse{result=entity.UserPage}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *userHandler) SearchUsers(c *gin.Context) APIResponse {
+	var req request.SearchUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	filter := req.ToFilter()
+	if !filter.OrganizationID.IsZero() {
+		if code, err := CheckOrganizationAccess(c, filter.OrganizationID); err != nil {
 			return CreateResponse(err, code, "", err.Error(), nil)
 		}
-		if !ok {
-			err = fmt.Errorf("Couldn't update user info: email=%s, _id=%s", userWallet.Email, userID)
-			return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
-		}
 	}
-	return HandlerResponse(code, "", "", true)
+
+	page, code, err := h.UserService.SearchUsers(scope, filter, &common.Paging{Page: req.Page, Limit: req.Limit})
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", page)
+}
+
+// InviteUser	godoc
+// InviteUser	API
+//
+//	@Summary		Invite user
+//	@Description	Create the account of the email when there is none, bind the role in the organization and sync the role and organization claims. The invite link lets the user choose a password.
+//	@Tags			user
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/user/invite [post]
+//	@Param			request	body		request.InviteUserRequest	true	"Invite User Request"
+//	@Success		200		{object}	APIResponse{result=entity.UserInvitation}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+func (h *userHandler) InviteUser(c *gin.Context) APIResponse {
+	var req request.InviteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	if req.RoleName == "" {
+		req.RoleName = string(entity.ORG_ADMIN_ROLE)
+	}
+	grants, err := GetRoleGrantsFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	orgID, _ := primitive.ObjectIDFromHex(req.OrganizationID)
+	if code, err := CheckOrganizationAccess(c, orgID); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	org, code, err := h.OrganizationService.GetDetailOrganization(&req.OrganizationID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	binding := entity.RoleBinding{OrganizationID: org.ID, RoleName: req.RoleName}
+	// The role is checked before the account is created
+	if code, err := h.RoleService.CanAssignRole(grants, &binding); err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	invitation, code, err := h.UserService.InviteUser(&req, org)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	binding.UserID = invitation.User.ID
+	bound, code, err := h.RoleService.AssignRole(grants, &binding)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	invitation.RoleBinding = *bound
+	user, code, err := h.UserService.SyncUserClaims(&bound.UserID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+	invitation.User = *user
+
+	return HandlerResponse(code, "", "", invitation)
+}
+
+// DeactivateUser	godoc
+// DeactivateUser	API
+//
+//	@Summary		Deactivate user
+//	@Description	Deactivate a user of an organization the admin manages: the account is disabled and the tokens of the user are refused
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/user/{user_id}/deactivate [put]
+//	@Param			user_id	path		string	true	"User ID"
+//	@Success		200		{object}	APIResponse{result=entity.User}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+func (h *userHandler) DeactivateUser(c *gin.Context) APIResponse {
+	return h.setUserActive(c, false)
+}
+
+// ReactivateUser	godoc
+// ReactivateUser	API
+//
+//	@Summary		Reactivate user
+//	@Description	Enable the account of a deactivated user again
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/user/{user_id}/reactivate [put]
+//	@Param			user_id	path		string	true	"User ID"
+//	@Success		200		{object}	APIResponse{result=entity.User}
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+func (h *userHandler) ReactivateUser(c *gin.Context) APIResponse {
+	return h.setUserActive(c, true)
+}
+
+func (h *userHandler) setUserActive(c *gin.Context, active bool) APIResponse {
+	req := request.UserIDRequest{UserID: c.Param("user_id")}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	user, code, err := h.UserService.SetUserActive(scope, &req.UserID, active)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	return HandlerResponse(code, "", "", user)
 }
 
-func (h *userHandler) requestUserWalletBulk(emails *[]string) (*presenter.CreateWalletsBulkResponse, error) {
-	data := map[string]interface{}{
-		"client_id": config.C.Wallet.WALLET_CLIENT_ID,
-		"emails":    emails,
+// SyncUserClaims	godoc
+// SyncUserClaims	API
+//
+//	@Summary		Sync user claims
+//	@Description	Set the role and organization claims of the user from the role bindings: SUPER_ADMIN when bound for every organization, ORG_ADMIN and its organization when bound in one, no role otherwise
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/admin/user/{user_id}/sync-claims [put]
+//	@Param			user_id	path		string	true	"User ID"
+//	@Success		200		{object}	APIResponse{result=entity.User}
+//	@Failure		403		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+func (h *userHandler) SyncUserClaims(c *gin.Context) APIResponse {
+	req := request.UserIDRequest{UserID: c.Param("user_id")}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	if !scope.AllOrganizations {
+		err = errors.New(common.MessageErrorForbidden + ": only global admins can sync user claims")
+		return CreateResponse(err, http.StatusForbidden, "", err.Error(), nil)
 	}
 
-	req, err := callers.CreateRequest(config.C.Wallet.WALLET_DOMAIN_V1+"/wallets/bulk", "POST", data)
+	user, code, err := h.UserService.SyncUserClaims(&req.UserID)
 	if err != nil {
-		return nil, err
+		return CreateResponse(err, code, "", err.Error(), nil)
 	}
 
-	req.Header.Set("x-client-key", config.C.Wallet.WALLET_CLIENT_KEY)
+	return HandlerResponse(code, "", "", user)
+}
 
-	client := http.Client{
-		Timeout: time.Duration(config.C.Wallet.WALLET_API_TIMEOUT) * time.Second,
+// GetCollection	godoc
+// GetCollection	API
+//
+//	@Summary		Get Collection
+//	@Description	Get the items the user claimed, newest first, with their product, organization, item index, story link and NFT status
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user/collection [get]
+//	@Param			page	query		int	false	"Page, from 1"
+//	@Param			limit	query		int	false	"Items per page, 20 by default"
+//	@Success		200		{object}	APIResponse{result=entity.CollectionPage}
+//	@Failure		400		{object}	APIResponse
+func (h *userHandler) GetCollection(c *gin.Context) APIResponse {
+	var req request.CollectionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
+	}
+	if e := h.Validator.Validate(req); e != nil {
+		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
+	}
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
 
-	resp, err := callers.SendRequest(&client, req)
-	defer resp.Body.Close()
+	page, code, err := h.UserService.GetCollection(&info.ID, &common.Paging{Page: req.Page, Limit: req.Limit})
 	if err != nil {
-		return nil, err
+		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("Received non-OK response: %s", resp.Status)
-		return nil, err
+
+	return HandlerResponse(code, "", "", page)
+}
+
+// GetCollectionItem	godoc
+// GetCollectionItem	API
+//
+//	@Summary		Get Collection Item
+//	@Description	Get an item the user claimed, with its product, organization, story link and NFT
+//	@Tags			user
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Router			/user/collection/{product_item_id} [get]
+//	@Param			product_item_id	path		string	true	"Product item ID"
+//	@Success		200				{object}	APIResponse{result=entity.CollectionItem}
+//	@Failure		400				{object}	APIResponse
+//	@Failure		404				{object}	APIResponse
+func (h *userHandler) GetCollectionItem(c *gin.Context) APIResponse {
+	info, err := GetUserFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusBadRequest, "", err.Error(), nil)
 	}
-	responseData := presenter.CreateWalletsBulkResponse{}
 
-	// Decode JSON response into responseData
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&responseData); err != nil {
-		return nil, err
+	pItemID := c.Param("product_item_id")
+	item, code, err := h.UserService.GetCollectionItem(&info.ID, &pItemID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
 	}
-	return &responseData, nil
+
+	return HandlerResponse(code, "", "", item)
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -58,7 +59,16 @@ func (h *webPageHandler) CreateWebPage(c *gin.Context) APIResponse {
 	if e := h.Validator.Validate(request); e != nil {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
-	id, code, err := h.WebPageService.CreateWebPage(&request)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+	orgID, code, err := ResolveOrganizationID(scope, request.OrganizationID)
+	if err != nil {
+		return CreateResponse(err, code, "", err.Error(), nil)
+	}
+
+	id, code, err := h.WebPageService.CreateWebPage(orgID, &request)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -78,8 +88,12 @@ func (h *webPageHandler) CreateWebPage(c *gin.Context) APIResponse {
 //	@Success		200	{object}	APIResponse{result=presenter.AllWebpagesResponse}
 //	@Failure		500	{object}	APIResponse
 func (h *webPageHandler) GetAllWebPages(c *gin.Context) APIResponse {
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
 
-	pages, code, err := h.WebPageService.GetAllWebPages()
+	pages, code, err := h.WebPageService.GetAllWebPages(scope)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -98,6 +112,7 @@ func (h *webPageHandler) GetAllWebPages(c *gin.Context) APIResponse {
 //	@Produce		json
 //	@Router			/web-page/{webpage_id} [get]
 //	@Param			webpage_id	path		string	true	"Webpage ID"
+//	@Param			lang		query		string	false	"Languages to write localized texts in, e.g. fr,en"
 //	@Success		200			{object}	APIResponse{result=presenter.WebpageDetailResponse}
 //	@Failure		500			{object}	APIResponse
 func (h *webPageHandler) GetWebPage(c *gin.Context) APIResponse {
@@ -114,7 +129,7 @@ func (h *webPageHandler) GetWebPage(c *gin.Context) APIResponse {
 
 	result := h.WebpagePresenter.ResponseWebpageDetail(page)
 
-	return HandlerResponse(code, "", "", result)
+	return HandlerResponse(code, "", "", LocalizeResult(c, &result))
 }
 
 // UpdateWebPage	godoc
@@ -131,6 +146,7 @@ func (h *webPageHandler) GetWebPage(c *gin.Context) APIResponse {
 //	@Param			update_webpage_request	body		request.UpdateWebpageRequest	true	"Update Webpage Request"
 //	@Success		200						{object}	APIResponse{result=string}
 //	@Failure		400						{object}	APIResponse
+//	@Failure		404						{object}	APIResponse
 func (h *webPageHandler) UpdateWebPage(c *gin.Context) APIResponse {
 
 	var request request.UpdateWebpageRequest
@@ -142,7 +158,12 @@ func (h *webPageHandler) UpdateWebPage(c *gin.Context) APIResponse {
 	if e := h.Validator.Validate(request); e != nil {
 		return CreateResponse(e, http.StatusBadRequest, "", "", nil)
 	}
-	id, code, err := h.WebPageService.UpdateWebPage(&request)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	id, code, err := h.WebPageService.UpdateWebPage(scope, &request)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), nil)
 	}
@@ -160,7 +181,10 @@ func (h *webPageHandler) UpdateWebPage(c *gin.Context) APIResponse {
 //	@Produce		json
 //	@Router			/admin/web-page/{webpage_id} [delete]
 //	@Param			webpage_id	path		string	true	"Webpage ID"
+//	@Param			cascade		query		bool	false	"Also remove the webpage from the templates using it"
 //	@Success		200			{object}	APIResponse{result=bool}
+//	@Failure		404			{object}	APIResponse
+//	@Failure		409			{object}	APIResponse
 //	@Failure		500			{object}	APIResponse
 func (h *webPageHandler) DeleteWebPage(c *gin.Context) APIResponse {
 	pageID := c.Param("webpage_id")
@@ -168,7 +192,13 @@ func (h *webPageHandler) DeleteWebPage(c *gin.Context) APIResponse {
 		return CreateResponse(errors.New("Invalid pageID"), http.StatusBadRequest, "", "", nil)
 	}
 
-	result, code, err := h.WebPageService.DeleteWebPage(&pageID)
+	scope, err := GetAccessScopeFromGinContext(c)
+	if err != nil {
+		return CreateResponse(err, http.StatusInternalServerError, "", err.Error(), nil)
+	}
+
+	cascade, _ := strconv.ParseBool(c.Query("cascade"))
+	result, code, err := h.WebPageService.DeleteWebPage(scope, &pageID, cascade)
 	if err != nil {
 		return CreateResponse(err, code, "", err.Error(), result)
 	}
@@ -1,38 +1,53 @@
 package authentication
 
 import (
-	"backend-service/internal/core_backend/entity"
-	"backend-service/internal/core_backend/infrastructure/firebase"
-	"strings"
+	"backend-service/internal/core_backend/infrastructure/identity"
+	"backend-service/internal/core_backend/usecase/role"
+	"backend-service/internal/core_backend/usecase/user"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AdminAuthenticator struct {
-	fbClient *firebase.FirebaseClient
+	identity    identity.Provider
+	roleService role.UseCase
+	userService user.UseCase
 }
 
-func NewAdminAuthenticator(fbClient *firebase.FirebaseClient) *AdminAuthenticator {
+func NewAdminAuthenticator(identityProvider identity.Provider, roleService role.UseCase, userService user.UseCase) *AdminAuthenticator {
 	return &AdminAuthenticator{
-		fbClient: fbClient,
+		identity:    identityProvider,
+		roleService: roleService,
+		userService: userService,
 	}
 }
 
+// Authenticate lets in users holding at least one role, route permissions are checked by Authorize
 func (a *AdminAuthenticator) Authenticate(c *gin.Context) {
-	tokenString, err := a.fbClient.ExtractToken(c)
+	tokenString, err := identity.ExtractToken(c)
 	if err != nil {
 		ResponseUnauthorized(c, err.Error())
 		return
 	}
-	token, err := a.fbClient.VerifyToken(tokenString)
+	token, err := a.identity.VerifyToken(tokenString)
 	if err != nil {
 		ResponseUnauthorized(c, "Cannot decode token data")
 		return
 	}
-	if !strings.EqualFold(token.Role, string(entity.SUPER_ADMIN_ROLE)) && !strings.EqualFold(token.Role, string(entity.ORG_ADMIN_ROLE)) {
-		ResponseUnauthorized(c, "Invalid user role: required super admin or org admin role")
+	user := identity.FromTokenToUser(token)
+	if !checkUserActive(c, a.userService, user) {
 		return
 	}
-	c.Set(USER_INFO_KEY, a.fbClient.FromTokenToUser(token))
+	grants, _, err := a.roleService.GetUserGrants(user)
+	if err != nil {
+		ResponseUnauthorized(c, "Cannot resolve user roles: "+err.Error())
+		return
+	}
+	if len(grants) == 0 {
+		ResponseUnauthorized(c, "Invalid user role: no admin role granted")
+		return
+	}
+	c.Set(USER_INFO_KEY, user)
+	c.Set(ROLE_GRANTS_KEY, grants)
 	c.Next()
 }
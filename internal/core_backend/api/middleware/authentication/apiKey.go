@@ -0,0 +1,45 @@
+package authentication
+
+import (
+	"strings"
+
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/usecase/apiKey"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API_KEY_HEADER carries the key of server to server requests
+const API_KEY_HEADER = "X-API-Key"
+
+type APIKeyAuthenticator struct {
+	apiKeyService apiKey.UseCase
+}
+
+func NewAPIKeyAuthenticator(apiKeyService apiKey.UseCase) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Authenticate lets in requests carrying a usable API key, acting with the key's permissions in its organization
+func (a *APIKeyAuthenticator) Authenticate(c *gin.Context) {
+	plain := strings.TrimSpace(c.GetHeader(API_KEY_HEADER))
+	if plain == "" {
+		ResponseUnauthorized(c, "Missing "+API_KEY_HEADER+" header")
+		return
+	}
+	key, _, err := a.apiKeyService.Authenticate(plain)
+	if err != nil {
+		ResponseUnauthorized(c, err.Error())
+		return
+	}
+	c.Set(USER_INFO_KEY, key.User())
+	c.Set(ROLE_GRANTS_KEY, []entity.RoleGrant{key.Grant()})
+	c.Next()
+}
+
+// HasAPIKey reports whether the request authenticates with an API key
+func HasAPIKey(c *gin.Context) bool {
+	return c.GetHeader(API_KEY_HEADER) != ""
+}
@@ -0,0 +1,49 @@
+package authentication
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+)
+
+// Authorize requires the permissions on the route and injects the organizations they are granted in
+// as the access scope of the request. It runs after an authenticator that set the role grants.
+func Authorize(permissions ...entity.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get(ROLE_GRANTS_KEY)
+		grants, _ := value.([]entity.RoleGrant)
+		if !ok {
+			ResponseForbidden(c, permissions)
+			return
+		}
+		user, _ := c.Get(USER_INFO_KEY)
+		userID := ""
+		if info, ok := user.(*entity.User); ok {
+			userID = info.ID
+		}
+
+		scope := entity.NewAccessScope(userID, grants, permissions...)
+		if scope.IsEmpty() {
+			ResponseForbidden(c, permissions)
+			return
+		}
+		c.Set(ACCESS_SCOPE_KEY, scope)
+		c.Next()
+	}
+}
+
+func ResponseForbidden(c *gin.Context, permissions []entity.Permission) {
+	required := make([]string, len(permissions))
+	for i, permission := range permissions {
+		required[i] = string(permission)
+	}
+	message := common.MessageErrorForbidden + ": " + strings.Join(required, ", ")
+	logger.LogError(message)
+	c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: " + message})
+	c.Abort()
+}
@@ -0,0 +1,59 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// authorizedEngine authorizes the request with the grants an authenticator would have set and answers
+// with the scope it was given
+func authorizedEngine(grants []entity.RoleGrant, setGrants bool, permissions ...entity.Permission) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/", func(c *gin.Context) {
+		c.Set(USER_INFO_KEY, &entity.User{ID: "user-1"})
+		if setGrants {
+			c.Set(ROLE_GRANTS_KEY, grants)
+		}
+	}, Authorize(permissions...), func(c *gin.Context) {
+		value, _ := c.Get(ACCESS_SCOPE_KEY)
+		c.JSON(http.StatusOK, value)
+	})
+	return engine
+}
+
+func TestAuthorize(t *testing.T) {
+	orgID := primitive.NewObjectID()
+	reader := entity.Role{RoleName: "reader", Permissions: []entity.Permission{entity.PermissionProductRead}}
+	orgAdmin := entity.SystemRoles[string(entity.ORG_ADMIN_ROLE)]
+
+	tests := []struct {
+		name        string
+		grants      []entity.RoleGrant
+		setGrants   bool
+		permissions []entity.Permission
+		want        int
+	}{
+		{"not authenticated", nil, false, []entity.Permission{entity.PermissionProductRead}, http.StatusForbidden},
+		{"no grant", nil, true, []entity.Permission{entity.PermissionProductRead}, http.StatusForbidden},
+		{"permission held", []entity.RoleGrant{{OrganizationID: orgID, Role: reader}}, true, []entity.Permission{entity.PermissionProductRead}, http.StatusOK},
+		{"permission missing", []entity.RoleGrant{{OrganizationID: orgID, Role: reader}}, true, []entity.Permission{entity.PermissionProductWrite}, http.StatusForbidden},
+		{"one of the permissions missing", []entity.RoleGrant{{OrganizationID: orgID, Role: reader}}, true, []entity.Permission{entity.PermissionProductRead, entity.PermissionProductWrite}, http.StatusForbidden},
+		{"global permission of an org admin", []entity.RoleGrant{{OrganizationID: orgID, Role: orgAdmin}}, true, []entity.Permission{entity.PermissionNFTDeploy}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			authorizedEngine(tt.grants, tt.setGrants, tt.permissions...).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			if rec.Code != tt.want {
+				t.Errorf("got status %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -4,15 +4,21 @@ import (
 	"net/http"
 
 	"backend-service/internal/core_backend/common/logger"
-	"backend-service/internal/core_backend/infrastructure/firebase"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/identity"
 	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/apiKey"
+	"backend-service/internal/core_backend/usecase/role"
+	"backend-service/internal/core_backend/usecase/user"
 
 	"firebase.google.com/go/auth"
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	USER_INFO_KEY = "userInfo"
+	USER_INFO_KEY    = "userInfo"
+	ROLE_GRANTS_KEY  = "roleGrants"
+	ACCESS_SCOPE_KEY = "accessScope"
 )
 
 type Authenticator interface {
@@ -23,16 +29,33 @@ type AuthenticationService struct {
 	AdminAuth  Authenticator
 	UserAuth   Authenticator
 	PubsubAuth Authenticator
+	APIKeyAuth Authenticator
 }
 
-func NewAuthenticationService(fbClient *firebase.FirebaseClient, productItemRepo *repository.ProductItemRepository, productRepo *repository.ProductRepository) *AuthenticationService {
+func NewAuthenticationService(identityProvider identity.Provider, productItemRepo *repository.ProductItemRepository, productRepo *repository.ProductRepository, roleService role.UseCase, userService user.UseCase, apiKeyService apiKey.UseCase) *AuthenticationService {
 	return &AuthenticationService{
-		AdminAuth:  NewAdminAuthenticator(fbClient),
-		UserAuth:   NewUserAuthenticator(fbClient),
-		PubsubAuth: NewPubsubAuthenticator(),
+		AdminAuth:  NewAdminAuthenticator(identityProvider, roleService, userService),
+		UserAuth:   NewUserAuthenticator(identityProvider, userService),
+		PubsubAuth: NewPubsubAuthenticatorFromConfig(),
+		APIKeyAuth: NewAPIKeyAuthenticator(apiKeyService),
 	}
 }
 
+// AdminOrAPIKey authenticates admin routes with the API key when the request carries one,
+// with the admin's ID token otherwise
+func (s *AuthenticationService) AdminOrAPIKey(c *gin.Context) {
+	if s.APIKeyAuth != nil && HasAPIKey(c) {
+		s.APIKeyAuth.Authenticate(c)
+		return
+	}
+	s.AdminAuth.Authenticate(c)
+}
+
+// Authorize see the package level Authorize
+func (s *AuthenticationService) Authorize(permissions ...entity.Permission) gin.HandlerFunc {
+	return Authorize(permissions...)
+}
+
 type AuthenticatorDecoder struct {
 	fbAuth *auth.Client
 }
@@ -1,29 +1,123 @@
 package authentication
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 
 	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/infrastructure/identity"
 )
 
+// PUBSUB_SIGNATURE_HEADER carries "sha256=" and the hex HMAC-SHA256 of the body
+const PUBSUB_SIGNATURE_HEADER = "X-Pubsub-Signature"
+
+// maxPushBodySize the largest push request body read to check its signature
+const maxPushBodySize = 10 << 20
+
+// PubsubAuthenticator authenticates push requests by their OIDC bearer token, or by the HMAC signature of their body
 type PubsubAuthenticator struct {
+	oidc       *identity.OIDCVerifier
+	hmacSecret []byte
+}
+
+// NewPubsubAuthenticator a nil verifier or an empty secret refuses the requests authenticated that way
+func NewPubsubAuthenticator(oidc *identity.OIDCVerifier, hmacSecret []byte) *PubsubAuthenticator {
+	return &PubsubAuthenticator{
+		oidc:       oidc,
+		hmacSecret: hmacSecret,
+	}
 }
 
-func NewPubsubAuthenticator() *PubsubAuthenticator {
-	return &PubsubAuthenticator{}
+// NewPubsubAuthenticatorFromConfig OIDC tokens are verified when PUBSUB_OIDC_AUDIENCE is set, signatures when PUBSUB_HMAC_SECRET is
+func NewPubsubAuthenticatorFromConfig() *PubsubAuthenticator {
+	var oidc *identity.OIDCVerifier
+	if config.C.Pubsub.OIDC_AUDIENCE != "" {
+		var issuers []string
+		for _, issuer := range strings.Split(config.C.Pubsub.OIDC_ISSUERS, ",") {
+			if issuer = strings.TrimSpace(issuer); issuer != "" {
+				issuers = append(issuers, issuer)
+			}
+		}
+		verifier, err := identity.NewOIDCVerifier(
+			identity.NewRemoteKeySet(config.C.Pubsub.OIDC_JWKS_URL),
+			issuers,
+			config.C.Pubsub.OIDC_AUDIENCE,
+			config.C.Pubsub.OIDC_SERVICE_ACCOUNT_EMAIL,
+		)
+		// Running without the configured authentication would refuse every push, or accept pushes it should not
+		if err != nil {
+			log.Fatalf("Invalid pubsub OIDC configuration: %v", err)
+		}
+		oidc = verifier
+	}
+
+	return NewPubsubAuthenticator(oidc, []byte(config.C.Pubsub.HMAC_SECRET))
 }
 
 func (a *PubsubAuthenticator) Authenticate(c *gin.Context) {
-	token, ok := c.GetQuery("token")
-	if !ok {
-		ResponseUnauthorized(c, "Can not get token")
+	if c.GetHeader("Authorization") != "" {
+		a.authenticateToken(c)
+		return
+	}
+	if c.GetHeader(PUBSUB_SIGNATURE_HEADER) != "" {
+		a.authenticateSignature(c)
+		return
+	}
+
+	ResponseUnauthorized(c, "Missing push authentication")
+}
+
+func (a *PubsubAuthenticator) authenticateToken(c *gin.Context) {
+	if a.oidc == nil {
+		ResponseUnauthorized(c, "OIDC push authentication is not configured")
 		return
 	}
-	//TODO: decode token by key
+	token, err := identity.ExtractToken(c)
+	if err != nil {
+		ResponseUnauthorized(c, err.Error())
+		return
+	}
+	if err = a.oidc.Verify(token); err != nil {
+		ResponseUnauthorized(c, "Invalid push token: "+err.Error())
+		return
+	}
+	c.Next()
+}
 
-	if token != config.C.Pubsub.AuthenToken {
-		ResponseUnauthorized(c, "Invalid Token")
+func (a *PubsubAuthenticator) authenticateSignature(c *gin.Context) {
+	if len(a.hmacSecret) == 0 {
+		ResponseUnauthorized(c, "Signed push authentication is not configured")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPushBodySize))
+	if err != nil {
+		ResponseUnauthorized(c, "Can not read push body")
+		return
+	}
+	// The handler reads the body again
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !validSignature(a.hmacSecret, body, c.GetHeader(PUBSUB_SIGNATURE_HEADER)) {
+		ResponseUnauthorized(c, "Invalid push signature")
 		return
 	}
 	c.Next()
 }
+
+func validSignature(secret []byte, body []byte, header string) bool {
+	signature, err := hex.DecodeString(strings.TrimPrefix(header, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hmac.Equal(signature, mac.Sum(nil))
+}
@@ -0,0 +1,170 @@
+package authentication
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-service/internal/core_backend/infrastructure/identity"
+)
+
+const (
+	testAudience       = "https://backend.example.com/pubsub/upsert-user"
+	testServiceAccount = "push@project.iam.gserviceaccount.com"
+	testIssuer         = "https://accounts.google.com"
+)
+
+// pushEngine echoes the body of authenticated push requests
+func pushEngine(a *PubsubAuthenticator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/pubsub", a.Authenticate, func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+	return engine
+}
+
+func push(engine *gin.Engine, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pubsub", strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func sign(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestPubsubSignatureAuthentication(t *testing.T) {
+	engine := pushEngine(NewPubsubAuthenticator(nil, []byte("secret")))
+	body := `{"message":{"messageId":"1"}}`
+
+	rec := push(engine, body, map[string]string{PUBSUB_SIGNATURE_HEADER: sign("secret", body)})
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Fatalf("signed request: got %d %q", rec.Code, rec.Body.String())
+	}
+
+	refused := map[string]map[string]string{
+		"no authentication": {},
+		"other secret":      {PUBSUB_SIGNATURE_HEADER: sign("other", body)},
+		"other body":        {PUBSUB_SIGNATURE_HEADER: sign("secret", body+" ")},
+		"not hex":           {PUBSUB_SIGNATURE_HEADER: "sha256=zz"},
+		"query token":       {"Authorization": "Bearer secret"},
+	}
+	for name, headers := range refused {
+		t.Run(name, func(t *testing.T) {
+			if rec := push(engine, body, headers); rec.Code != http.StatusUnauthorized {
+				t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+
+	// Signatures are refused when no secret is configured
+	unsigned := pushEngine(NewPubsubAuthenticator(nil, nil))
+	if rec := push(unsigned, body, map[string]string{PUBSUB_SIGNATURE_HEADER: sign("", body)}); rec.Code != http.StatusUnauthorized {
+		t.Errorf("empty secret: got status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestPubsubOIDCAuthentication(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "google-key",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(jwks)
+	}))
+	defer server.Close()
+
+	verifier, err := identity.NewOIDCVerifier(identity.NewRemoteKeySet(server.URL), []string{testIssuer}, testAudience, testServiceAccount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := pushEngine(NewPubsubAuthenticator(verifier, nil))
+
+	claims := func(change func(map[string]any)) map[string]any {
+		c := map[string]any{
+			"iss":            testIssuer,
+			"aud":            testAudience,
+			"sub":            "1234567890",
+			"email":          testServiceAccount,
+			"email_verified": true,
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+		if change != nil {
+			change(c)
+		}
+		return c
+	}
+	bearer := func(token string) map[string]string {
+		return map[string]string{"Authorization": "Bearer " + token}
+	}
+
+	if rec := push(engine, "{}", bearer(signToken(t, key, "google-key", claims(nil)))); rec.Code != http.StatusOK {
+		t.Fatalf("valid token: got status %d: %s", rec.Code, rec.Body.String())
+	}
+
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	refused := map[string]string{
+		"other audience":        signToken(t, key, "google-key", claims(func(c map[string]any) { c["aud"] = "https://other.example.com" })),
+		"other issuer":          signToken(t, key, "google-key", claims(func(c map[string]any) { c["iss"] = "https://issuer.example.com" })),
+		"other service account": signToken(t, key, "google-key", claims(func(c map[string]any) { c["email"] = "other@project.iam.gserviceaccount.com" })),
+		"unverified email":      signToken(t, key, "google-key", claims(func(c map[string]any) { c["email_verified"] = false })),
+		"expired":               signToken(t, key, "google-key", claims(func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() })),
+		"other key":             signToken(t, otherKey, "google-key", claims(nil)),
+		"unknown key":           signToken(t, key, "rotated-key", claims(nil)),
+	}
+	for name, token := range refused {
+		t.Run(name, func(t *testing.T) {
+			if rec := push(engine, "{}", bearer(token)); rec.Code != http.StatusUnauthorized {
+				t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+
+	// Tokens are refused when OIDC is not configured
+	signed := pushEngine(NewPubsubAuthenticator(nil, []byte("secret")))
+	if rec := push(signed, "{}", bearer(signToken(t, key, "google-key", claims(nil)))); rec.Code != http.StatusUnauthorized {
+		t.Errorf("OIDC not configured: got status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
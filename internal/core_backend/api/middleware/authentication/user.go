@@ -1,32 +1,56 @@
 package authentication
 
 import (
-	"backend-service/internal/core_backend/infrastructure/firebase"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/identity"
+	"backend-service/internal/core_backend/usecase/user"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserAuthenticator struct {
-	fbClient *firebase.FirebaseClient
+	identity    identity.Provider
+	userService user.UseCase
 }
 
-func NewUserAuthenticator(fbClient *firebase.FirebaseClient) *UserAuthenticator {
+func NewUserAuthenticator(identityProvider identity.Provider, userService user.UseCase) *UserAuthenticator {
 	return &UserAuthenticator{
-		fbClient: fbClient,
+		identity:    identityProvider,
+		userService: userService,
 	}
 }
 
 func (a *UserAuthenticator) Authenticate(c *gin.Context) {
-	tokenString, err := a.fbClient.ExtractToken(c)
+	tokenString, err := identity.ExtractToken(c)
 	if err != nil {
 		ResponseUnauthorized(c, err.Error())
 		return
 	}
-	token, err := a.fbClient.VerifyToken(tokenString)
+	token, err := a.identity.VerifyToken(tokenString)
 	if err != nil {
 		ResponseUnauthorized(c, "Cannot decode token data")
 		return
 	}
-	c.Set(USER_INFO_KEY, a.fbClient.FromTokenToUser(token))
+	info := identity.FromTokenToUser(token)
+	if !checkUserActive(c, a.userService, info) {
+		return
+	}
+	c.Set(USER_INFO_KEY, info)
 	c.Next()
 }
+
+// checkUserActive refuses the tokens of deactivated users, they stay valid at the identity provider until they expire
+func checkUserActive(c *gin.Context, userService user.UseCase, info *entity.User) bool {
+	active, _, err := userService.IsUserActive(&info.ID)
+	if err != nil {
+		ResponseUnauthorized(c, "Cannot resolve user status: "+err.Error())
+		return false
+	}
+	if !active {
+		ResponseUnauthorized(c, common.MessageErrorUserDeactivated)
+		return false
+	}
+
+	return true
+}
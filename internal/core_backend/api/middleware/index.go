@@ -2,16 +2,19 @@ package middleware
 
 import (
 	"backend-service/internal/core_backend/api/middleware/authentication"
-	"backend-service/internal/core_backend/infrastructure/firebase"
+	"backend-service/internal/core_backend/infrastructure/identity"
 	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/apiKey"
+	"backend-service/internal/core_backend/usecase/role"
+	"backend-service/internal/core_backend/usecase/user"
 )
 
 type MidddlewareServices struct {
 	AuthenMiddleware *authentication.AuthenticationService
 }
 
-func NewMiddlewareServices(fbClient *firebase.FirebaseClient, productItemRepo *repository.ProductItemRepository, productRepo *repository.ProductRepository) MidddlewareServices {
+func NewMiddlewareServices(identityProvider identity.Provider, productItemRepo *repository.ProductItemRepository, productRepo *repository.ProductRepository, roleService role.UseCase, userService user.UseCase, apiKeyService apiKey.UseCase) MidddlewareServices {
 	return MidddlewareServices{
-		AuthenMiddleware: authentication.NewAuthenticationService(fbClient, productItemRepo, productRepo),
+		AuthenMiddleware: authentication.NewAuthenticationService(identityProvider, productItemRepo, productRepo, roleService, userService, apiKeyService),
 	}
 }
@@ -0,0 +1,11 @@
+package presenter
+
+import (
+	"backend-service/internal/core_backend/entity"
+)
+
+// CreateAPIKeyResponse the created key with its plain value, shown only once
+type CreateAPIKeyResponse struct {
+	APIKey *entity.APIKey `json:"api_key"`
+	Key    string         `json:"key"`
+}
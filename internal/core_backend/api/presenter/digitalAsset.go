@@ -1,10 +1,9 @@
 package presenter
 
 import (
-	"backend-service/internal/core_backend/entity"
-	"fmt"
 	"strconv"
-	"strings"
+
+	"backend-service/internal/core_backend/entity"
 )
 
 // DigitalAssetResponse data struct
@@ -27,40 +26,31 @@ type ListDigitalAssetsResponse struct {
 	DigitalAssets []DigitalAssetResponse `json:"digital_assets"`
 }
 
-type MetadataResponse struct {
-	Description  string              `json:"description"`
-	ExternalURL  string              `json:"external_url"`
-	Image        string              `json:"image"`
-	Name         string              `json:"name"`
-	Attributes   []MetadataAttribute `json:"attributes"`
-	AnimationURL string              `json:"animation_url"`
-}
-
-type MetadataAttribute struct {
-	TraitType   string `json:"trait_type"`
-	Value       string `json:"value"`
-	DisplayType string `json:"display_type"`
-}
-
-type WalletAddressResponse struct {
-	WalletAddress string `json:"wallet_address"`
-}
-
-type CreateWalletResponse struct {
-	Result WalletAddressResponse `json:"result"`
+// TokenMetadataResponse is the ERC-721 metadata JSON served as the tokenURI
+type TokenMetadataResponse struct {
+	Name         string                   `json:"name"`
+	Description  string                   `json:"description"`
+	Image        string                   `json:"image"`
+	ExternalURL  string                   `json:"external_url,omitempty"`
+	AnimationURL string                   `json:"animation_url,omitempty"`
+	Attributes   []TokenMetadataAttribute `json:"attributes"`
 }
 
-type UserWalletResponse struct {
-	Email  string                `json:"email"`
-	Wallet WalletAddressResponse `json:"wallet"`
+type TokenMetadataAttribute struct {
+	TraitType   string `json:"trait_type,omitempty"`
+	Value       any    `json:"value"`
+	DisplayType string `json:"display_type,omitempty"`
 }
 
-type ListWalletsResponse struct {
-	ListWallet []UserWalletResponse `json:"list_wallets"`
-}
-
-type CreateWalletsBulkResponse struct {
-	Result ListWalletsResponse `json:"result"`
+// ContractMetadataResponse is the collection level JSON served as the contractURI
+type ContractMetadataResponse struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	Image                string `json:"image,omitempty"`
+	BannerImage          string `json:"banner_image,omitempty"`
+	ExternalLink         string `json:"external_link,omitempty"`
+	SellerFeeBasisPoints int    `json:"seller_fee_basis_points"`
+	FeeRecipient         string `json:"fee_recipient,omitempty"`
 }
 
 // presenterDigitalAsset struct
@@ -69,7 +59,8 @@ type PresenterDigitalAsset struct{}
 // presenterDigitalAsset interface
 type ConvertDigitalAsset interface {
 	ResponseDigitalAssets(digitalAsset *[]entity.DigitalAsset) *ListDigitalAssetsResponse
-	ResponseGetMetadata(itemIndex int, org *entity.Organization, product *entity.Product) *MetadataResponse
+	ResponseTokenMetadata(metadata *entity.Metadata) *TokenMetadataResponse
+	ResponseContractMetadata(collection *entity.DigitalAssetCollection) *ContractMetadataResponse
 	ResponseGetDetailDigitalAssets(digitalAssets *[]entity.DigitalAsset, collections *[]entity.DigitalAssetCollection, owners *[]entity.User) *ListDigitalAssetsResponse
 }
 
@@ -94,89 +85,6 @@ func (pp *PresenterDigitalAsset) ResponseDigitalAssets(digitalAssets *[]entity.D
 	return &response
 }
 
-func (pp *PresenterDigitalAsset) ResponseGetMetadata(itemIndex int, org *entity.Organization, product *entity.Product) *MetadataResponse {
-	var metadata = MetadataResponse{
-		ExternalURL: "https://nomion.io/",
-		Image:       product.Image.URL,
-	}
-
-	switch org.NameTag {
-	case "lej":
-		metadata.Name = product.ProductName + " #" + strconv.Itoa(itemIndex)
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Origin",
-			Value:     product.Origin,
-		})
-		attribute := product.Attribute.(entity.AttributeCoffee)
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Farm",
-			Value:     attribute.FarmName,
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Preprocessed Type",
-			Value:     attribute.Process,
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Acidity",
-			Value:     attribute.Acidity,
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Bitter",
-			Value:     attribute.Bitter,
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Sweet",
-			Value:     attribute.Sweet,
-		})
-	case "da-non-nuoc":
-		metadata.Name = product.ProductName
-		attribute := product.Attribute.(entity.AttributeSculpture)
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Tác Giả",
-			Value:     attribute.Craftsman.Name,
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Cuộc Thi",
-			Value:     "Đá Non Nước 2023",
-		})
-		stoneTarget := attribute.Stone.Translation
-		materialName := (stoneTarget["vi"].(map[string]string))["name"]
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Chất Liệu",
-			Value:     fmt.Sprintf("%v", materialName),
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Chiều Dài",
-			Value:     attribute.SculptureLength,
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Chiều Rộng",
-			Value:     attribute.SculptureWidth,
-		})
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Chiều Cao",
-			Value:     attribute.SculptureHeight,
-		})
-		// TODO - Remove HTML tag
-		viDescription := attribute.Translation["vi"].(map[string]string)["description"]
-		metadata.Description = fmt.Sprintf("%v", viDescription)
-		metadata.AnimationURL = product.ThreeDimension.URL
-	case "astronaut":
-		metadata.Name = product.ProductName
-		metadata.AnimationURL = product.Video.URL
-		metadata.Image = product.Video.ThumbnailURL
-		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
-			TraitType: "Owner",
-			Value:     strings.SplitN(product.ProductName, " ", 2)[1],
-		})
-	default:
-		metadata.Name = product.ProductName + " #" + strconv.Itoa(itemIndex)
-		return &metadata
-	}
-
-	return &metadata
-}
-
 func (pp *PresenterDigitalAsset) ResponseGetDetailDigitalAssets(digitalAssets *[]entity.DigitalAsset, collections *[]entity.DigitalAssetCollection, owners *[]entity.User) *ListDigitalAssetsResponse {
 	var response ListDigitalAssetsResponse
 	for i, digitalAsset := range *digitalAssets {
@@ -198,3 +106,56 @@ func (pp *PresenterDigitalAsset) ResponseGetDetailDigitalAssets(digitalAssets *[
 	}
 	return &response
 }
+
+// ResponseTokenMetadata numeric and date traits are served as numbers as marketplaces expect
+func (pp *PresenterDigitalAsset) ResponseTokenMetadata(metadata *entity.Metadata) *TokenMetadataResponse {
+	response := TokenMetadataResponse{
+		Name:         metadata.Name,
+		Description:  metadata.Description,
+		Image:        metadata.Image,
+		ExternalURL:  metadata.ExternalURL,
+		AnimationURL: metadata.AnimationURL,
+		Attributes:   []TokenMetadataAttribute{},
+	}
+	for _, attribute := range metadata.Attributes {
+		var value any = attribute.Value
+		switch attribute.DisplayType {
+		case "number", "boost_number", "boost_percentage":
+			if number, err := strconv.ParseFloat(attribute.Value, 64); err == nil {
+				value = number
+			}
+		case "date":
+			if timestamp, err := strconv.ParseInt(attribute.Value, 10, 64); err == nil {
+				value = timestamp
+			}
+		}
+		response.Attributes = append(response.Attributes, TokenMetadataAttribute{
+			TraitType:   attribute.TraitType,
+			Value:       value,
+			DisplayType: attribute.DisplayType,
+		})
+	}
+
+	return &response
+}
+
+func (pp *PresenterDigitalAsset) ResponseContractMetadata(collection *entity.DigitalAssetCollection) *ContractMetadataResponse {
+	contract := collection.ContractMetadata
+	response := ContractMetadataResponse{
+		Name:                 contract.Name,
+		Description:          contract.Description,
+		Image:                contract.Image,
+		BannerImage:          contract.BannerImage,
+		ExternalLink:         contract.ExternalLink,
+		SellerFeeBasisPoints: contract.SellerFeeBasisPoints,
+		FeeRecipient:         contract.FeeRecipient,
+	}
+	if response.Name == "" {
+		response.Name = collection.Name
+	}
+	if response.Description == "" {
+		response.Description = collection.Description
+	}
+
+	return &response
+}
@@ -4,6 +4,7 @@ import (
 	"backend-service/internal/core_backend/entity"
 	"sort"
 	"sync"
+	"time"
 )
 
 // MappingResponse data struct
@@ -18,18 +19,20 @@ func (m ByTagID) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
 func (m ByTagID) Less(i, j int) bool { return m[i].TagID < m[j].TagID }
 
 type Mapping struct {
-	TagID              string `json:"tag_id"`
-	ProductItemID      string `json:"product_item_id"`
-	OrganizationID     string `json:"org_id"`
-	ExternalURL        string `json:"external_url"`
-	Claimable          bool   `json:"claimable"`
-	OwnerID            string `json:"owner_id"`
-	OwnerEmail         string `json:"owner_email"`
-	OwnerName          string `json:"owner_name"`
-	DigitalAssetID     string `json:"digital_asset_id"`
-	CollectionID       string `json:"collection_id"`
-	TokenID            int64  `json:"token_id"`
-	DigitalAssetStatus string `json:"digital_asset_status"`
+	TagID              string     `json:"tag_id"`
+	ProductItemID      string     `json:"product_item_id"`
+	OrganizationID     string     `json:"org_id"`
+	ExternalURL        string     `json:"external_url"`
+	Claimable          bool       `json:"claimable"`
+	ClaimState         string     `json:"claim_state"`
+	ClaimableUntil     *time.Time `json:"claimable_until,omitempty"`
+	OwnerID            string     `json:"owner_id"`
+	OwnerEmail         string     `json:"owner_email"`
+	OwnerName          string     `json:"owner_name"`
+	DigitalAssetID     string     `json:"digital_asset_id"`
+	CollectionID       string     `json:"collection_id"`
+	TokenID            int64      `json:"token_id"`
+	DigitalAssetStatus string     `json:"digital_asset_status"`
 }
 
 type ProductsAbleToMapping struct {
@@ -66,6 +69,7 @@ func (pp *PresenterMapping) ResponseGetAllMapping(mappings *[]entity.Mapping, ow
 		response GetAllMappingResponse
 		wg       sync.WaitGroup
 		mutex    sync.Mutex
+		now      = time.Now()
 	)
 
 	wg.Add(len(*mappings))
@@ -75,7 +79,9 @@ func (pp *PresenterMapping) ResponseGetAllMapping(mappings *[]entity.Mapping, ow
 				TagID:          mapping.TagID,
 				ProductItemID:  mapping.ProductItemID.Hex(),
 				OrganizationID: mapping.OrganizationID.Hex(),
-				Claimable:      mapping.Claimable,
+				Claimable:      mapping.IsClaimableAt(now),
+				ClaimState:     mapping.ClaimStateAt(now),
+				ClaimableUntil: mapping.ClaimableUntil,
 				ExternalURL:    mapping.ExternalURL,
 			}
 
@@ -2,8 +2,9 @@ package presenter
 
 import (
 	"backend-service/internal/core_backend/entity"
-	"math/big"
+	"backend-service/pkg/common/translation"
 	"sort"
+	"time"
 )
 
 // ProductItemResponse data struct
@@ -54,8 +55,10 @@ type StoryDetailResponse struct {
 }
 
 type StoryMappingResponse struct {
-	ExternalURL string `json:"external_url"`
-	Claimable   bool   `json:"claimable"`
+	ExternalURL    string     `json:"external_url"`
+	Claimable      bool       `json:"claimable"`
+	ClaimState     string     `json:"claim_state"`
+	ClaimableUntil *time.Time `json:"claimable_until,omitempty"`
 }
 
 type StoryProductItemResponse struct {
@@ -88,13 +91,8 @@ type StoryTemplatePagesResponse struct {
 }
 
 type StoryTemplateMenuResponse struct {
-	Title StoryTemplateMenuTitleResponse `json:"title"`
-	URL   string                         `json:"url"`
-}
-
-type StoryTemplateMenuTitleResponse struct {
-	VI string `json:"vi"`
-	EN string `json:"en"`
+	Title translation.LocalizedString `json:"title" swaggertype:"object,string"`
+	URL   string                      `json:"url"`
 }
 
 type StoryHomepageResponse struct {
@@ -149,22 +147,6 @@ type GalleryProductItemsResponse struct {
 	Standard           string                       `json:"standard"`
 }
 
-// Test NFT
-type ProductItemMetadataResponse struct {
-	Description   string      `json:"description"`
-	ExternalURL   string      `json:"external_url"`
-	Image         string      `json:"image"`
-	Name          string      `json:"name"`
-	Attributes    []Attribute `json:"attributes"`
-	Animation_url string      `json:"animation_url"`
-}
-
-type Attribute struct {
-	TraitType   string      `json:"trait_type"`
-	Value       interface{} `json:"value"`
-	DisplayType string      `json:"display_type"`
-}
-
 type ByItemIndex []ProductItemDetailWithOwnerResponse
 
 func (m ByItemIndex) Len() int           { return len(m) }
@@ -190,7 +172,6 @@ type ConvertProductItem interface {
 	ResponseGetStoryDetail(mapping *entity.Mapping, product *entity.Product, productItem *entity.ProductItem, owner *entity.User, template *entity.TemplateWebpages, homepage *entity.WebPage, organization *entity.Organization, da *entity.DigitalAsset, dac *entity.DigitalAssetCollection, author *entity.Author) *StoryDetailResponse
 	ResponseGalleryProductItems(*string, []int, *[]entity.Mapping, *[]entity.Product, *[]entity.WebPage, *[]entity.WebPage, *[]entity.TemplateWebpages, *[]entity.DigitalAsset, *[]entity.DigitalAssetCollection) *GalleryProductItemsListResponse
 	ResponseGalleryProductItemsV2(mappings *[]*entity.Mapping, products *[]*entity.Product, productItems *[]*entity.ProductItem, owners *[]*entity.User, templates *[]*entity.TemplateWebpages, organizations *[]*entity.Organization, das *[]*entity.DigitalAsset, dacs *[]*entity.DigitalAssetCollection) *GalleryProductItemsListResponseV2
-	ResponseGetMetadata(*big.Int) *ProductItemMetadataResponse
 }
 
 // NewPresenterProductItem Constructs presenter
@@ -198,54 +179,6 @@ func NewPresenterProductItem() ConvertProductItem {
 	return &PresenterProductItem{}
 }
 
-func (pp *PresenterProductItem) ResponseGetMetadata(tokenId *big.Int) *ProductItemMetadataResponse {
-	metadata := &ProductItemMetadataResponse{
-		Description: "Chiếc nón lá gắn liền với đời sống tinh thần của người dân, của người phụ nữ Việt. Trải dài mọi miền đất nước, hình ảnh nón lá luôn hiện diện, đó chính là nét đẹp, nét duyên, là sự bình dị mộc mạc của người phụ nữ Việt Nam nói chung  và của người phụ nữ Đà Nẵng nói riêng",
-		ExternalURL: "https://kyokai.vn",
-		Image:       "https://storage.googleapis.com/assets-fygito/images/user_id/1688617560-Chiếc Nón - Đàm Đông.png",
-		Name:        "Chiếc Nón - Đàm Đông",
-		Attributes: []Attribute{
-			{
-				TraitType: "Tác Giả",
-				Value:     "Đàm Đông",
-			},
-			{
-				TraitType: "Cuộc thi",
-				Value:     "Đá Non Nước 2023",
-			},
-			{
-				TraitType: "Chất Liệu",
-				Value:     "Đá Marble Trắng",
-			},
-			{
-				TraitType: "Chiều Dài",
-				Value:     "65 cm",
-			},
-			{
-				TraitType: "Chiều Rộng",
-				Value:     "40 cm",
-			},
-			{
-				TraitType: "Chiều Cao",
-				Value:     "150 cm",
-			},
-			{
-				TraitType: "Category",
-				Value:     "Sculpture",
-			},
-			{
-				TraitType: "Origin",
-				Value:     "Viet Nam",
-			},
-		},
-		Animation_url: "https://storage.googleapis.com/assets-fygito/images/user_id/1688612305-Chiếc Nón - Đàm Đông.glb",
-	}
-	if tokenId.Cmp(big.NewInt(2)) == 0 {
-		metadata.Animation_url = "https://storage.googleapis.com/assets-fygito/images/user_id/1689675844-%C4%90a%CC%80%20Na%CC%86%CC%83ng%20Ti%CC%80nh%20Ngu%CC%9Bo%CC%9B%CC%80i%20-%20%C4%90o%CC%82%CC%83%20Va%CC%86n%20Hie%CC%A3%CC%82u.glb"
-	}
-	return metadata
-}
-
 // Return property data response
 func (pp *PresenterProductItem) ResponseProductItemDetail(productItem *entity.ProductItem, product *entity.Product) *ProductItemDetailResponse {
 	response := &ProductItemDetailResponse{
@@ -322,8 +255,10 @@ func (pp *PresenterProductItem) ResponseGetStoryDetail(mapping *entity.Mapping,
 
 	if mapping != nil {
 		response.MappingDetail = StoryMappingResponse{
-			ExternalURL: mapping.ExternalURL,
-			Claimable:   mapping.Claimable,
+			ExternalURL:    mapping.ExternalURL,
+			Claimable:      mapping.IsClaimableAt(time.Now()),
+			ClaimState:     mapping.ClaimStateAt(time.Now()),
+			ClaimableUntil: mapping.ClaimableUntil,
 		}
 	}
 
@@ -357,11 +292,8 @@ func (pp *PresenterProductItem) ResponseGetStoryDetail(mapping *entity.Mapping,
 		}
 		for _, menu := range template.Menu {
 			menus = append(menus, StoryTemplateMenuResponse{
-				Title: StoryTemplateMenuTitleResponse{
-					VI: menu.Title.VI,
-					EN: menu.Title.EN,
-				},
-				URL: menu.URLLink,
+				Title: menu.Title,
+				URL:   menu.URLLink,
 			})
 		}
 		response.TemplateDetail = StoryTemplateResponse{
@@ -470,8 +402,10 @@ func (pp *PresenterProductItem) ResponseGalleryProductItemsV2(mappings *[]*entit
 		}
 		if mapping != nil {
 			info.MappingDetail = StoryMappingResponse{
-				ExternalURL: mapping.ExternalURL,
-				Claimable:   mapping.Claimable,
+				ExternalURL:    mapping.ExternalURL,
+				Claimable:      mapping.IsClaimableAt(time.Now()),
+				ClaimState:     mapping.ClaimStateAt(time.Now()),
+				ClaimableUntil: mapping.ClaimableUntil,
 			}
 		}
 
@@ -505,11 +439,8 @@ func (pp *PresenterProductItem) ResponseGalleryProductItemsV2(mappings *[]*entit
 			}
 			for _, menu := range template.Menu {
 				menus = append(menus, StoryTemplateMenuResponse{
-					Title: StoryTemplateMenuTitleResponse{
-						VI: menu.Title.VI,
-						EN: menu.Title.EN,
-					},
-					URL: menu.URLLink,
+					Title: menu.Title,
+					URL:   menu.URLLink,
 				})
 			}
 			info.TemplateDetail = StoryTemplateResponse{
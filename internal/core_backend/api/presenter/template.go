@@ -2,16 +2,20 @@ package presenter
 
 import (
 	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type TemplateWebpagesResponse struct {
-	ID        string                          `json:"template_id"`
-	Name      string                          `json:"name"`
-	Category  string                          `json:"category"`
-	Languages []string                        `json:"languages"`
-	Pages     []TemplateWebpagesPagesResponse `json:"pages"`
-	Menu      []TemplateWebpagesMenuResponse  `json:"menu"`
+	ID             string                          `json:"template_id"`
+	OrganizationID string                          `json:"org_id,omitempty"`
+	Name           string                          `json:"name"`
+	Category       string                          `json:"category"`
+	Languages      []string                        `json:"languages"`
+	Pages          []TemplateWebpagesPagesResponse `json:"pages"`
+	Menu           []TemplateWebpagesMenuResponse  `json:"menu"`
 }
 
 type TemplateWebpagesPagesResponse struct {
@@ -24,28 +28,30 @@ type TemplateWebpagesPagesResponse struct {
 	Type       string                 `json:"type"`
 	Category   string                 `json:"category"`
 	Attributes map[string]interface{} `json:"attributes"`
+	Blocks     []entity.WebPageBlock  `json:"blocks"`
 }
 
 type TemplateWebpagesMenuResponse struct {
-	Title      TemplateMenuTitleResponse `json:"title"`
-	ID         string                    `json:"page_id"`
-	Status     string                    `json:"status"`
-	CreatedAt  time.Time                 `json:"created_at"`
-	UpdatedAt  time.Time                 `json:"updated_at"`
-	Name       string                    `json:"name"`
-	URLLink    string                    `json:"url_link"`
-	Type       string                    `json:"type"`
-	Category   string                    `json:"category"`
-	Attributes map[string]interface{}    `json:"attributes"`
+	Title      translation.LocalizedString `json:"title" swaggertype:"object,string"`
+	ID         string                      `json:"page_id"`
+	Status     string                      `json:"status"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+	Name       string                      `json:"name"`
+	URLLink    string                      `json:"url_link"`
+	Type       string                      `json:"type"`
+	Category   string                      `json:"category"`
+	Attributes map[string]interface{}      `json:"attributes"`
 }
 
 type TemplateResponse struct {
-	ID        string                  `json:"template_id"`
-	Name      string                  `json:"name"`
-	Category  string                  `json:"category"`
-	Languages []string                `json:"languages"`
-	Pages     []TemplatePagesResponse `json:"pages"`
-	Menu      []TemplateMenuResponse  `json:"menu"`
+	ID             string                  `json:"template_id"`
+	OrganizationID string                  `json:"org_id,omitempty"`
+	Name           string                  `json:"name"`
+	Category       string                  `json:"category"`
+	Languages      []string                `json:"languages"`
+	Pages          []TemplatePagesResponse `json:"pages"`
+	Menu           []TemplateMenuResponse  `json:"menu"`
 }
 
 type TemplatePagesResponse struct {
@@ -53,13 +59,8 @@ type TemplatePagesResponse struct {
 }
 
 type TemplateMenuResponse struct {
-	Title  TemplateMenuTitleResponse `json:"title"`
-	PageID string                    `json:"page_id"`
-}
-
-type TemplateMenuTitleResponse struct {
-	VI string `json:"vi"`
-	EN string `json:"en"`
+	Title  translation.LocalizedString `json:"title" swaggertype:"object,string"`
+	PageID string                      `json:"page_id"`
 }
 
 type ListTemplateResponse struct {
@@ -88,20 +89,18 @@ func (pt *PresenterTemplate) ResponseGetTemplate(template *entity.Template) *Tem
 	var menus []TemplateMenuResponse
 	for _, menu := range template.Menu {
 		menus = append(menus, TemplateMenuResponse{
-			Title: TemplateMenuTitleResponse{
-				VI: menu.Title.VI,
-				EN: menu.Title.EN,
-			},
+			Title:  menu.Title,
 			PageID: menu.PageID.Hex(),
 		})
 	}
 	return &TemplateResponse{
-		ID:        template.ID.Hex(),
-		Name:      template.Name,
-		Category:  template.Category,
-		Languages: template.Languages,
-		Pages:     pages,
-		Menu:      menus,
+		ID:             template.ID.Hex(),
+		OrganizationID: organizationIDHex(template.OrganizationID),
+		Name:           template.Name,
+		Category:       template.Category,
+		Languages:      template.Languages,
+		Pages:          pages,
+		Menu:           menus,
 	}
 }
 
@@ -117,15 +116,13 @@ func (pt *PresenterTemplate) ResponseGetTemplateWebpages(templateWebpages *entit
 			URLLink:    page.URLLink,
 			Type:       page.Type,
 			Attributes: page.Attributes,
+			Blocks:     page.Blocks,
 		})
 	}
 	var menus []TemplateWebpagesMenuResponse
 	for _, menu := range templateWebpages.Menu {
 		menus = append(menus, TemplateWebpagesMenuResponse{
-			Title: TemplateMenuTitleResponse{
-				VI: menu.Title.VI,
-				EN: menu.Title.EN,
-			},
+			Title:      menu.Title,
 			ID:         menu.ID.Hex(),
 			Status:     menu.Status,
 			CreatedAt:  menu.CreatedAt,
@@ -137,12 +134,13 @@ func (pt *PresenterTemplate) ResponseGetTemplateWebpages(templateWebpages *entit
 		})
 	}
 	return &TemplateWebpagesResponse{
-		ID:        templateWebpages.ID.Hex(),
-		Name:      templateWebpages.Name,
-		Category:  templateWebpages.Category,
-		Languages: templateWebpages.Languages,
-		Pages:     pages,
-		Menu:      menus,
+		ID:             templateWebpages.ID.Hex(),
+		OrganizationID: organizationIDHex(templateWebpages.OrganizationID),
+		Name:           templateWebpages.Name,
+		Category:       templateWebpages.Category,
+		Languages:      templateWebpages.Languages,
+		Pages:          pages,
+		Menu:           menus,
 	}
 
 }
@@ -159,21 +157,28 @@ func (pt *PresenterTemplate) ResponseGetAllTemplates(templates *[]entity.Templat
 		var menus []TemplateMenuResponse
 		for _, menu := range template.Menu {
 			menus = append(menus, TemplateMenuResponse{
-				Title: TemplateMenuTitleResponse{
-					VI: menu.Title.VI,
-					EN: menu.Title.EN,
-				},
+				Title:  menu.Title,
 				PageID: menu.PageID.Hex(),
 			})
 		}
 		response.TemplateList = append(response.TemplateList, TemplateResponse{
-			ID:        template.ID.Hex(),
-			Name:      template.Name,
-			Category:  template.Category,
-			Languages: template.Languages,
-			Pages:     pages,
-			Menu:      menus,
+			ID:             template.ID.Hex(),
+			OrganizationID: organizationIDHex(template.OrganizationID),
+			Name:           template.Name,
+			Category:       template.Category,
+			Languages:      template.Languages,
+			Pages:          pages,
+			Menu:           menus,
 		})
 	}
 	return &response
 }
+
+// organizationIDHex is empty for resources shared by every organization
+func organizationIDHex(orgID primitive.ObjectID) string {
+	if orgID.IsZero() {
+		return ""
+	}
+
+	return orgID.Hex()
+}
@@ -1,7 +1,11 @@
 package presenter
 
 import (
+	"time"
+
+	config "backend-service/config/core_backend"
 	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/siwe"
 )
 
 // UserResponse data struct
@@ -11,12 +15,22 @@ type UserResponse struct {
 	Status string `json:"status"`
 }
 
+// WalletNonceResponse what a client needs to build the SIWE message to sign
+type WalletNonceResponse struct {
+	Domain    string    `json:"domain"`
+	Version   string    `json:"version"`
+	Nonce     string    `json:"nonce"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expiration_time"`
+}
+
 // presenterUser struct
 type PresenterUser struct{}
 
 // presenterUser interface
 type ConvertUser interface {
 	ResponseUser(user *entity.User) *UserResponse
+	ResponseWalletNonce(nonce *entity.SiweNonce) *WalletNonceResponse
 }
 
 // NewPresenterUser Constructs presenter
@@ -34,3 +48,13 @@ func (pp *PresenterUser) ResponseUser(user *entity.User) *UserResponse {
 
 	return response
 }
+
+func (pp *PresenterUser) ResponseWalletNonce(nonce *entity.SiweNonce) *WalletNonceResponse {
+	return &WalletNonceResponse{
+		Domain:    config.C.Siwe.DOMAIN,
+		Version:   siwe.Version,
+		Nonce:     nonce.Nonce,
+		IssuedAt:  nonce.CreatedAt.UTC().Truncate(time.Second),
+		ExpiresAt: nonce.ExpiresAt.UTC().Truncate(time.Second),
+	}
+}
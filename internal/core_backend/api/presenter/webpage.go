@@ -6,15 +6,17 @@ import (
 )
 
 type WebpageDetailResponse struct {
-	ID         string                 `json:"id"`
-	Status     string                 `json:"status"`
-	CreatedAt  time.Time              `json:"created_at"`
-	UpdatedAt  time.Time              `json:"updated_at"`
-	Name       string                 `json:"name"`
-	URLLink    string                 `json:"url_link"`
-	Type       string                 `json:"type"`
-	Category   string                 `json:"category"`
-	Attributes map[string]interface{} `json:"attributes"`
+	ID             string                 `json:"id"`
+	OrganizationID string                 `json:"org_id,omitempty"`
+	Status         string                 `json:"status"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	Name           string                 `json:"name"`
+	URLLink        string                 `json:"url_link"`
+	Type           string                 `json:"type"`
+	Category       string                 `json:"category"`
+	Attributes     map[string]interface{} `json:"attributes"`
+	Blocks         []entity.WebPageBlock  `json:"blocks"`
 }
 
 type AllWebpagesResponse struct {
@@ -38,14 +40,16 @@ func NewPresenterWebpage() ConvertWebpage {
 // Return property data response
 func (pw *PresenterWebpage) ResponseWebpageDetail(webpage *entity.WebPage) WebpageDetailResponse {
 	return WebpageDetailResponse{
-		ID:         webpage.ID.Hex(),
-		Status:     webpage.Status,
-		CreatedAt:  webpage.CreatedAt,
-		UpdatedAt:  webpage.UpdatedAt,
-		Name:       webpage.Name,
-		URLLink:    webpage.URLLink,
-		Type:       webpage.Type,
-		Attributes: webpage.Attributes,
+		ID:             webpage.ID.Hex(),
+		OrganizationID: organizationIDHex(webpage.OrganizationID),
+		Status:         webpage.Status,
+		CreatedAt:      webpage.CreatedAt,
+		UpdatedAt:      webpage.UpdatedAt,
+		Name:           webpage.Name,
+		URLLink:        webpage.URLLink,
+		Type:           webpage.Type,
+		Attributes:     webpage.Attributes,
+		Blocks:         webpage.Blocks,
 	}
 }
 
@@ -53,14 +57,16 @@ func (pw *PresenterWebpage) ResponseAllWebpages(webpages *[]entity.WebPage) AllW
 	var response AllWebpagesResponse
 	for _, webpage := range *webpages {
 		response.WebpagesList = append(response.WebpagesList, WebpageDetailResponse{
-			ID:         webpage.ID.Hex(),
-			Status:     webpage.Status,
-			CreatedAt:  webpage.CreatedAt,
-			UpdatedAt:  webpage.UpdatedAt,
-			Name:       webpage.Name,
-			URLLink:    webpage.URLLink,
-			Type:       webpage.Type,
-			Attributes: webpage.Attributes,
+			ID:             webpage.ID.Hex(),
+			OrganizationID: organizationIDHex(webpage.OrganizationID),
+			Status:         webpage.Status,
+			CreatedAt:      webpage.CreatedAt,
+			UpdatedAt:      webpage.UpdatedAt,
+			Name:           webpage.Name,
+			URLLink:        webpage.URLLink,
+			Type:           webpage.Type,
+			Attributes:     webpage.Attributes,
+			Blocks:         webpage.Blocks,
 		})
 	}
 	return response
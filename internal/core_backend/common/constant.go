@@ -21,6 +21,52 @@ const VI_LANG = "vi"
 const (
 	StatusActive   = "Active"
 	StatusInactive = "Inactive"
+	StatusDeleted  = "Deleted"
+)
+
+const (
+	SignerTypeKeystore = "keystore"
+	SignerTypeRemote   = "remote"
+)
+
+const (
+	WalletProviderHTTP = "http"
+	WalletProviderFake = "fake"
+)
+
+// wallet statuses users are searched by
+const (
+	WalletStatusNone      = "none"
+	WalletStatusCustodial = "custodial"
+	WalletStatusLinked    = "linked"
+)
+
+const (
+	ClaimPolicyRelease  = "release"
+	ClaimPolicyReassign = "reassign"
+)
+
+// Reasons the claim state of a product item changed, admins give one of the first five and the service records the others
+const (
+	ClaimReasonSale           = "sale"
+	ClaimReasonOwnerRequest   = "owner_request"
+	ClaimReasonDispute        = "dispute"
+	ClaimReasonFraud          = "fraud"
+	ClaimReasonCorrection     = "correction"
+	ClaimReasonClaimed        = "claimed"
+	ClaimReasonAccountDeleted = "account_deleted"
+)
+
+const (
+	IdentityProviderFirebase = "firebase"
+	IdentityProviderLocal    = "local"
+)
+
+const (
+	WalletProvisionSucceeded = "Succeeded"
+	WalletProvisionFailed    = "Failed"
+	// WalletProvisionNotApplied the provider returned a wallet but the user already had one
+	WalletProvisionNotApplied = "NotApplied"
 )
 
 const (
@@ -30,24 +76,119 @@ const (
 )
 
 const (
-	MessageErrorEmailAlreadyUsed     = "email already used"
-	MessageErrorInvalidToken         = "invalid token provided"
-	MessageErrorExistedEmail         = "email already exists"
-	MessageErrorOrgNotFound          = "organization not found"
-	MessageErrorExistedMapping       = "chip or productItem is already mapped"
-	MessageErrorExistedOrganization  = "this organization's name tage is already taken"
-	MessageErrorExistedTag           = "this tag is already added"
-	MessageErrorWrongFormat          = "wrong format provided"
-	MessageErrorExistedProductName   = "the product name already exists"
-	MessageErrorNotAbleToClaim       = "product is not available to claim"
-	MessageErrorNotFoundUser         = "user is not registered yet!"
-	MessageErrorNotFoundOrganization = "organization's name tag doesn't exist"
-	MessageErrorCreateOrgFail        = "error on creating organization, tagname is taken"
-	MessageErrorCreateTagFail        = "error on creating tag!"
-	MessageErrorCreateTemplateFail   = "error creating template!"
-	MessageErrorUpdateTemplateFail   = "error creating template!"
-	MessageErrorFailDetectUser       = "cannot detect user information"
-	MessageErrorInvalidEntityID      = "invalid entity id provided"
-	MessageErrorInvalidTemplateID    = "invalid template id provided"
-	MessageErrorInvalidOrgTagName    = "Organization Tag Name has invalid characters (only allow a-z (lowercase characters), A-Z (uppercase characters), 0-9 (number), - (hyphen), _ (underscore))"
+	ProductVersionDraft     = "Draft"
+	ProductVersionPublished = "Published"
+)
+
+// ProductOrderByRelevance orders the product search by how well products match its query
+const ProductOrderByRelevance = "relevance"
+
+const (
+	NFTStatusNotMinted = "not_minted"
+	NFTStatusPending   = "pending"
+	NFTStatusMinted    = "minted"
+	NFTStatusFailed    = "failed"
+)
+
+// statuses of the exported stories of product items
+const (
+	StoryStatusPublished   = "published"
+	StoryStatusUnpublished = "unpublished"
+	StoryStatusFailed      = "failed"
+)
+
+const (
+	MessageErrorEmailAlreadyUsed           = "email already used"
+	MessageErrorInvalidToken               = "invalid token provided"
+	MessageErrorExistedEmail               = "email already exists"
+	MessageErrorOrgNotFound                = "organization not found"
+	MessageErrorExistedMapping             = "chip or productItem is already mapped"
+	MessageErrorExistedOrganization        = "this organization's name tage is already taken"
+	MessageErrorExistedTag                 = "this tag is already added"
+	MessageErrorWrongFormat                = "wrong format provided"
+	MessageErrorExistedProductName         = "the product name already exists"
+	MessageErrorNotAbleToClaim             = "product is not available to claim"
+	MessageErrorNotFoundUser               = "user is not registered yet!"
+	MessageErrorNotFoundOrganization       = "organization's name tag doesn't exist"
+	MessageErrorCreateOrgFail              = "error on creating organization, tagname is taken"
+	MessageErrorCreateTagFail              = "error on creating tag!"
+	MessageErrorCreateTemplateFail         = "error creating template!"
+	MessageErrorUpdateTemplateFail         = "error creating template!"
+	MessageErrorFailDetectUser             = "cannot detect user information"
+	MessageErrorInvalidEntityID            = "invalid entity id provided"
+	MessageErrorInvalidTemplateID          = "invalid template id provided"
+	MessageErrorCollectionNotFound         = "digital asset collection not found"
+	MessageErrorTokenNotMinted             = "token has not been minted"
+	MessageErrorMetadataTemplateNotFound   = "metadata template not found for this collection"
+	MessageErrorInvalidPlaceholder         = "invalid placeholder"
+	MessageErrorTemplateNotConfigurable    = "this contract template does not support royalties"
+	MessageErrorTemplateNotAvailable       = "this contract template is not built on this server"
+	MessageErrorContractDeploymentNotFound = "contract deployment not found"
+	MessageErrorOrganizationHasCollection  = "organization already has a digital asset collection or a deployment in progress"
+	MessageErrorUserHasNoEmail             = "user has no email to create a wallet for"
+	MessageErrorWalletNotReturned          = "wallet service returned no wallet for this email"
+	MessageErrorUserHasWallet              = "user already has a wallet"
+	MessageErrorWalletNotApplied           = "user got a wallet meanwhile, the wallet returned was not applied"
+	MessageErrorSiweNotConfigured          = "sign-in with ethereum is not configured"
+	MessageErrorInvalidSiweNonce           = "nonce is unknown, expired or already used"
+	MessageErrorWalletLinkedToOtherUser    = "this wallet is linked to another account"
+	MessageErrorWalletNotLinked            = "this wallet is not linked to your account"
+	MessageErrorRoleNotFound               = "role not found"
+	MessageErrorRoleBindingNotFound        = "role binding not found"
+	MessageErrorCollectionItemNotFound     = "item not found in the collection"
+	MessageErrorRoleNameTaken              = "a role with this name already exists"
+	MessageErrorInvalidPermission          = "unknown permission"
+	MessageErrorPermissionNotHeld          = "you can only grant permissions you hold in this organization"
+	MessageErrorGlobalRoleForbidden        = "only users granted for all organizations can manage roles for all organizations"
+	MessageErrorSuperAdminIsGlobal         = "SUPER_ADMIN can only be granted for all organizations"
+	MessageErrorForbidden                  = "missing permission"
+	MessageErrorProductNotFound            = "product not found"
+	MessageErrorProductItemNotFound        = "product item not found"
+	MessageErrorProductDraftNotFound       = "the product has no draft"
+	MessageErrorProductVersionNotFound     = "product version not found"
+	MessageErrorProductVersionIsLive       = "this version is the published one"
+	MessageErrorProductDraftOutdated       = "another version was published since the draft was saved, save the draft again over it"
+	MessageErrorProductPublishedMeanwhile  = "another version of the product was published meanwhile, retry"
+	MessageErrorInvalidCursor              = "invalid cursor, pass the next_cursor of the previous page"
+	MessageErrorRelevanceWithoutQuery      = "products can only be ordered by relevance to a search query q"
+	MessageErrorProductTypeNotFound        = "product type not found"
+	MessageErrorUnknownProductType         = "unknown product type"
+	MessageErrorProductTypeTaken           = "a product type with this name already exists"
+	MessageErrorProductTypeInUse           = "the product type is used by products"
+	MessageErrorProductTypeChanged         = "the product type was changed meanwhile, reload it and retry"
+	MessageErrorInvalidProductTypeName     = "product type names start with a-z and only allow a-z, 0-9, - and _"
+	MessageErrorInvalidSchema              = "invalid schema"
+	MessageErrorInvalidAttribute           = "attribute does not match the schema of the product type"
+	MessageErrorInvalidLanguage            = "languages must be BCP 47 tags"
+	MessageErrorDuplicateLanguage          = "a language is given more than once"
+	MessageErrorMenuLanguage               = "menu titles must be written in the languages of the template"
+	MessageErrorInvalidPageID              = "invalid webpage id provided"
+	MessageErrorTemplateNotFound           = "template not found"
+	MessageErrorTemplatePageMissing        = "the template references webpages that no longer exist"
+	MessageErrorTemplateOfOtherOrg         = "the template of the product belongs to another organization, clone the template with the product"
+	MessageErrorTemplateWithoutHome        = "a template needs a page of type home"
+	MessageErrorWebPageNotFound            = "webpage not found"
+	MessageErrorAuthorNotFound             = "author not found"
+	MessageErrorInvalidBlock               = "invalid block"
+	MessageErrorWebPageInUse               = "the webpage is used by templates, delete it with cascade to remove it from them"
+	MessageErrorHomePageInUse              = "the webpage is the home page of templates, replace it in them before deleting it"
+	MessageErrorMappingNotFound            = "mapping not found"
+	MessageErrorClaimTransition            = "the claim state of the item cannot change"
+	MessageErrorClaimStateChanged          = "the claim state of the item changed meanwhile, reload it and try again"
+	MessageErrorItemLocked                 = "the item is locked"
+	MessageErrorStoryNotFound              = "the story of the product item has not been exported yet"
+	MessageErrorOrganizationRequired       = "org_id is required when you manage several organizations"
+	MessageErrorSharedResource             = "resources shared by every organization can only be changed by admins of all organizations"
+	MessageErrorAPIKeyNotFound             = "api key not found"
+	MessageErrorInvalidAPIKey              = "invalid, expired or revoked api key"
+	MessageErrorAPIKeyPermission           = "api keys cannot hold this permission"
+	MessageErrorAPIKeyExpiry               = "expires_at must be in the future"
+	MessageErrorAPIKeyNeedsOrganization    = "api keys belong to a single organization, org_id is required"
+	MessageErrorPubsubMessageID            = "messageId and publishTime are required"
+	MessageErrorPubsubReplayWindow         = "message was published outside the replay window"
+	MessageErrorAccountOwnsOrganization    = "transfer the ownership of your organizations before deleting your account"
+	MessageErrorAccountDeletionConfirm     = "confirm must be true to delete the account"
+	MessageErrorUserDeactivated            = "this account is deactivated"
+	MessageErrorDeactivateSelf             = "you cannot deactivate your own account"
+	MessageErrorInvalidOrgTagName          = "Organization Tag Name has invalid characters (only allow a-z (lowercase characters), A-Z (uppercase characters), 0-9 (number), - (hyphen), _ (underscore))"
 )
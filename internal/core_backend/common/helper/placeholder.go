@@ -0,0 +1,199 @@
+package helper
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/pkg/common/translation"
+)
+
+var (
+	placeholderPattern = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+	htmlTagPattern     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// placeholderFilters can be chained after a path: {{attribute.description | strip_html}}
+var placeholderFilters = map[string]func(string) string{
+	"upper":           strings.ToUpper,
+	"lower":           strings.ToLower,
+	"trim":            strings.TrimSpace,
+	"strip_html":      StripHTML,
+	"skip_first_word": SkipFirstWord,
+}
+
+// ToPlaceholderData converts a bson-tagged struct into a nested map keyed by its bson field names
+func ToPlaceholderData(v any) map[string]any {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		logger.LogError("Error marshaling placeholder data: " + err.Error())
+		return map[string]any{}
+	}
+	var data map[string]any
+	if err = bson.Unmarshal(raw, &data); err != nil {
+		logger.LogError("Error unmarshaling placeholder data: " + err.Error())
+		return map[string]any{}
+	}
+
+	return data
+}
+
+// FindPlaceholders returns the paths of all placeholders used in text
+func FindPlaceholders(text string) []string {
+	var paths []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		path, _, _ := strings.Cut(match[1], "|")
+		paths = append(paths, strings.TrimSpace(path))
+	}
+
+	return paths
+}
+
+// IsSinglePlaceholder reports whether the whole text is one placeholder, such as {{product.image.url}}
+func IsSinglePlaceholder(text string) bool {
+	match := placeholderPattern.FindStringIndex(text)
+	return match != nil && match[0] == 0 && match[1] == len(text)
+}
+
+// RenderPlaceholders replaces every {{path | filter}} in text with the value found at path in data.
+// Unknown paths are rendered as an empty string.
+func RenderPlaceholders(text string, data map[string]any) string {
+	return renderPlaceholders(text, data, FormatPlaceholderValue)
+}
+
+// RenderLocalizedPlaceholders is RenderPlaceholders where a path to a localized text, such as {{author.name}},
+// is rendered in the first language of the chain it has a text in
+func RenderLocalizedPlaceholders(text string, data map[string]any, chain translation.FallbackChain) string {
+	return renderPlaceholders(text, data, func(value any) string {
+		if texts, ok := localizedTexts(value); ok {
+			if localized, err := translation.NewLocalizedString(texts); err == nil {
+				return localized.Get(chain)
+			}
+		}
+		return FormatPlaceholderValue(value)
+	})
+}
+
+func renderPlaceholders(text string, data map[string]any, format func(any) string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		expression := placeholderPattern.FindStringSubmatch(match)[1]
+		parts := strings.Split(expression, "|")
+		value, ok := LookupPath(data, strings.TrimSpace(parts[0]))
+		if !ok {
+			return ""
+		}
+		result := format(value)
+		for _, name := range parts[1:] {
+			if filter, ok := placeholderFilters[strings.TrimSpace(name)]; ok {
+				result = filter(result)
+			}
+		}
+
+		return result
+	})
+}
+
+// LookupPath walks a dotted path (e.g. product.image.url or processes.0.image_url) through nested maps and slices
+func LookupPath(data map[string]any, path string) (any, bool) {
+	var current any = data
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case primitive.M:
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case primitive.D:
+			value, ok := node.Map()[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case primitive.A:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, current != nil
+}
+
+// localizedTexts the texts of a document whose values are all texts, by key
+func localizedTexts(value any) (map[string]string, bool) {
+	var document map[string]any
+	switch v := value.(type) {
+	case map[string]any:
+		document = v
+	case primitive.M:
+		document = v
+	case primitive.D:
+		document = v.Map()
+	default:
+		return nil, false
+	}
+	texts := make(map[string]string, len(document))
+	for key, item := range document {
+		text, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		texts[key] = text
+	}
+
+	return texts, len(texts) > 0
+}
+
+// FormatPlaceholderValue renders a looked up value as text
+func FormatPlaceholderValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case primitive.ObjectID:
+		return v.Hex()
+	case primitive.DateTime:
+		return v.Time().UTC().Format("2006-01-02")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// StripHTML removes HTML tags and unescapes entities
+func StripHTML(text string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(text, "")))
+}
+
+// SkipFirstWord removes the first word of text, nothing is left of a single word
+func SkipFirstWord(text string) string {
+	_, rest, _ := strings.Cut(strings.TrimSpace(text), " ")
+	return strings.TrimSpace(rest)
+}
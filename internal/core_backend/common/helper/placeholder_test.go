@@ -0,0 +1,128 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/pkg/common/translation"
+)
+
+func TestRenderPlaceholders(t *testing.T) {
+	id := primitive.NewObjectID()
+	data := map[string]any{
+		"product": primitive.M{
+			"_id":          id,
+			"product_name": "Astronaut Neil Armstrong",
+			"rating":       4.5,
+			"item_count":   int32(3),
+			"launched_at":  primitive.NewDateTimeFromTime(time.Date(1969, 7, 16, 13, 32, 0, 0, time.UTC)),
+			"images":       primitive.A{primitive.M{"url": "first.png"}, primitive.M{"url": "second.png"}},
+			"attribute":    primitive.D{{Key: "description", Value: "<p>Tom &amp; Jerry</p>"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"no placeholder", "plain text", "plain text"},
+		{"string", "{{product.product_name}}", "Astronaut Neil Armstrong"},
+		{"spaces inside the braces", "{{ product.product_name }} #1", "Astronaut Neil Armstrong #1"},
+		{"object id", "{{product._id}}", id.Hex()},
+		{"float", "{{product.rating}}", "4.5"},
+		{"integer", "{{product.item_count}}", "3"},
+		{"date", "{{product.launched_at}}", "1969-07-16"},
+		{"array index", "{{product.images.1.url}}", "second.png"},
+		{"array index out of range", "{{product.images.2.url}}", ""},
+		{"ordered document", "{{product.attribute.description | strip_html}}", "Tom & Jerry"},
+		{"unknown path", "[{{product.farm_name}}]", "[]"},
+		{"path through a text", "{{product.product_name.en}}", ""},
+		{"chained filters", "{{product.product_name | skip_first_word | upper}}", "NEIL ARMSTRONG"},
+		{"unknown filter", "{{product.product_name | reverse}}", "Astronaut Neil Armstrong"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderPlaceholders(tt.text, data); got != tt.want {
+				t.Errorf("RenderPlaceholders(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderLocalizedPlaceholders(t *testing.T) {
+	data := map[string]any{"author": primitive.M{
+		"name":  primitive.M{"en": "Dam Dong", "vi": "Đàm Đông"},
+		"years": primitive.M{"start": int32(1970)},
+	}}
+	chain := translation.NewFallbackChain("fr", "vi")
+
+	if got := RenderLocalizedPlaceholders("{{author.name}}", data, chain); got != "Đàm Đông" {
+		t.Errorf("localized text = %q", got)
+	}
+	if got := RenderLocalizedPlaceholders("{{author.name.en}}", data, chain); got != "Dam Dong" {
+		t.Errorf("explicit language = %q", got)
+	}
+	if got := RenderLocalizedPlaceholders("{{author.years}}", data, chain); got != "map[start:1970]" {
+		t.Errorf("document that is not a localized text = %q", got)
+	}
+}
+
+func TestFindPlaceholders(t *testing.T) {
+	paths := FindPlaceholders("{{product.product_name}} #{{ item.item_index }} {{attribute.description | strip_html}}")
+	want := []string{"product.product_name", "item.item_index", "attribute.description"}
+	if len(paths) != len(want) {
+		t.Fatalf("got %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("got %v, want %v", paths, want)
+		}
+	}
+}
+
+func TestIsSinglePlaceholder(t *testing.T) {
+	tests := map[string]bool{
+		"{{product.image.url}}":          true,
+		"{{ product.image.url | trim }}": true,
+		"javascript:{{product.name}}":    false,
+		"{{product.url}}?autoplay=1":     false,
+		"{{product.a}}{{product.b}}":     false,
+		"https://example.com":            false,
+	}
+	for text, want := range tests {
+		if got := IsSinglePlaceholder(text); got != want {
+			t.Errorf("IsSinglePlaceholder(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestToPlaceholderData(t *testing.T) {
+	type item struct {
+		Index int    `bson:"item_index"`
+		Owner string `bson:"owner_id"`
+	}
+	data := ToPlaceholderData(item{Index: 7, Owner: "user-1"})
+	if got := RenderPlaceholders("{{item_index}} {{owner_id}}", data); got != "7 user-1" {
+		t.Errorf("got %q from %v", got, data)
+	}
+	if data := ToPlaceholderData(bson.A{1}); len(data) != 0 {
+		t.Errorf("not a document: got %v", data)
+	}
+}
+
+func TestSkipFirstWord(t *testing.T) {
+	for text, want := range map[string]string{
+		"Astronaut Neil Armstrong": "Neil Armstrong",
+		"  Astronaut   Neil ":      "Neil",
+		"Astronaut":                "",
+		"":                         "",
+	} {
+		if got := SkipFirstWord(text); got != want {
+			t.Errorf("SkipFirstWord(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Unlicense
+pragma solidity ^0.8.9;
+
+import "openzeppelin/contracts/token/ERC721/ERC721.sol";
+import "openzeppelin/contracts/token/common/ERC2981.sol";
+import "openzeppelin/contracts/access/Ownable.sol";
+import "openzeppelin/contracts/utils/Counters.sol";
+import "openzeppelin/contracts/utils/Strings.sol";
+
+// Collection contract deployed from the admin API: name, symbol, base URI and royalty are chosen per collection
+contract PhygitalNFT is ERC721, ERC2981, Ownable {
+    using Strings for uint256;
+    using Counters for Counters.Counter;
+    Counters.Counter private _tokenIdCounter;
+    string private baseURL;
+    string private contractURIString;
+
+    constructor(
+        string memory _name,
+        string memory _symbol,
+        string memory _baseURL,
+        string memory _contractURI,
+        address _royaltyReceiver,
+        uint96 _royaltyFeeNumerator
+    ) ERC721(_name, _symbol) {
+        baseURL = _baseURL;
+        contractURIString = _contractURI;
+        if (_royaltyReceiver != address(0)) {
+            _setDefaultRoyalty(_royaltyReceiver, _royaltyFeeNumerator);
+        }
+    }
+
+    function safeMint(address to) public onlyOwner {
+        require(to != address(0), "Cannot mint to zero address");
+
+        _tokenIdCounter.increment();
+        uint256 tokenId = _tokenIdCounter.current();
+
+        _safeMint(to, tokenId);
+    }
+
+    function _baseURI() internal view override returns (string memory) {
+        return baseURL;
+    }
+
+    function setBaseURI(string memory _uri) external onlyOwner {
+        baseURL = _uri;
+    }
+
+    function contractURI() public view returns (string memory) {
+        return contractURIString;
+    }
+
+    function setContractURI(string memory _uri) external onlyOwner {
+        contractURIString = _uri;
+    }
+
+    function setDefaultRoyalty(address receiver, uint96 feeNumerator) external onlyOwner {
+        _setDefaultRoyalty(receiver, feeNumerator);
+    }
+
+    function tokenURI(uint256 tokenId) public view override returns (string memory)
+    {
+        require(_exists(tokenId), "ERC721URIStorage: URI query for nonexistent token");
+
+        return string(abi.encodePacked(_baseURI(), tokenId.toString()));
+    }
+
+    function supportsInterface(bytes4 interfaceId) public view override(ERC721, ERC2981) returns (bool) {
+        return super.supportsInterface(interfaceId);
+    }
+}
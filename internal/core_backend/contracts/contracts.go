@@ -55,30 +55,29 @@ func GetNFTInstance(contractAdd *string, client *ethclient.Client) interface{} {
 
 func SafeMint(contractAdd *string, client *ethclient.Client, auth *bind.TransactOpts, ownerAdd *common.Address) (*types.Transaction, error) {
 	address := common.HexToAddress(*contractAdd)
-	var tx *types.Transaction
 	switch *contractAdd {
 	case "0xb53351913607390576c3e8161405d9b16bae77f0":
 		instance, err := lej_nft.NewLejNft(address, client)
 		if err != nil {
-			logger.LogError(err.Error())
+			return nil, err
 		}
-		tx, err = instance.SafeMint(auth, *ownerAdd)
+		return instance.SafeMint(auth, *ownerAdd)
 	case "0x4f3b348167bded078d6be6bcfdad473cccda8e86":
 		instance, err := danonnuoc_nft.NewDanonnuocNft(address, client)
 		if err != nil {
-			logger.LogError(err.Error())
+			return nil, err
 		}
-		tx, err = instance.SafeMint(auth, *ownerAdd)
+		return instance.SafeMint(auth, *ownerAdd)
 	case "0xadbaeff0e947a8917104b76e700bc2111baeb70f":
 		instance, err := astronaut_nft.NewAstronautNft(address, client)
 		if err != nil {
-			logger.LogError(err.Error())
+			return nil, err
 		}
-		tx, err = instance.SafeMint(auth, *ownerAdd)
+		return instance.SafeMint(auth, *ownerAdd)
 	default:
-		logger.LogError("Unknown contract address!: " + *contractAdd)
+		// contracts deployed from the admin API
+		return safeMintGeneric(address, client, auth, ownerAdd)
 	}
-	return tx, nil
 }
 
 func ParseTransfer(contractAdd *string, client *ethclient.Client, vLog types.Log) (*entity.EventTransfer, error) {
@@ -156,7 +155,14 @@ func ParseTransfer(contractAdd *string, client *ethclient.Client, vLog types.Log
 			return nil, err
 		}
 	default:
-		err := errors.New("Unknown contract address!: " + *contractAdd)
+		// contracts deployed from the admin API
+		if len(vLog.Topics) > 0 && vLog.Topics[0] == LogOwnershipTransferredSigHash {
+			return nil, nil
+		}
+		if transferEvent, ok := parseTransferGeneric(vLog); ok {
+			return transferEvent, nil
+		}
+		err := errors.New("Unknown event type on contract " + *contractAdd)
 		logger.LogError(err.Error())
 		return nil, err
 	}
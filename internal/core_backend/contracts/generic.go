@@ -0,0 +1,42 @@
+package contracts
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// mintableERC721ABI the part of the collection contracts used by the backend, shared by every template
+const mintableERC721ABI = `[
+	{"type":"function","name":"safeMint","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"}],"outputs":[]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"tokenId","type":"uint256","indexed":true}]}
+]`
+
+// safeMintGeneric mints on contracts deployed from the admin API, which have no dedicated binding
+func safeMintGeneric(address common.Address, client *ethclient.Client, auth *bind.TransactOpts, ownerAdd *common.Address) (*types.Transaction, error) {
+	parsed, err := abi.JSON(strings.NewReader(mintableERC721ABI))
+	if err != nil {
+		return nil, err
+	}
+
+	return bind.NewBoundContract(address, parsed, client, client, client).Transact(auth, "safeMint", *ownerAdd)
+}
+
+// parseTransferGeneric reads an ERC-721 Transfer log, all of its arguments are indexed
+func parseTransferGeneric(vLog types.Log) (*entity.EventTransfer, bool) {
+	if len(vLog.Topics) != 4 || vLog.Topics[0] != LogTransferSigHash {
+		return nil, false
+	}
+
+	return &entity.EventTransfer{
+		FromAddr: common.HexToAddress(vLog.Topics[1].Hex()),
+		ToAddr:   common.HexToAddress(vLog.Topics[2].Hex()),
+		TokenID:  vLog.Topics[3].Big(),
+	}, true
+}
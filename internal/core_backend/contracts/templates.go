@@ -0,0 +1,147 @@
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/contracts/astronaut_nft"
+	"backend-service/internal/core_backend/contracts/danonnuoc_nft"
+	"backend-service/internal/core_backend/contracts/lej_nft"
+)
+
+// DeployParams constructor arguments of a collection contract
+type DeployParams struct {
+	Name                string
+	Symbol              string
+	BaseURI             string
+	ContractURI         string
+	RoyaltyReceiver     common.Address
+	RoyaltyFeeNumerator uint16
+}
+
+// Template a collection contract that can be deployed from the admin API.
+// Only configurable templates take the name, symbol, contract URI and royalty as constructor arguments,
+// the legacy ones hard-code their name and symbol. Templates compiled outside of the Go build are only
+// available once their artifacts are present.
+type Template struct {
+	Name         string `json:"name"`
+	Standard     string `json:"standard"`
+	Configurable bool   `json:"configurable"`
+	Available    bool   `json:"available"`
+	deploy       func(auth *bind.TransactOpts, backend bind.ContractBackend, params *DeployParams) (common.Address, *types.Transaction, error)
+	available    func() bool
+}
+
+var templates = []Template{
+	{
+		Name:         "phygital",
+		Standard:     "ERC721",
+		Configurable: true,
+		deploy:       deployPhygitalNft,
+		available:    phygitalArtifactsBuilt,
+	},
+	{
+		Name:     "lej",
+		Standard: "ERC721",
+		deploy: func(auth *bind.TransactOpts, backend bind.ContractBackend, params *DeployParams) (common.Address, *types.Transaction, error) {
+			address, tx, _, err := lej_nft.DeployLejNft(auth, backend, params.BaseURI)
+			return address, tx, err
+		},
+	},
+	{
+		Name:     "danonnuoc",
+		Standard: "ERC721",
+		deploy: func(auth *bind.TransactOpts, backend bind.ContractBackend, params *DeployParams) (common.Address, *types.Transaction, error) {
+			address, tx, _, err := danonnuoc_nft.DeployDanonnuocNft(auth, backend, params.BaseURI)
+			return address, tx, err
+		},
+	},
+	{
+		Name:     "astronaut",
+		Standard: "ERC721",
+		deploy: func(auth *bind.TransactOpts, backend bind.ContractBackend, params *DeployParams) (common.Address, *types.Transaction, error) {
+			address, tx, _, err := astronaut_nft.DeployAstronautNft(auth, backend, params.BaseURI)
+			return address, tx, err
+		},
+	},
+}
+
+// ListTemplates returns the deployable contract templates
+func ListTemplates() []Template {
+	list := make([]Template, 0, len(templates))
+	for _, template := range templates {
+		list = append(list, withAvailability(template))
+	}
+
+	return list
+}
+
+// GetTemplate returns the template with the given name
+func GetTemplate(name string) (*Template, error) {
+	for _, template := range templates {
+		if template.Name == name {
+			template = withAvailability(template)
+			return &template, nil
+		}
+	}
+
+	return nil, errors.New("unknown contract template: " + name)
+}
+
+func withAvailability(template Template) Template {
+	template.Available = template.available == nil || template.available()
+	return template
+}
+
+// Deploy sends the deployment transaction of the template
+func (t *Template) Deploy(auth *bind.TransactOpts, backend bind.ContractBackend, params *DeployParams) (common.Address, *types.Transaction, error) {
+	return t.deploy(auth, backend, params)
+}
+
+// phygitalArtifacts the paths of the solc artifacts of PhygitalNFT.sol built by `make contract-artifacts`
+func phygitalArtifacts() (abiFile string, binFile string) {
+	dir := config.C.NFT.CONTRACT_ARTIFACTS_DIR
+	return filepath.Join(dir, "PhygitalNFT.abi"), filepath.Join(dir, "PhygitalNFT.bin")
+}
+
+func phygitalArtifactsBuilt() bool {
+	abiFile, binFile := phygitalArtifacts()
+	for _, file := range []string{abiFile, binFile} {
+		if info, err := os.Stat(file); err != nil || info.Size() == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deployPhygitalNft deploys PhygitalNFT.sol from its solc artifacts
+func deployPhygitalNft(auth *bind.TransactOpts, backend bind.ContractBackend, params *DeployParams) (common.Address, *types.Transaction, error) {
+	abiFile, binFile := phygitalArtifacts()
+	abiJSON, err := os.ReadFile(abiFile)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	bin, err := os.ReadFile(binFile)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(abiJSON)))
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(strings.TrimSpace(string(bin))), backend,
+		params.Name, params.Symbol, params.BaseURI, params.ContractURI, params.RoyaltyReceiver, big.NewInt(int64(params.RoyaltyFeeNumerator)))
+
+	return address, tx, err
+}
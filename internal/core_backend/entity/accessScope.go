@@ -0,0 +1,48 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// AccessScope the organizations a request may act on, given the permissions its route requires
+type AccessScope struct {
+	UserID           string
+	AllOrganizations bool
+	OrganizationIDs  []primitive.ObjectID
+}
+
+// NewAccessScope keeps the organizations where one of the grants holds every permission
+func NewAccessScope(userID string, grants []RoleGrant, permissions ...Permission) *AccessScope {
+	scope := AccessScope{UserID: userID}
+	for i := range grants {
+		if !grants[i].Role.HasPermissions(permissions...) {
+			continue
+		}
+		if grants[i].IsGlobal() {
+			scope.AllOrganizations = true
+			continue
+		}
+		if !scope.CanAccess(grants[i].OrganizationID) {
+			scope.OrganizationIDs = append(scope.OrganizationIDs, grants[i].OrganizationID)
+		}
+	}
+
+	return &scope
+}
+
+// IsEmpty reports whether the scope grants no organization at all
+func (s *AccessScope) IsEmpty() bool {
+	return !s.AllOrganizations && len(s.OrganizationIDs) == 0
+}
+
+// CanAccess reports whether the organization is in scope
+func (s *AccessScope) CanAccess(orgID primitive.ObjectID) bool {
+	if s.AllOrganizations {
+		return true
+	}
+	for _, id := range s.OrganizationIDs {
+		if id == orgID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewAccessScope(t *testing.T) {
+	orgA, orgB := primitive.NewObjectID(), primitive.NewObjectID()
+	reader := Role{RoleName: "reader", Permissions: []Permission{PermissionProductRead}}
+	writer := Role{RoleName: "writer", Permissions: []Permission{PermissionProductRead, PermissionProductWrite}}
+
+	tests := []struct {
+		name   string
+		grants []RoleGrant
+		want   AccessScope
+	}{
+		{"no grant", nil, AccessScope{}},
+		{"grant without the permission", []RoleGrant{{OrganizationID: orgA, Role: reader}}, AccessScope{}},
+		{"org grant", []RoleGrant{{OrganizationID: orgA, Role: writer}}, AccessScope{OrganizationIDs: []primitive.ObjectID{orgA}}},
+		{
+			"org grants merged once per organization",
+			[]RoleGrant{{OrganizationID: orgA, Role: writer}, {OrganizationID: orgB, Role: reader}, {OrganizationID: orgB, Role: writer}, {OrganizationID: orgA, Role: writer}},
+			AccessScope{OrganizationIDs: []primitive.ObjectID{orgA, orgB}},
+		},
+		{"global grant", []RoleGrant{{Role: writer}}, AccessScope{AllOrganizations: true}},
+		{
+			"global and org grants",
+			[]RoleGrant{{OrganizationID: orgA, Role: writer}, {Role: writer}, {OrganizationID: orgB, Role: writer}},
+			AccessScope{AllOrganizations: true, OrganizationIDs: []primitive.ObjectID{orgA}},
+		},
+		{"global grant without the permission", []RoleGrant{{Role: reader}, {OrganizationID: orgB, Role: writer}}, AccessScope{OrganizationIDs: []primitive.ObjectID{orgB}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := NewAccessScope("user-1", tt.grants, PermissionProductWrite)
+			if scope.UserID != "user-1" || scope.AllOrganizations != tt.want.AllOrganizations || len(scope.OrganizationIDs) != len(tt.want.OrganizationIDs) {
+				t.Fatalf("got %+v, want %+v", scope, tt.want)
+			}
+			for i := range tt.want.OrganizationIDs {
+				if scope.OrganizationIDs[i] != tt.want.OrganizationIDs[i] {
+					t.Errorf("got organizations %v, want %v", scope.OrganizationIDs, tt.want.OrganizationIDs)
+				}
+			}
+			if scope.IsEmpty() != (!tt.want.AllOrganizations && len(tt.want.OrganizationIDs) == 0) {
+				t.Errorf("IsEmpty() = %v for %+v", scope.IsEmpty(), scope)
+			}
+			if tt.want.AllOrganizations && !scope.CanAccess(primitive.NewObjectID()) {
+				t.Error("a global scope cannot access every organization")
+			}
+		})
+	}
+}
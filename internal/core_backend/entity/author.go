@@ -1,16 +1,29 @@
 package entity
 
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/pkg/common/translation"
+)
+
+// Author authors without an organization are shared by every organization
 type Author struct {
 	BaseModel      `bson:",inline" json:",inline"`
-	Name           MultipleLanguages `bson:"name" json:"name" binding:"required"`
-	ExperienceYear string            `bson:"experience_year" json:"experience_year" binding:"omitempty"`
-	ArtworksCount  string            `bson:"artworks_count" json:"artworks_count" binding:"omitempty"`
-	Phone          string            `bson:"phone" json:"phone"  binding:"omitempty"`
-	Email          string            `bson:"email" json:"email"  binding:"omitempty,email"`
-	Avatar         Media             `bson:"avatar" json:"avatar"`
-	Translation    map[string]any    `bson:"translation" json:"translation"`
-	Type           string            `bson:"type" json:"type" binding:"omitempty"`
-	ContactName    string            `bson:"contact_name" json:"contact_name"`
+	OrganizationID primitive.ObjectID          `bson:"org_id,omitempty" json:"org_id,omitempty" swaggertype:"string"`
+	Name           translation.LocalizedString `bson:"name" json:"name" swaggertype:"object,string"`
+	ExperienceYear string                      `bson:"experience_year" json:"experience_year" binding:"omitempty"`
+	ArtworksCount  string                      `bson:"artworks_count" json:"artworks_count" binding:"omitempty"`
+	Phone          string                      `bson:"phone" json:"phone"  binding:"omitempty"`
+	Email          string                      `bson:"email" json:"email"  binding:"omitempty,email"`
+	Avatar         Media                       `bson:"avatar" json:"avatar"`
+	Translation    translation.Translations    `bson:"translation,omitempty" json:"translation,omitempty"`
+	Type           string                      `bson:"type" json:"type" binding:"omitempty"`
+	ContactName    string                      `bson:"contact_name" json:"contact_name"`
+}
+
+// GetTranslations the texts of the author in other languages, by language then by JSON field name
+func (a *Author) GetTranslations() translation.Translations {
+	return a.Translation
 }
 
 // CollectionName Collection name of Author
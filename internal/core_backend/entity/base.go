@@ -58,8 +58,3 @@ type Media struct {
 	Type         string `bson:"type" json:"type"`
 	ThumbnailURL string `bson:"thumbnail_url" json:"thumbnail_url"`
 }
-
-type MultipleLanguages struct {
-	EN string `bson:"en" json:"en"`
-	VI string `bson:"vi" json:"vi"`
-}
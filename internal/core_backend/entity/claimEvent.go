@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ClaimEvent a change of the claim state of a product item, the audit trail of its ownership.
+// ActorID is the admin who changed the state, or the user who claimed the item.
+type ClaimEvent struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProductItemID   primitive.ObjectID `bson:"product_item_id" json:"product_item_id"`
+	OrganizationID  primitive.ObjectID `bson:"org_id" json:"org_id"`
+	FromState       string             `bson:"from_state" json:"from_state"`
+	ToState         string             `bson:"to_state" json:"to_state"`
+	PreviousOwnerID string             `bson:"previous_owner_id" json:"previous_owner_id"`
+	OwnerID         string             `bson:"owner_id" json:"owner_id"`
+	ClaimableUntil  *time.Time         `bson:"claimable_until" json:"claimable_until,omitempty"`
+	ReasonCode      string             `bson:"reason_code" json:"reason_code"`
+	Note            string             `bson:"note" json:"note"`
+	ActorID         string             `bson:"actor_id" json:"actor_id"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName Collection name of ClaimEvent
+func (ClaimEvent) CollectionName() string {
+	return "claim_events"
+}
+
+// ProductItemLike a like of a product item, users like an item once
+type ProductItemLike struct {
+	ProductItemID primitive.ObjectID `bson:"product_item_id" json:"product_item_id"`
+	UserID        string             `bson:"user_id" json:"user_id"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName Collection name of ProductItemLike
+func (ProductItemLike) CollectionName() string {
+	return "product_item_likes"
+}
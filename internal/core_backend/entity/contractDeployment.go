@@ -0,0 +1,31 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ContractDeployment tracks a collection contract deployed from the admin API.
+// The DigitalAssetCollection is created with CollectionID once the deployment is confirmed,
+// SignerName is one of the configured signers, the default keystore when empty.
+// ReservedOrgID holds the organization while the deployment is pending or succeeded, a unique index keeps one per organization.
+type ContractDeployment struct {
+	BaseModel           `bson:"inline"`
+	OrganizationID      primitive.ObjectID  `bson:"org_id" json:"org_id"`
+	ReservedOrgID       *primitive.ObjectID `bson:"reserved_org_id,omitempty" json:"-"`
+	CollectionID        primitive.ObjectID  `bson:"collection_id" json:"collection_id"`
+	Template            string              `bson:"template" json:"template"`
+	ChainID             int64               `bson:"chain_id" json:"chain_id"`
+	Name                string              `bson:"name" json:"name"`
+	Symbol              string              `bson:"symbol" json:"symbol"`
+	BaseURI             string              `bson:"base_uri" json:"base_uri"`
+	RoyaltyReceiver     string              `bson:"royalty_receiver" json:"royalty_receiver"`
+	RoyaltyFeeNumerator uint16              `bson:"royalty_fee_numerator" json:"royalty_fee_numerator"`
+	SignerName          string              `bson:"signer_name" json:"signer_name"`
+	DeployerAddress     string              `bson:"deployer_address" json:"deployer_address"`
+	TxHash              string              `bson:"tx_hash" json:"tx_hash"`
+	ContractAddress     string              `bson:"contract_address" json:"contract_address"`
+	Error               string              `bson:"error" json:"error"`
+}
+
+// CollectionName Collection name of ContractDeployment
+func (ContractDeployment) CollectionName() string {
+	return "contract_deployments"
+}
@@ -7,7 +7,7 @@ import (
 type DigitalAsset struct {
 	BaseModel    `bson:"inline"`
 	CollectionID primitive.ObjectID `bson:"collection_id"`
-	TokenID      int64                `bson:"token_id"`
+	TokenID      int64              `bson:"token_id"`
 	TxHash       string             `bson:"tx_hash"`
 	OwnerAddress string             `bson:"owner_address"`
 	Metadata     Metadata           `bson:"metadata"`
@@ -20,9 +20,22 @@ func (DigitalAsset) CollectionName() string {
 
 type DigitalAssetProductAggregate struct {
 	DigitalAsset `bson:"inline"`
-	ItemIndex    int     `bson:"item_index"`
-	OrgTagName   string  `bson:"org_tag_name"`
-	Product      Product `bson:"product"`
+	ItemIndex    int          `bson:"item_index"`
+	OrgTagName   string       `bson:"org_tag_name"`
+	ProductItem  ProductItem  `bson:"product_item"`
+	Organization Organization `bson:"organization"`
+	Product      Product      `bson:"product"`
+	Author       *Author      `bson:"author"`
+}
+
+// MetadataSource returns the documents the collection's metadata template is rendered against
+func (a *DigitalAssetProductAggregate) MetadataSource() *MetadataSource {
+	return &MetadataSource{
+		Product:      &a.Product,
+		ProductItem:  &a.ProductItem,
+		Author:       a.Author,
+		Organization: &a.Organization,
+	}
 }
 
 type Metadata struct {
@@ -1,19 +1,67 @@
 package entity
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common/helper"
+)
 
 type DigitalAssetCollection struct {
-	BaseModel       `bson:"inline"`
-	Name            string             `bson:"name"`
-	Chain           string             `bson:"chain"`
-	ChainID         int                `bson:"chain_id"`
-	Description     string             `bson:"description"`
-	ContractAddress string             `bson:"contract_address"`
-	Standard        string             `bson:"standard"`
-	OrganizationID  primitive.ObjectID `bson:"org_id"`
+	BaseModel           `bson:"inline"`
+	Name                string             `bson:"name"`
+	Chain               string             `bson:"chain"`
+	ChainID             int                `bson:"chain_id"`
+	Description         string             `bson:"description"`
+	ContractAddress     string             `bson:"contract_address"`
+	Standard            string             `bson:"standard"`
+	OrganizationID      primitive.ObjectID `bson:"org_id"`
+	Revealed            *bool              `bson:"revealed,omitempty"`
+	PlaceholderMetadata Metadata           `bson:"placeholder_metadata"`
+	ContractMetadata    ContractMetadata   `bson:"contract_metadata"`
+	SignerName          string             `bson:"signer_name"`
 }
 
 // CollectionName Collection name of DigitalAssetCollection
 func (DigitalAssetCollection) CollectionName() string {
 	return "digital_asset_collections"
 }
+
+// IsRevealed collections created before the reveal flag existed are treated as revealed
+func (c *DigitalAssetCollection) IsRevealed() bool {
+	return c.Revealed == nil || *c.Revealed
+}
+
+// RenderPlaceholderMetadata builds the metadata served for a token while the collection is unrevealed.
+// The placeholder may use {{token_id}} and {{collection.name}}.
+func (c *DigitalAssetCollection) RenderPlaceholderMetadata(tokenID int) *Metadata {
+	data := map[string]any{
+		"token_id":   strconv.Itoa(tokenID),
+		"collection": map[string]any{"name": c.Name, "chain": c.Chain},
+	}
+	metadata := Metadata{
+		Name:         helper.RenderPlaceholders(c.PlaceholderMetadata.Name, data),
+		Description:  helper.RenderPlaceholders(c.PlaceholderMetadata.Description, data),
+		Image:        c.PlaceholderMetadata.Image,
+		AnimationURL: c.PlaceholderMetadata.AnimationURL,
+		ExternalURL:  c.PlaceholderMetadata.ExternalURL,
+		Attributes:   c.PlaceholderMetadata.Attributes,
+	}
+	if metadata.Name == "" {
+		metadata.Name = c.Name + " #" + strconv.Itoa(tokenID)
+	}
+
+	return &metadata
+}
+
+// ContractMetadata is the collection level document served as the contractURI
+type ContractMetadata struct {
+	Name                 string `bson:"name" json:"name"`
+	Description          string `bson:"description" json:"description"`
+	Image                string `bson:"image" json:"image"`
+	BannerImage          string `bson:"banner_image" json:"banner_image"`
+	ExternalLink         string `bson:"external_link" json:"external_link"`
+	SellerFeeBasisPoints int    `bson:"seller_fee_basis_points" json:"seller_fee_basis_points" validate:"min=0,max=10000"`
+	FeeRecipient         string `bson:"fee_recipient" json:"fee_recipient" validate:"omitempty,eth_addr"`
+}
@@ -1,7 +1,21 @@
 package entity
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Claim states of product items, claimed and locked items can have an owner
+const (
+	ClaimStateUnclaimable = "unclaimable"
+	ClaimStateClaimable   = "claimable"
+	ClaimStateClaimed     = "claimed"
+	ClaimStateLocked      = "locked"
+)
+
+// Mapping a tag mapped to a product item. ClaimState is one of the ClaimState constants, a claimable item
+// with a ClaimableUntil can only be claimed until then.
 type Mapping struct {
 	BaseModel      `bson:"inline"`
 	ProductItemID  primitive.ObjectID `bson:"product_item_id"`
@@ -9,7 +23,10 @@ type Mapping struct {
 	ExternalURL    string             `bson:"external_url"`
 	OrganizationID primitive.ObjectID `bson:"org_id"`
 	OwnerID        string             `bson:"owner_id"`
-	Claimable      bool               `bson:"claimable"`
+	// ClaimedAt when the owner got the item, nil without owner
+	ClaimedAt      *time.Time         `bson:"claimed_at,omitempty"`
+	ClaimState     string             `bson:"claim_state"`
+	ClaimableUntil *time.Time         `bson:"claimable_until"`
 	DigitalAssetID primitive.ObjectID `bson:"digital_asset_id"`
 	IsMinted       bool               `bson:"is_minted"`
 }
@@ -18,3 +35,43 @@ type Mapping struct {
 func (Mapping) CollectionName() string {
 	return "mappings"
 }
+
+// ClaimStateAt the claim state of the item at the time, a claimable item whose claim window closed is unclaimable
+func (m *Mapping) ClaimStateAt(at time.Time) string {
+	switch {
+	case m.ClaimState == ClaimStateClaimable && m.ClaimableUntil != nil && !at.Before(*m.ClaimableUntil):
+		return ClaimStateUnclaimable
+	case m.ClaimState == "" && m.OwnerID != "":
+		return ClaimStateClaimed
+	case m.ClaimState == "":
+		return ClaimStateUnclaimable
+	}
+	return m.ClaimState
+}
+
+// IsClaimableAt whether a user can claim the item at the time
+func (m *Mapping) IsClaimableAt(at time.Time) bool {
+	return m.ClaimStateAt(at) == ClaimStateClaimable
+}
+
+// claimTransitions the states admins can move an item to from each state, users claim items themselves
+var claimTransitions = map[string][]string{
+	ClaimStateUnclaimable: {ClaimStateClaimable, ClaimStateLocked},
+	ClaimStateClaimable:   {ClaimStateUnclaimable, ClaimStateLocked},
+	ClaimStateClaimed:     {ClaimStateUnclaimable, ClaimStateClaimable, ClaimStateLocked},
+	ClaimStateLocked:      {ClaimStateUnclaimable, ClaimStateClaimable, ClaimStateClaimed},
+}
+
+// CanChangeClaimState whether an admin can move the item from its state at the time to the state.
+// Only a locked item with an owner goes back to claimed, making it claimable or unclaimable releases its owner.
+func (m *Mapping) CanChangeClaimState(to string, at time.Time) bool {
+	if to == ClaimStateClaimed && m.OwnerID == "" {
+		return false
+	}
+	for _, state := range claimTransitions[m.ClaimStateAt(at)] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
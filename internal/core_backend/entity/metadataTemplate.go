@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common/helper"
+)
+
+// MetadataTemplate describes how the NFT metadata of every digital asset in a
+// collection is built. Every string field may contain placeholders such as
+// {{product.product_name}}, {{item.item_index}}, {{author.name.vi}} or
+// {{attribute.farm_name}} which are resolved against a MetadataSource.
+type MetadataTemplate struct {
+	BaseModel    `bson:"inline"`
+	CollectionID primitive.ObjectID          `bson:"collection_id" json:"collection_id"`
+	Name         string                      `bson:"name" json:"name"`
+	Description  string                      `bson:"description" json:"description"`
+	Image        string                      `bson:"image" json:"image"`
+	AnimationURL string                      `bson:"animation_url" json:"animation_url"`
+	ExternalURL  string                      `bson:"external_url" json:"external_url"`
+	Attributes   []MetadataTemplateAttribute `bson:"attributes" json:"attributes"`
+}
+
+// MetadataTemplateAttribute is a single trait of the rendered metadata.
+// Attributes whose rendered value is empty are dropped unless Required is set.
+type MetadataTemplateAttribute struct {
+	TraitType   string `bson:"trait_type" json:"trait_type" validate:"required"`
+	Value       string `bson:"value" json:"value" validate:"required"`
+	DisplayType string `bson:"display_type" json:"display_type" validate:"omitempty,oneof=number boost_number boost_percentage date"`
+	Required    bool   `bson:"required" json:"required"`
+}
+
+// CollectionName Collection name of MetadataTemplate
+func (MetadataTemplate) CollectionName() string {
+	return "metadata_templates"
+}
+
+// MetadataPlaceholderRoots are the top level keys a placeholder path may start with
+var MetadataPlaceholderRoots = []string{"product", "attribute", "item", "author", "organization"}
+
+// IsMetadataPlaceholderRoot reports whether a placeholder path starts with a known root
+func IsMetadataPlaceholderRoot(path string) bool {
+	root, _, _ := strings.Cut(path, ".")
+	for _, r := range MetadataPlaceholderRoots {
+		if root == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MetadataSource holds the documents a MetadataTemplate is rendered against
+type MetadataSource struct {
+	Product      *Product      `bson:"product"`
+	ProductItem  *ProductItem  `bson:"product_item"`
+	Author       *Author       `bson:"author"`
+	Organization *Organization `bson:"organization"`
+}
+
+// PlaceholderData flattens the source into the lookup tree used by placeholders.
+// `attribute` is a shortcut to `product.attribute`.
+func (s *MetadataSource) PlaceholderData() map[string]any {
+	data := map[string]any{}
+	if s.Product != nil {
+		product := helper.ToPlaceholderData(s.Product)
+		data["product"] = product
+		data["attribute"] = product["attribute"]
+	}
+	if s.ProductItem != nil {
+		data["item"] = helper.ToPlaceholderData(s.ProductItem)
+	}
+	if s.Author != nil {
+		data["author"] = helper.ToPlaceholderData(s.Author)
+	}
+	if s.Organization != nil {
+		data["organization"] = helper.ToPlaceholderData(s.Organization)
+	}
+
+	return data
+}
+
+// Render builds the metadata of one digital asset from the template
+func (t *MetadataTemplate) Render(source *MetadataSource) *Metadata {
+	data := source.PlaceholderData()
+	metadata := Metadata{
+		Name:         helper.RenderPlaceholders(t.Name, data),
+		Description:  helper.RenderPlaceholders(t.Description, data),
+		Image:        helper.RenderPlaceholders(t.Image, data),
+		AnimationURL: helper.RenderPlaceholders(t.AnimationURL, data),
+		ExternalURL:  helper.RenderPlaceholders(t.ExternalURL, data),
+		Attributes:   []MetadataAttribute{},
+	}
+	for _, attribute := range t.Attributes {
+		value := helper.RenderPlaceholders(attribute.Value, data)
+		if value == "" && !attribute.Required {
+			continue
+		}
+		metadata.Attributes = append(metadata.Attributes, MetadataAttribute{
+			TraitType:   attribute.TraitType,
+			Value:       value,
+			DisplayType: attribute.DisplayType,
+		})
+	}
+
+	return &metadata
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+
+	"backend-service/pkg/common/translation"
+)
+
+func TestMetadataTemplateRender(t *testing.T) {
+	template := MetadataTemplate{
+		Name:        "{{product.product_name}} #{{item.item_index}}",
+		Description: "{{attribute.description | strip_html}}",
+		Image:       "{{product.image.url}}",
+		ExternalURL: "https://nomion.io/",
+		Attributes: []MetadataTemplateAttribute{
+			{TraitType: "Owner", Value: "{{product.product_name | skip_first_word}}"},
+			{TraitType: "Author", Value: "{{author.name.vi}}"},
+			{TraitType: "Organization", Value: "{{organization.org_tag_name}}"},
+			{TraitType: "Farm", Value: "{{attribute.farm_name}}"},
+			{TraitType: "Origin", Value: "{{product.origin}}", Required: true},
+			{TraitType: "Edition", Value: "{{item.item_index}}", DisplayType: "number"},
+		},
+	}
+	name, err := translation.NewLocalizedString(map[string]string{"vi": "Đàm Đông"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := MetadataSource{
+		Product: &Product{
+			ProductName: "Astronaut Neil",
+			Image:       Media{URL: "https://example.com/neil.png"},
+			Attribute:   map[string]any{"description": "<p>First <b>step</b></p>"},
+		},
+		ProductItem:  &ProductItem{ItemIndex: 7},
+		Author:       &Author{Name: name},
+		Organization: &Organization{NameTag: "astronaut"},
+	}
+
+	metadata := template.Render(&source)
+	if metadata.Name != "Astronaut Neil #7" || metadata.Description != "First step" || metadata.Image != "https://example.com/neil.png" || metadata.ExternalURL != "https://nomion.io/" {
+		t.Errorf("got %+v", metadata)
+	}
+	want := []MetadataAttribute{
+		{TraitType: "Owner", Value: "Neil"},
+		{TraitType: "Author", Value: "Đàm Đông"},
+		{TraitType: "Organization", Value: "astronaut"},
+		// Farm is dropped as it is empty, Origin is kept as it is required
+		{TraitType: "Origin", Value: ""},
+		{TraitType: "Edition", Value: "7", DisplayType: "number"},
+	}
+	if len(metadata.Attributes) != len(want) {
+		t.Fatalf("got attributes %+v, want %+v", metadata.Attributes, want)
+	}
+	for i := range want {
+		if metadata.Attributes[i] != want[i] {
+			t.Errorf("attribute %d = %+v, want %+v", i, metadata.Attributes[i], want[i])
+		}
+	}
+
+	// Without documents every placeholder renders empty
+	empty := template.Render(&MetadataSource{})
+	if empty.Name != " #" || len(empty.Attributes) != 1 {
+		t.Errorf("empty source: got %+v", empty)
+	}
+}
+
+func TestIsMetadataPlaceholderRoot(t *testing.T) {
+	for path, want := range map[string]bool{
+		"product.product_name": true,
+		"attribute":            true,
+		"author.name.vi":       true,
+		"owner.email":          false,
+		"products.name":        false,
+	} {
+		if got := IsMetadataPlaceholderRoot(path); got != want {
+			t.Errorf("IsMetadataPlaceholderRoot(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
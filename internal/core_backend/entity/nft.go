@@ -2,6 +2,7 @@ package entity
 
 import (
 	"math/big"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -20,3 +21,8 @@ type Variable struct {
 func (Variable) CollectionName() string {
 	return "variables"
 }
+
+// SyncBlockVariableID id of the variable holding the last synced block of a chain
+func SyncBlockVariableID(chainID int64) string {
+	return "blockchain_" + strconv.FormatInt(chainID, 10)
+}
@@ -0,0 +1,67 @@
+package entity
+
+// Permission an action on a kind of resource, written resource:action
+type Permission string
+
+const (
+	PermissionProductRead  Permission = "product:read"
+	PermissionProductWrite Permission = "product:write"
+	// PermissionProductPublish makes the draft of a product live, editors with product:write only prepare it
+	PermissionProductPublish  Permission = "product:publish"
+	PermissionProductTypeRead Permission = "product_type:read"
+	// PermissionProductTypeWrite changes product types, they are shared so it also needs a global grant
+	PermissionProductTypeWrite Permission = "product_type:write"
+	PermissionProductItemRead  Permission = "product_item:read"
+	PermissionProductItemWrite Permission = "product_item:write"
+	PermissionMappingRead      Permission = "mapping:read"
+	PermissionMappingWrite     Permission = "mapping:write"
+	PermissionTagWrite         Permission = "tag:write"
+	PermissionTemplateRead     Permission = "template:read"
+	PermissionTemplateWrite    Permission = "template:write"
+	PermissionWebpageRead      Permission = "webpage:read"
+	PermissionWebpageWrite     Permission = "webpage:write"
+	PermissionAuthorRead       Permission = "author:read"
+	PermissionAuthorWrite      Permission = "author:write"
+	PermissionOrganizationRead Permission = "organization:read"
+	// PermissionOrganizationWrite updates an organization, creating one needs a global grant
+	PermissionOrganizationWrite Permission = "organization:write"
+	PermissionDigitalAssetRead  Permission = "digital_asset:read"
+	PermissionDigitalAssetWrite Permission = "digital_asset:write"
+	PermissionNFTMint           Permission = "nft:mint"
+	PermissionNFTDeploy         Permission = "nft:deploy"
+	PermissionRoleRead          Permission = "role:read"
+	PermissionRoleWrite         Permission = "role:write"
+	PermissionUserRead          Permission = "user:read"
+	PermissionUserWrite         Permission = "user:write"
+	PermissionAPIKeyRead        Permission = "api_key:read"
+	PermissionAPIKeyWrite       Permission = "api_key:write"
+)
+
+// AllPermissions every permission a role can be composed of
+var AllPermissions = []Permission{
+	PermissionProductRead, PermissionProductWrite, PermissionProductPublish,
+	PermissionProductTypeRead, PermissionProductTypeWrite,
+	PermissionProductItemRead, PermissionProductItemWrite,
+	PermissionMappingRead, PermissionMappingWrite,
+	PermissionTagWrite,
+	PermissionTemplateRead, PermissionTemplateWrite,
+	PermissionWebpageRead, PermissionWebpageWrite,
+	PermissionAuthorRead, PermissionAuthorWrite,
+	PermissionOrganizationRead, PermissionOrganizationWrite,
+	PermissionDigitalAssetRead, PermissionDigitalAssetWrite,
+	PermissionNFTMint, PermissionNFTDeploy,
+	PermissionRoleRead, PermissionRoleWrite,
+	PermissionUserRead, PermissionUserWrite,
+	PermissionAPIKeyRead, PermissionAPIKeyWrite,
+}
+
+// IsValid reports whether the permission is known
+func (p Permission) IsValid() bool {
+	for _, permission := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ProductCloneOptions what a deep clone of a product copies besides the product.
+// OrganizationID is the organization of the clone, the organization of the product when zero.
+// The clone keeps the template of the product unless CloneTemplate, which copies the template and all its webpages.
+type ProductCloneOptions struct {
+	OrganizationID primitive.ObjectID
+	CloneTemplate  bool
+	TotalItem      int
+}
+
+// ProductClone the documents of a deep clone of a product, they are inserted together or not at all.
+// Template is nil and WebPages empty when the template is kept.
+type ProductClone struct {
+	Product  Product
+	Version  ProductVersion
+	Template *Template
+	WebPages []WebPage
+	Items    []ProductItem
+}
+
+// ProductCloneResult the clone of a product and the ID of the copy of each document it references, by hex ID of the original
+type ProductCloneResult struct {
+	Product Product              `json:"product"`
+	IDMap   map[string]string    `json:"id_map"`
+	ItemIDs []primitive.ObjectID `json:"item_ids"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "encoding/json"
+
+// ProductType a vertical of products, the attribute of its products must match the JSON Schema
+type ProductType struct {
+	BaseModel `bson:"inline"`
+	// Name the value of the type of its products, it cannot be changed
+	Name        string `bson:"name" json:"name"`
+	DisplayName string `bson:"display_name" json:"display_name"`
+	// Version increases with every change of the schema
+	Version int `bson:"version" json:"version"`
+	// Schema kept as JSON, MongoDB restricts field names starting with $ which most schemas use
+	Schema json.RawMessage `bson:"schema" json:"schema" swaggertype:"object"`
+}
+
+// CollectionName Collection name of ProductType
+func (ProductType) CollectionName() string {
+	return "product_types"
+}
+
+// ProductTypeSchema a version of the schema of a product type, products record the version they were validated against
+type ProductTypeSchema struct {
+	BaseModel `bson:"inline"`
+	TypeName  string          `bson:"type_name" json:"type_name"`
+	Version   int             `bson:"version" json:"version"`
+	Schema    json.RawMessage `bson:"schema" json:"schema" swaggertype:"object"`
+}
+
+// CollectionName Collection name of ProductTypeSchema
+func (ProductTypeSchema) CollectionName() string {
+	return "product_type_schemas"
+}
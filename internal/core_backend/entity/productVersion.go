@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DraftVersion the version number of the draft of a product, published versions are numbered from 1
+const DraftVersion = 0
+
+// ProductVersion the content of a product at a version. Published versions are never changed,
+// the live product document holds the content of the latest one. A product has at most one draft,
+// the content editors prepare for the next version; scans keep reading the live product until it is published.
+// BaseVersion is the published version a draft was written over, RestoredFrom the version a rollback published again.
+type ProductVersion struct {
+	BaseModel      `bson:"inline"`
+	ProductID      primitive.ObjectID `bson:"product_id" json:"product_id"`
+	OrganizationID primitive.ObjectID `bson:"org_id" json:"org_id"`
+	Version        int                `bson:"version" json:"version"`
+	BaseVersion    int                `bson:"base_version,omitempty" json:"base_version,omitempty"`
+	RestoredFrom   int                `bson:"restored_from,omitempty" json:"restored_from,omitempty"`
+	Content        Product            `bson:"content" json:"content"`
+	EditedBy       string             `bson:"edited_by" json:"edited_by"`
+	PublishedBy    string             `bson:"published_by,omitempty" json:"published_by,omitempty"`
+	PublishedAt    *time.Time         `bson:"published_at,omitempty" json:"published_at,omitempty"`
+}
+
+// CollectionName Collection name of ProductVersion
+func (ProductVersion) CollectionName() string {
+	return "product_versions"
+}
+
+// IsDraft whether the version is the draft of the next version
+func (v *ProductVersion) IsDraft() bool {
+	return v.Version == DraftVersion
+}
+
+// ProductChange a field of the content of a product that differs between two versions.
+// Path is the JSON path of the field, attribute.farm.name; Before and After are missing when the field was added or removed.
+type ProductChange struct {
+	Path   string `json:"path"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// ProductDiff the changes of the content of a product from one version to another, the draft is version 0
+type ProductDiff struct {
+	ProductID primitive.ObjectID `json:"product_id"`
+	From      int                `json:"from"`
+	To        int                `json:"to"`
+	Changes   []ProductChange    `json:"changes"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// PubsubMessage a push message received, kept for the replay window so each message is processed once
+type PubsubMessage struct {
+	// ID the message ID given by Pub/Sub
+	ID          string    `bson:"_id" json:"id"`
+	PublishTime time.Time `bson:"publish_time" json:"publish_time"`
+	ReceivedAt  time.Time `bson:"received_at" json:"received_at"`
+	// ExpiresAt the end of the replay window, a TTL index removes the message then
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// CollectionName Collection name of PubsubMessage
+func (PubsubMessage) CollectionName() string {
+	return "pubsub_messages"
+}
@@ -2,13 +2,92 @@ package entity
 
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
+// Role a named set of permissions. Roles without OrganizationID can be granted in every organization,
+// the others only in their own.
 type Role struct {
 	BaseModel      `bson:"inline"`
-	OrganizationID primitive.ObjectID `bson:"org_id"`
-	RoleName       string             `bson:"role_name"`
+	OrganizationID primitive.ObjectID `bson:"org_id" json:"org_id"`
+	RoleName       string             `bson:"role_name" json:"role_name"`
+	Description    string             `bson:"description" json:"description"`
+	Permissions    []Permission       `bson:"permissions" json:"permissions"`
+	System         bool               `bson:"-" json:"system"`
 }
 
 // CollectionName Collection name of Role
 func (Role) CollectionName() string {
 	return "roles"
 }
+
+// HasPermissions reports whether the role holds every given permission
+func (r *Role) HasPermissions(permissions ...Permission) bool {
+	for _, required := range permissions {
+		found := false
+		for _, permission := range r.Permissions {
+			if permission == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SystemRoles built-in roles, they are not stored and cannot be changed.
+// They match the role custom claim of Firebase users.
+var SystemRoles = map[string]Role{
+	string(SUPER_ADMIN_ROLE): {
+		RoleName:    string(SUPER_ADMIN_ROLE),
+		Description: "Every permission, granted for all organizations",
+		Permissions: AllPermissions,
+		System:      true,
+	},
+	string(ORG_ADMIN_ROLE): {
+		RoleName:    string(ORG_ADMIN_ROLE),
+		Description: "Manages the catalog, tags, pages and collection of an organization",
+		Permissions: []Permission{
+			PermissionProductRead, PermissionProductWrite, PermissionProductPublish,
+			PermissionProductTypeRead,
+			PermissionProductItemRead, PermissionProductItemWrite,
+			PermissionMappingRead, PermissionMappingWrite,
+			PermissionTagWrite,
+			PermissionTemplateRead, PermissionTemplateWrite,
+			PermissionWebpageRead, PermissionWebpageWrite,
+			PermissionAuthorRead, PermissionAuthorWrite,
+			PermissionOrganizationRead, PermissionOrganizationWrite,
+			PermissionDigitalAssetRead, PermissionDigitalAssetWrite,
+			PermissionNFTMint,
+			PermissionRoleRead, PermissionRoleWrite,
+			PermissionUserRead, PermissionUserWrite,
+			PermissionAPIKeyRead, PermissionAPIKeyWrite,
+		},
+		System: true,
+	},
+}
+
+// RoleBinding grants a role to a user in an organization, or in every organization when OrganizationID is nil
+type RoleBinding struct {
+	BaseModel      `bson:"inline"`
+	UserID         string             `bson:"user_id" json:"user_id"`
+	OrganizationID primitive.ObjectID `bson:"org_id" json:"org_id"`
+	RoleName       string             `bson:"role_name" json:"role_name"`
+}
+
+// CollectionName Collection name of RoleBinding
+func (RoleBinding) CollectionName() string {
+	return "role_bindings"
+}
+
+// RoleGrant a resolved role binding
+type RoleGrant struct {
+	OrganizationID primitive.ObjectID
+	Role           Role
+}
+
+// IsGlobal reports whether the grant applies to every organization
+func (g *RoleGrant) IsGlobal() bool {
+	return g.OrganizationID.IsZero()
+}
@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common/helper"
+	"backend-service/pkg/common/translation"
+)
+
+// ErrTemplateWithoutHome the template has no page of type home to open the site on
+var ErrTemplateWithoutHome = errors.New("the template has no home page")
+
+// Site the website of a product item, its template rendered in one language with every placeholder substituted.
+// Pages are in the order of the template and HomePageID is the page the site opens on.
+type Site struct {
+	TemplateID primitive.ObjectID `json:"template_id"`
+	Language   string             `json:"language"`
+	HomePageID primitive.ObjectID `json:"home_page_id"`
+	Pages      []SitePage         `json:"pages"`
+	Menu       []SiteMenuEntry    `json:"menu"`
+}
+
+// SitePage a webpage of a site, Blocks are its WebPageBlock with their texts in one language
+type SitePage struct {
+	ID         primitive.ObjectID `json:"id"`
+	Name       string             `json:"name"`
+	Type       string             `json:"type"`
+	URLLink    string             `json:"url_link"`
+	Attributes map[string]any     `json:"attributes"`
+	Blocks     []any              `json:"blocks"`
+}
+
+// SiteMenuEntry an entry of the menu of a site, PageID is zero for entries without a page
+type SiteMenuEntry struct {
+	Title   string             `json:"title"`
+	PageID  primitive.ObjectID `json:"page_id"`
+	URLLink string             `json:"url_link"`
+}
+
+// SitePlaceholderData the lookup tree of the placeholders of a site, the one of metadata templates
+// where `item.index` is a shortcut to `item.item_index`
+func SitePlaceholderData(source *MetadataSource) map[string]any {
+	data := source.PlaceholderData()
+	if item, ok := data["item"].(map[string]any); ok {
+		item["index"] = item["item_index"]
+	}
+
+	return data
+}
+
+// HomePage the page of type home the site opens on, nil when the template has none
+func (t *TemplateWebpages) HomePage() *WebPage {
+	for i := range t.Pages {
+		if t.Pages[i].Type == WebPageTypeHome && !t.Pages[i].ID.IsZero() {
+			return &t.Pages[i]
+		}
+	}
+
+	return nil
+}
+
+// Render the site of the source from the template, in the first language of the chain each text has.
+// Pages the template references that no longer exist are left out.
+func (t *TemplateWebpages) Render(source *MetadataSource, chain translation.FallbackChain) (*Site, error) {
+	data := SitePlaceholderData(source)
+	site := &Site{TemplateID: t.ID, Pages: []SitePage{}, Menu: []SiteMenuEntry{}}
+	if language, ok := chain.Match(t.Languages); ok {
+		site.Language = language
+	} else if len(t.Languages) > 0 {
+		site.Language = t.Languages[0]
+	}
+
+	home := t.HomePage()
+	if home == nil {
+		return nil, ErrTemplateWithoutHome
+	}
+	site.HomePageID = home.ID
+
+	for _, page := range t.Pages {
+		if page.ID.IsZero() {
+			continue
+		}
+		site.Pages = append(site.Pages, SitePage{
+			ID:         page.ID,
+			Name:       helper.RenderLocalizedPlaceholders(page.Name, data, chain),
+			Type:       page.Type,
+			URLLink:    helper.RenderLocalizedPlaceholders(page.URLLink, data, chain),
+			Attributes: renderSiteAttributes(page.Attributes, data, chain),
+			Blocks:     renderSiteBlocks(page.Blocks, data, chain),
+		})
+	}
+
+	for _, entry := range t.Menu {
+		site.Menu = append(site.Menu, SiteMenuEntry{
+			Title:   helper.RenderLocalizedPlaceholders(entry.Title.Get(chain), data, chain),
+			PageID:  entry.ID,
+			URLLink: helper.RenderLocalizedPlaceholders(entry.URLLink, data, chain),
+		})
+	}
+
+	return site, nil
+}
+
+// renderSiteAttributes the attributes of a page with their placeholders substituted, empty when the page has none
+func renderSiteAttributes(attributes map[string]any, data map[string]any, chain translation.FallbackChain) map[string]any {
+	rendered, ok := renderSiteValue(attributes, data, chain).(map[string]any)
+	if !ok || rendered == nil {
+		return map[string]any{}
+	}
+
+	return rendered
+}
+
+// renderSiteBlocks the blocks as written to JSON with their localized texts in the first language of the chain
+// and their placeholders substituted, the blocks of the template are left as they are.
+// Blocks whose substituted URLs are not absolute http(s) are left out.
+func renderSiteBlocks(blocks []WebPageBlock, data map[string]any, chain translation.FallbackChain) []any {
+	rendered := []any{}
+	raw, err := json.Marshal(blocks)
+	if err != nil {
+		return rendered
+	}
+	var localized []WebPageBlock
+	if err = json.Unmarshal(raw, &localized); err != nil {
+		return rendered
+	}
+	translation.TranslateCollection(localized, chain)
+	if raw, err = json.Marshal(localized); err != nil {
+		return rendered
+	}
+	if err = json.Unmarshal(raw, &rendered); err != nil {
+		return []any{}
+	}
+
+	safe := []any{}
+	for _, block := range renderSiteValue(rendered, data, chain).([]any) {
+		if !hasUnsafeURL(block) {
+			safe = append(safe, block)
+		}
+	}
+
+	return safe
+}
+
+// renderSiteValue the value with the placeholders of its texts substituted, documents and arrays as maps and slices
+func renderSiteValue(value any, data map[string]any, chain translation.FallbackChain) any {
+	switch v := plainValue(value).(type) {
+	case string:
+		return helper.RenderLocalizedPlaceholders(v, data, chain)
+	case map[string]any:
+		rendered := make(map[string]any, len(v))
+		for key, item := range v {
+			rendered[key] = renderSiteValue(item, data, chain)
+		}
+		return rendered
+	case []any:
+		rendered := make([]any, len(v))
+		for i, item := range v {
+			rendered[i] = renderSiteValue(item, data, chain)
+		}
+		return rendered
+	default:
+		return v
+	}
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/pkg/common/translation"
+)
+
+func TestTemplateWebpagesHomePage(t *testing.T) {
+	home := WebPage{WebPageBase: WebPageBase{BaseModel: BaseModel{ID: primitive.NewObjectID()}, Type: WebPageTypeHome}}
+	craftsmen := WebPage{WebPageBase: WebPageBase{BaseModel: BaseModel{ID: primitive.NewObjectID()}, Type: "craftsmen"}}
+	// A home page the template references that no longer exists is skipped
+	deleted := WebPage{WebPageBase: WebPageBase{Type: WebPageTypeHome}}
+
+	template := TemplateWebpages{Pages: []WebPage{craftsmen, deleted, home}}
+	if page := template.HomePage(); page == nil || page.ID != home.ID {
+		t.Errorf("got home page %+v", page)
+	}
+	site, err := template.Render(&MetadataSource{}, translation.NewFallbackChain("en"))
+	if err != nil || site.HomePageID != home.ID || len(site.Pages) != 2 {
+		t.Errorf("got site %+v, %v", site, err)
+	}
+
+	template = TemplateWebpages{Pages: []WebPage{craftsmen, deleted}}
+	if page := template.HomePage(); page != nil {
+		t.Errorf("got home page %+v", page)
+	}
+	if _, err = template.Render(&MetadataSource{}, translation.NewFallbackChain("en")); !errors.Is(err, ErrTemplateWithoutHome) {
+		t.Errorf("got %v, want %v", err, ErrTemplateWithoutHome)
+	}
+}
+
+func TestRenderLeavesOutUnsafeBlockURLs(t *testing.T) {
+	label, err := translation.NewLocalizedString(map[string]string{"en": "Shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	home := WebPage{WebPageBase: WebPageBase{BaseModel: BaseModel{ID: primitive.NewObjectID()}, Type: WebPageTypeHome}}
+	home.Blocks = []WebPageBlock{
+		{ID: "shop", Type: BlockTypeCallToAction, CallToAction: &CallToActionBlock{Label: label, URL: "{{product.product_name}}"}},
+		{ID: "video", Type: BlockTypeVideo, Video: &VideoBlock{URL: "https://cdn.example.com/a.mp4", ThumbnailURL: "{{product.product_name}}"}},
+		{ID: "gallery", Type: BlockTypeImageGallery, ImageGallery: &ImageGalleryBlock{Images: []GalleryImage{{URL: "{{product.origin}}"}}}},
+	}
+	template := TemplateWebpages{Pages: []WebPage{home}}
+
+	// A placeholder substituted with a script URL is not served
+	site, err := template.Render(&MetadataSource{Product: &Product{ProductName: "javascript:alert(1)"}}, translation.NewFallbackChain("en"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := site.Pages[0].Blocks
+	if len(blocks) != 1 || blocks[0].(map[string]any)["id"] != "gallery" {
+		t.Errorf("got blocks %v, want only the gallery", blocks)
+	}
+
+	site, err = template.Render(&MetadataSource{Product: &Product{ProductName: "https://shop.example.com"}}, translation.NewFallbackChain("en"))
+	if err != nil || len(site.Pages[0].Blocks) != 3 {
+		t.Errorf("got site %+v, %v, want the 3 blocks", site, err)
+	}
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// SiweNonce a single use nonce issued to a user for a Sign-In with Ethereum message
+type SiweNonce struct {
+	Nonce     string    `bson:"_id" json:"nonce"`
+	UserID    string    `bson:"user_id" json:"-"`
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName Collection name of SiweNonce
+func (SiweNonce) CollectionName() string {
+	return "siwe_nonces"
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"path"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Formats stories are exported in
+const (
+	StoryFormatJSON = "json"
+	StoryFormatHTML = "html"
+)
+
+// Story the story of a product item in one language, the content of its exported JSON file.
+// Ownership tells whether the item is claimed without telling who the owner is beyond their name.
+type Story struct {
+	ProductItemID primitive.ObjectID `json:"product_item_id"`
+	ProductName   string             `json:"product_name"`
+	ItemIndex     int                `json:"item_index"`
+	Languages     []string           `json:"languages"`
+	Site          Site               `json:"site"`
+	Ownership     StoryOwnership     `json:"ownership"`
+	RenderedAt    time.Time          `json:"rendered_at"`
+}
+
+// StoryOwnership the claim state of a product item
+type StoryOwnership struct {
+	Claimed   bool   `json:"claimed" bson:"claimed"`
+	Claimable bool   `json:"claimable" bson:"claimable"`
+	OwnerName string `json:"owner_name,omitempty" bson:"owner_name"`
+	// ClaimableUntil the end of the claim window of a claimable item, the story is queued again when it ends
+	ClaimableUntil *time.Time `json:"claimable_until,omitempty" bson:"claimable_until"`
+}
+
+// StoryArtifact the exported files of the story of a product item. Stale artifacts are rebuilt by the story publisher,
+// Revision grows each time a change queues the item so a build of an older revision does not overwrite a newer one.
+type StoryArtifact struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProductItemID primitive.ObjectID `bson:"product_item_id" json:"product_item_id"`
+	Revision      int                `bson:"revision" json:"revision"`
+	Stale         bool               `bson:"stale" json:"stale"`
+	Status        string             `bson:"status" json:"status"`
+	Error         string             `bson:"error,omitempty" json:"error,omitempty"`
+	Files         []StoryFile        `bson:"files" json:"files"`
+	RenderedAt    time.Time          `bson:"rendered_at" json:"rendered_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// StoryFile an exported file of a story
+type StoryFile struct {
+	Language string `bson:"language" json:"language"`
+	Format   string `bson:"format" json:"format"`
+	Path     string `bson:"path" json:"path"`
+	URL      string `bson:"url" json:"url"`
+}
+
+// CollectionName Collection name of StoryArtifact
+func (StoryArtifact) CollectionName() string {
+	return "story_artifacts"
+}
+
+// StoryFilePath the path of the file of the story of the product item in the language, <root>/<product item id>/<language>.<format>
+func StoryFilePath(root string, productItemID primitive.ObjectID, language, format string) string {
+	return path.Join(root, productItemID.Hex(), language+"."+format)
+}
+
+// File the exported file of the language in the format, nil when the artifact has none
+func (a *StoryArtifact) File(language, format string) *StoryFile {
+	for i := range a.Files {
+		if a.Files[i].Language == language && a.Files[i].Format == format {
+			return &a.Files[i]
+		}
+	}
+	return nil
+}
@@ -2,16 +2,20 @@ package entity
 
 import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/pkg/common/translation"
 )
 
+// Template a website template. Templates without an organization are shared by every organization.
 type Template struct {
 	BaseModel       `bson:"inline"`
-	Name            string          `bson:"name"`
-	Category        string          `bson:"category"`
-	CreatedByUserID string          `bson:"created_by_user_id"`
-	Languages       []string        `bson:"languages"`
-	Pages           []TemplatePages `bson:"pages"`
-	Menu            []TemplateMenu  `bson:"menu"`
+	OrganizationID  primitive.ObjectID `bson:"org_id,omitempty"`
+	Name            string             `bson:"name"`
+	Category        string             `bson:"category"`
+	CreatedByUserID string             `bson:"created_by_user_id"`
+	Languages       []string           `bson:"languages"`
+	Pages           []TemplatePages    `bson:"pages"`
+	Menu            []TemplateMenu     `bson:"menu"`
 }
 
 type TemplatePages struct {
@@ -19,17 +23,13 @@ type TemplatePages struct {
 }
 
 type TemplateMenu struct {
-	Title  TemplateMenuTitle  `bson:"title"`
-	PageID primitive.ObjectID `bson:"page_id"`
-}
-
-type TemplateMenuTitle struct {
-	VI string `bson:"vi"`
-	EN string `bson:"en"`
+	Title  translation.LocalizedString `bson:"title"`
+	PageID primitive.ObjectID          `bson:"page_id"`
 }
 
 type TemplateWebpages struct {
 	BaseModel       `bson:"inline"`
+	OrganizationID  primitive.ObjectID     `bson:"org_id,omitempty"`
 	Name            string                 `bson:"name"`
 	Category        string                 `bson:"category"`
 	CreatedByUserID string                 `bson:"created_by_user_id"`
@@ -39,7 +39,7 @@ type TemplateWebpages struct {
 }
 
 type TemplateWebpagesMenu struct {
-	Title   TemplateMenuTitle `bson:"title"`
+	Title   translation.LocalizedString `bson:"title"`
 	WebPage `bson:"inline"`
 }
 
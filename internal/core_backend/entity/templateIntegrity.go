@@ -0,0 +1,13 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// DanglingReference a field of a document referencing a document that no longer exists,
+// such as a template page targeting a deleted webpage or a product of a deleted template
+type DanglingReference struct {
+	Collection     string             `bson:"collection" json:"collection"`
+	DocumentID     primitive.ObjectID `bson:"document_id" json:"document_id"`
+	OrganizationID primitive.ObjectID `bson:"org_id" json:"org_id"`
+	Field          string             `bson:"field" json:"field"`
+	MissingID      primitive.ObjectID `bson:"missing_id" json:"missing_id"`
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,7 +21,43 @@ type User struct {
 	UpdatedAt      time.Time          `json:"updated_at,omitempty" bson:"updated_at"`
 	Firebase       Firebase           `json:"firebase"`
 	WalletAddress  string             `json:"wallet_address" bson:"wallet_address"`
+	// LinkedWallets self-custodied addresses whose ownership the user proved with SIWE
+	LinkedWallets     []LinkedWallet `json:"linked_wallets" bson:"linked_wallets,omitempty"`
+	MintWalletAddress string         `json:"mint_wallet_address" bson:"mint_wallet_address,omitempty"`
+	// DeletedAt when the user deleted the account, the personal data was erased then
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
 }
+
+// LinkedWallet an address linked through Sign-In with Ethereum
+type LinkedWallet struct {
+	Address  string    `json:"address" bson:"address"`
+	ChainID  int64     `json:"chain_id" bson:"chain_id"`
+	LinkedAt time.Time `json:"linked_at" bson:"linked_at"`
+}
+
+// MintAddress the address claimed items are minted to: the chosen linked wallet, or the custodial wallet
+func (u *User) MintAddress() string {
+	if u.MintWalletAddress != "" {
+		return u.MintWalletAddress
+	}
+
+	return u.WalletAddress
+}
+
+// HasWallet reports whether the address is the custodial wallet or a linked wallet of the user
+func (u *User) HasWallet(address string) bool {
+	if strings.EqualFold(u.WalletAddress, address) {
+		return true
+	}
+	for _, wallet := range u.LinkedWallets {
+		if strings.EqualFold(wallet.Address, address) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Firebase struct {
 	Identities struct {
 		GoogleCom []string `json:"google.com"`
@@ -0,0 +1,86 @@
+package entity
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// UserFilter the criteria admins search users by, empty criteria match every user
+type UserFilter struct {
+	// Email part of the email, case insensitive
+	Email string
+	// OrganizationID users of the organization or bound to a role in it
+	OrganizationID primitive.ObjectID
+	// RoleName users bound to the role, or holding it as legacy role claim
+	RoleName     string
+	WalletStatus string
+	Status       string
+}
+
+// ManagedUser a user with the role bindings admins manage
+type ManagedUser struct {
+	User         `bson:",inline"`
+	RoleBindings []RoleBinding `json:"role_bindings" bson:"role_bindings"`
+}
+
+// InOrganizations reports whether the user belongs to, or is bound to a role in, one of the organizations
+func (u *ManagedUser) InOrganizations(orgIDs []primitive.ObjectID) bool {
+	for _, orgID := range orgIDs {
+		if u.OrganizationID == orgID {
+			return true
+		}
+		for _, binding := range u.RoleBindings {
+			if binding.OrganizationID == orgID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// OnlyInOrganizations reports whether every organization the user belongs to, or is bound to a role in, is one of the organizations
+func (u *ManagedUser) OnlyInOrganizations(orgIDs []primitive.ObjectID) bool {
+	in := func(orgID primitive.ObjectID) bool {
+		for _, id := range orgIDs {
+			if id == orgID {
+				return true
+			}
+		}
+		return false
+	}
+	if !u.OrganizationID.IsZero() && !in(u.OrganizationID) {
+		return false
+	}
+	for _, binding := range u.RoleBindings {
+		if !binding.OrganizationID.IsZero() && !in(binding.OrganizationID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasGlobalBinding reports whether the user is bound to a role in every organization
+func (u *ManagedUser) HasGlobalBinding() bool {
+	for _, binding := range u.RoleBindings {
+		if binding.OrganizationID.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UserPage a page of users
+type UserPage struct {
+	Users []ManagedUser `json:"users"`
+	Page  int           `json:"page"`
+	Limit int           `json:"limit"`
+	Total int64         `json:"total"`
+}
+
+// UserInvitation the account created for an invited user
+type UserInvitation struct {
+	User        User        `json:"user"`
+	RoleBinding RoleBinding `json:"role_binding"`
+	// InviteLink lets the user choose a password, empty when the identity provider has none
+	InviteLink string `json:"invite_link,omitempty"`
+}
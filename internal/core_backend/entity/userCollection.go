@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CollectionItem a product item the user claimed, as shown in the collection of the consumer app
+type CollectionItem struct {
+	TagID         string                 `bson:"tag_id" json:"tag_id"`
+	ProductItemID primitive.ObjectID     `bson:"product_item_id" json:"product_item_id"`
+	ItemIndex     int                    `bson:"item_index" json:"item_index"`
+	TotalLike     int                    `bson:"total_like" json:"total_like"`
+	ClaimedAt     time.Time              `bson:"claimed_at" json:"claimed_at"`
+	Product       CollectionProduct      `bson:"product" json:"product"`
+	Organization  CollectionOrganization `bson:"organization" json:"organization"`
+	// StoryLanguage the first language of the product template, the story is shown in it
+	StoryLanguage string `bson:"story_language" json:"-"`
+	StoryURL      string `bson:"-" json:"story_url"`
+	// NFTStatus not_minted, pending, minted or failed
+	NFTStatus string           `bson:"-" json:"nft_status"`
+	NFT       *CollectionAsset `bson:"nft,omitempty" json:"nft,omitempty"`
+}
+
+// CollectionProduct the product of a collection item
+type CollectionProduct struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	ProductName string             `bson:"product_name" json:"product_name"`
+	Type        string             `bson:"type" json:"type"`
+	Image       Media              `bson:"image" json:"image"`
+}
+
+// CollectionOrganization the organization of a collection item
+type CollectionOrganization struct {
+	ID               primitive.ObjectID `bson:"_id" json:"id"`
+	OrganizationName string             `bson:"org_name" json:"org_name"`
+	NameTag          string             `bson:"org_tag_name" json:"org_tag_name"`
+	LogoURL          string             `bson:"org_logo_url" json:"org_logo_url"`
+}
+
+// CollectionAsset the token minted for a collection item
+type CollectionAsset struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	Status       string             `bson:"status" json:"-"`
+	CollectionID primitive.ObjectID `bson:"collection_id" json:"collection_id"`
+	TokenID      int64              `bson:"token_id" json:"token_id"`
+	TxHash       string             `bson:"tx_hash" json:"tx_hash"`
+	OwnerAddress string             `bson:"owner_address" json:"owner_address,omitempty"`
+	Metadata     Metadata           `bson:"metadata" json:"metadata"`
+}
+
+// CollectionPage a page of the collection of a user
+type CollectionPage struct {
+	Items []CollectionItem `json:"items"`
+	Page  int              `json:"page"`
+	Limit int              `json:"limit"`
+	Total int64            `json:"total"`
+}
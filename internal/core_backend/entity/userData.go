@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ClaimedItem a product item the user claimed, with its token when it was minted
+type ClaimedItem struct {
+	TagID          string             `bson:"tag_id" json:"tag_id"`
+	OrganizationID primitive.ObjectID `bson:"org_id" json:"org_id"`
+	ProductItemID  primitive.ObjectID `bson:"product_item_id" json:"product_item_id"`
+	ItemIndex      int                `bson:"item_index" json:"item_index"`
+	ProductID      primitive.ObjectID `bson:"product_id" json:"product_id"`
+	ProductName    string             `bson:"product_name" json:"product_name"`
+	IsMinted       bool               `bson:"is_minted" json:"is_minted"`
+	CollectionID   primitive.ObjectID `bson:"collection_id,omitempty" json:"collection_id,omitempty"`
+	TokenID        *int64             `bson:"token_id,omitempty" json:"token_id,omitempty"`
+	TxHash         string             `bson:"tx_hash,omitempty" json:"tx_hash,omitempty"`
+}
+
+// UserDataExport everything stored about a user, the wallet addresses are part of the profile.
+// The ownership history holds the claim events the user was the owner, the previous owner or the actor of.
+type UserDataExport struct {
+	ExportedAt          time.Time            `json:"exported_at"`
+	Profile             User                 `json:"profile"`
+	ClaimedItems        []ClaimedItem        `json:"claimed_items"`
+	OwnershipHistory    []ClaimEvent         `json:"ownership_history"`
+	Likes               []ProductItemLike    `json:"likes"`
+	RoleBindings        []RoleBinding        `json:"role_bindings"`
+	WalletProvisionings []WalletProvisioning `json:"wallet_provisionings"`
+}
+
+// AccountDeletion outcome of the deletion of an account
+type AccountDeletion struct {
+	UserID           string    `json:"user_id"`
+	ClaimPolicy      string    `json:"claim_policy"`
+	ReleasedClaims   int64     `json:"released_claims"`
+	ReassignedClaims int64     `json:"reassigned_claims"`
+	DeletedAt        time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// WalletProvisioning outcome of the last custodial wallet request made for a user.
+// Status is Succeeded or Failed, Attempts counts every request made so far.
+type WalletProvisioning struct {
+	BaseModel      `bson:"inline"`
+	UserID         string    `bson:"user_id" json:"user_id"`
+	Email          string    `bson:"email" json:"email"`
+	WalletAddress  string    `bson:"wallet_address" json:"wallet_address"`
+	IdempotencyKey string    `bson:"idempotency_key" json:"idempotency_key"`
+	Attempts       int       `bson:"attempts" json:"attempts"`
+	Error          string    `bson:"error" json:"error"`
+	LastAttemptAt  time.Time `bson:"last_attempt_at" json:"last_attempt_at"`
+}
+
+// CollectionName Collection name of WalletProvisioning
+func (WalletProvisioning) CollectionName() string {
+	return "wallet_provisionings"
+}
+
+// WalletReconciliation summary of one reconciliation run
+type WalletReconciliation struct {
+	Total     int                  `json:"total"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Skipped   int                  `json:"skipped"`
+	Results   []WalletProvisioning `json:"results"`
+}
@@ -0,0 +1,292 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"backend-service/internal/core_backend/common/helper"
+	"backend-service/pkg/common/translation"
+)
+
+// Types of the content blocks of a webpage
+const (
+	BlockTypeRichText     = "rich_text"
+	BlockTypeImageGallery = "image_gallery"
+	BlockTypeVideo        = "video"
+	BlockTypeModel3D      = "model_3d"
+	BlockTypeMapLocation  = "map_location"
+	BlockTypeTimeline     = "timeline"
+	BlockTypeQuote        = "quote"
+	BlockTypeCallToAction = "call_to_action"
+)
+
+// model3DFormats the file formats of 3D models viewers can open
+var model3DFormats = []string{".glb", ".gltf", ".usdz"}
+
+// WebPageBlock a content block of a webpage, blocks are shown in the order of the page.
+// The content of the block is the field of its type, the other content fields are nil.
+type WebPageBlock struct {
+	ID           string             `bson:"id" json:"id"`
+	Type         string             `bson:"type" json:"type"`
+	RichText     *RichTextBlock     `bson:"rich_text,omitempty" json:"rich_text,omitempty"`
+	ImageGallery *ImageGalleryBlock `bson:"image_gallery,omitempty" json:"image_gallery,omitempty"`
+	Video        *VideoBlock        `bson:"video,omitempty" json:"video,omitempty"`
+	Model3D      *Model3DBlock      `bson:"model_3d,omitempty" json:"model_3d,omitempty"`
+	MapLocation  *MapLocationBlock  `bson:"map_location,omitempty" json:"map_location,omitempty"`
+	Timeline     *TimelineBlock     `bson:"timeline,omitempty" json:"timeline,omitempty"`
+	Quote        *QuoteBlock        `bson:"quote,omitempty" json:"quote,omitempty"`
+	CallToAction *CallToActionBlock `bson:"call_to_action,omitempty" json:"call_to_action,omitempty"`
+}
+
+// RichTextBlock formatted text, Body is HTML
+type RichTextBlock struct {
+	Title translation.LocalizedString `bson:"title" json:"title" swaggertype:"object,string"`
+	Body  translation.LocalizedString `bson:"body" json:"body" swaggertype:"object,string"`
+}
+
+// ImageGalleryBlock images shown in order
+type ImageGalleryBlock struct {
+	Title  translation.LocalizedString `bson:"title" json:"title" swaggertype:"object,string"`
+	Images []GalleryImage              `bson:"images" json:"images"`
+}
+
+// GalleryImage an image of a gallery
+type GalleryImage struct {
+	URL     string                      `bson:"url" json:"url"`
+	Caption translation.LocalizedString `bson:"caption" json:"caption" swaggertype:"object,string"`
+}
+
+// VideoBlock a video and the image shown before it plays
+type VideoBlock struct {
+	Title        translation.LocalizedString `bson:"title" json:"title" swaggertype:"object,string"`
+	URL          string                      `bson:"url" json:"url"`
+	ThumbnailURL string                      `bson:"thumbnail_url" json:"thumbnail_url"`
+}
+
+// Model3DBlock a 3D model and the image shown while it loads
+type Model3DBlock struct {
+	Title     translation.LocalizedString `bson:"title" json:"title" swaggertype:"object,string"`
+	URL       string                      `bson:"url" json:"url"`
+	PosterURL string                      `bson:"poster_url" json:"poster_url"`
+}
+
+// MapLocationBlock a place shown on a map
+type MapLocationBlock struct {
+	Title     translation.LocalizedString `bson:"title" json:"title" swaggertype:"object,string"`
+	Label     translation.LocalizedString `bson:"label" json:"label" swaggertype:"object,string"`
+	Latitude  float64                     `bson:"latitude" json:"latitude"`
+	Longitude float64                     `bson:"longitude" json:"longitude"`
+	Zoom      int                         `bson:"zoom" json:"zoom"`
+}
+
+// TimelineBlock events shown in order
+type TimelineBlock struct {
+	Title  translation.LocalizedString `bson:"title" json:"title" swaggertype:"object,string"`
+	Events []TimelineEvent             `bson:"events" json:"events"`
+}
+
+// TimelineEvent an event of a timeline, Date is free text such as 1998 or Spring 2023
+type TimelineEvent struct {
+	Date        string                      `bson:"date" json:"date"`
+	Title       translation.LocalizedString `bson:"title" json:"title" swaggertype:"object,string"`
+	Description translation.LocalizedString `bson:"description" json:"description" swaggertype:"object,string"`
+}
+
+// QuoteBlock a quote and who said it
+type QuoteBlock struct {
+	Text   translation.LocalizedString `bson:"text" json:"text" swaggertype:"object,string"`
+	Author translation.LocalizedString `bson:"author" json:"author" swaggertype:"object,string"`
+}
+
+// CallToActionBlock a button opening URL
+type CallToActionBlock struct {
+	Label translation.LocalizedString `bson:"label" json:"label" swaggertype:"object,string"`
+	URL   string                      `bson:"url" json:"url"`
+}
+
+// Validate the block has the content of its type, and only that content, and the content is complete
+func (b *WebPageBlock) Validate() error {
+	contents := map[string]bool{
+		BlockTypeRichText:     b.RichText != nil,
+		BlockTypeImageGallery: b.ImageGallery != nil,
+		BlockTypeVideo:        b.Video != nil,
+		BlockTypeModel3D:      b.Model3D != nil,
+		BlockTypeMapLocation:  b.MapLocation != nil,
+		BlockTypeTimeline:     b.Timeline != nil,
+		BlockTypeQuote:        b.Quote != nil,
+		BlockTypeCallToAction: b.CallToAction != nil,
+	}
+	set, known := contents[b.Type]
+	if !known {
+		return fmt.Errorf("unknown block type %q", b.Type)
+	}
+	if !set {
+		return fmt.Errorf("a %s block needs its %s content", b.Type, b.Type)
+	}
+	for blockType, set := range contents {
+		if set && blockType != b.Type {
+			return fmt.Errorf("a %s block cannot have %s content", b.Type, blockType)
+		}
+	}
+
+	switch b.Type {
+	case BlockTypeRichText:
+		return b.RichText.validate()
+	case BlockTypeImageGallery:
+		return b.ImageGallery.validate()
+	case BlockTypeVideo:
+		return b.Video.validate()
+	case BlockTypeModel3D:
+		return b.Model3D.validate()
+	case BlockTypeMapLocation:
+		return b.MapLocation.validate()
+	case BlockTypeTimeline:
+		return b.Timeline.validate()
+	case BlockTypeQuote:
+		return b.Quote.validate()
+	default:
+		return b.CallToAction.validate()
+	}
+}
+
+func (c *RichTextBlock) validate() error {
+	if c.Body.IsEmpty() {
+		return errors.New("body is required")
+	}
+	return nil
+}
+
+func (c *ImageGalleryBlock) validate() error {
+	if len(c.Images) == 0 {
+		return errors.New("a gallery needs at least one image")
+	}
+	for i, image := range c.Images {
+		if err := validateBlockURL(image.URL, true); err != nil {
+			return fmt.Errorf("image %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (c *VideoBlock) validate() error {
+	if err := validateBlockURL(c.URL, true); err != nil {
+		return err
+	}
+	if err := validateBlockURL(c.ThumbnailURL, false); err != nil {
+		return fmt.Errorf("thumbnail: %w", err)
+	}
+	return nil
+}
+
+func (c *Model3DBlock) validate() error {
+	if err := validateBlockURL(c.URL, true); err != nil {
+		return err
+	}
+	if !helper.IsSinglePlaceholder(c.URL) {
+		parsed, _ := url.Parse(c.URL)
+		format := strings.ToLower(path.Ext(parsed.Path))
+		supported := false
+		for _, known := range model3DFormats {
+			supported = supported || format == known
+		}
+		if !supported {
+			return fmt.Errorf("3D models must be one of %s", strings.Join(model3DFormats, ", "))
+		}
+	}
+	if err := validateBlockURL(c.PosterURL, false); err != nil {
+		return fmt.Errorf("poster: %w", err)
+	}
+	return nil
+}
+
+func (c *MapLocationBlock) validate() error {
+	if c.Latitude < -90 || c.Latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	if c.Zoom < 0 || c.Zoom > 22 {
+		return errors.New("zoom must be between 0 and 22")
+	}
+	return nil
+}
+
+func (c *TimelineBlock) validate() error {
+	if len(c.Events) == 0 {
+		return errors.New("a timeline needs at least one event")
+	}
+	for i, event := range c.Events {
+		if event.Title.IsEmpty() {
+			return fmt.Errorf("event %d: title is required", i+1)
+		}
+	}
+	return nil
+}
+
+func (c *QuoteBlock) validate() error {
+	if c.Text.IsEmpty() {
+		return errors.New("text is required")
+	}
+	return nil
+}
+
+func (c *CallToActionBlock) validate() error {
+	if c.Label.IsEmpty() {
+		return errors.New("label is required")
+	}
+	return validateBlockURL(c.URL, true)
+}
+
+// validateBlockURL the URL is absolute http(s), or a single placeholder such as {{product.image.url}} whose value
+// is checked when the page is rendered
+func validateBlockURL(rawURL string, required bool) error {
+	if rawURL == "" {
+		if required {
+			return errors.New("url is required")
+		}
+		return nil
+	}
+	if helper.IsSinglePlaceholder(rawURL) {
+		return nil
+	}
+	if !isHTTPURL(rawURL) {
+		return fmt.Errorf("invalid url %q", rawURL)
+	}
+	return nil
+}
+
+// isHTTPURL the URL is absolute http(s)
+func isHTTPURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// blockURLKeys the JSON keys of the URLs of block contents
+var blockURLKeys = map[string]bool{"url": true, "thumbnail_url": true, "poster_url": true}
+
+// hasUnsafeURL reports whether a URL of the rendered block content is not absolute http(s)
+// once its placeholder was substituted, empty URLs are left to the viewer
+func hasUnsafeURL(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if rawURL, ok := item.(string); ok && blockURLKeys[key] && rawURL != "" && !isHTTPURL(rawURL) {
+				return true
+			}
+			if hasUnsafeURL(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if hasUnsafeURL(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
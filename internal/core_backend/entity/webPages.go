@@ -1,14 +1,25 @@
 package entity
 
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// WebPageTypeHome the type of the page a site opens on, every template has one
+const WebPageTypeHome = "home"
+
+// WebPageBase pages without an organization are shared by every organization
 type WebPageBase struct {
-	BaseModel `bson:"inline"`
-	Name      string `bson:"name,omitempty"`
-	URLLink   string `bson:"url_link"`
-	Type      string `bson:"type,omitempty"`
+	BaseModel      `bson:"inline"`
+	OrganizationID primitive.ObjectID `bson:"org_id,omitempty"`
+	Name           string             `bson:"name,omitempty"`
+	URLLink        string             `bson:"url_link"`
+	Type           string             `bson:"type,omitempty"`
 }
+
+// WebPage a page of templates. Story pages hold their content in ordered typed Blocks,
+// Attributes are the untyped content pages had before blocks and are kept for the pages not migrated yet.
 type WebPage struct {
 	WebPageBase `bson:"inline"`
 	Attributes  map[string]interface{} `bson:"attributes"`
+	Blocks      []WebPageBlock         `bson:"blocks"`
 }
 
 // CollectionName of WebPage
@@ -0,0 +1,226 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/common/logger"
+)
+
+// Chain an RPC client bound to one EVM network with its configuration
+type Chain struct {
+	Config config.ChainConfig
+	Client *ethclient.Client
+}
+
+// ChainPool holds one client per configured chain ID.
+// Chains unreachable at startup are dialed again when they are used.
+type ChainPool struct {
+	mu          sync.Mutex
+	chains      map[int64]*Chain
+	unreachable map[int64]config.ChainConfig
+	// undiscovered chains have no configured chain ID and were unreachable
+	undiscovered []config.ChainConfig
+}
+
+// errChainIDMismatch the RPC of a chain serves another chain ID than the configured one
+var errChainIDMismatch = errors.New("chain ID mismatch")
+
+// NewChainPool dials every configured chain and checks the node serves the configured chain ID.
+// A mismatch is returned as an error so a misconfigured RPC URL stops the service at startup,
+// an unreachable RPC is only reported.
+func NewChainPool(configs []config.ChainConfig) (*ChainPool, error) {
+	pool := &ChainPool{chains: map[int64]*Chain{}, unreachable: map[int64]config.ChainConfig{}}
+	for _, cfg := range configs {
+		if cfg.GasPriceMultiplier == 0 {
+			cfg.GasPriceMultiplier = 1
+		}
+		if pool.has(cfg.ChainID) {
+			return nil, fmt.Errorf("chain ID %d is configured twice", cfg.ChainID)
+		}
+
+		chain, err := dial(cfg)
+		if errors.Is(err, errChainIDMismatch) {
+			return nil, err
+		}
+		if err != nil {
+			logger.LogError(fmt.Sprintf("[EVM] - Chain %s is unreachable, it is dialed again when used: %s", cfg.Name, err.Error()))
+			if cfg.ChainID == 0 {
+				pool.undiscovered = append(pool.undiscovered, cfg)
+			} else {
+				pool.unreachable[cfg.ChainID] = cfg
+			}
+			continue
+		}
+		if pool.has(chain.Config.ChainID) {
+			return nil, fmt.Errorf("chain ID %d is configured twice", chain.Config.ChainID)
+		}
+		pool.chains[chain.Config.ChainID] = chain
+	}
+
+	return pool, nil
+}
+
+// has reports whether a chain with the chain ID is configured, 0 being the ID of the chains to discover
+func (p *ChainPool) has(chainID int64) bool {
+	_, reachable := p.chains[chainID]
+	_, unreachable := p.unreachable[chainID]
+
+	return chainID != 0 && (reachable || unreachable)
+}
+
+// dial connects to the chain, discovering its chain ID when it is not configured
+func dial(cfg config.ChainConfig) (*Chain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, cfg.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("dial chain %s: %w", cfg.Name, err)
+	}
+
+	nodeChainID, err := client.ChainID(ctx)
+	if err != nil {
+		if cfg.ChainID == 0 {
+			client.Close()
+			return nil, fmt.Errorf("cannot discover the chain ID of %s: %w", cfg.Name, err)
+		}
+		logger.LogError(fmt.Sprintf("[EVM] - Cannot verify chain ID of %s: %s", cfg.Name, err.Error()))
+	} else if cfg.ChainID == 0 {
+		cfg.ChainID = nodeChainID.Int64()
+	} else if nodeChainID.Int64() != cfg.ChainID {
+		client.Close()
+		return nil, fmt.Errorf("%w: chain %s is configured with chain ID %d but its RPC serves chain ID %d", errChainIDMismatch, cfg.Name, cfg.ChainID, nodeChainID.Int64())
+	}
+
+	return &Chain{Config: cfg, Client: client}, nil
+}
+
+// Get returns the chain serving the chain ID, dialing it again when it was unreachable
+func (p *ChainPool) Get(chainID int64) (*Chain, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if chain, ok := p.chains[chainID]; ok {
+		return chain, nil
+	}
+
+	if cfg, ok := p.unreachable[chainID]; ok {
+		chain, err := dial(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("chain ID %d is unreachable: %w", chainID, err)
+		}
+		delete(p.unreachable, chainID)
+		p.chains[chainID] = chain
+		return chain, nil
+	}
+
+	// Chains without a configured ID may serve it once discovered
+	remaining := p.undiscovered[:0]
+	for _, cfg := range p.undiscovered {
+		chain, err := dial(cfg)
+		if err != nil {
+			remaining = append(remaining, cfg)
+			continue
+		}
+		if _, ok := p.chains[chain.Config.ChainID]; !ok {
+			p.chains[chain.Config.ChainID] = chain
+		}
+	}
+	p.undiscovered = remaining
+	if chain, ok := p.chains[chainID]; ok {
+		return chain, nil
+	}
+
+	return nil, fmt.Errorf("chain ID %d is not configured", chainID)
+}
+
+// Configured reports whether the chain ID may be served by a configured chain, reachable or not.
+// A chain whose ID is not discovered yet may serve any.
+func (p *ChainPool) Configured(chainID int64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.has(chainID) || len(p.undiscovered) != 0
+}
+
+// Chains returns every reachable chain
+func (p *ChainPool) Chains() []*Chain {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	chains := make([]*Chain, 0, len(p.chains))
+	for _, chain := range p.chains {
+		chains = append(chains, chain)
+	}
+
+	return chains
+}
+
+// ID chain ID as expected by transaction signers
+func (c *Chain) ID() *big.Int {
+	return big.NewInt(c.Config.ChainID)
+}
+
+// SetGasPrice fills the fee fields of the transaction options following the chain's gas strategy
+func (c *Chain) SetGasPrice(ctx context.Context, opts *bind.TransactOpts) error {
+	if !c.Config.EIP1559 {
+		gasPrice, err := c.Client.SuggestGasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		opts.GasPrice = multiply(gasPrice, c.Config.GasPriceMultiplier)
+		return nil
+	}
+
+	head, err := c.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if head.BaseFee == nil {
+		return errors.New("chain " + c.Config.Name + " is configured for EIP-1559 but has no base fee")
+	}
+
+	var tip *big.Int
+	if c.Config.MaxPriorityFeeGwei > 0 {
+		tip = multiply(big.NewInt(params.GWei), c.Config.MaxPriorityFeeGwei)
+	} else if tip, err = c.Client.SuggestGasTipCap(ctx); err != nil {
+		return err
+	}
+	opts.GasTipCap = tip
+	opts.GasFeeCap = new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip)
+
+	return nil
+}
+
+// WaitForConfirmations blocks until the receipt's block is buried under the configured number of confirmations
+func (c *Chain) WaitForConfirmations(ctx context.Context, receipt *types.Receipt, interval time.Duration) error {
+	target := receipt.BlockNumber.Uint64() + c.Config.Confirmations
+	for {
+		head, err := c.Client.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if head >= target {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func multiply(value *big.Int, factor float64) *big.Int {
+	result, _ := new(big.Float).Mul(new(big.Float).SetInt(value), big.NewFloat(factor)).Int(nil)
+	return result
+}
@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config "backend-service/config/core_backend"
+)
+
+// newTestNode serves the chain ID of a node
+func newTestNode(t *testing.T, chainID string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + chainID + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestNewChainPoolUnreachableChain(t *testing.T) {
+	pool, err := NewChainPool([]config.ChainConfig{
+		{ChainID: 1337, Name: "local", RPCURL: newTestNode(t, "0x539")},
+		{ChainID: 80001, Name: "down", RPCURL: "ws://127.0.0.1:1"},
+	})
+	require.NoError(t, err)
+
+	chain, err := pool.Get(1337)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, chain.Config.GasPriceMultiplier)
+	assert.Len(t, pool.Chains(), 1)
+
+	assert.True(t, pool.Configured(80001))
+	_, err = pool.Get(80001)
+	assert.ErrorContains(t, err, "chain ID 80001 is unreachable")
+	assert.False(t, pool.Configured(56))
+}
+
+func TestNewChainPoolRefusesMisconfiguredChains(t *testing.T) {
+	_, err := NewChainPool([]config.ChainConfig{{ChainID: 56, Name: "bsc", RPCURL: newTestNode(t, "0x1")}})
+	assert.ErrorContains(t, err, "configured with chain ID 56 but its RPC serves chain ID 1")
+
+	url := newTestNode(t, "0x539")
+	_, err = NewChainPool([]config.ChainConfig{{Name: "default", RPCURL: url}, {ChainID: 1337, Name: "local", RPCURL: url}})
+	assert.ErrorContains(t, err, "chain ID 1337 is configured twice")
+}
+
+func TestChainPoolDiscoversChainLater(t *testing.T) {
+	pool, err := NewChainPool([]config.ChainConfig{{Name: "default", RPCURL: "ws://127.0.0.1:1"}})
+	require.NoError(t, err)
+	assert.True(t, pool.Configured(1337))
+
+	_, err = pool.Get(1337)
+	assert.ErrorContains(t, err, "chain ID 1337 is not configured")
+}
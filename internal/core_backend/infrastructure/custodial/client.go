@@ -0,0 +1,151 @@
+package custodial
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ClientConfig settings of the wallet service client
+type ClientConfig struct {
+	BaseURL    string
+	ClientID   string
+	ClientKey  string
+	Timeout    time.Duration
+	MaxRetry   int
+	RetryDelay time.Duration
+}
+
+// Client calls the custodial wallet service over HTTP.
+// Network errors, 429 and 5xx responses are retried with an exponential backoff, reusing the idempotency key.
+type Client struct {
+	cfg    ClientConfig
+	client *http.Client
+}
+
+// StatusError a non-OK response of the wallet service
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("wallet service responded %d: %s", e.StatusCode, e.Body)
+}
+
+// Retryable reports whether the request may succeed when sent again
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// NewClient create wallet service client
+func NewClient(cfg ClientConfig) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = 500 * time.Millisecond
+	}
+
+	return &Client{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// CreateWallet creates the wallet of one email
+func (c *Client) CreateWallet(ctx context.Context, email string, idempotencyKey string) (*Wallet, error) {
+	data := map[string]any{
+		"client_id": c.cfg.ClientID,
+		"email":     email,
+	}
+	var response struct {
+		Result Wallet `json:"result"`
+	}
+	if err := c.post(ctx, "/wallets", idempotencyKey, data, &response); err != nil {
+		return nil, err
+	}
+	if response.Result.WalletAddress == "" {
+		return nil, errors.New("wallet service returned no wallet address")
+	}
+
+	return &response.Result, nil
+}
+
+// CreateWallets creates the wallets of several emails in one request
+func (c *Client) CreateWallets(ctx context.Context, emails []string, idempotencyKey string) ([]UserWallet, error) {
+	data := map[string]any{
+		"client_id": c.cfg.ClientID,
+		"emails":    emails,
+	}
+	var response struct {
+		Result struct {
+			ListWallet []UserWallet `json:"list_wallets"`
+		} `json:"result"`
+	}
+	if err := c.post(ctx, "/wallets/bulk", idempotencyKey, data, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Result.ListWallet, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, idempotencyKey string, data any, out any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	delay := c.cfg.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err = c.send(ctx, path, idempotencyKey, body, out)
+		if err == nil || attempt >= c.cfg.MaxRetry || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, path string, idempotencyKey string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-client-key", c.cfg.ClientKey)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(message)}
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+	// The caller gave up, retrying would not help
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+package custodial
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(url string) *Client {
+	return NewClient(ClientConfig{
+		BaseURL:    url,
+		ClientID:   "client",
+		ClientKey:  "key",
+		Timeout:    time.Second,
+		MaxRetry:   2,
+		RetryDelay: time.Millisecond,
+	})
+}
+
+func TestClientRetriesWithSameIdempotencyKey(t *testing.T) {
+	var calls int32
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		assert.Equal(t, "key", r.Header.Get("x-client-key"))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var body struct {
+			Emails []string `json:"emails"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		wallets := []UserWallet{}
+		for _, email := range body.Emails {
+			wallets = append(wallets, UserWallet{Email: email, Wallet: Wallet{WalletAddress: FakeAddress(email)}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": map[string]any{"list_wallets": wallets}})
+	}))
+	defer server.Close()
+
+	emails := []string{"a@example.com", "b@example.com"}
+	key := IdempotencyKey(AttemptedEmail{Email: "a@example.com"}, AttemptedEmail{Email: "b@example.com", Attempt: 1})
+	wallets, err := newTestClient(server.URL).CreateWallets(context.Background(), emails, key)
+	require.NoError(t, err)
+	assert.Len(t, wallets, 2)
+	assert.Equal(t, int32(3), calls)
+	assert.Equal(t, []string{key, key, key}, keys)
+	assert.Equal(t, IdempotencyKey(AttemptedEmail{Email: "b@example.com", Attempt: 1}, AttemptedEmail{Email: "A@example.com"}), key)
+	assert.NotEqual(t, IdempotencyKey(AttemptedEmail{Email: "a@example.com"}, AttemptedEmail{Email: "b@example.com", Attempt: 2}), key)
+}
+
+func TestClientDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).CreateWallet(context.Background(), "a@example.com", "key")
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestFakeReplaysIdempotentRequests(t *testing.T) {
+	fake := NewFake()
+	fake.FailEmails["b@example.com"] = true
+
+	emails := []string{"a@example.com", "b@example.com"}
+	first, err := fake.CreateWallets(context.Background(), emails, "batch")
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, FakeAddress("a@example.com"), first[0].Wallet.WalletAddress)
+
+	// Replaying the key returns the first response even if the provider changed since
+	delete(fake.FailEmails, "b@example.com")
+	replay, err := fake.CreateWallets(context.Background(), emails, "batch")
+	require.NoError(t, err)
+	assert.Equal(t, first, replay)
+
+	_, err = fake.CreateWallet(context.Background(), "c@example.com", "single")
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.Calls)
+}
@@ -0,0 +1,71 @@
+package custodial
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Fake provider for local runs and tests. Wallet addresses are derived from the email so they are stable
+// across restarts, and idempotency keys are honoured like the real service.
+type Fake struct {
+	mu        sync.Mutex
+	responses map[string][]UserWallet
+	// FailEmails emails the provider refuses to create a wallet for
+	FailEmails map[string]bool
+	// Calls number of requests received, replays included
+	Calls int
+}
+
+// NewFake create fake provider
+func NewFake() *Fake {
+	return &Fake{responses: map[string][]UserWallet{}, FailEmails: map[string]bool{}}
+}
+
+// CreateWallet creates the wallet of one email
+func (f *Fake) CreateWallet(ctx context.Context, email string, idempotencyKey string) (*Wallet, error) {
+	wallets, err := f.CreateWallets(ctx, []string{email}, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(wallets) == 0 {
+		return nil, &StatusError{StatusCode: 422, Body: "wallet creation refused for " + email}
+	}
+
+	return &wallets[0].Wallet, nil
+}
+
+// CreateWallets creates the wallets of the emails that are not in FailEmails
+func (f *Fake) CreateWallets(ctx context.Context, emails []string, idempotencyKey string) ([]UserWallet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls++
+	if wallets, ok := f.responses[idempotencyKey]; ok {
+		return wallets, nil
+	}
+
+	wallets := []UserWallet{}
+	for _, email := range emails {
+		if f.FailEmails[email] {
+			continue
+		}
+		wallets = append(wallets, UserWallet{Email: email, Wallet: Wallet{WalletAddress: FakeAddress(email)}})
+	}
+	f.responses[idempotencyKey] = wallets
+
+	return wallets, nil
+}
+
+// FakeAddress the address the fake provider gives to an email
+func FakeAddress(email string) string {
+	hash := crypto.Keccak256([]byte(strings.ToLower(email)))
+
+	return common.BytesToAddress(hash[12:]).Hex()
+}
@@ -0,0 +1,67 @@
+package custodial
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	config "backend-service/config/core_backend"
+	constant "backend-service/internal/core_backend/common"
+)
+
+// Provider creates custodial wallets for user emails.
+// A request retried with the same idempotency key must return the wallets created by the first attempt.
+type Provider interface {
+	CreateWallet(ctx context.Context, email string, idempotencyKey string) (*Wallet, error)
+	CreateWallets(ctx context.Context, emails []string, idempotencyKey string) ([]UserWallet, error)
+}
+
+// Wallet a wallet held by the provider
+type Wallet struct {
+	WalletAddress string `json:"wallet_address"`
+}
+
+// UserWallet the wallet created for an email by a bulk request
+type UserWallet struct {
+	Email  string `json:"email"`
+	Wallet Wallet `json:"wallet"`
+}
+
+// NewProvider returns the provider selected by WALLET_PROVIDER
+func NewProvider() Provider {
+	if config.C.Wallet.WALLET_PROVIDER == constant.WalletProviderFake {
+		return NewFake()
+	}
+
+	return NewClient(ClientConfig{
+		BaseURL:    config.C.Wallet.WALLET_DOMAIN_V1,
+		ClientID:   config.C.Wallet.WALLET_CLIENT_ID,
+		ClientKey:  config.C.Wallet.WALLET_CLIENT_KEY,
+		Timeout:    time.Duration(config.C.Wallet.WALLET_API_TIMEOUT) * time.Second,
+		MaxRetry:   config.C.Wallet.WALLET_API_MAX_RETRY,
+		RetryDelay: time.Duration(config.C.Wallet.WALLET_API_RETRY_DELAY_IN_MS) * time.Millisecond,
+	})
+}
+
+// AttemptedEmail an email of a wallet request with the number of attempts already made for it
+type AttemptedEmail struct {
+	Email   string
+	Attempt int
+}
+
+// IdempotencyKey derives a stable key from the emails of a request and their attempt numbers.
+// Replaying an attempt is safe, the next attempt after a failure is a new request for the provider.
+func IdempotencyKey(emails ...AttemptedEmail) string {
+	sorted := make([]string, len(emails))
+	for i, email := range emails {
+		sorted[i] = strings.ToLower(email.Email) + "#" + strconv.Itoa(email.Attempt)
+	}
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+
+	return "wallet-" + hex.EncodeToString(sum[:16])
+}
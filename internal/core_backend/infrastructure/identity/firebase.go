@@ -1,47 +1,25 @@
-package firebase
+package identity
 
 import (
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 
 	"backend-service/internal/core_backend/common/logger"
 	"backend-service/internal/core_backend/entity"
 
 	firebase "firebase.google.com/go"
-	"github.com/gin-gonic/gin"
+	"firebase.google.com/go/auth"
 	"google.golang.org/api/option"
 )
 
+// FirebaseClient identity provider backed by Firebase Authentication
 type FirebaseClient struct {
 	App *firebase.App
 }
 
-type Token struct {
-	Name          string `json:"name"`
-	Picture       string `json:"picture"`
-	Role          string `json:"role"`
-	Organization  string `json:"organization"`
-	Iss           string `json:"iss"`
-	Aud           string `json:"aud"`
-	AuthTime      int    `json:"auth_time"`
-	UserID        string `json:"user_id"`
-	Sub           string `json:"sub"`
-	Iat           int    `json:"iat"`
-	Exp           int    `json:"exp"`
-	Email         string `json:"email"`
-	EmailVerified bool   `json:"email_verified"`
-	Firebase      struct {
-		Identities struct {
-			GoogleCom []string `json:"google.com"`
-			Email     []string `json:"email"`
-		} `json:"identities"`
-		SignInProvider string `json:"sign_in_provider"`
-	} `json:"firebase"`
-}
-
+// NewFirebaseClient verifies the tokens of the Firebase project, with the credentials of ./service-account-file.json
 func NewFirebaseClient(projectID string) (*FirebaseClient, error) {
 	ctx := context.Background()
 	app, err := firebase.NewApp(
@@ -89,34 +67,7 @@ func (fc *FirebaseClient) VerifyToken(token string) (*Token, error) {
 	return &tk, nil
 }
 
-func (fc *FirebaseClient) ExtractToken(c *gin.Context) (string, error) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		logger.LogInfo("No authorization header")
-		return "", errors.New("No authorization header")
-	}
-	authParts := strings.Split(authHeader, " ")
-	if len(authParts) != 2 || !strings.EqualFold(authParts[0], "bearer") {
-		logger.LogError("Invalid authorization header")
-		return "", errors.New("Invalid authorization header")
-	}
-	return authParts[1], nil
-}
-
-func (fc *FirebaseClient) FromTokenToUser(token *Token) *entity.User {
-	return &entity.User{
-		ID:            token.UserID,
-		Email:         token.Email,
-		EmailVerified: token.EmailVerified,
-		Name:          token.Name,
-		Picture:       token.Picture,
-		Organization:  token.Organization,
-		Role:          token.Role,
-		Firebase:      token.Firebase,
-	}
-}
-
-func (fc *FirebaseClient) GetFirebaseUser(userID string) (*entity.FbUser, error) {
+func (fc *FirebaseClient) GetUser(userID string) (*entity.FbUser, error) {
 	ctx := context.Background()
 	authApp, err := fc.App.Auth(ctx)
 	if err != nil {
@@ -147,7 +98,7 @@ func (fc *FirebaseClient) GetFirebaseUser(userID string) (*entity.FbUser, error)
 	return &userInfo, nil
 }
 
-func (fc *FirebaseClient) UpdateUserDetails(user *entity.User) error {
+func (fc *FirebaseClient) UpdateUserClaims(user *entity.User) error {
 	if user == nil {
 		return errors.New("Error getting updated user info")
 	}
@@ -172,3 +123,74 @@ func (fc *FirebaseClient) UpdateUserDetails(user *entity.User) error {
 
 	return nil
 }
+
+func (fc *FirebaseClient) DeleteUser(userID string) error {
+	ctx := context.Background()
+	authApp, err := fc.App.Auth(ctx)
+	if err != nil {
+		logger.LogError("Error when initializing Authenticate of Firebase app: " + err.Error())
+		return err
+	}
+
+	err = authApp.DeleteUser(ctx, userID)
+	if err != nil && !auth.IsUserNotFound(err) {
+		logger.LogError("Failed to delete user: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (fc *FirebaseClient) InviteUser(email string, name string) (*entity.FbUser, string, error) {
+	ctx := context.Background()
+	authApp, err := fc.App.Auth(ctx)
+	if err != nil {
+		logger.LogError("Error when initializing Authenticate of Firebase app: " + err.Error())
+		return nil, "", err
+	}
+
+	record, err := authApp.GetUserByEmail(ctx, email)
+	if auth.IsUserNotFound(err) {
+		record, err = authApp.CreateUser(ctx, (&auth.UserToCreate{}).Email(email).DisplayName(name))
+	}
+	if err != nil {
+		logger.LogError("Failed to create invited user: " + err.Error())
+		return nil, "", err
+	}
+
+	link, err := authApp.PasswordResetLink(ctx, email)
+	if err != nil {
+		logger.LogError("Failed to generate invite link: " + err.Error())
+		return nil, "", err
+	}
+
+	user, err := fc.GetUser(record.UID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, link, nil
+}
+
+func (fc *FirebaseClient) SetUserDisabled(userID string, disabled bool) error {
+	ctx := context.Background()
+	authApp, err := fc.App.Auth(ctx)
+	if err != nil {
+		logger.LogError("Error when initializing Authenticate of Firebase app: " + err.Error())
+		return err
+	}
+
+	if _, err = authApp.UpdateUser(ctx, userID, (&auth.UserToUpdate{}).Disabled(disabled)); err != nil {
+		logger.LogError("Failed to update disabled user: " + err.Error())
+		return err
+	}
+	// ID tokens already issued stay valid until they expire, the authenticators check the user status
+	if disabled {
+		if err = authApp.RevokeRefreshTokens(ctx, userID); err != nil {
+			logger.LogError("Failed to revoke refresh tokens: " + err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"backend-service/internal/core_backend/common/logger"
+)
+
+// clockSkew tolerated between the token issuer and this service
+const clockSkew = time.Minute
+
+// keyRefreshInterval a remote key set is fetched at most this often, however many unknown keys tokens name
+const keyRefreshInterval = time.Minute
+
+// keySource resolves the RSA key a token names
+type keySource interface {
+	key(kid string) *rsa.PublicKey
+}
+
+// staticKeys keys by ID, the only key also verifies tokens naming none
+type staticKeys map[string]*rsa.PublicKey
+
+func (k staticKeys) key(kid string) *rsa.PublicKey {
+	if kid == "" && len(k) == 1 {
+		for _, key := range k {
+			return key
+		}
+	}
+
+	return k[kid]
+}
+
+// RemoteKeySet the keys published at a JWKS URL. They are fetched on first use and again
+// when a token names an unknown key, so rotated keys are picked up.
+type RemoteKeySet struct {
+	url string
+	// fetching lets a single request fetch the keys, the others wait for its result
+	fetching  sync.Mutex
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewRemoteKeySet create key set of the JWKS URL
+func NewRemoteKeySet(url string) *RemoteKeySet {
+	return &RemoteKeySet{url: url}
+}
+
+// key known keys are resolved without waiting for a fetch in progress
+func (s *RemoteKeySet) key(kid string) *rsa.PublicKey {
+	if key, _, ok := s.cached(kid); ok {
+		return key
+	}
+
+	s.fetching.Lock()
+	defer s.fetching.Unlock()
+	// The keys may have been fetched while waiting
+	key, fetchedAt, ok := s.cached(kid)
+	if ok {
+		return key
+	}
+	if time.Since(fetchedAt) < keyRefreshInterval {
+		return nil
+	}
+
+	keys, err := LoadJWKS(s.url)
+	s.mu.Lock()
+	s.fetchedAt = time.Now()
+	if err == nil {
+		s.keys = keys
+	}
+	s.mu.Unlock()
+	if err != nil {
+		// The keys fetched before stay in use
+		logger.LogError("Error when fetching JWKS " + s.url + ": " + err.Error())
+		return nil
+	}
+
+	return keys[kid]
+}
+
+// cached the key of the last fetch and when it was made
+func (s *RemoteKeySet) cached(kid string) (*rsa.PublicKey, time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[kid]
+
+	return key, s.fetchedAt, ok
+}
+
+// registeredClaims the claims every verified token is checked against
+type registeredClaims struct {
+	Iss string   `json:"iss"`
+	Sub string   `json:"sub"`
+	Aud audience `json:"aud"`
+	Exp *int64   `json:"exp"`
+	Nbf int64    `json:"nbf"`
+	Iat int64    `json:"iat"`
+}
+
+// validate checks the token is valid at now and was issued by one of the issuers for the audience.
+// No issuers or an empty audience accepts any.
+func (c *registeredClaims) validate(now time.Time, issuers []string, aud string) error {
+	switch {
+	case c.Exp == nil:
+		return errors.New("token has no expiry")
+	case !now.Before(time.Unix(*c.Exp, 0).Add(clockSkew)):
+		return errors.New("token is expired")
+	case now.Add(clockSkew).Before(time.Unix(c.Nbf, 0)), now.Add(clockSkew).Before(time.Unix(c.Iat, 0)):
+		return errors.New("token is not valid yet")
+	case len(issuers) > 0 && !audience(issuers).contains(c.Iss):
+		return errors.New("token issuer is not accepted")
+	case aud != "" && !c.Aud.contains(aud):
+		return errors.New("token audience is not accepted")
+	case c.Sub == "":
+		return errors.New("token has no subject")
+	}
+
+	return nil
+}
+
+// verifyJWT checks the signature of the token and returns its payload. HS256 tokens need the secret and
+// RS256 tokens a key of the source, so an RSA public key is never used as an HMAC secret.
+func verifyJWT(token string, secret []byte, keys keySource) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token signature")
+	}
+
+	signed := parts[0] + "." + parts[1]
+	switch header.Alg {
+	case "HS256":
+		if len(secret) == 0 {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		if !hmac.Equal(signature, signHS256(secret, signed)) {
+			return nil, errors.New("invalid token signature")
+		}
+	case "RS256":
+		var key *rsa.PublicKey
+		if keys != nil {
+			key = keys.key(header.Kid)
+		}
+		if key == nil {
+			return nil, errors.New("unknown token signing key")
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return nil, errors.New("invalid token signature")
+		}
+	default:
+		return nil, errors.New("unsupported token algorithm: " + header.Alg)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token")
+	}
+
+	return payload, nil
+}
+
+// LoadJWKS reads the RSA signature keys of a JSON Web Key Set from a file or an http(s) URL
+func LoadJWKS(source string) (map[string]*rsa.PublicKey, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		data, err = fetchJWKS(source)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJWKS(data)
+}
+
+// ParseJWKS returns the RSA signature keys of the set by key ID
+func ParseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: invalid modulus", k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("key %s: invalid exponent", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS holds no RSA signature key")
+	}
+
+	return keys, nil
+}
+
+func fetchJWKS(url string) ([]byte, error) {
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS returned status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+func signHS256(secret []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.New("malformed token")
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return errors.New("malformed token")
+	}
+
+	return nil
+}
+
+func encodeSegment(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// audience the aud claim, a single value or a list
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single != "" {
+			*a = audience{single}
+		}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package identity
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRemoteKeySetFetchesOnce(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+		w.Write(jwks("current", &key.PublicKey))
+	}))
+	defer server.Close()
+	keys := NewRemoteKeySet(server.URL)
+
+	// Concurrent tokens naming a key not fetched yet wait for a single fetch
+	var wg sync.WaitGroup
+	found := make(chan *rsa.PublicKey, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found <- keys.key("current")
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(found)
+	for k := range found {
+		if k == nil || k.N.Cmp(key.N) != 0 {
+			t.Fatalf("got key %v", k)
+		}
+	}
+
+	// Unknown keys are not fetched again before the refresh interval
+	if k := keys.key("rotated"); k != nil {
+		t.Errorf("unknown key resolved to %v", k)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", n)
+	}
+}
+
+func TestRemoteKeySetServesKnownKeysDuringFetch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write(jwks("current", &key.PublicKey))
+	}))
+	defer server.Close()
+	defer close(release)
+	keys := NewRemoteKeySet(server.URL)
+	keys.keys = map[string]*rsa.PublicKey{"known": &key.PublicKey}
+
+	// A token naming an unknown key starts a fetch that hangs
+	go keys.key("rotated")
+	time.Sleep(50 * time.Millisecond)
+
+	resolved := make(chan *rsa.PublicKey)
+	go func() { resolved <- keys.key("known") }()
+	select {
+	case k := <-resolved:
+		if k != &key.PublicKey {
+			t.Errorf("got key %v", k)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a known key waited for the fetch")
+	}
+}
@@ -0,0 +1,287 @@
+package identity
+
+import (
+	"container/list"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/entity"
+)
+
+const (
+	// localSignInProvider the sign in provider of the accounts known to the local provider
+	localSignInProvider = "local"
+	// minSecretLength the shortest HS256 secret accepted, the size of the SHA-256 output (RFC 7518)
+	minSecretLength = 32
+	// maxLocalUsers the accounts kept in memory, the least recently seen are forgotten first
+	maxLocalUsers = 10000
+)
+
+// LocalConfig the keys and the expected issuer and audience of locally issued tokens
+type LocalConfig struct {
+	// Secret verifies, and mints, HS256 tokens
+	Secret []byte
+	// Keys verify RS256 tokens by key ID
+	Keys     map[string]*rsa.PublicKey
+	Issuer   string
+	Audience string
+}
+
+// LocalProvider verifies JWTs without Firebase, for local runs and integration tests.
+// Role and organization claims come from the token but, like with Firebase, only the stored role bindings grant
+// roles (cmd/mint_token stores them). Accounts are known once one of their tokens was verified.
+type LocalProvider struct {
+	cfg LocalConfig
+	now func() time.Time
+	mu  sync.Mutex
+	// users the elements of seen by user ID, seen holds the accounts most recently seen first
+	users    map[string]*list.Element
+	seen     *list.List
+	maxUsers int
+	// disabled the users whose tokens are refused
+	disabled map[string]bool
+}
+
+// NewLocalProvider create local provider, it needs a secret of at least 32 bytes or at least one key
+func NewLocalProvider(cfg LocalConfig) (*LocalProvider, error) {
+	if len(cfg.Secret) == 0 && len(cfg.Keys) == 0 {
+		return nil, errors.New("local identity provider needs LOCAL_JWT_SECRET or LOCAL_JWT_JWKS")
+	}
+	if len(cfg.Secret) != 0 && len(cfg.Secret) < minSecretLength {
+		return nil, fmt.Errorf("LOCAL_JWT_SECRET must be at least %d bytes", minSecretLength)
+	}
+
+	return &LocalProvider{
+		cfg:      cfg,
+		now:      time.Now,
+		users:    map[string]*list.Element{},
+		seen:     list.New(),
+		maxUsers: maxLocalUsers,
+		disabled: map[string]bool{},
+	}, nil
+}
+
+// NewLocalProviderFromConfig create local provider from the LOCAL_JWT_* settings, it is refused in production
+func NewLocalProviderFromConfig() (*LocalProvider, error) {
+	if config.C.App.ENV == config.EnvProd {
+		return nil, errors.New("the local identity provider cannot be used in production")
+	}
+	cfg := LocalConfig{
+		Secret:   []byte(config.C.Identity.LOCAL_JWT_SECRET),
+		Issuer:   config.C.Identity.LOCAL_JWT_ISSUER,
+		Audience: config.C.Identity.LOCAL_JWT_AUDIENCE,
+	}
+	if config.C.Identity.LOCAL_JWT_JWKS != "" {
+		keys, err := LoadJWKS(config.C.Identity.LOCAL_JWT_JWKS)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Keys = keys
+	}
+
+	return NewLocalProvider(cfg)
+}
+
+// VerifyToken accepts HS256 tokens signed with the secret and RS256 tokens signed by one of the keys.
+// The token must not be expired and must match the configured issuer and audience.
+func (p *LocalProvider) VerifyToken(token string) (*Token, error) {
+	payload, err := verifyJWT(token, p.cfg.Secret, staticKeys(p.cfg.Keys))
+	if err != nil {
+		return nil, err
+	}
+	var registered registeredClaims
+	if err = json.Unmarshal(payload, &registered); err != nil {
+		return nil, errors.New("malformed token")
+	}
+	var issuers []string
+	if p.cfg.Issuer != "" {
+		issuers = []string{p.cfg.Issuer}
+	}
+	if err = registered.validate(p.now(), issuers, p.cfg.Audience); err != nil {
+		return nil, err
+	}
+
+	// aud can be a list, it is read from the registered claims
+	var claims struct {
+		Token
+		Aud json.RawMessage `json:"aud"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, errors.New("malformed token")
+	}
+	if claims.UserID != "" && claims.UserID != registered.Sub {
+		return nil, errors.New("token user does not match its subject")
+	}
+	if p.isDisabled(registered.Sub) {
+		return nil, errors.New("user is disabled")
+	}
+
+	tk := claims.Token
+	tk.UserID = registered.Sub
+	if len(registered.Aud) > 0 {
+		tk.Aud = registered.Aud[0]
+	}
+	if tk.Firebase.SignInProvider == "" {
+		tk.Firebase.SignInProvider = localSignInProvider
+	}
+	p.remember(&tk)
+
+	return &tk, nil
+}
+
+// GetUser returns the account as of the last verified token of the user
+func (p *LocalProvider) GetUser(userID string) (*entity.FbUser, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	element, ok := p.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %s has not signed in with a local token", userID)
+	}
+	user := element.Value.(entity.FbUser)
+
+	return &user, nil
+}
+
+// UpdateUserClaims updates the known account. Tokens keep the claims they were minted with.
+func (p *LocalProvider) UpdateUserClaims(user *entity.User) error {
+	if user == nil {
+		return errors.New("Error getting updated user info")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	account := entity.FbUser{ID: user.ID, Email: user.Email, Username: user.Name, Provider: localSignInProvider}
+	if element, ok := p.users[user.ID]; ok {
+		account = element.Value.(entity.FbUser)
+	}
+	account.Role = user.Role
+	account.Organization = user.Organization
+	p.store(account)
+
+	return nil
+}
+
+// DeleteUser forgets the account. Tokens already minted stay valid until they expire.
+func (p *LocalProvider) DeleteUser(userID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if element, ok := p.users[userID]; ok {
+		p.seen.Remove(element)
+		delete(p.users, userID)
+	}
+
+	return nil
+}
+
+// InviteUser returns the known account of the email, or creates one. There is no password to choose, the link is empty.
+func (p *LocalProvider) InviteUser(email string, name string) (*entity.FbUser, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for element := p.seen.Front(); element != nil; element = element.Next() {
+		if user := element.Value.(entity.FbUser); strings.EqualFold(user.Email, email) {
+			return &user, "", nil
+		}
+	}
+
+	id := make([]byte, 14)
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", err
+	}
+	user := entity.FbUser{ID: hex.EncodeToString(id), Email: email, Username: name, Provider: localSignInProvider}
+	p.store(user)
+
+	return &user, "", nil
+}
+
+// SetUserDisabled refuses, or accepts again, the tokens of the user
+func (p *LocalProvider) SetUserDisabled(userID string, disabled bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if disabled {
+		p.disabled[userID] = true
+	} else {
+		delete(p.disabled, userID)
+	}
+
+	return nil
+}
+
+// MintToken signs an HS256 token with the claims, valid for ttl.
+// The subject is the user ID, issuer and audience default to the configured ones.
+func (p *LocalProvider) MintToken(claims Token, ttl time.Duration) (string, error) {
+	if len(p.cfg.Secret) == 0 {
+		return "", errors.New("minting tokens needs LOCAL_JWT_SECRET")
+	}
+	if claims.UserID == "" {
+		return "", errors.New("token needs a user ID")
+	}
+	now := p.now()
+	claims.Sub = claims.UserID
+	claims.Iat = int(now.Unix())
+	claims.AuthTime = claims.Iat
+	claims.Exp = int(now.Add(ttl).Unix())
+	if claims.Iss == "" {
+		claims.Iss = p.cfg.Issuer
+	}
+	if claims.Aud == "" {
+		claims.Aud = p.cfg.Audience
+	}
+
+	header, err := encodeSegment(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := header + "." + payload
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signHS256(p.cfg.Secret, signed)), nil
+}
+
+func (p *LocalProvider) isDisabled(userID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.disabled[userID]
+}
+
+func (p *LocalProvider) remember(tk *Token) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.store(entity.FbUser{
+		ID:            tk.UserID,
+		Email:         tk.Email,
+		Username:      tk.Name,
+		Role:          tk.Role,
+		Organization:  tk.Organization,
+		EmailVerified: tk.EmailVerified,
+		PhotoURL:      tk.Picture,
+		Provider:      tk.Firebase.SignInProvider,
+	})
+}
+
+// store keeps the account as the most recently seen and forgets the least recently seen beyond maxUsers.
+// A forgotten account is known again at its next verified token. p.mu must be held.
+func (p *LocalProvider) store(user entity.FbUser) {
+	if element, ok := p.users[user.ID]; ok {
+		element.Value = user
+		p.seen.MoveToFront(element)
+		return
+	}
+	p.users[user.ID] = p.seen.PushFront(user)
+	for p.seen.Len() > p.maxUsers {
+		oldest := p.seen.Back()
+		p.seen.Remove(oldest)
+		delete(p.users, oldest.Value.(entity.FbUser).ID)
+	}
+}
@@ -0,0 +1,236 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/entity"
+)
+
+// testSecret an HS256 secret of the minimum length
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestProvider(t *testing.T, cfg LocalConfig) *LocalProvider {
+	t.Helper()
+	p, err := NewLocalProvider(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// signRS256 signs the claims the way an external issuer publishing a JWKS would
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := encodeSegment(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, _ := encodeSegment(claims)
+	digest := sha256.Sum256([]byte(header + "." + payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func jwks(kid string, key *rsa.PublicKey) []byte {
+	set := map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	data, _ := json.Marshal(set)
+	return data
+}
+
+func TestMintedTokenCarriesClaims(t *testing.T) {
+	p := newTestProvider(t, LocalConfig{Secret: testSecret, Issuer: "local", Audience: "backend"})
+	token, err := p.MintToken(Token{UserID: "user-1", Email: "a@example.com", Role: "ORG_ADMIN", Organization: "acme"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := p.VerifyToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := FromTokenToUser(tk)
+	if user.ID != "user-1" || user.Email != "a@example.com" || user.Role != "ORG_ADMIN" || user.Organization != "acme" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	account, err := p.GetUser("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Organization != "acme" || account.Provider != localSignInProvider {
+		t.Errorf("unexpected account %+v", account)
+	}
+	if err = p.UpdateUserClaims(&entity.User{ID: "user-1", Role: "SUPER_ADMIN"}); err != nil {
+		t.Fatal(err)
+	}
+	if account, _ = p.GetUser("user-1"); account.Role != "SUPER_ADMIN" {
+		t.Errorf("claims not updated: %+v", account)
+	}
+	if _, err = p.GetUser("unknown"); err == nil {
+		t.Error("unknown user was found")
+	}
+}
+
+func TestVerifyTokenRefusesInvalidTokens(t *testing.T) {
+	now := time.Now()
+	p := newTestProvider(t, LocalConfig{Secret: testSecret, Issuer: "local", Audience: "backend"})
+	valid, _ := p.MintToken(Token{UserID: "user-1"}, time.Hour)
+
+	other := newTestProvider(t, LocalConfig{Secret: []byte("another-secret-of-at-least-32-bytes"), Issuer: "local", Audience: "backend"})
+	otherSecret, _ := other.MintToken(Token{UserID: "user-1"}, time.Hour)
+	otherIssuer, _ := p.MintToken(Token{UserID: "user-1", Iss: "someone-else"}, time.Hour)
+	otherAudience, _ := p.MintToken(Token{UserID: "user-1", Aud: "other-service"}, time.Hour)
+	p.now = func() time.Time { return now.Add(-2 * time.Hour) }
+	expired, _ := p.MintToken(Token{UserID: "user-1"}, time.Hour)
+	p.now = func() time.Time { return now.Add(time.Hour) }
+	notYetValid, _ := p.MintToken(Token{UserID: "user-1"}, time.Hour)
+	p.now = func() time.Time { return now }
+
+	parts := strings.Split(valid, ".")
+	none, _ := encodeSegment(map[string]string{"alg": "none"})
+	tampered, _ := encodeSegment(map[string]any{"sub": "admin", "exp": now.Add(time.Hour).Unix(), "iss": "local", "aud": "backend"})
+	noExpiry, _ := encodeSegment(map[string]any{"sub": "user-1", "iss": "local", "aud": "backend"})
+
+	cases := map[string]string{
+		"empty":            "",
+		"malformed":        "a.b",
+		"other secret":     otherSecret,
+		"other issuer":     otherIssuer,
+		"other audience":   otherAudience,
+		"expired":          expired,
+		"not yet valid":    notYetValid,
+		"alg none":         none + "." + parts[1] + ".",
+		"tampered payload": parts[0] + "." + tampered + "." + parts[2],
+		"no expiry":        parts[0] + "." + noExpiry + "." + base64.RawURLEncoding.EncodeToString(signHS256(testSecret, parts[0]+"."+noExpiry)),
+	}
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := p.VerifyToken(token); err == nil {
+				t.Error("token was accepted")
+			}
+		})
+	}
+}
+
+func TestVerifyTokenWithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ParseJWKS(jwks("key-1", &key.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newTestProvider(t, LocalConfig{Keys: keys, Audience: "backend"})
+	claims := map[string]any{
+		"sub":          "user-2",
+		"aud":          []string{"other-service", "backend"},
+		"exp":          time.Now().Add(time.Hour).Unix(),
+		"role":         "SUPER_ADMIN",
+		"organization": "acme",
+	}
+
+	tk, err := p.VerifyToken(signRS256(t, key, "key-1", claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.UserID != "user-2" || tk.Role != "SUPER_ADMIN" || tk.Organization != "acme" {
+		t.Errorf("unexpected claims %+v", tk)
+	}
+
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err = p.VerifyToken(signRS256(t, otherKey, "key-1", claims)); err == nil {
+		t.Error("token signed by another key was accepted")
+	}
+	if _, err = p.VerifyToken(signRS256(t, key, "key-2", claims)); err == nil {
+		t.Error("token of an unknown key was accepted")
+	}
+
+	// The public key must not be usable as an HMAC secret
+	header, _ := encodeSegment(map[string]string{"alg": "HS256", "kid": "key-1"})
+	payload, _ := encodeSegment(claims)
+	forged := header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(signHS256(jwks("key-1", &key.PublicKey), header+"."+payload))
+	if _, err = p.VerifyToken(forged); err == nil {
+		t.Error("HS256 token was accepted without a secret")
+	}
+	if _, err = p.MintToken(Token{UserID: "user-2"}, time.Hour); err == nil {
+		t.Error("minted a token without a secret")
+	}
+}
+
+func TestNewLocalProviderNeedsAKey(t *testing.T) {
+	if _, err := NewLocalProvider(LocalConfig{}); err == nil {
+		t.Error("provider created without a secret or keys")
+	}
+	if _, err := NewLocalProvider(LocalConfig{Secret: testSecret[:31]}); err == nil {
+		t.Error("provider created with a secret shorter than 32 bytes")
+	}
+	if _, err := ParseJWKS([]byte(`{"keys":[{"kty":"EC","kid":"ec"}]}`)); err == nil {
+		t.Error("JWKS without RSA keys was accepted")
+	}
+}
+
+func TestNewLocalProviderFromConfigRefusedInProduction(t *testing.T) {
+	previous := config.C
+	t.Cleanup(func() { config.C = previous })
+	config.C.Identity.LOCAL_JWT_SECRET = string(testSecret)
+
+	config.C.App.ENV = config.EnvProd
+	if _, err := NewLocalProviderFromConfig(); err == nil {
+		t.Error("local provider created in production")
+	}
+	config.C.App.ENV = "dev"
+	if _, err := NewLocalProviderFromConfig(); err != nil {
+		t.Errorf("local provider in dev: %v", err)
+	}
+}
+
+func TestLocalProviderForgetsLeastRecentlySeenUsers(t *testing.T) {
+	p := newTestProvider(t, LocalConfig{Secret: testSecret})
+	p.maxUsers = 2
+	verify := func(userID string) {
+		t.Helper()
+		token, err := p.MintToken(Token{UserID: userID}, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = p.VerifyToken(token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	verify("user-1")
+	verify("user-2")
+	verify("user-1")
+	verify("user-3")
+	if _, err := p.GetUser("user-2"); err == nil {
+		t.Error("least recently seen user was kept")
+	}
+	for _, userID := range []string{"user-1", "user-3"} {
+		if _, err := p.GetUser(userID); err != nil {
+			t.Errorf("%s: %v", userID, err)
+		}
+	}
+	if _, _, err := p.InviteUser("new@example.com", "New"); err != nil || len(p.users) != 2 || p.seen.Len() != 2 {
+		t.Errorf("after an invitation: %d users, %d seen, %v", len(p.users), p.seen.Len(), err)
+	}
+	if err := p.DeleteUser("user-1"); err != nil || p.seen.Len() != len(p.users) {
+		t.Errorf("after a deletion: %d users, %d seen, %v", len(p.users), p.seen.Len(), err)
+	}
+}
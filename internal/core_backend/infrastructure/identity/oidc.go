@@ -0,0 +1,53 @@
+package identity
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// OIDCVerifier verifies the RS256 OIDC tokens service accounts sign their requests with, e.g. Pub/Sub push requests
+type OIDCVerifier struct {
+	keys     keySource
+	issuers  []string
+	audience string
+	// email the service account the token must be issued to
+	email string
+	now   func() time.Time
+}
+
+// NewOIDCVerifier create verifier of the tokens of the key set. The audience and the email are required.
+func NewOIDCVerifier(keys *RemoteKeySet, issuers []string, aud string, email string) (*OIDCVerifier, error) {
+	if aud == "" || email == "" {
+		return nil, errors.New("OIDC verification needs an audience and a service account email")
+	}
+
+	return &OIDCVerifier{keys: keys, issuers: issuers, audience: aud, email: email, now: time.Now}, nil
+}
+
+// Verify checks the signature, the validity, the issuer and audience of the token and that it was issued
+// to the verified email of the service account
+func (v *OIDCVerifier) Verify(token string) error {
+	payload, err := verifyJWT(token, nil, v.keys)
+	if err != nil {
+		return err
+	}
+
+	var claims struct {
+		registeredClaims
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return errors.New("malformed token")
+	}
+	if err = claims.validate(v.now(), v.issuers, v.audience); err != nil {
+		return err
+	}
+	if !claims.EmailVerified || !strings.EqualFold(claims.Email, v.email) {
+		return errors.New("token is not issued to the expected service account")
+	}
+
+	return nil
+}
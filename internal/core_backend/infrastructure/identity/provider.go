@@ -0,0 +1,96 @@
+package identity
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	config "backend-service/config/core_backend"
+	constant "backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+)
+
+// Provider verifies the ID tokens users sign in with and keeps the role and organization claims of their accounts
+type Provider interface {
+	// VerifyToken checks the signature and the validity of the token and returns its claims
+	VerifyToken(token string) (*Token, error)
+	// GetUser returns the account of the user
+	GetUser(userID string) (*entity.FbUser, error)
+	// UpdateUserClaims sets the role and organization claims of the next tokens of the user
+	UpdateUserClaims(user *entity.User) error
+	// DeleteUser deletes the account, deleting an unknown account succeeds
+	DeleteUser(userID string) error
+	// InviteUser returns the account of the email, created when there is none, and a link to choose a password
+	InviteUser(email string, name string) (*entity.FbUser, string, error)
+	// SetUserDisabled disables or enables the account, disabled users cannot sign in nor refresh their tokens
+	SetUserDisabled(userID string, disabled bool) error
+}
+
+// Token the claims of an ID token
+type Token struct {
+	Name          string          `json:"name"`
+	Picture       string          `json:"picture"`
+	Role          string          `json:"role"`
+	Organization  string          `json:"organization"`
+	Iss           string          `json:"iss"`
+	Aud           string          `json:"aud"`
+	AuthTime      int             `json:"auth_time"`
+	UserID        string          `json:"user_id"`
+	Sub           string          `json:"sub"`
+	Iat           int             `json:"iat"`
+	Exp           int             `json:"exp"`
+	Email         string          `json:"email"`
+	EmailVerified bool            `json:"email_verified"`
+	Firebase      entity.Firebase `json:"firebase"`
+}
+
+// NewProvider returns the provider selected by IDENTITY_PROVIDER
+func NewProvider() (Provider, error) {
+	switch config.C.Identity.IDENTITY_PROVIDER {
+	case constant.IdentityProviderLocal:
+		provider, err := NewLocalProviderFromConfig()
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case constant.IdentityProviderFirebase, "":
+		provider, err := NewFirebaseClient(config.C.Firebase.FirebaseProjectID)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	default:
+		return nil, errors.New("unknown identity provider: " + config.C.Identity.IDENTITY_PROVIDER)
+	}
+}
+
+// ExtractToken returns the bearer token of the Authorization header
+func ExtractToken(c *gin.Context) (string, error) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		logger.LogInfo("No authorization header")
+		return "", errors.New("No authorization header")
+	}
+	authParts := strings.Split(authHeader, " ")
+	if len(authParts) != 2 || !strings.EqualFold(authParts[0], "bearer") {
+		logger.LogError("Invalid authorization header")
+		return "", errors.New("Invalid authorization header")
+	}
+	return authParts[1], nil
+}
+
+// FromTokenToUser the user the token was issued to
+func FromTokenToUser(token *Token) *entity.User {
+	return &entity.User{
+		ID:            token.UserID,
+		Email:         token.Email,
+		EmailVerified: token.EmailVerified,
+		Name:          token.Name,
+		Picture:       token.Picture,
+		Organization:  token.Organization,
+		Role:          token.Role,
+		Firebase:      token.Firebase,
+	}
+}
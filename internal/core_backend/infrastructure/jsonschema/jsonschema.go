@@ -0,0 +1,112 @@
+// Package jsonschema validates JSON documents against draft 2020-12 JSON Schemas,
+// with github.com/santhosh-tekuri/jsonschema.
+//
+// Schemas are self-contained: references to other documents are never loaded, from the network or from files.
+// Formats are annotations and are not enforced, as the draft defines them.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// schemaURL the location a compiled schema is known at, its local references resolve against it
+const schemaURL = "urn:jsonschema:schema.json"
+
+// Schema a compiled JSON Schema
+type Schema struct {
+	schema *jsonschema.Schema
+}
+
+// Compile parses a JSON Schema, it fails on malformed schemas, on schemas the draft 2020-12 meta-schema
+// rejects and on references that are unknown or to another document
+func Compile(raw []byte) (*Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("reference to %s is not allowed, schemas must be self-contained", url)
+	}
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(raw)); err != nil {
+		return nil, compileError(err)
+	}
+
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, compileError(err)
+	}
+
+	return &Schema{schema: schema}, nil
+}
+
+// compileError the error without the internal location of the schema
+func compileError(err error) error {
+	return errors.New(strings.ReplaceAll(err.Error(), schemaURL, "schema"))
+}
+
+// FieldError a value that does not match the schema, Path is a JSON pointer to it
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError every mismatch of a document
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		path := fe.Path
+		if path == "" {
+			path = "/"
+		}
+		msgs[i] = path + ": " + fe.Message
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Validate checks the document against the schema and returns a *ValidationError when it does not match.
+// The document may be any value encoding/json marshals, it is compared in its JSON form.
+func (s *Schema) Validate(doc any) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	err = s.schema.Validate(value)
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	errs := fieldErrors(verr, nil)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Path < errs[j].Path })
+
+	return &ValidationError{Errors: errs}
+}
+
+// fieldErrors the mismatches the error is made of, its causes that have none themselves
+func fieldErrors(verr *jsonschema.ValidationError, errs []FieldError) []FieldError {
+	if len(verr.Causes) == 0 {
+		return append(errs, FieldError{Path: verr.InstanceLocation, Message: verr.Message})
+	}
+	for _, cause := range verr.Causes {
+		errs = fieldErrors(cause, errs)
+	}
+
+	return errs
+}
@@ -0,0 +1,139 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wineSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Wine",
+	"type": "object",
+	"required": ["vintage", "grapes"],
+	"additionalProperties": false,
+	"properties": {
+		"vintage": {"type": "integer", "minimum": 1900, "maximum": 2100},
+		"grapes": {"type": "array", "minItems": 1, "items": {"type": "string", "minLength": 2}},
+		"color": {"enum": ["red", "white", "rose"]},
+		"label": {"$ref": "#/$defs/media"},
+		"alcohol": {"type": ["number", "null"], "exclusiveMinimum": 0}
+	},
+	"$defs": {
+		"media": {
+			"type": "object",
+			"required": ["url"],
+			"properties": {"url": {"type": "string", "pattern": "^https://"}}
+		}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(wineSchema))
+	require.NoError(t, err)
+
+	valid := map[string]any{
+		"vintage": 2019,
+		"grapes":  []string{"Merlot", "Cabernet Sauvignon"},
+		"color":   "red",
+		"label":   map[string]any{"url": "https://cdn.example.com/label.png"},
+		"alcohol": nil,
+	}
+	assert.NoError(t, schema.Validate(valid))
+
+	err = schema.Validate(map[string]any{
+		"vintage": 2019.5,
+		"grapes":  []any{},
+		"color":   "blue",
+		"label":   map[string]any{"url": "http://cdn.example.com/label.png"},
+		"alcohol": 0,
+		"price":   10,
+	})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	var paths []string
+	for _, fe := range verr.Errors {
+		assert.NotEmpty(t, fe.Message, fe.Path)
+		paths = append(paths, fe.Path)
+	}
+	// Properties that are not allowed are reported on the object holding them
+	assert.Equal(t, []string{"", "/alcohol", "/color", "/grapes", "/label/url", "/vintage"}, paths)
+	assert.Contains(t, verr.Error(), "/: additionalProperties 'price' not allowed")
+
+	err = schema.Validate(map[string]any{})
+	require.ErrorAs(t, err, &verr)
+	assert.Contains(t, verr.Error(), "'vintage', 'grapes'")
+
+	assert.Error(t, schema.Validate("not an object"))
+}
+
+func TestValidateCombinators(t *testing.T) {
+	schema, err := Compile([]byte(`{
+		"oneOf": [{"type": "string"}, {"type": "integer"}, {"type": "number"}],
+		"anyOf": [{"const": 1}, {"const": "one"}, {"type": "number"}]
+	}`))
+	require.NoError(t, err)
+
+	assert.NoError(t, schema.Validate("one"))
+	// 2.5 is a number but not an integer, so it matches exactly one schema
+	assert.NoError(t, schema.Validate(2.5))
+	// 1 is both an integer and a number
+	assert.Error(t, schema.Validate(1))
+	assert.Error(t, schema.Validate("two"))
+}
+
+func TestCompileRejectsInvalidSchemas(t *testing.T) {
+	for name, raw := range map[string]string{
+		"invalid JSON":        `{"type": `,
+		"not a schema":        `"object"`,
+		"unknown type":        `{"type": "date"}`,
+		"invalid pattern":     `{"pattern": "("}`,
+		"negative bound":      `{"minLength": -1}`,
+		"unknown reference":   `{"$ref": "#/$defs/missing"}`,
+		"remote reference":    `{"$ref": "https://example.com/schema.json"}`,
+		"file reference":      `{"$ref": "file:///etc/passwd"}`,
+		"relative reference":  `{"$ref": "other.json"}`,
+		"nested invalid":      `{"properties": {"a": {"type": 1}}}`,
+		"required not string": `{"required": [1]}`,
+		"reference to itself": `{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`,
+	} {
+		_, err := Compile([]byte(raw))
+		assert.Error(t, err, name)
+	}
+
+	_, err := Compile([]byte(`true`))
+	assert.NoError(t, err)
+}
+
+func TestValidateConditionals(t *testing.T) {
+	// Keywords of the draft beyond the basic ones are enforced, not ignored
+	schema, err := Compile([]byte(`{
+		"type": "object",
+		"if": {"properties": {"material": {"const": "gold"}}, "required": ["material"]},
+		"then": {"required": ["karat"]},
+		"properties": {"karat": {"not": {"type": "string"}}},
+		"dependentRequired": {"stone": ["carat"]}
+	}`))
+	require.NoError(t, err)
+
+	assert.NoError(t, schema.Validate(map[string]any{"material": "gold", "karat": 18}))
+	assert.NoError(t, schema.Validate(map[string]any{"material": "silver"}))
+	assert.Error(t, schema.Validate(map[string]any{"material": "gold"}))
+	assert.Error(t, schema.Validate(map[string]any{"material": "gold", "karat": "18"}))
+	assert.Error(t, schema.Validate(map[string]any{"stone": "ruby"}))
+}
+
+func TestValidateRecursiveSchema(t *testing.T) {
+	schema, err := Compile([]byte(`{
+		"$ref": "#/$defs/part",
+		"$defs": {"part": {"type": "object", "required": ["name"], "properties": {
+			"name": {"type": "string"},
+			"parts": {"type": "array", "items": {"$ref": "#/$defs/part"}}
+		}}}
+	}`))
+	require.NoError(t, err)
+
+	assert.NoError(t, schema.Validate(map[string]any{"name": "case", "parts": []any{map[string]any{"name": "dial"}}}))
+	assert.Error(t, schema.Validate(map[string]any{"name": "case", "parts": []any{map[string]any{}}}))
+}
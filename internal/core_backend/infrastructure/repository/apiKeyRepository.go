@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// APIKeyRepository struct
+type APIKeyRepository struct {
+	dbMongo *mongo.Database
+}
+
+// NewAPIKeyRepository create repository
+func NewAPIKeyRepository(dbMongo *mongo.Database) *APIKeyRepository {
+	return &APIKeyRepository{dbMongo: dbMongo}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(key *entity.APIKey) (*entity.APIKey, error) {
+	result, err := r.dbMongo.Collection(key.CollectionName()).InsertOne(context.TODO(), key)
+	if err != nil {
+		return nil, err
+	}
+	key.ID = result.InsertedID.(primitive.ObjectID)
+
+	return key, nil
+}
+
+// GetAPIKeyByPrefix - the key the prefix identifies, revoked or not
+func (r *APIKeyRepository) GetAPIKeyByPrefix(prefix string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.dbMongo.Collection(key.CollectionName()).FindOne(context.TODO(), bson.M{"prefix": prefix}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// GetAPIKeys - the keys of the organizations in the access scope, newest first
+func (r *APIKeyRepository) GetAPIKeys(scope *entity.AccessScope) (*[]entity.APIKey, error) {
+	option := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.dbMongo.Collection(entity.APIKey{}.CollectionName()).Find(context.TODO(), scopeFilter(bson.M{}, "org_id", scope), option)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []entity.APIKey{}
+	if err = cursor.All(context.TODO(), &keys); err != nil {
+		return nil, err
+	}
+
+	return &keys, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyInScope(keyID *string, scope *entity.AccessScope) (*entity.APIKey, error) {
+	id, err := primitive.ObjectIDFromHex(*keyID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var key entity.APIKey
+	err = r.dbMongo.Collection(key.CollectionName()).FindOne(context.TODO(), scopeFilter(bson.M{"_id": id}, "org_id", scope)).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// RevokeAPIKey - revoking twice keeps the first revocation time
+func (r *APIKeyRepository) RevokeAPIKey(keyID primitive.ObjectID, at time.Time) (bool, error) {
+	filter := bson.M{"_id": keyID, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": at, "updated_at": at}}
+	result, err := r.dbMongo.Collection(entity.APIKey{}.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount != 0, nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(keyID primitive.ObjectID, at time.Time) error {
+	_, err := r.dbMongo.Collection(entity.APIKey{}.CollectionName()).UpdateOne(
+		context.TODO(),
+		bson.M{"_id": keyID},
+		bson.M{"$set": bson.M{"last_used_at": at}},
+	)
+
+	return err
+}
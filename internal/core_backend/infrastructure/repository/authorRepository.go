@@ -33,9 +33,9 @@ func (r *AuthorRepository) CreateAuthor(author *entity.Author) (*entity.Author,
 	return author, nil
 }
 
-// GetListAuthor - get all authors
-func (r *AuthorRepository) GetListAuthor() (*[]entity.Author, error) {
-	cursor, err := r.dbMongo.Collection(entity.Author{}.CollectionName()).Find(context.TODO(), bson.D{{}})
+// GetListAuthor - the shared authors and the authors of the organizations in scope
+func (r *AuthorRepository) GetListAuthor(scope *entity.AccessScope) (*[]entity.Author, error) {
+	cursor, err := r.dbMongo.Collection(entity.Author{}.CollectionName()).Find(context.TODO(), sharedScopeFilter(bson.M{}, "org_id", scope))
 	if err != nil {
 		return nil, err
 	}
@@ -68,12 +68,32 @@ func (r *AuthorRepository) GetAuthorByID(authorID *string) (*entity.Author, erro
 	return &author, nil
 }
 
-// UpdateAuthor -
-func (r *AuthorRepository) UpdateAuthor(author *entity.Author) (*entity.Author, error) {
+// GetAuthorInScope returns nil when the author is neither shared nor of an organization in scope
+func (r *AuthorRepository) GetAuthorInScope(authorID *string, scope *entity.AccessScope) (*entity.Author, error) {
+	aID, err := primitive.ObjectIDFromHex(*authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var author entity.Author
+	err = r.dbMongo.Collection(author.CollectionName()).FindOne(context.TODO(), sharedScopeFilter(bson.M{"_id": aID}, "org_id", scope)).Decode(&author)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &author, nil
+}
+
+// UpdateAuthor - only replaces an author of an organization in scope
+func (r *AuthorRepository) UpdateAuthor(author *entity.Author, scope *entity.AccessScope) (*entity.Author, error) {
 	ctx := context.Background()
 	err := r.dbMongo.Collection(author.CollectionName()).FindOneAndDelete(
 		ctx,
-		bson.D{{Key: "_id", Value: author.ID}},
+		scopeFilter(bson.M{"_id": author.ID}, "org_id", scope),
 	).Err()
 	if err != nil {
 		return nil, err
@@ -29,6 +29,9 @@ func (r *DigitalAssetCollectionRepository) GetCollectionByOrgID(orgID *string) (
 	var collection entity.DigitalAssetCollection
 	err = r.dbMongo.Collection(collection.CollectionName()).FindOne(context.TODO(), bson.D{{Key: "org_id", Value: oID}}).Decode(&collection)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -43,8 +46,64 @@ func (r *DigitalAssetCollectionRepository) GetCollectionByID(cID *string) (*enti
 	var dac entity.DigitalAssetCollection
 	err = r.dbMongo.Collection(dac.CollectionName()).FindOne(context.TODO(), bson.M{"_id": colID}).Decode(&dac)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
 		return nil, err
 	}
 
 	return &dac, nil
 }
+
+// GetCollectionInScope returns the collection if its organization is in the access scope
+func (r *DigitalAssetCollectionRepository) GetCollectionInScope(cID *string, scope *entity.AccessScope) (*entity.DigitalAssetCollection, error) {
+	colID, err := primitive.ObjectIDFromHex(*cID)
+	if err != nil {
+		return nil, err
+	}
+	var dac entity.DigitalAssetCollection
+	filter := scopeFilter(bson.M{"_id": colID}, "org_id", scope)
+	err = r.dbMongo.Collection(dac.CollectionName()).FindOne(context.TODO(), filter).Decode(&dac)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &dac, nil
+}
+
+// GetCollectionsInScope returns the collections of the organizations in the access scope
+func (r *DigitalAssetCollectionRepository) GetCollectionsInScope(scope *entity.AccessScope) (*[]entity.DigitalAssetCollection, error) {
+	cursor, err := r.dbMongo.Collection(entity.DigitalAssetCollection{}.CollectionName()).Find(context.TODO(), scopeFilter(bson.M{}, "org_id", scope))
+	if err != nil {
+		return nil, err
+	}
+
+	var collections []entity.DigitalAssetCollection
+	if err = cursor.All(context.TODO(), &collections); err != nil {
+		return nil, err
+	}
+
+	return &collections, nil
+}
+
+// UpdateCollectionMetadata - update the reveal flag, placeholder and contract level metadata of a collection
+func (r *DigitalAssetCollectionRepository) UpdateCollectionMetadata(dac *entity.DigitalAssetCollection) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: dac.ID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "revealed", Value: dac.Revealed},
+			{Key: "placeholder_metadata", Value: dac.PlaceholderMetadata},
+			{Key: "contract_metadata", Value: dac.ContractMetadata},
+			{Key: "updated_at", Value: dac.UpdatedAt},
+		}},
+	}
+	result, err := r.dbMongo.Collection(dac.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
@@ -45,13 +45,11 @@ func (r *DigitalAssetRepository) GetActiveDigitalAssetByCollectionID(collectionI
 		return nil, err
 	}
 
-	filter := bson.D{
-		{Key: "$and",
-			Value: bson.A{
-				bson.D{{Key: "status", Value: common.StatusActive}},
-				bson.D{{Key: "collection_id", Value: cID}},
-			},
-		},
+	filter := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "status", Value: common.StatusActive}},
+		bson.D{{Key: "collection_id", Value: cID}},
+	},
+	},
 	}
 	cursor, err := r.dbMongo.Collection(entity.DigitalAsset{}.CollectionName()).Find(context.TODO(), filter)
 	if err != nil {
@@ -84,19 +82,20 @@ func (r *DigitalAssetRepository) GetDigitalAssetByTokenID(collectionID *string,
 		return nil, err
 	}
 
-	filter := bson.D{
-		{Key: "$and",
-			Value: bson.A{
-				bson.D{{Key: "status", Value: common.StatusActive}},
-				bson.D{{Key: "collection_id", Value: cID}},
-				bson.D{{Key: "token_id", Value: *tokenID}},
-			},
-		},
+	filter := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "status", Value: common.StatusActive}},
+		bson.D{{Key: "collection_id", Value: cID}},
+		bson.D{{Key: "token_id", Value: *tokenID}},
+	},
+	},
 	}
 
 	var asset entity.DigitalAsset
 	err = r.dbMongo.Collection(asset.CollectionName()).FindOne(context.TODO(), filter).Decode(&asset)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -135,10 +134,9 @@ func (r *DigitalAssetRepository) UpdateDigitalAssetMetadata(daID *string, metada
 		return false, err
 	}
 	filter := bson.D{{Key: "_id", Value: daObjectID}}
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "metadata", Value: *metadata},
-		}}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "metadata", Value: *metadata},
+	}}}
 	_, err = r.dbMongo.Collection(entity.DigitalAsset{}.CollectionName()).UpdateOne(
 		context.TODO(),
 		&filter,
@@ -165,109 +163,76 @@ func (r *DigitalAssetRepository) GetAllActiveDigitalAssets() (*[]entity.DigitalA
 	return &digitalAssets, nil
 }
 
-func (r *DigitalAssetRepository) GetDigitalAssetsProductAggregate() (*[]entity.DigitalAssetProductAggregate, error) {
+func (r *DigitalAssetRepository) GetDigitalAssetsProductAggregate(collectionID *string) (*[]entity.DigitalAssetProductAggregate, error) {
+	cID, err := primitive.ObjectIDFromHex(*collectionID)
+	if err != nil {
+		return nil, err
+	}
 	coll := r.dbMongo.Collection(entity.DigitalAsset{}.CollectionName())
 	cursor, err := coll.Aggregate(context.TODO(), bson.A{
-		bson.D{
-			{"$lookup",
-				bson.D{
-					{"from", "mappings"},
-					{"localField", "_id"},
-					{"foreignField", "digital_asset_id"},
-					{"as", "mapping"},
-				},
-			},
-		},
-		bson.D{
-			{"$unwind",
-				bson.D{
-					{"path", "$mapping"},
-					{"preserveNullAndEmptyArrays", false},
-				},
-			},
-		},
-		bson.D{
-			{"$set",
-				bson.D{
-					{"product_item_id", "$mapping.product_item_id"},
-					{"org_id", "$mapping.org_id"},
-				},
-			},
-		},
-		bson.D{{"$unset", "mapping"}},
-		bson.D{
-			{"$lookup",
-				bson.D{
-					{"from", "organizations"},
-					{"localField", "org_id"},
-					{"foreignField", "_id"},
-					{"as", "organization"},
-				},
-			},
-		},
-		bson.D{
-			{"$unwind",
-				bson.D{
-					{"path", "$organization"},
-					{"preserveNullAndEmptyArrays", false},
-				},
-			},
-		},
-		bson.D{{"$set", bson.D{{"org_tag_name", "$organization.org_tag_name"}}}},
-		bson.D{
-			{"$unset",
-				bson.A{
-					"organization",
-					"org_id",
-				},
-			},
-		},
-		bson.D{
-			{"$lookup",
-				bson.D{
-					{"from", "product_items"},
-					{"localField", "product_item_id"},
-					{"foreignField", "_id"},
-					{"as", "product_item"},
-				},
-			},
-		},
-		bson.D{
-			{"$unwind",
-				bson.D{
-					{"path", "$product_item"},
-					{"preserveNullAndEmptyArrays", false},
-				},
-			},
-		},
-		bson.D{
-			{"$set",
-				bson.D{
-					{"product_id", "$product_item.product_id"},
-					{"item_index", "$product_item.item_index"},
-				},
-			},
-		},
-		bson.D{{"$unset", "product_item"}},
-		bson.D{
-			{"$lookup",
-				bson.D{
-					{"from", "products"},
-					{"localField", "product_id"},
-					{"foreignField", "_id"},
-					{"as", "product"},
-				},
-			},
-		},
-		bson.D{
-			{"$unwind",
-				bson.D{
-					{"path", "$product"},
-					{"preserveNullAndEmptyArrays", false},
-				},
-			},
-		},
-		bson.D{{"$unset", "product_id"}},
+		bson.D{{Key: "$match", Value: bson.D{{Key: "collection_id", Value: cID}}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "mappings"},
+			{Key: "localField", Value: "_id"},
+			{Key: "foreignField", Value: "digital_asset_id"},
+			{Key: "as", Value: "mapping"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$mapping"},
+			{Key: "preserveNullAndEmptyArrays", Value: false},
+		}}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "product_item_id", Value: "$mapping.product_item_id"},
+			{Key: "org_id", Value: "$mapping.org_id"},
+		}}},
+		bson.D{{Key: "$unset", Value: "mapping"}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "organizations"},
+			{Key: "localField", Value: "org_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "organization"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$organization"},
+			{Key: "preserveNullAndEmptyArrays", Value: false},
+		}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "org_tag_name", Value: "$organization.org_tag_name"}}}},
+		bson.D{{Key: "$unset", Value: "org_id"}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "product_items"},
+			{Key: "localField", Value: "product_item_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "product_item"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$product_item"},
+			{Key: "preserveNullAndEmptyArrays", Value: false},
+		}}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "product_id", Value: "$product_item.product_id"},
+			{Key: "item_index", Value: "$product_item.item_index"},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "products"},
+			{Key: "localField", Value: "product_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "product"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$product"},
+			{Key: "preserveNullAndEmptyArrays", Value: false},
+		}}},
+		bson.D{{Key: "$unset", Value: "product_id"}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "authors"},
+			{Key: "localField", Value: "product.author_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "author"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$author"},
+			{Key: "preserveNullAndEmptyArrays", Value: true},
+		}}},
 	})
 	if err != nil {
 		return nil, err
@@ -279,3 +244,59 @@ func (r *DigitalAssetRepository) GetDigitalAssetsProductAggregate() (*[]entity.D
 	}
 	return &aggregations, nil
 }
+
+// GetMetadataSourceByProductItemID - get the product item with its product, organization and author
+func (r *DigitalAssetRepository) GetMetadataSourceByProductItemID(pItemID *string) (*entity.MetadataSource, error) {
+	pID, err := primitive.ObjectIDFromHex(*pItemID)
+	if err != nil {
+		return nil, err
+	}
+	coll := r.dbMongo.Collection(entity.ProductItem{}.CollectionName())
+	cursor, err := coll.Aggregate(context.TODO(), bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "_id", Value: pID}}}},
+		bson.D{{Key: "$replaceWith", Value: bson.D{{Key: "product_item", Value: "$$ROOT"}}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "products"},
+			{Key: "localField", Value: "product_item.product_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "product"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$product"},
+			{Key: "preserveNullAndEmptyArrays", Value: false},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "organizations"},
+			{Key: "localField", Value: "product.org_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "organization"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$organization"},
+			{Key: "preserveNullAndEmptyArrays", Value: true},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "authors"},
+			{Key: "localField", Value: "product.author_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "author"},
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.D{
+			{Key: "path", Value: "$author"},
+			{Key: "preserveNullAndEmptyArrays", Value: true},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+	var sources []entity.MetadataSource
+	if err = cursor.All(context.TODO(), &sources); err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	return &sources[0], nil
+}
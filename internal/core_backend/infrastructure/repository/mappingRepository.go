@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"backend-service/internal/core_backend/api/handler/request"
 	"backend-service/internal/core_backend/entity"
@@ -22,9 +23,13 @@ func NewMappingRepository(dbMongo *mongo.Database) *MappingRepository {
 	return &MappingRepository{dbMongo: dbMongo}
 }
 
-// GetAllMapping
-func (r *MappingRepository) GetAllMapping() (*[]entity.Mapping, error) {
-	cursor, err := r.dbMongo.Collection(entity.Mapping{}.CollectionName()).Find(context.TODO(), bson.D{{}})
+// GetMappings returns the mappings of the access scope, narrowed to one organization when orgID is given
+func (r *MappingRepository) GetMappings(scope *entity.AccessScope, orgID *primitive.ObjectID) (*[]entity.Mapping, error) {
+	filter := scopeFilter(bson.M{}, "org_id", scope)
+	if orgID != nil {
+		filter = bson.M{"$and": bson.A{filter, bson.M{"org_id": *orgID}}}
+	}
+	cursor, err := r.dbMongo.Collection(entity.Mapping{}.CollectionName()).Find(context.TODO(), filter)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +70,9 @@ func (r *MappingRepository) UpsertMapping(mapping *entity.Mapping) (bool, error)
 			{Key: "product_item_id", Value: mapping.ProductItemID},
 			{Key: "org_id", Value: mapping.OrganizationID},
 			{Key: "external_url", Value: mapping.ExternalURL},
-			{Key: "claimable", Value: mapping.Claimable},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "claim_state", Value: entity.ClaimStateUnclaimable},
 		}}}
 
 	result, err := r.dbMongo.Collection(mapping.CollectionName()).UpdateOne(
@@ -95,6 +102,22 @@ func (r *MappingRepository) GetMappingWithTagID(tagID *string) (*entity.Mapping,
 	return &mapping, nil
 }
 
+// GetMappingWithTagIDInScope returns the mapping of the tag if its organization is in the access scope
+func (r *MappingRepository) GetMappingWithTagIDInScope(tagID *string, scope *entity.AccessScope) (*entity.Mapping, error) {
+	var mapping entity.Mapping
+	filter := scopeFilter(bson.M{"tag_id": *tagID}, "org_id", scope)
+	err := r.dbMongo.Collection(mapping.CollectionName()).FindOne(context.TODO(), filter).Decode(&mapping)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &mapping, nil
+}
+
 func (r *MappingRepository) GetMappingWithProductItemID(productItemID *string) (*entity.Mapping, error) {
 	pID, _ := primitive.ObjectIDFromHex(*productItemID)
 	var mapping entity.Mapping
@@ -108,6 +131,24 @@ func (r *MappingRepository) GetMappingWithProductItemID(productItemID *string) (
 	return &mapping, nil
 }
 
+// GetMappingWithProductItemIDInScope returns the mapping of the product item if its organization is in the access scope
+func (r *MappingRepository) GetMappingWithProductItemIDInScope(productItemID *string, scope *entity.AccessScope) (*entity.Mapping, error) {
+	pID, err := primitive.ObjectIDFromHex(*productItemID)
+	if err != nil {
+		return nil, nil
+	}
+	var mapping entity.Mapping
+	filter := scopeFilter(bson.M{"product_item_id": pID}, "org_id", scope)
+	err = r.dbMongo.Collection(mapping.CollectionName()).FindOne(context.TODO(), filter).Decode(&mapping)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &mapping, nil
+}
+
 func (r *MappingRepository) GetAllMappingForProduct(productID *string, orgID *string) (*[]entity.Mapping, error) {
 	pID, err := primitive.ObjectIDFromHex(*productID)
 	if err != nil {
@@ -197,3 +238,86 @@ func (r *MappingRepository) GetMappingByDigitalAsset(digitalAssetID *string) (*e
 
 	return &mapping, nil
 }
+
+// EnsureClaimIndexes - creates the index of the claim events of a product item, newest first
+func (r *MappingRepository) EnsureClaimIndexes() error {
+	_, err := r.dbMongo.Collection(entity.ClaimEvent{}.CollectionName()).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "product_item_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+
+	return err
+}
+
+// ChangeClaimState - moves the item from its stored state and owner, the FromState and PreviousOwnerID of the event,
+// to the state and owner of the event and records the event. Returns false when the state or owner changed meanwhile.
+func (r *MappingRepository) ChangeClaimState(storedState string, event *entity.ClaimEvent) (bool, error) {
+	session, err := r.dbMongo.Client().StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(context.TODO())
+
+	changed, err := session.WithTransaction(context.TODO(), func(ctx mongo.SessionContext) (any, error) {
+		filter := bson.M{
+			"product_item_id": event.ProductItemID,
+			"claim_state":     emptyOrValue(storedState),
+			"owner_id":        emptyOrValue(event.PreviousOwnerID),
+		}
+		set := bson.M{
+			"claim_state":     event.ToState,
+			"owner_id":        event.OwnerID,
+			"claimable_until": event.ClaimableUntil,
+			"updated_at":      event.CreatedAt,
+		}
+		if event.OwnerID != event.PreviousOwnerID {
+			set["claimed_at"] = claimedAt(event.OwnerID, event.CreatedAt)
+		}
+		update := bson.M{"$set": set}
+		result, err := r.dbMongo.Collection(entity.Mapping{}.CollectionName()).UpdateOne(ctx, filter, update)
+		if err != nil || result.MatchedCount == 0 {
+			return false, err
+		}
+		if _, err = r.dbMongo.Collection(event.CollectionName()).InsertOne(ctx, event); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return changed.(bool), nil
+}
+
+// claimedAt the claim time of an item given to the owner at the time, nil when the item has no owner
+func claimedAt(ownerID string, at time.Time) *time.Time {
+	if ownerID == "" {
+		return nil
+	}
+
+	return &at
+}
+
+// GetClaimEvents - the claim events of the product item, newest first
+func (r *MappingRepository) GetClaimEvents(productItemID primitive.ObjectID) (*[]entity.ClaimEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.dbMongo.Collection(entity.ClaimEvent{}.CollectionName()).Find(context.TODO(), bson.M{"product_item_id": productItemID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []entity.ClaimEvent{}
+	if err = cursor.All(context.TODO(), &events); err != nil {
+		return nil, err
+	}
+
+	return &events, nil
+}
+
+// emptyOrValue matches the value, an empty value also matches documents written without the field
+func emptyOrValue(value string) any {
+	if value == "" {
+		return bson.M{"$in": bson.A{nil, ""}}
+	}
+	return value
+}
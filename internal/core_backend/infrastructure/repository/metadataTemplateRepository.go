@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// MetadataTemplateRepository struct
+type MetadataTemplateRepository struct {
+	dbMongo *mongo.Database
+}
+
+// NewMetadataTemplateRepository create repository
+func NewMetadataTemplateRepository(dbMongo *mongo.Database) *MetadataTemplateRepository {
+	return &MetadataTemplateRepository{dbMongo: dbMongo}
+}
+
+// GetTemplateByCollectionID - get the metadata template of a collection, nil if the collection has none
+func (r *MetadataTemplateRepository) GetTemplateByCollectionID(collectionID *string) (*entity.MetadataTemplate, error) {
+	cID, err := primitive.ObjectIDFromHex(*collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var template entity.MetadataTemplate
+	err = r.dbMongo.Collection(template.CollectionName()).FindOne(context.TODO(), bson.D{{Key: "collection_id", Value: cID}}).Decode(&template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// UpsertTemplate - create or replace the metadata template of a collection
+func (r *MetadataTemplateRepository) UpsertTemplate(template *entity.MetadataTemplate) (*entity.MetadataTemplate, error) {
+	var (
+		filter = bson.D{{Key: "collection_id", Value: template.CollectionID}}
+		update = bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "name", Value: template.Name},
+				{Key: "description", Value: template.Description},
+				{Key: "image", Value: template.Image},
+				{Key: "animation_url", Value: template.AnimationURL},
+				{Key: "external_url", Value: template.ExternalURL},
+				{Key: "attributes", Value: template.Attributes},
+				{Key: "status", Value: template.Status},
+				{Key: "updated_at", Value: template.UpdatedAt},
+			}},
+			{Key: "$setOnInsert", Value: bson.D{
+				{Key: "created_at", Value: template.CreatedAt},
+			}},
+		}
+		opts = options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	)
+
+	var updated entity.MetadataTemplate
+	err := r.dbMongo.Collection(template.CollectionName()).FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
@@ -1,12 +1,16 @@
 package repository
 
 import (
+	constant "backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // NFTRepository struct
@@ -19,17 +23,18 @@ func NewNFTRepository(dbMongo *mongo.Database) *NFTRepository {
 	return &NFTRepository{dbMongo: dbMongo}
 }
 
-func (r *NFTRepository) GetLastSyncBlock() (int64, error) {
+func (r *NFTRepository) GetLastSyncBlock(chainID int64) (int64, error) {
 	var variable entity.Variable
-	err := r.dbMongo.Collection(entity.Variable{}.CollectionName()).FindOne(context.TODO(), bson.M{}).Decode(&variable)
+	filter := bson.D{{Key: "_id", Value: entity.SyncBlockVariableID(chainID)}}
+	err := r.dbMongo.Collection(entity.Variable{}.CollectionName()).FindOne(context.TODO(), filter).Decode(&variable)
 	if err != nil {
 		return 0, err
 	}
 	return variable.LastSyncBlockNumber, nil
 }
 
-func (r *NFTRepository) UpdateLastSyncBlock(blockNumber uint64) (bool, error) {
-	filter := bson.D{{Key: "_id", Value: "blockchain"}}
+func (r *NFTRepository) UpdateLastSyncBlock(chainID int64, blockNumber uint64) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: entity.SyncBlockVariableID(chainID)}}
 	update := bson.D{
 		{Key: "$set", Value: bson.D{
 			{Key: "last_sync_block_number", Value: blockNumber},
@@ -38,17 +43,18 @@ func (r *NFTRepository) UpdateLastSyncBlock(blockNumber uint64) (bool, error) {
 	result, err := r.dbMongo.Collection(entity.Variable{}.CollectionName()).UpdateOne(
 		context.TODO(),
 		&filter,
-		&update)
+		&update,
+		options.Update().SetUpsert(true))
 
 	if err != nil {
 		return false, err
 	}
 
-	return result.MatchedCount != 0, nil
+	return result.MatchedCount+result.UpsertedCount != 0, nil
 }
 
-func (r *NFTRepository) GetListContractAddresses() (*[]common.Address, error) {
-	cursor, err := r.dbMongo.Collection(entity.DigitalAssetCollection{}.CollectionName()).Find(context.TODO(), bson.M{})
+func (r *NFTRepository) GetListContractAddresses(chainID int64) (*[]common.Address, error) {
+	cursor, err := r.dbMongo.Collection(entity.DigitalAssetCollection{}.CollectionName()).Find(context.TODO(), bson.M{"chain_id": chainID})
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +72,26 @@ func (r *NFTRepository) GetListContractAddresses() (*[]common.Address, error) {
 	return &addresses, nil
 }
 
+// GetCollectionChainIDs - distinct chain IDs collections are deployed on
+func (r *NFTRepository) GetCollectionChainIDs() ([]int64, error) {
+	values, err := r.dbMongo.Collection(entity.DigitalAssetCollection{}.CollectionName()).Distinct(context.TODO(), "chain_id", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var chainIDs []int64
+	for _, value := range values {
+		switch v := value.(type) {
+		case int32:
+			chainIDs = append(chainIDs, int64(v))
+		case int64:
+			chainIDs = append(chainIDs, v)
+		case float64:
+			chainIDs = append(chainIDs, int64(v))
+		}
+	}
+	return chainIDs, nil
+}
+
 func (r *NFTRepository) UpdateMintedDigitalAssets(txHash *string, status *string, tokenID int64) (bool, error) {
 	filter := bson.D{{Key: "tx_hash", Value: *txHash}}
 	update := bson.D{
@@ -85,3 +111,119 @@ func (r *NFTRepository) UpdateMintedDigitalAssets(txHash *string, status *string
 
 	return result.MatchedCount != 0, nil
 }
+
+func (r *NFTRepository) CreateContractDeployment(deployment *entity.ContractDeployment) (*entity.ContractDeployment, error) {
+	deployment.SetTime()
+	result, err := r.dbMongo.Collection(deployment.CollectionName()).InsertOne(context.TODO(), deployment)
+	if err != nil {
+		return nil, err
+	}
+	deployment.ID = result.InsertedID.(primitive.ObjectID)
+
+	return deployment, nil
+}
+
+// UpdateContractDeploymentStatus - a failed deployment releases its organization for another deployment
+func (r *NFTRepository) UpdateContractDeploymentStatus(deploymentID primitive.ObjectID, status string, errMessage string) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: deploymentID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "status", Value: status},
+			{Key: "error", Value: errMessage},
+			{Key: "updated_at", Value: time.Now()},
+		}}}
+	if status == constant.StatusTxFailure {
+		update = append(update, bson.E{Key: "$unset", Value: bson.D{{Key: "reserved_org_id", Value: ""}}})
+	}
+
+	result, err := r.dbMongo.Collection(entity.ContractDeployment{}.CollectionName()).UpdateOne(context.TODO(), &filter, &update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+// UpdateContractDeploymentTx - records the transaction sent for the deployment
+func (r *NFTRepository) UpdateContractDeploymentTx(deployment *entity.ContractDeployment) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: deployment.ID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "deployer_address", Value: deployment.DeployerAddress},
+			{Key: "contract_address", Value: deployment.ContractAddress},
+			{Key: "tx_hash", Value: deployment.TxHash},
+			{Key: "updated_at", Value: time.Now()},
+		}}}
+
+	result, err := r.dbMongo.Collection(entity.ContractDeployment{}.CollectionName()).UpdateOne(context.TODO(), &filter, &update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+func (r *NFTRepository) GetContractDeploymentByID(deploymentID *string) (*entity.ContractDeployment, error) {
+	id, err := primitive.ObjectIDFromHex(*deploymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployment entity.ContractDeployment
+	err = r.dbMongo.Collection(entity.ContractDeployment{}.CollectionName()).FindOne(context.TODO(), bson.M{"_id": id}).Decode(&deployment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+// GetPendingContractDeployments - deployments whose transaction was sent but not yet confirmed
+func (r *NFTRepository) GetPendingContractDeployments() (*[]entity.ContractDeployment, error) {
+	cursor, err := r.dbMongo.Collection(entity.ContractDeployment{}.CollectionName()).Find(context.TODO(), bson.M{"status": constant.StatusTxPending})
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []entity.ContractDeployment
+	if err = cursor.All(context.TODO(), &deployments); err != nil {
+		return nil, err
+	}
+
+	return &deployments, nil
+}
+
+// CreateCollection - inserts the collection with the ID reserved by its deployment, ignoring duplicates so a confirmation can be replayed
+func (r *NFTRepository) CreateCollection(collection *entity.DigitalAssetCollection) (bool, error) {
+	collection.SetTime()
+	_, err := r.dbMongo.Collection(collection.CollectionName()).InsertOne(context.TODO(), collection)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// EnsureCollectionIndexes - an organization has a single collection, and a single deployment pending or succeeded.
+// Legacy collections without an organization are left out.
+func (r *NFTRepository) EnsureCollectionIndexes() error {
+	_, err := r.dbMongo.Collection(entity.DigitalAssetCollection{}.CollectionName()).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "org_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"org_id": bson.M{"$gt": primitive.NilObjectID}}),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.dbMongo.Collection(entity.ContractDeployment{}.CollectionName()).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "reserved_org_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"reserved_org_id": bson.M{"$exists": true}}),
+	})
+
+	return err
+}
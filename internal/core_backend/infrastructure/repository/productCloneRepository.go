@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// GetTemplateToClone - the template if the scope can read it, shared templates included; nil when there is none
+func (r *ProductRepository) GetTemplateToClone(templateID primitive.ObjectID, scope *entity.AccessScope) (*entity.Template, error) {
+	filter := sharedScopeFilter(bson.M{"_id": templateID}, "org_id", scope)
+	var template entity.Template
+	err := r.dbMongo.Collection(template.CollectionName()).FindOne(context.TODO(), filter).Decode(&template)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// GetWebPagesToClone - the webpages the scope can read among pageIDs, shared webpages included
+func (r *ProductRepository) GetWebPagesToClone(pageIDs []primitive.ObjectID, scope *entity.AccessScope) (*[]entity.WebPage, error) {
+	filter := sharedScopeFilter(bson.M{"_id": bson.M{"$in": pageIDs}}, "org_id", scope)
+	cursor, err := r.dbMongo.Collection(entity.WebPage{}.CollectionName()).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := []entity.WebPage{}
+	if err = cursor.All(context.TODO(), &pages); err != nil {
+		return nil, err
+	}
+
+	return &pages, nil
+}
+
+// InsertProductClone - inserts all the documents of the clone in a transaction, none is inserted when one fails
+func (r *ProductRepository) InsertProductClone(clone *entity.ProductClone) error {
+	session, err := r.dbMongo.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.TODO())
+
+	_, err = session.WithTransaction(context.TODO(), func(ctx mongo.SessionContext) (any, error) {
+		if len(clone.WebPages) > 0 {
+			pages := make([]any, 0, len(clone.WebPages))
+			for i := range clone.WebPages {
+				pages = append(pages, clone.WebPages[i])
+			}
+			if _, err := r.dbMongo.Collection(entity.WebPage{}.CollectionName()).InsertMany(ctx, pages); err != nil {
+				return nil, err
+			}
+		}
+		if clone.Template != nil {
+			if _, err := r.dbMongo.Collection(clone.Template.CollectionName()).InsertOne(ctx, clone.Template); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := r.dbMongo.Collection(clone.Product.CollectionName()).InsertOne(ctx, clone.Product); err != nil {
+			return nil, err
+		}
+		if _, err := r.dbMongo.Collection(clone.Version.CollectionName()).InsertOne(ctx, clone.Version); err != nil {
+			return nil, err
+		}
+		if len(clone.Items) > 0 {
+			items := make([]any, 0, len(clone.Items))
+			for i := range clone.Items {
+				items = append(items, clone.Items[i])
+			}
+			if _, err := r.dbMongo.Collection(entity.ProductItem{}.CollectionName()).InsertMany(ctx, items); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+
+	return err
+}
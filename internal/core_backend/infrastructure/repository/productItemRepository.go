@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -98,51 +99,6 @@ func (r *ProductItemRepository) GetDetailProductItemByID(productItemID *string)
 	return &item, nil
 }
 
-func (r *ProductItemRepository) IsAbleToClaim(productItemID *string) (bool, error) {
-	iID, err := primitive.ObjectIDFromHex(*productItemID)
-	if err != nil {
-		return false, err
-	}
-
-	var mapping entity.Mapping
-	err = r.dbMongo.Collection(mapping.CollectionName()).FindOne(context.TODO(), bson.M{"product_item_id": iID}).Decode(&mapping)
-	if err != nil {
-		return false, err
-	}
-
-	return mapping.Claimable, nil
-}
-
-func (r *ProductItemRepository) SetOwner(productItemID, ownerID *string) (bool, error) {
-	iID, err := primitive.ObjectIDFromHex(*productItemID)
-	if err != nil {
-		return false, err
-	}
-
-	if err != nil {
-		return false, err
-	}
-
-	isUpsert := true
-	option := options.UpdateOptions{Upsert: &isUpsert}
-	filter := bson.D{{Key: "product_item_id", Value: iID}}
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "owner_id", Value: *ownerID},
-			{Key: "claimable", Value: false},
-		}}}
-	result, err := r.dbMongo.Collection(entity.Mapping{}.CollectionName()).UpdateOne(
-		context.TODO(),
-		&filter,
-		&update,
-		&option)
-	if err != nil {
-		return false, err
-	}
-
-	return result.ModifiedCount+result.UpsertedCount+result.MatchedCount != 0, nil
-}
-
 func (r *ProductItemRepository) GetDetailWithTagID(tagID *string) (*entity.ProductItem, error) {
 	var mapping entity.Mapping
 	err := r.dbMongo.Collection(mapping.CollectionName()).FindOne(context.TODO(), bson.M{"tag_id": tagID}).Decode(&mapping)
@@ -229,60 +185,33 @@ func (r *ProductItemRepository) GetOrganizationNameByProductItemID(productItemID
 	return org.OrganizationName, nil
 }
 
-func (r *ProductItemRepository) UpdateTotalLike(productItemID *string) (bool, error) {
-	piID, err := primitive.ObjectIDFromHex(*productItemID)
-	if err != nil {
-		return false, err
-	}
-	filter := bson.D{{Key: "_id", Value: piID}}
-	update := bson.D{
-		{Key: "$inc", Value: bson.D{
-			{Key: "total_like", Value: 1},
-		}}}
-	result, err := r.dbMongo.Collection(entity.ProductItem{}.CollectionName()).UpdateOne(
-		context.TODO(),
-		filter,
-		update,
-	)
-	if err != nil {
-		return false, err
-	}
+// EnsureLikeIndexes - creates the unique index that lets a user like an item once
+func (r *ProductItemRepository) EnsureLikeIndexes() error {
+	_, err := r.dbMongo.Collection(entity.ProductItemLike{}.CollectionName()).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "product_item_id", Value: 1}, {Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
 
-	return result.ModifiedCount+result.UpsertedCount+result.MatchedCount != 0, nil
+	return err
 }
 
-func (r *ProductItemRepository) ToggleClaimable(productItemID *string) (bool, error) {
-	iID, err := primitive.ObjectIDFromHex(*productItemID)
-	if err != nil {
-		return false, err
-	}
-
-	var mapping entity.Mapping
-
-	filter := bson.D{{Key: "product_item_id", Value: iID}}
-	err = r.dbMongo.Collection(mapping.CollectionName()).FindOne(context.TODO(), filter).Decode(&mapping)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
+// LikeProductItem - records the like of the user and counts it, returns false when the user already liked the item
+func (r *ProductItemRepository) LikeProductItem(productItemID primitive.ObjectID, userID string) (bool, error) {
+	like := entity.ProductItemLike{ProductItemID: productItemID, UserID: userID, CreatedAt: time.Now()}
+	if _, err := r.dbMongo.Collection(like.CollectionName()).InsertOne(context.TODO(), like); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
 			return false, nil
 		}
-
 		return false, err
 	}
 
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "claimable", Value: !mapping.Claimable},
-		}}}
-
-	resultUpdate, err := r.dbMongo.Collection(mapping.CollectionName()).UpdateOne(
-		context.TODO(),
-		&filter,
-		&update)
-	if err != nil {
+	filter := bson.M{"_id": productItemID}
+	update := bson.M{"$inc": bson.M{"total_like": 1}}
+	if _, err := r.dbMongo.Collection(entity.ProductItem{}.CollectionName()).UpdateOne(context.TODO(), filter, update); err != nil {
 		return false, err
 	}
 
-	return resultUpdate.ModifiedCount+resultUpdate.UpsertedCount+resultUpdate.MatchedCount != 0, nil
+	return true, nil
 }
 
 func (r *ProductItemRepository) CountNumProductItems(productID *string) (int, error) {
@@ -393,3 +322,38 @@ func (r *ProductItemRepository) GetProductItemProductOrgAggregate(pItemID *strin
 	}
 	return &aggregations[0], nil
 }
+
+// GetMetadataSource - the product item with its product, organization and author, nil when the item or its product does not exist
+func (r *ProductItemRepository) GetMetadataSource(productItemID primitive.ObjectID) (*entity.MetadataSource, error) {
+	lookup := func(from, localField, as string) bson.D {
+		return bson.D{{Key: "$lookup", Value: bson.M{"from": from, "localField": localField, "foreignField": "_id", "as": as}}}
+	}
+	unwind := func(path string, preserve bool) bson.D {
+		return bson.D{{Key: "$unwind", Value: bson.M{"path": path, "preserveNullAndEmptyArrays": preserve}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": productItemID}}},
+		{{Key: "$replaceWith", Value: bson.M{"product_item": "$$ROOT"}}},
+		lookup(entity.Product{}.CollectionName(), "product_item.product_id", "product"),
+		unwind("$product", false),
+		lookup(entity.Organization{}.CollectionName(), "product.org_id", "organization"),
+		unwind("$organization", true),
+		lookup(entity.Author{}.CollectionName(), "product.author_id", "author"),
+		unwind("$author", true),
+	}
+	cursor, err := r.dbMongo.Collection(entity.ProductItem{}.CollectionName()).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []entity.MetadataSource
+	if err = cursor.All(context.TODO(), &sources); err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	sources[0].Product.ParseAttribute()
+
+	return &sources[0], nil
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-	"log"
+	"time"
 
 	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
@@ -22,12 +22,34 @@ func NewProductRepository(dbMongo *mongo.Database) *ProductRepository {
 	return &ProductRepository{dbMongo: dbMongo}
 }
 
-func (r *ProductRepository) CreateProduct(product *entity.Product) (*entity.Product, error) {
-	result, err := r.dbMongo.Collection(product.CollectionName()).InsertOne(context.TODO(), &product)
+// CreateProduct - inserts the product and its first version in a transaction, none is inserted when one fails
+func (r *ProductRepository) CreateProduct(product *entity.Product, version *entity.ProductVersion) (*entity.Product, error) {
+	session, err := r.dbMongo.Client().StartSession()
 	if err != nil {
 		return nil, err
 	}
-	product.ID = result.InsertedID.(primitive.ObjectID)
+	defer session.EndSession(context.TODO())
+
+	_, err = session.WithTransaction(context.TODO(), func(ctx mongo.SessionContext) (any, error) {
+		result, err := r.dbMongo.Collection(product.CollectionName()).InsertOne(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+		product.ID = result.InsertedID.(primitive.ObjectID)
+
+		version.ProductID = product.ID
+		version.Content.ID = product.ID
+		result, err = r.dbMongo.Collection(version.CollectionName()).InsertOne(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+		version.ID = result.InsertedID.(primitive.ObjectID)
+		return nil, nil
+	})
+	if err != nil {
+		product.ID = primitive.NilObjectID
+		return nil, err
+	}
 
 	return product, nil
 }
@@ -50,36 +72,18 @@ func (r *ProductRepository) CheckExistedProduct(product *entity.Product) (bool,
 	return count != 0, nil
 }
 
-func (r *ProductRepository) GetAllProducts() (*[]entity.Product, error) {
-	cursor, err := r.dbMongo.Collection(entity.Product{}.CollectionName()).Find(context.TODO(), bson.D{{Key: "status", Value: common.StatusActive}})
-	if err != nil {
-		return nil, err
-	}
-
-	var products []entity.Product
-	err = cursor.All(context.TODO(), &products)
-	if err != nil {
-		return nil, err
-	}
-
-	return &products, nil
-}
-
-func (r *ProductRepository) GetAllProductsInOrg(orgID *primitive.ObjectID) (*[]entity.Product, error) {
-	filter := bson.D{
-		{Key: "$and",
-			Value: bson.A{
-				bson.D{{Key: "status", Value: common.StatusActive}},
-				bson.D{{Key: "org_id", Value: *orgID}},
-			},
-		},
+// GetProducts active products of the organizations in scope, only of orgID when given
+func (r *ProductRepository) GetProducts(scope *entity.AccessScope, orgID *primitive.ObjectID) (*[]entity.Product, error) {
+	filter := scopeFilter(bson.M{"status": common.StatusActive}, "org_id", scope)
+	if orgID != nil {
+		filter["$and"] = bson.A{bson.M{"org_id": *orgID}}
 	}
 	cursor, err := r.dbMongo.Collection(entity.Product{}.CollectionName()).Find(context.TODO(), filter)
 	if err != nil {
 		return nil, err
 	}
 
-	var products []entity.Product
+	products := []entity.Product{}
 	if err = cursor.All(context.TODO(), &products); err != nil {
 		return nil, err
 	}
@@ -103,38 +107,43 @@ func (r *ProductRepository) GetProductByID(productID *string) (*entity.Product,
 	return &product, nil
 }
 
-// UpdateProductDetail
-func (r *ProductRepository) UpdateProductDetail(product *entity.Product, productID *string) (bool, error) {
-	log.Println(product)
+// GetProductInScope returns nil when the product does not exist or belongs to an organization out of scope
+func (r *ProductRepository) GetProductInScope(productID *string, scope *entity.AccessScope) (*entity.Product, error) {
 	pID, err := primitive.ObjectIDFromHex(*productID)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	var newProduct entity.Product
-	err = r.dbMongo.Collection(product.CollectionName()).FindOneAndReplace(context.TODO(), bson.D{{Key: "_id", Value: pID}}, product).Decode(&newProduct)
+	var product entity.Product
+	filter := scopeFilter(bson.M{"_id": pID}, "org_id", scope)
+	err = r.dbMongo.Collection(product.CollectionName()).FindOne(context.TODO(), filter).Decode(&product)
 	if err != nil {
-		return false, err
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
 	}
+	product.ParseAttribute()
 
-	return newProduct.ID == product.ID, nil
+	return &product, nil
 }
 
-func (r *ProductRepository) SoftDeleteProductByID(productID *string) (bool, error) {
+func (r *ProductRepository) SoftDeleteProductByID(productID *string, scope *entity.AccessScope) (bool, error) {
 	oID, err := primitive.ObjectIDFromHex(*productID)
 	if err != nil {
 		return false, err
 	}
 
-	filter := bson.D{{Key: "_id", Value: oID}}
+	filter := scopeFilter(bson.M{"_id": oID}, "org_id", scope)
 	update := bson.D{
 		{Key: "$set", Value: bson.D{
 			{Key: "status", Value: common.StatusInactive},
+			{Key: "updated_at", Value: time.Now()},
 		}}}
 
 	result, err := r.dbMongo.Collection(entity.Product{}.CollectionName()).UpdateOne(
 		context.TODO(),
-		&filter,
+		filter,
 		&update)
 
 	if err != nil {
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	mongopkg "backend-service/pkg/common/mongo"
+	"backend-service/pkg/common/pagination"
+)
+
+// scoreField the text score of the products matching the search query
+const scoreField = "score"
+
+// searchedProduct a product of the catalog with its text score
+type searchedProduct struct {
+	entity.Product `bson:",inline"`
+	Score          float64 `bson:"score"`
+}
+
+// productCursor the position of a product in the catalog, only the field the catalog is ordered by is set
+type productCursor struct {
+	ID          primitive.ObjectID `json:"id"`
+	CreatedAt   *time.Time         `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time         `json:"updated_at,omitempty"`
+	ProductName *string            `json:"product_name,omitempty"`
+	TotalItem   *int               `json:"total_item,omitempty"`
+	Score       *float64           `json:"score,omitempty"`
+}
+
+func newProductCursor(product *searchedProduct, orderBy string) productCursor {
+	cursor := productCursor{ID: product.ID}
+	switch orderBy {
+	case "created_at":
+		cursor.CreatedAt = &product.CreatedAt
+	case "updated_at":
+		cursor.UpdatedAt = &product.UpdatedAt
+	case "product_name":
+		cursor.ProductName = &product.ProductName
+	case "total_item":
+		cursor.TotalItem = &product.TotalItem
+	case scoreField:
+		cursor.Score = &product.Score
+	}
+
+	return cursor
+}
+
+// position the position of the cursor in the catalog ordered by orderBy, nil when it was made for another order
+func (c productCursor) position(orderBy string) *mongopkg.CursorPosition {
+	var value any
+	switch {
+	case orderBy == "created_at" && c.CreatedAt != nil:
+		value = *c.CreatedAt
+	case orderBy == "updated_at" && c.UpdatedAt != nil:
+		value = *c.UpdatedAt
+	case orderBy == "product_name" && c.ProductName != nil:
+		value = *c.ProductName
+	case orderBy == "total_item" && c.TotalItem != nil:
+		value = *c.TotalItem
+	case orderBy == scoreField && c.Score != nil:
+		value = *c.Score
+	default:
+		return nil
+	}
+
+	return &mongopkg.CursorPosition{Value: value, ID: c.ID}
+}
+
+// SearchProducts - a page of the products of the organizations in scope matching the filter, and how many match.
+// A page starts after the cursor of the pagination, or at its page when there is no cursor.
+// Products with the same value of the order are ordered by id, the next cursor of a page skipped to may miss some of them.
+// An invalid cursor is reported as pagination.ErrorInvalidLenCursor.
+func (r *ProductRepository) SearchProducts(scope *entity.AccessScope, filter *entity.ProductFilter, paging *pagination.Pagination) (*entity.ProductPage, error) {
+	match := bson.M{}
+	if filter.Query != "" {
+		match["$text"] = bson.M{"$search": filter.Query}
+	}
+	if filter.Type != "" {
+		match["type"] = filter.Type
+	}
+	if !filter.AuthorID.IsZero() {
+		match["author_id"] = filter.AuthorID
+	}
+	if filter.Status != "" {
+		match["status"] = filter.Status
+	}
+	match = scopeFilter(match, "org_id", scope)
+	if !filter.OrganizationID.IsZero() {
+		match["$and"] = bson.A{bson.M{"org_id": filter.OrganizationID}}
+	}
+
+	// Products ordered by relevance are sorted by their text score
+	order := *paging
+	if order.OrderBy == common.ProductOrderByRelevance {
+		order.OrderBy = scoreField
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	if filter.Query != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{scoreField: bson.M{"$meta": "textScore"}}}})
+	}
+	// Pages after the first are read by cursor unless a page is asked for without one
+	byCursor := paging.Cursor != "" || paging.Page <= 1
+	if byCursor {
+		var position *mongopkg.CursorPosition
+		if paging.Cursor != "" {
+			var cursor productCursor
+			if err := pagination.DecodeCursor(paging.Cursor, &cursor); err != nil {
+				return nil, err
+			}
+			if position = cursor.position(order.OrderBy); position == nil {
+				return nil, pagination.ErrorInvalidLenCursor
+			}
+		}
+		pipeline = append(pipeline, mongopkg.BuildCursorPaginationPipeline(&order, position)...)
+	} else {
+		pipeline = append(pipeline, mongopkg.BuildPagePaginationPipeline(&order)...)
+	}
+
+	collection := r.dbMongo.Collection(entity.Product{}.CollectionName())
+	cursor, err := collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var found []searchedProduct
+	if err = cursor.All(context.TODO(), &found); err != nil {
+		return nil, err
+	}
+	total, err := collection.CountDocuments(context.TODO(), match)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cursor pipeline returns one more product than the limit when there is a next page
+	hasNext := int64(len(found)) > paging.Limit
+	if !byCursor {
+		hasNext = paging.Page*paging.Limit < total
+	}
+	if int64(len(found)) > paging.Limit {
+		found = found[:paging.Limit]
+	}
+
+	page := &entity.ProductPage{Products: make([]entity.Product, 0, len(found)), Total: total}
+	for i := range found {
+		page.Products = append(page.Products, *found[i].ParseAttribute())
+	}
+	if hasNext && len(found) > 0 {
+		page.NextCursor, err = pagination.EncodeCursor(newProductCursor(&found[len(found)-1], order.OrderBy))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// EnsureProductIndexes - creates the indexes of the product catalog search, indexes that exist already are kept
+func (r *ProductRepository) EnsureProductIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "product_name", Value: "text"}, {Key: "origin", Value: "text"}, {Key: "tags", Value: "text"}},
+			Options: options.Index().SetName("product_search").SetWeights(bson.M{"product_name": 10, "tags": 5, "origin": 1}).SetDefaultLanguage("none"),
+		},
+		{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "status", Value: 1}, {Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "status", Value: 1}, {Key: "product_name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+	}
+	_, err := r.dbMongo.Collection(entity.Product{}.CollectionName()).Indexes().CreateMany(context.TODO(), indexes)
+
+	return err
+}
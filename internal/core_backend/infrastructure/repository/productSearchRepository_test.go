@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/pagination"
+)
+
+func TestProductCursorRoundTrip(t *testing.T) {
+	product := &searchedProduct{
+		Product: entity.Product{
+			BaseModel:   entity.BaseModel{ID: primitive.NewObjectID(), CreatedAt: time.Date(2026, 10, 19, 8, 30, 0, 123000000, time.UTC)},
+			ProductName: "Arabica",
+			TotalItem:   12,
+		},
+		Score: 1.5,
+	}
+	wants := map[string]any{
+		"created_at":   product.CreatedAt,
+		"product_name": "Arabica",
+		"total_item":   12,
+		scoreField:     1.5,
+	}
+
+	for orderBy, want := range wants {
+		encoded, err := pagination.EncodeCursor(newProductCursor(product, orderBy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cursor productCursor
+		if err = pagination.DecodeCursor(encoded, &cursor); err != nil {
+			t.Fatal(err)
+		}
+		position := cursor.position(orderBy)
+		if position == nil || position.Value != want || position.ID != product.ID {
+			t.Errorf("%s: got %+v", orderBy, position)
+		}
+		if other := cursor.position("updated_at"); other != nil {
+			t.Errorf("%s: a cursor of another order gave %+v", orderBy, other)
+		}
+	}
+
+	if err := pagination.DecodeCursor("not a cursor", &productCursor{}); err != pagination.ErrorInvalidLenCursor {
+		t.Errorf("invalid cursor: got %v", err)
+	}
+}
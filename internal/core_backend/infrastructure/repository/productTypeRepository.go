@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// ProductTypeRepository struct
+type ProductTypeRepository struct {
+	dbMongo *mongo.Database
+}
+
+// NewProductTypeRepository create repository
+func NewProductTypeRepository(dbMongo *mongo.Database) *ProductTypeRepository {
+	return &ProductTypeRepository{dbMongo: dbMongo}
+}
+
+func (r *ProductTypeRepository) CreateProductType(productType *entity.ProductType) (*entity.ProductType, error) {
+	result, err := r.dbMongo.Collection(productType.CollectionName()).InsertOne(context.TODO(), productType)
+	if err != nil {
+		return nil, err
+	}
+	productType.ID = result.InsertedID.(primitive.ObjectID)
+
+	return productType, nil
+}
+
+// GetProductType - the type of the name, nil when there is none
+func (r *ProductTypeRepository) GetProductType(name *string) (*entity.ProductType, error) {
+	var productType entity.ProductType
+	err := r.dbMongo.Collection(productType.CollectionName()).FindOne(context.TODO(), bson.M{"name": *name}).Decode(&productType)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &productType, nil
+}
+
+// GetProductTypes - every type, by name
+func (r *ProductTypeRepository) GetProductTypes() (*[]entity.ProductType, error) {
+	option := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.dbMongo.Collection(entity.ProductType{}.CollectionName()).Find(context.TODO(), bson.M{}, option)
+	if err != nil {
+		return nil, err
+	}
+
+	productTypes := []entity.ProductType{}
+	if err = cursor.All(context.TODO(), &productTypes); err != nil {
+		return nil, err
+	}
+
+	return &productTypes, nil
+}
+
+// UpdateProductType - replaces the type when it is still at the previous version, so concurrent schema changes do not overwrite each other
+func (r *ProductTypeRepository) UpdateProductType(productType *entity.ProductType, previousVersion int) (bool, error) {
+	filter := bson.M{"_id": productType.ID, "version": previousVersion}
+	result, err := r.dbMongo.Collection(productType.CollectionName()).ReplaceOne(context.TODO(), filter, productType)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+// DeleteProductType - deletes the type and its schema versions
+func (r *ProductTypeRepository) DeleteProductType(name *string) (bool, error) {
+	result, err := r.dbMongo.Collection(entity.ProductType{}.CollectionName()).DeleteOne(context.TODO(), bson.M{"name": *name})
+	if err != nil {
+		return false, err
+	}
+	_, err = r.dbMongo.Collection(entity.ProductTypeSchema{}.CollectionName()).DeleteMany(context.TODO(), bson.M{"type_name": *name})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount != 0, nil
+}
+
+func (r *ProductTypeRepository) CreateProductTypeSchema(schema *entity.ProductTypeSchema) (*entity.ProductTypeSchema, error) {
+	result, err := r.dbMongo.Collection(schema.CollectionName()).InsertOne(context.TODO(), schema)
+	if err != nil {
+		return nil, err
+	}
+	schema.ID = result.InsertedID.(primitive.ObjectID)
+
+	return schema, nil
+}
+
+// GetProductTypeSchemas - the schema versions of the type, newest first
+func (r *ProductTypeRepository) GetProductTypeSchemas(name *string) (*[]entity.ProductTypeSchema, error) {
+	option := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := r.dbMongo.Collection(entity.ProductTypeSchema{}.CollectionName()).Find(context.TODO(), bson.M{"type_name": *name}, option)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := []entity.ProductTypeSchema{}
+	if err = cursor.All(context.TODO(), &schemas); err != nil {
+		return nil, err
+	}
+
+	return &schemas, nil
+}
+
+// CountProductsOfType - the products of the type, deleted ones included
+func (r *ProductTypeRepository) CountProductsOfType(name *string) (int64, error) {
+	return r.dbMongo.Collection(entity.Product{}.CollectionName()).CountDocuments(context.TODO(), bson.M{"type": *name})
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// SaveProductDraft - replaces the draft of the product, creating it when there is none
+func (r *ProductRepository) SaveProductDraft(draft *entity.ProductVersion) (*entity.ProductVersion, error) {
+	filter := bson.M{"product_id": draft.ProductID, "version": entity.DraftVersion}
+	var saved entity.ProductVersion
+	option := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+	err := r.dbMongo.Collection(draft.CollectionName()).FindOneAndReplace(context.TODO(), filter, draft, option).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+	saved.Content.ParseAttribute()
+
+	return &saved, nil
+}
+
+// DeleteProductDraft - false when the product has no draft
+func (r *ProductRepository) DeleteProductDraft(productID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"product_id": productID, "version": entity.DraftVersion}
+	result, err := r.dbMongo.Collection(entity.ProductVersion{}.CollectionName()).DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount != 0, nil
+}
+
+// GetProductVersion - the version of the product, the draft for entity.DraftVersion, nil when there is none
+func (r *ProductRepository) GetProductVersion(productID primitive.ObjectID, version int) (*entity.ProductVersion, error) {
+	var productVersion entity.ProductVersion
+	filter := bson.M{"product_id": productID, "version": version}
+	err := r.dbMongo.Collection(productVersion.CollectionName()).FindOne(context.TODO(), filter).Decode(&productVersion)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	productVersion.Content.ParseAttribute()
+
+	return &productVersion, nil
+}
+
+// GetProductVersions - the published versions of the product, newest first
+func (r *ProductRepository) GetProductVersions(productID primitive.ObjectID) (*[]entity.ProductVersion, error) {
+	filter := bson.M{"product_id": productID, "version": bson.M{"$gt": entity.DraftVersion}}
+	option := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := r.dbMongo.Collection(entity.ProductVersion{}.CollectionName()).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []entity.ProductVersion{}
+	if err = cursor.All(context.TODO(), &versions); err != nil {
+		return nil, err
+	}
+	for i := range versions {
+		versions[i].Content.ParseAttribute()
+	}
+
+	return &versions, nil
+}
+
+// errProductVersionMoved aborts the publication when the live product is no longer at the previous version
+var errProductVersionMoved = errors.New("the live product is no longer at the previous version")
+
+// PublishProductVersion - records the version and replaces the live product in a transaction, when it is still at the previous version.
+// False and nothing recorded when it is not; the unique index on product_id and version makes concurrent publications fail.
+// Products created before versioning have no published_version and count as version 0.
+func (r *ProductRepository) PublishProductVersion(product *entity.Product, previousVersion int, version *entity.ProductVersion) (bool, error) {
+	filter := bson.M{"_id": product.ID, "published_version": previousVersion}
+	if previousVersion == 0 {
+		filter["published_version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	session, err := r.dbMongo.Client().StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(context.TODO())
+
+	_, err = session.WithTransaction(context.TODO(), func(ctx mongo.SessionContext) (any, error) {
+		result, err := r.dbMongo.Collection(version.CollectionName()).InsertOne(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+		version.ID = result.InsertedID.(primitive.ObjectID)
+
+		replaced, err := r.dbMongo.Collection(product.CollectionName()).ReplaceOne(ctx, filter, product)
+		if err != nil {
+			return nil, err
+		}
+		if replaced.MatchedCount == 0 {
+			return nil, errProductVersionMoved
+		}
+		return nil, nil
+	})
+	if errors.Is(err, errProductVersionMoved) {
+		version.ID = primitive.NilObjectID
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// PubsubMessageRepository struct
+type PubsubMessageRepository struct {
+	dbMongo *mongo.Database
+}
+
+// NewPubsubMessageRepository create repository
+func NewPubsubMessageRepository(dbMongo *mongo.Database) *PubsubMessageRepository {
+	return &PubsubMessageRepository{dbMongo: dbMongo}
+}
+
+// CreatePubsubMessage - false when a message with the same ID was already received
+func (r *PubsubMessageRepository) CreatePubsubMessage(msg *entity.PubsubMessage) (bool, error) {
+	_, err := r.dbMongo.Collection(msg.CollectionName()).InsertOne(context.TODO(), msg)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *PubsubMessageRepository) DeletePubsubMessage(messageID *string) (bool, error) {
+	result, err := r.dbMongo.Collection(entity.PubsubMessage{}.CollectionName()).DeleteOne(context.TODO(), bson.M{"_id": *messageID})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount != 0, nil
+}
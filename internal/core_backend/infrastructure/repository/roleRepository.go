@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// RoleRepository struct
+type RoleRepository struct {
+	dbMongo *mongo.Database
+}
+
+// NewRoleRepository create repository
+func NewRoleRepository(dbMongo *mongo.Database) *RoleRepository {
+	return &RoleRepository{dbMongo: dbMongo}
+}
+
+func (r *RoleRepository) GetRoleBindingsByUserID(userID *string) (*[]entity.RoleBinding, error) {
+	cursor, err := r.dbMongo.Collection(entity.RoleBinding{}.CollectionName()).Find(context.TODO(), bson.M{"user_id": *userID})
+	if err != nil {
+		return nil, err
+	}
+
+	var bindings []entity.RoleBinding
+	if err = cursor.All(context.TODO(), &bindings); err != nil {
+		return nil, err
+	}
+
+	return &bindings, nil
+}
+
+// GetRolesByOrgIDs - custom roles defined for the organizations and the custom roles available to all of them.
+// Every custom role is returned when orgIDs is nil.
+func (r *RoleRepository) GetRolesByOrgIDs(orgIDs []primitive.ObjectID) (*[]entity.Role, error) {
+	filter := bson.M{}
+	if orgIDs != nil {
+		filter = bson.M{"org_id": bson.M{"$in": append(orgIDs, primitive.NilObjectID)}}
+	}
+	cursor, err := r.dbMongo.Collection(entity.Role{}.CollectionName()).Find(context.TODO(), filter, options.Find().SetSort(bson.D{{Key: "role_name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []entity.Role
+	if err = cursor.All(context.TODO(), &roles); err != nil {
+		return nil, err
+	}
+
+	return &roles, nil
+}
+
+func (r *RoleRepository) GetRoleByID(roleID *string) (*entity.Role, error) {
+	id, err := primitive.ObjectIDFromHex(*roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var role entity.Role
+	err = r.dbMongo.Collection(role.CollectionName()).FindOne(context.TODO(), bson.M{"_id": id}).Decode(&role)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// GetRoleByName - the custom role of the organization with this name, or the one available to all organizations
+func (r *RoleRepository) GetRoleByName(orgID primitive.ObjectID, roleName *string) (*entity.Role, error) {
+	filter := bson.M{"role_name": *roleName, "org_id": bson.M{"$in": []primitive.ObjectID{orgID, primitive.NilObjectID}}}
+	option := options.FindOne().SetSort(bson.D{{Key: "org_id", Value: -1}})
+
+	var role entity.Role
+	err := r.dbMongo.Collection(role.CollectionName()).FindOne(context.TODO(), filter, option).Decode(&role)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (r *RoleRepository) CreateRole(role *entity.Role) (*entity.Role, error) {
+	role.SetTime()
+	result, err := r.dbMongo.Collection(role.CollectionName()).InsertOne(context.TODO(), role)
+	if err != nil {
+		return nil, err
+	}
+	role.ID = result.InsertedID.(primitive.ObjectID)
+
+	return role, nil
+}
+
+func (r *RoleRepository) UpdateRolePermissions(role *entity.Role) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: role.ID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "description", Value: role.Description},
+			{Key: "permissions", Value: role.Permissions},
+			{Key: "updated_at", Value: time.Now()},
+		}}}
+	result, err := r.dbMongo.Collection(role.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+// UpsertRoleBinding - grants the role once, granting it again is a no-op
+func (r *RoleRepository) UpsertRoleBinding(binding *entity.RoleBinding) (*entity.RoleBinding, error) {
+	now := time.Now()
+	filter := bson.D{
+		{Key: "user_id", Value: binding.UserID},
+		{Key: "org_id", Value: binding.OrganizationID},
+		{Key: "role_name", Value: binding.RoleName},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "updated_at", Value: now}}},
+		{Key: "$setOnInsert", Value: bson.D{{Key: "created_at", Value: now}}},
+	}
+	option := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var upserted entity.RoleBinding
+	err := r.dbMongo.Collection(binding.CollectionName()).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&upserted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upserted, nil
+}
+
+func (r *RoleRepository) GetRoleBindingByID(bindingID *string) (*entity.RoleBinding, error) {
+	id, err := primitive.ObjectIDFromHex(*bindingID)
+	if err != nil {
+		return nil, err
+	}
+
+	var binding entity.RoleBinding
+	err = r.dbMongo.Collection(binding.CollectionName()).FindOne(context.TODO(), bson.M{"_id": id}).Decode(&binding)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &binding, nil
+}
+
+func (r *RoleRepository) DeleteRoleBinding(bindingID primitive.ObjectID) (bool, error) {
+	result, err := r.dbMongo.Collection(entity.RoleBinding{}.CollectionName()).DeleteOne(context.TODO(), bson.M{"_id": bindingID})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount != 0, nil
+}
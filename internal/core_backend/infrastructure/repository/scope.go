@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// scopeFilter restricts filter to the documents whose field is an organization of the access scope.
+// A nil or empty scope matches no document, so a query can never run unscoped by mistake.
+func scopeFilter(filter bson.M, field string, scope *entity.AccessScope) bson.M {
+	if scope != nil && scope.AllOrganizations {
+		return filter
+	}
+	filter[field] = bson.M{"$in": scopeOrganizationIDs(scope)}
+
+	return filter
+}
+
+// sharedScopeFilter is scopeFilter that also matches the documents owned by no organization,
+// which every admin may read but only admins of all organizations may change
+func sharedScopeFilter(filter bson.M, field string, scope *entity.AccessScope) bson.M {
+	if scope != nil && scope.AllOrganizations {
+		return filter
+	}
+	filter["$or"] = bson.A{
+		bson.M{field: bson.M{"$in": scopeOrganizationIDs(scope)}},
+		bson.M{field: bson.M{"$exists": false}},
+		bson.M{field: primitive.NilObjectID},
+	}
+
+	return filter
+}
+
+func scopeOrganizationIDs(scope *entity.AccessScope) []primitive.ObjectID {
+	if scope == nil || scope.OrganizationIDs == nil {
+		return []primitive.ObjectID{}
+	}
+
+	return scope.OrganizationIDs
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+func TestScopeFilter(t *testing.T) {
+	orgA, orgB := primitive.NewObjectID(), primitive.NewObjectID()
+	id := primitive.NewObjectID()
+
+	tests := []struct {
+		name  string
+		scope *entity.AccessScope
+		want  bson.M
+	}{
+		{
+			name:  "all organizations adds no filter",
+			scope: &entity.AccessScope{AllOrganizations: true},
+			want:  bson.M{"_id": id},
+		},
+		{
+			name:  "organizations of the scope",
+			scope: &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgA, orgB}},
+			want:  bson.M{"_id": id, "org_id": bson.M{"$in": []primitive.ObjectID{orgA, orgB}}},
+		},
+		{
+			name:  "empty scope matches nothing",
+			scope: &entity.AccessScope{},
+			want:  bson.M{"_id": id, "org_id": bson.M{"$in": []primitive.ObjectID{}}},
+		},
+		{
+			name:  "nil scope matches nothing",
+			scope: nil,
+			want:  bson.M{"_id": id, "org_id": bson.M{"$in": []primitive.ObjectID{}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scopeFilter(bson.M{"_id": id}, "org_id", tt.scope)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scopeFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharedScopeFilter(t *testing.T) {
+	orgA := primitive.NewObjectID()
+
+	got := sharedScopeFilter(bson.M{}, "org_id", &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgA}})
+	want := bson.M{"$or": bson.A{
+		bson.M{"org_id": bson.M{"$in": []primitive.ObjectID{orgA}}},
+		bson.M{"org_id": bson.M{"$exists": false}},
+		bson.M{"org_id": primitive.NilObjectID},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sharedScopeFilter() = %v, want %v", got, want)
+	}
+
+	if got := sharedScopeFilter(bson.M{}, "org_id", &entity.AccessScope{AllOrganizations: true}); len(got) != 0 {
+		t.Errorf("sharedScopeFilter() with all organizations = %v, want no filter", got)
+	}
+}
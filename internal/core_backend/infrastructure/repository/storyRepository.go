@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// StoryRepository struct
+type StoryRepository struct {
+	dbMongo *mongo.Database
+}
+
+// NewStoryRepository create repository
+func NewStoryRepository(dbMongo *mongo.Database) *StoryRepository {
+	return &StoryRepository{dbMongo: dbMongo}
+}
+
+// EnsureStoryIndexes - creates the indexes of the story artifacts, queueing merges on the unique product_item_id
+func (r *StoryRepository) EnsureStoryIndexes() error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "product_item_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "stale", Value: 1}, {Key: "updated_at", Value: 1}}},
+	}
+	_, err := r.dbMongo.Collection(entity.StoryArtifact{}.CollectionName()).Indexes().CreateMany(context.TODO(), indexes)
+
+	return err
+}
+
+// QueueChangedStories marks stale the stories of the product items whose product, template, webpages, author,
+// ownership or item changed after since, or whose claim window ended since, returns the number of stories queued
+func (r *StoryRepository) QueueChangedStories(since time.Time) (int64, error) {
+	now := time.Now()
+	changed := bson.M{"updated_at": bson.M{"$gt": since}}
+
+	templateIDs, err := r.distinct(entity.Template{}.CollectionName(), "_id", changed)
+	if err != nil {
+		return 0, err
+	}
+	pageIDs, err := r.distinct(entity.WebPage{}.CollectionName(), "_id", changed)
+	if err != nil {
+		return 0, err
+	}
+	if len(pageIDs) > 0 {
+		usingPages, err := r.distinct(entity.Template{}.CollectionName(), "_id", bson.M{"$or": bson.A{
+			bson.M{"pages.page_id": bson.M{"$in": pageIDs}},
+			bson.M{"menu.page_id": bson.M{"$in": pageIDs}},
+		}})
+		if err != nil {
+			return 0, err
+		}
+		templateIDs = append(templateIDs, usingPages...)
+	}
+	authorIDs, err := r.distinct(entity.Author{}.CollectionName(), "_id", changed)
+	if err != nil {
+		return 0, err
+	}
+	productIDs, err := r.distinct(entity.Product{}.CollectionName(), "_id", bson.M{"$or": bson.A{
+		changed,
+		bson.M{"template_id": bson.M{"$in": templateIDs}},
+		bson.M{"author_id": bson.M{"$in": authorIDs}},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	itemIDs, err := r.distinct(entity.Mapping{}.CollectionName(), "product_item_id", bson.M{"$or": bson.A{
+		changed,
+		bson.M{"claimable_until": bson.M{"$gt": since, "$lte": now}},
+	}})
+	if err != nil {
+		return 0, err
+	}
+
+	return r.queue(now, bson.M{"$or": bson.A{
+		changed,
+		bson.M{"_id": bson.M{"$in": itemIDs}},
+		bson.M{"product_id": bson.M{"$in": productIDs}},
+	}})
+}
+
+// QueueProductStories marks stale the stories of every item of the product, returns the number of stories queued
+func (r *StoryRepository) QueueProductStories(productID primitive.ObjectID) (int64, error) {
+	return r.queue(time.Now(), bson.M{"product_id": productID})
+}
+
+// queue merges the product items matching the filter into the story artifacts as stale at the given time, with their revision bumped
+func (r *StoryRepository) queue(at time.Time, itemFilter bson.M) (int64, error) {
+	artifacts := entity.StoryArtifact{}.CollectionName()
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: itemFilter}},
+		{{Key: "$project", Value: bson.M{
+			"_id":             0,
+			"product_item_id": "$_id",
+			"revision":        bson.M{"$literal": 1},
+			"stale":           bson.M{"$literal": true},
+			"files":           bson.A{},
+			"updated_at":      at,
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into": artifacts,
+			"on":   "product_item_id",
+			"whenMatched": bson.A{bson.M{"$set": bson.M{
+				"stale":      true,
+				"revision":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$revision", 0}}, 1}},
+				"updated_at": at,
+			}}},
+			"whenNotMatched": "insert",
+		}}},
+	}
+	items := r.dbMongo.Collection(entity.ProductItem{}.CollectionName())
+	count, err := items.CountDocuments(context.TODO(), itemFilter)
+	if err != nil || count == 0 {
+		return 0, err
+	}
+	cursor, err := items.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, cursor.Close(context.TODO())
+}
+
+func (r *StoryRepository) distinct(collection, field string, filter bson.M) (bson.A, error) {
+	values, err := r.dbMongo.Collection(collection).Distinct(context.TODO(), field, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.A(values), nil
+}
+
+// GetLastStoryQueuedAt the last time stories were queued, zero when none was
+func (r *StoryRepository) GetLastStoryQueuedAt() (time.Time, error) {
+	var artifact entity.StoryArtifact
+	opts := options.FindOne().SetSort(bson.D{{Key: "updated_at", Value: -1}}).SetProjection(bson.M{"updated_at": 1})
+	err := r.dbMongo.Collection(artifact.CollectionName()).FindOne(context.TODO(), bson.M{}, opts).Decode(&artifact)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return time.Time{}, err
+	}
+
+	return artifact.UpdatedAt, nil
+}
+
+// GetStaleStories the stale story artifacts queued first, at most limit
+func (r *StoryRepository) GetStaleStories(limit int) (*[]entity.StoryArtifact, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := r.dbMongo.Collection(entity.StoryArtifact{}.CollectionName()).Find(context.TODO(), bson.M{"stale": true}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := []entity.StoryArtifact{}
+	if err = cursor.All(context.TODO(), &artifacts); err != nil {
+		return nil, err
+	}
+
+	return &artifacts, nil
+}
+
+// SaveStoryArtifact writes the build of the artifact unless the item was queued again meanwhile, returns whether it was written
+func (r *StoryRepository) SaveStoryArtifact(artifact *entity.StoryArtifact) (bool, error) {
+	filter := bson.M{"product_item_id": artifact.ProductItemID, "revision": artifact.Revision}
+	update := bson.M{"$set": bson.M{
+		"stale":       false,
+		"status":      artifact.Status,
+		"error":       artifact.Error,
+		"files":       artifact.Files,
+		"rendered_at": artifact.RenderedAt,
+	}}
+	result, err := r.dbMongo.Collection(artifact.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+// GetStoryArtifact the story artifact of the product item, nil when the item was never queued
+func (r *StoryRepository) GetStoryArtifact(productItemID primitive.ObjectID) (*entity.StoryArtifact, error) {
+	var artifact entity.StoryArtifact
+	err := r.dbMongo.Collection(artifact.CollectionName()).FindOne(context.TODO(), bson.M{"product_item_id": productItemID}).Decode(&artifact)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &artifact, nil
+}
+
+// GetStoryOwnership the claim state of the product item and the name of its owner
+func (r *StoryRepository) GetStoryOwnership(productItemID primitive.ObjectID) (*entity.StoryOwnership, error) {
+	var mapping entity.Mapping
+	err := r.dbMongo.Collection(mapping.CollectionName()).FindOne(context.TODO(), bson.M{"product_item_id": productItemID}).Decode(&mapping)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &entity.StoryOwnership{}, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	ownership := &entity.StoryOwnership{Claimed: mapping.OwnerID != "", Claimable: mapping.IsClaimableAt(now), ClaimableUntil: mapping.ClaimableUntil}
+	if !ownership.Claimed {
+		return ownership, nil
+	}
+	var owner entity.User
+	err = r.dbMongo.Collection(owner.CollectionName()).FindOne(context.TODO(), bson.M{"_id": mapping.OwnerID}, options.FindOne().SetProjection(bson.M{"full_name": 1})).Decode(&owner)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	ownership.OwnerName = owner.Name
+
+	return ownership, nil
+}
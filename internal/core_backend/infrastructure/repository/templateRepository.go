@@ -29,21 +29,22 @@ func (r *TemplateRepository) CreateTemplate(template *entity.Template) (*entity.
 	return template, nil
 }
 
-func (r *TemplateRepository) UpdateTemplate(template *entity.Template) error {
+// UpdateTemplate only updates a template owned by an organization in scope
+func (r *TemplateRepository) UpdateTemplate(template *entity.Template, scope *entity.AccessScope) (bool, error) {
 	update := bson.M{
 		"$set": template,
 	}
 
-	filter := bson.M{
+	filter := scopeFilter(bson.M{
 		"_id": template.ID,
-	}
+	}, "org_id", scope)
 
-	_, err := r.dbMongo.Collection(template.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	result, err := r.dbMongo.Collection(template.CollectionName()).UpdateOne(context.TODO(), filter, update)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return result.MatchedCount != 0, nil
 }
 
 func (r *TemplateRepository) GetTemplate(ID *string) (*entity.Template, error) {
@@ -63,6 +64,24 @@ func (r *TemplateRepository) GetTemplate(ID *string) (*entity.Template, error) {
 	return &template, nil
 }
 
+// GetTemplateInScope returns nil when the template is neither shared nor owned by an organization in scope
+func (r *TemplateRepository) GetTemplateInScope(ID *string, scope *entity.AccessScope) (*entity.Template, error) {
+	templateID, err := primitive.ObjectIDFromHex(*ID)
+	if err != nil {
+		return nil, err
+	}
+	filter := sharedScopeFilter(bson.M{"_id": templateID}, "org_id", scope)
+	var template entity.Template
+	err = r.dbMongo.Collection(template.CollectionName()).FindOne(context.TODO(), filter).Decode(&template)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
 func (r *TemplateRepository) GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, error) {
 	templateID, err := primitive.ObjectIDFromHex(*tID)
 	if err != nil {
@@ -209,8 +228,9 @@ func (r *TemplateRepository) CheckExistedTemplate(templateID *string) (bool, err
 	return count != 0, nil
 }
 
-func (r *TemplateRepository) GetAllTemplates() (*[]entity.Template, error) {
-	filter := bson.M{} // Empty filter to retrieve all documents
+// GetAllTemplates the shared templates and the templates of the organizations in scope
+func (r *TemplateRepository) GetAllTemplates(scope *entity.AccessScope) (*[]entity.Template, error) {
+	filter := sharedScopeFilter(bson.M{}, "org_id", scope)
 
 	cursor, err := r.dbMongo.Collection(entity.Template{}.CollectionName()).Find(context.TODO(), filter)
 	if err != nil {
@@ -233,3 +253,82 @@ func (r *TemplateRepository) GetAllTemplates() (*[]entity.Template, error) {
 
 	return &templates, nil
 }
+
+// CountWebPagesInScope counts the pages among pageIDs that are shared or owned by an organization in scope
+func (r *TemplateRepository) CountWebPagesInScope(pageIDs []primitive.ObjectID, scope *entity.AccessScope) (int64, error) {
+	filter := sharedScopeFilter(bson.M{"_id": bson.M{"$in": pageIDs}}, "org_id", scope)
+	return r.dbMongo.Collection(entity.WebPage{}.CollectionName()).CountDocuments(context.TODO(), filter)
+}
+
+// CountWebPagesOfType counts the pages among pageIDs of the given type
+func (r *TemplateRepository) CountWebPagesOfType(pageIDs []primitive.ObjectID, pageType string) (int64, error) {
+	filter := bson.M{"_id": bson.M{"$in": pageIDs}, "type": pageType}
+	return r.dbMongo.Collection(entity.WebPage{}.CollectionName()).CountDocuments(context.TODO(), filter)
+}
+
+// GetDanglingReferences the pages and menu entries of templates targeting webpages that no longer exist,
+// and the products whose template no longer exists, among the documents the scope can read
+func (r *TemplateRepository) GetDanglingReferences(scope *entity.AccessScope) (*[]entity.DanglingReference, error) {
+	templateColl := entity.Template{}.CollectionName()
+	targets := func(field string) bson.M {
+		return bson.M{"$map": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$" + field, bson.A{}}},
+			"as":    "target",
+			"in":    bson.M{"field": field, "id": "$$target.page_id"},
+		}}
+	}
+	templatePipeline := mongo.Pipeline{
+		{{Key: "$match", Value: sharedScopeFilter(bson.M{}, "org_id", scope)}},
+		{{Key: "$project", Value: bson.M{"org_id": 1, "targets": bson.M{"$concatArrays": bson.A{targets("pages"), targets("menu")}}}}},
+		{{Key: "$unwind", Value: "$targets"}},
+		{{Key: "$lookup", Value: bson.M{"from": entity.WebPage{}.CollectionName(), "localField": "targets.id", "foreignField": "_id", "as": "found"}}},
+		{{Key: "$match", Value: bson.M{"found": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"collection":  bson.M{"$literal": templateColl},
+			"document_id": "$_id",
+			"org_id":      1,
+			"field":       "$targets.field",
+			"missing_id":  "$targets.id",
+		}}},
+	}
+	references, err := r.aggregateDanglingReferences(templateColl, templatePipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	productColl := entity.Product{}.CollectionName()
+	productPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: scopeFilter(bson.M{"template_id": bson.M{"$exists": true, "$ne": primitive.NilObjectID}}, "org_id", scope)}},
+		{{Key: "$lookup", Value: bson.M{"from": templateColl, "localField": "template_id", "foreignField": "_id", "as": "found"}}},
+		{{Key: "$match", Value: bson.M{"found": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"collection":  bson.M{"$literal": productColl},
+			"document_id": "$_id",
+			"org_id":      1,
+			"field":       bson.M{"$literal": "template_id"},
+			"missing_id":  "$template_id",
+		}}},
+	}
+	products, err := r.aggregateDanglingReferences(productColl, productPipeline)
+	if err != nil {
+		return nil, err
+	}
+	references = append(references, products...)
+
+	return &references, nil
+}
+
+func (r *TemplateRepository) aggregateDanglingReferences(collection string, pipeline mongo.Pipeline) ([]entity.DanglingReference, error) {
+	cursor, err := r.dbMongo.Collection(collection).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	references := []entity.DanglingReference{}
+	if err = cursor.All(context.TODO(), &references); err != nil {
+		return nil, err
+	}
+
+	return references, nil
+}
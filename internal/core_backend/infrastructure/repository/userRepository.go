@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"regexp"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"backend-service/internal/core_backend/api/handler/request"
+	constant "backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -88,16 +91,6 @@ func (r *UserRepository) UpsertUser(user *entity.User) error {
 	return nil
 }
 
-func (r *UserRepository) UpdateRole(role *string, userID *string) (bool, error) {
-	filter := bson.M{"_id": *userID}
-	update := bson.D{{Key: "$set", Value: bson.D{{Key: "role", Value: *role}}}}
-	result, err := r.dbMongo.Collection(entity.User{}.CollectionName()).UpdateOne(context.Background(), &filter, &update)
-	if err != nil {
-		return false, err
-	}
-	return (result.MatchedCount + result.ModifiedCount) != 0, nil
-}
-
 func (r *UserRepository) UpdateOrgID(orgID *primitive.ObjectID, userID *string) (bool, error) {
 	filter := bson.M{"_id": *userID}
 	update := bson.M{"$set": bson.M{"organization_id": *orgID}}
@@ -120,6 +113,25 @@ func (r *UserRepository) UpdateUserDetails(userID *string, req *request.UpdateUs
 	return true, nil
 }
 
+// SetWalletAddress - sets the wallet address of a user who has none yet
+func (r *UserRepository) SetWalletAddress(userID *string, walletAddress *string) (bool, error) {
+	filter := bson.M{
+		"_id": *userID,
+		"$or": []bson.M{
+			{"wallet_address": bson.M{"$exists": false}},
+			{"wallet_address": ""},
+			{"wallet_address": nil},
+		},
+	}
+	update := bson.M{"$set": bson.M{"wallet_address": *walletAddress, "updated_at": time.Now()}}
+	result, err := r.dbMongo.Collection(entity.User{}.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount != 0, nil
+}
+
 func (r *UserRepository) GetUserWithNoWallet() (*[]entity.User, error) {
 	filter := bson.M{
 		"$or": []bson.M{
@@ -141,3 +153,596 @@ func (r *UserRepository) GetUserWithNoWallet() (*[]entity.User, error) {
 
 	return &users, nil
 }
+
+func (r *UserRepository) CreateSiweNonce(nonce *entity.SiweNonce) error {
+	_, err := r.dbMongo.Collection(nonce.CollectionName()).InsertOne(context.TODO(), nonce)
+
+	return err
+}
+
+// ConsumeSiweNonce - deletes the nonce issued to the user so it cannot be used twice
+func (r *UserRepository) ConsumeSiweNonce(userID *string, nonce *string) (*entity.SiweNonce, error) {
+	filter := bson.M{"_id": *nonce, "user_id": *userID}
+	var siweNonce entity.SiweNonce
+	err := r.dbMongo.Collection(siweNonce.CollectionName()).FindOneAndDelete(context.TODO(), filter).Decode(&siweNonce)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &siweNonce, nil
+}
+
+// EnsureWalletIndexes - creates the unique index that links a wallet to a single user, users without wallets are not indexed,
+// and the TTL index removing the SIWE nonces once expired
+func (r *UserRepository) EnsureWalletIndexes() error {
+	_, err := r.dbMongo.Collection(entity.User{}.CollectionName()).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "linked_wallets.address", Value: 1}},
+		Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"linked_wallets.address": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.dbMongo.Collection(entity.SiweNonce{}.CollectionName()).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+
+	return err
+}
+
+func (r *UserRepository) GetUserByLinkedWallet(address *string) (*entity.User, error) {
+	var user entity.User
+	err := r.dbMongo.Collection(user.CollectionName()).FindOne(context.TODO(), bson.M{"linked_wallets.address": *address}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) AddLinkedWallet(userID *string, wallet *entity.LinkedWallet) (bool, error) {
+	filter := bson.M{"_id": *userID, "linked_wallets.address": bson.M{"$ne": wallet.Address}}
+	update := bson.M{
+		"$push": bson.M{"linked_wallets": *wallet},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	result, err := r.dbMongo.Collection(entity.User{}.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount != 0, nil
+}
+
+// RemoveLinkedWallet - unlinks the address and stops minting to it
+func (r *UserRepository) RemoveLinkedWallet(userID *string, address *string) (bool, error) {
+	collection := r.dbMongo.Collection(entity.User{}.CollectionName())
+	filter := bson.M{"_id": *userID}
+	update := bson.M{
+		"$pull": bson.M{"linked_wallets": bson.M{"address": *address}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	result, err := collection.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	filter = bson.M{"_id": *userID, "mint_wallet_address": *address}
+	if _, err = collection.UpdateOne(context.TODO(), filter, bson.M{"$unset": bson.M{"mint_wallet_address": ""}}); err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount != 0, nil
+}
+
+func (r *UserRepository) SetMintWalletAddress(userID *string, address *string) (bool, error) {
+	filter := bson.M{"_id": *userID}
+	update := bson.M{"$set": bson.M{"mint_wallet_address": *address, "updated_at": time.Now()}}
+	result, err := r.dbMongo.Collection(entity.User{}.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+// GetClaimedItems - the items the user owns, with their product and minted token
+func (r *UserRepository) GetClaimedItems(userID *string) (*[]entity.ClaimedItem, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"owner_id": *userID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.ProductItem{}.CollectionName(),
+			"localField":   "product_item_id",
+			"foreignField": "_id",
+			"as":           "product_item",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$product_item", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.Product{}.CollectionName(),
+			"localField":   "product_item.product_id",
+			"foreignField": "_id",
+			"as":           "product",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$product", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.DigitalAsset{}.CollectionName(),
+			"localField":   "digital_asset_id",
+			"foreignField": "_id",
+			"as":           "digital_asset",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$digital_asset", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$project", Value: bson.M{
+			"tag_id":          1,
+			"org_id":          1,
+			"product_item_id": 1,
+			"is_minted":       1,
+			"item_index":      "$product_item.item_index",
+			"product_id":      "$product._id",
+			"product_name":    "$product.product_name",
+			"collection_id":   "$digital_asset.collection_id",
+			"token_id":        "$digital_asset.token_id",
+			"tx_hash":         "$digital_asset.tx_hash",
+		}}},
+	}
+	cursor, err := r.dbMongo.Collection(entity.Mapping{}.CollectionName()).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []entity.ClaimedItem{}
+	if err = cursor.All(context.TODO(), &items); err != nil {
+		return nil, err
+	}
+
+	return &items, nil
+}
+
+// GetCollection - a page of the items the user claimed, last claimed first, with their product, organization and token.
+// Items claimed before the claim time was recorded are sorted by their last change.
+func (r *UserRepository) GetCollection(userID *string, paging *constant.Paging) (*[]entity.CollectionItem, int64, error) {
+	itemStages := bson.A{
+		bson.M{"$skip": int64((paging.Page - 1) * paging.Limit)},
+		bson.M{"$limit": int64(paging.Limit)},
+	}
+	for _, stage := range collectionItemStages() {
+		itemStages = append(itemStages, stage)
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"owner_id": *userID}}},
+		collectionClaimedAtStage(),
+		{{Key: "$sort", Value: bson.D{{Key: "claimed_at", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"items": itemStages,
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	}
+	cursor, err := r.dbMongo.Collection(entity.Mapping{}.CollectionName()).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []struct {
+		Items []entity.CollectionItem `bson:"items"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		return nil, 0, err
+	}
+
+	items := []entity.CollectionItem{}
+	var total int64
+	if len(result) > 0 {
+		items = append(items, result[0].Items...)
+		if len(result[0].Total) > 0 {
+			total = result[0].Total[0].Count
+		}
+	}
+
+	return &items, total, nil
+}
+
+// GetCollectionItem - the item of the user's collection, nil when the user does not own it
+func (r *UserRepository) GetCollectionItem(userID *string, productItemID primitive.ObjectID) (*entity.CollectionItem, error) {
+	pipeline := append(mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"owner_id": *userID, "product_item_id": productItemID}}},
+		{{Key: "$limit", Value: 1}},
+		collectionClaimedAtStage(),
+	}, collectionItemStages()...)
+	cursor, err := r.dbMongo.Collection(entity.Mapping{}.CollectionName()).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []entity.CollectionItem{}
+	if err = cursor.All(context.TODO(), &items); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	return &items[0], nil
+}
+
+// collectionClaimedAtStage - sets the claim time of mappings, their last change when it was not recorded
+func collectionClaimedAtStage() bson.D {
+	return bson.D{{Key: "$set", Value: bson.M{"claimed_at": bson.M{"$ifNull": bson.A{"$claimed_at", "$updated_at"}}}}}
+}
+
+// collectionItemStages - the stages turning mappings into collection items
+func collectionItemStages() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.ProductItem{}.CollectionName(),
+			"localField":   "product_item_id",
+			"foreignField": "_id",
+			"as":           "product_item",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$product_item", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.Product{}.CollectionName(),
+			"localField":   "product_item.product_id",
+			"foreignField": "_id",
+			"as":           "product",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$product", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.Template{}.CollectionName(),
+			"localField":   "product.template_id",
+			"foreignField": "_id",
+			"as":           "template",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$template", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.Organization{}.CollectionName(),
+			"localField":   "org_id",
+			"foreignField": "_id",
+			"as":           "organization",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$organization", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.DigitalAsset{}.CollectionName(),
+			"localField":   "digital_asset_id",
+			"foreignField": "_id",
+			"as":           "nft",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$nft", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$project", Value: bson.M{
+			"tag_id":          1,
+			"product_item_id": 1,
+			"item_index":      "$product_item.item_index",
+			"total_like":      "$product_item.total_like",
+			"claimed_at":      1,
+			"product": bson.M{
+				"_id":          "$product._id",
+				"product_name": "$product.product_name",
+				"type":         "$product.type",
+				"image":        "$product.image",
+			},
+			"organization": bson.M{
+				"_id":          "$organization._id",
+				"org_name":     "$organization.org_name",
+				"org_tag_name": "$organization.org_tag_name",
+				"org_logo_url": "$organization.org_logo_url",
+			},
+			"story_language": bson.M{"$arrayElemAt": bson.A{"$template.languages", 0}},
+			"nft":            1,
+		}}},
+	}
+}
+
+func (r *UserRepository) GetUserRoleBindings(userID *string) (*[]entity.RoleBinding, error) {
+	cursor, err := r.dbMongo.Collection(entity.RoleBinding{}.CollectionName()).Find(context.TODO(), bson.M{"user_id": *userID})
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := []entity.RoleBinding{}
+	if err = cursor.All(context.TODO(), &bindings); err != nil {
+		return nil, err
+	}
+
+	return &bindings, nil
+}
+
+func (r *UserRepository) GetUserWalletProvisionings(userID *string) (*[]entity.WalletProvisioning, error) {
+	cursor, err := r.dbMongo.Collection(entity.WalletProvisioning{}.CollectionName()).Find(context.TODO(), bson.M{"user_id": *userID})
+	if err != nil {
+		return nil, err
+	}
+
+	provisionings := []entity.WalletProvisioning{}
+	if err = cursor.All(context.TODO(), &provisionings); err != nil {
+		return nil, err
+	}
+
+	return &provisionings, nil
+}
+
+// GetUserLikes - the product items the user liked
+func (r *UserRepository) GetUserLikes(userID *string) (*[]entity.ProductItemLike, error) {
+	option := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.dbMongo.Collection(entity.ProductItemLike{}.CollectionName()).Find(context.TODO(), bson.M{"user_id": *userID}, option)
+	if err != nil {
+		return nil, err
+	}
+
+	likes := []entity.ProductItemLike{}
+	if err = cursor.All(context.TODO(), &likes); err != nil {
+		return nil, err
+	}
+
+	return &likes, nil
+}
+
+// GetUserClaimEvents - the claim events of the items the user owned, owns or acted on, oldest first
+func (r *UserRepository) GetUserClaimEvents(userID *string) (*[]entity.ClaimEvent, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"owner_id": *userID},
+		bson.M{"previous_owner_id": *userID},
+		bson.M{"actor_id": *userID},
+	}}
+	option := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.dbMongo.Collection(entity.ClaimEvent{}.CollectionName()).Find(context.TODO(), filter, option)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []entity.ClaimEvent{}
+	if err = cursor.All(context.TODO(), &events); err != nil {
+		return nil, err
+	}
+
+	return &events, nil
+}
+
+func (r *UserRepository) CountOwnedOrganizations(userID *string) (int64, error) {
+	return r.dbMongo.Collection(entity.Organization{}.CollectionName()).CountDocuments(context.TODO(), bson.M{"owner_id": *userID})
+}
+
+// ReleaseClaims - the items the user claimed in the organization can be claimed again, locked items stay locked
+func (r *UserRepository) ReleaseClaims(userID *string, orgID primitive.ObjectID) (int64, error) {
+	return r.handOverClaims(*userID, orgID, "")
+}
+
+// ReassignClaims - the items the user claimed in the organization are owned by ownerID
+func (r *UserRepository) ReassignClaims(userID *string, orgID primitive.ObjectID, ownerID *string) (int64, error) {
+	return r.handOverClaims(*userID, orgID, *ownerID)
+}
+
+// handOverClaims - the items the user owns in the organization are owned by ownerID, or released when it is empty.
+// A claim event of the deleted account is recorded for each item.
+func (r *UserRepository) handOverClaims(userID string, orgID primitive.ObjectID, ownerID string) (int64, error) {
+	collection := r.dbMongo.Collection(entity.Mapping{}.CollectionName())
+	cursor, err := collection.Find(context.TODO(), bson.M{"owner_id": userID, "org_id": orgID})
+	if err != nil {
+		return 0, err
+	}
+	mappings := []entity.Mapping{}
+	if err = cursor.All(context.TODO(), &mappings); err != nil {
+		return 0, err
+	}
+	if len(mappings) == 0 {
+		return 0, nil
+	}
+
+	session, err := r.dbMongo.Client().StartSession()
+	if err != nil {
+		return 0, err
+	}
+	defer session.EndSession(context.TODO())
+
+	now := time.Now()
+	handed, err := session.WithTransaction(context.TODO(), func(ctx mongo.SessionContext) (any, error) {
+		var handed int64
+		for _, mapping := range mappings {
+			from := mapping.ClaimStateAt(now)
+			to := from
+			if ownerID == "" && from != entity.ClaimStateLocked {
+				to = entity.ClaimStateClaimable
+			}
+			filter := bson.M{"_id": mapping.ID, "owner_id": userID}
+			update := bson.M{"$set": bson.M{"owner_id": ownerID, "claim_state": to, "claimable_until": nil, "claimed_at": claimedAt(ownerID, now), "updated_at": now}}
+			result, err := collection.UpdateOne(ctx, filter, update)
+			if err != nil {
+				return int64(0), err
+			}
+			if result.MatchedCount == 0 {
+				continue
+			}
+			event := &entity.ClaimEvent{
+				ProductItemID:   mapping.ProductItemID,
+				OrganizationID:  orgID,
+				FromState:       from,
+				ToState:         to,
+				PreviousOwnerID: userID,
+				OwnerID:         ownerID,
+				ReasonCode:      constant.ClaimReasonAccountDeleted,
+				ActorID:         userID,
+				CreatedAt:       now,
+			}
+			if _, err = r.dbMongo.Collection(event.CollectionName()).InsertOne(ctx, event); err != nil {
+				return int64(0), err
+			}
+			handed++
+		}
+		return handed, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return handed.(int64), nil
+}
+
+// AnonymizeUser - erases the personal data of the user, the document stays so references to the ID resolve
+func (r *UserRepository) AnonymizeUser(userID *string, at time.Time) (bool, error) {
+	filter := bson.M{"_id": *userID}
+	update := bson.M{
+		"$set": bson.M{
+			"email":          "",
+			"emailverified":  false,
+			"full_name":      "",
+			"picture":        "",
+			"organization":   "",
+			"org_id":         primitive.NilObjectID,
+			"role":           "",
+			"wallet_address": "",
+			"status":         constant.StatusDeleted,
+			"deleted_at":     at,
+			"updated_at":     at,
+		},
+		"$unset": bson.M{"linked_wallets": "", "mint_wallet_address": "", "firebase": ""},
+	}
+	result, err := r.dbMongo.Collection(entity.User{}.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+// DeleteUserRecords - deletes the role bindings, SIWE nonces, wallet provisioning records and likes of the user.
+// The like counts of the items are kept, they hold no personal data.
+func (r *UserRepository) DeleteUserRecords(userID *string) error {
+	filter := bson.M{"user_id": *userID}
+	for _, collection := range []string{
+		entity.RoleBinding{}.CollectionName(),
+		entity.SiweNonce{}.CollectionName(),
+		entity.WalletProvisioning{}.CollectionName(),
+		entity.ProductItemLike{}.CollectionName(),
+	} {
+		if _, err := r.dbMongo.Collection(collection).DeleteMany(context.TODO(), filter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SearchUsers - a page of the users matching the filter, with their role bindings.
+// When orgIDs is not nil only the users of, or bound to a role in, one of these organizations are searched.
+func (r *UserRepository) SearchUsers(filter *entity.UserFilter, orgIDs []primitive.ObjectID, paging *constant.Paging) (*[]entity.ManagedUser, int64, error) {
+	userMatch := bson.M{}
+	if filter.Email != "" {
+		userMatch["email"] = bson.M{"$regex": regexp.QuoteMeta(filter.Email), "$options": "i"}
+	}
+	if filter.Status != "" {
+		userMatch["status"] = filter.Status
+	}
+	switch filter.WalletStatus {
+	case constant.WalletStatusNone:
+		userMatch["wallet_address"] = bson.M{"$in": bson.A{"", nil}}
+	case constant.WalletStatusCustodial:
+		userMatch["wallet_address"] = bson.M{"$nin": bson.A{"", nil}}
+	case constant.WalletStatusLinked:
+		userMatch["linked_wallets.0"] = bson.M{"$exists": true}
+	}
+
+	bindingMatch := bson.A{}
+	if !filter.OrganizationID.IsZero() {
+		bindingMatch = append(bindingMatch, bson.M{"$or": bson.A{
+			bson.M{"org_id": filter.OrganizationID},
+			bson.M{"role_bindings.org_id": filter.OrganizationID},
+		}})
+	}
+	if orgIDs != nil {
+		bindingMatch = append(bindingMatch, bson.M{"$or": bson.A{
+			bson.M{"org_id": bson.M{"$in": orgIDs}},
+			bson.M{"role_bindings.org_id": bson.M{"$in": orgIDs}},
+		}})
+	}
+	if filter.RoleName != "" {
+		bindingMatch = append(bindingMatch, bson.M{"$or": bson.A{
+			bson.M{"role_bindings.role_name": filter.RoleName},
+			bson.M{"role": filter.RoleName},
+		}})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: userMatch}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         entity.RoleBinding{}.CollectionName(),
+			"localField":   "_id",
+			"foreignField": "user_id",
+			"as":           "role_bindings",
+		}}},
+	}
+	if len(bindingMatch) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$and": bindingMatch}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"users": bson.A{
+				bson.M{"$skip": int64((paging.Page - 1) * paging.Limit)},
+				bson.M{"$limit": int64(paging.Limit)},
+			},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	)
+	cursor, err := r.dbMongo.Collection(entity.User{}.CollectionName()).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []struct {
+		Users []entity.ManagedUser `bson:"users"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		return nil, 0, err
+	}
+
+	users := []entity.ManagedUser{}
+	var total int64
+	if len(result) > 0 {
+		users = append(users, result[0].Users...)
+		if len(result[0].Total) > 0 {
+			total = result[0].Total[0].Count
+		}
+	}
+
+	return &users, total, nil
+}
+
+// SetUserStatus - activates or deactivates the user
+func (r *UserRepository) SetUserStatus(userID *string, status string) (bool, error) {
+	filter := bson.M{"_id": *userID}
+	update := bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}}
+	result, err := r.dbMongo.Collection(entity.User{}.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
+
+// SetUserClaims - stores the role and organization claims of the user
+func (r *UserRepository) SetUserClaims(user *entity.User) (bool, error) {
+	filter := bson.M{"_id": user.ID}
+	update := bson.M{"$set": bson.M{
+		"role":         user.Role,
+		"organization": user.Organization,
+		"org_id":       user.OrganizationID,
+		"updated_at":   time.Now(),
+	}}
+	result, err := r.dbMongo.Collection(entity.User{}.CollectionName()).UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount != 0, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	constant "backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+)
+
+// testDatabase a database dropped at the end of the test, on the MongoDB given by MONGO_TEST_URI
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := client.Database(fmt.Sprintf("repository_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	return db
+}
+
+func insert(t *testing.T, db *mongo.Database, collection string, documents ...any) {
+	t.Helper()
+	if _, err := db.Collection(collection).InsertMany(context.Background(), documents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetCollection(t *testing.T) {
+	db := testDatabase(t)
+	r := NewUserRepository(db)
+
+	org := entity.Organization{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationName: "Lej", NameTag: "lej"}
+	template := entity.Template{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, Languages: []string{"en", "vi"}}
+	product := entity.Product{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, ProductName: "Arabica", TemplateID: template.ID}
+	asset := entity.DigitalAsset{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, TokenID: 7, TxHash: "0xhash"}
+	insert(t, db, org.CollectionName(), org)
+	insert(t, db, template.CollectionName(), template)
+	insert(t, db, product.CollectionName(), product)
+	insert(t, db, asset.CollectionName(), asset)
+
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 8, 0, 0, 0, time.UTC) }
+	var mappings []any
+	var items []any
+	// tag-1 was created first but claimed last, tag-3 was claimed before claim times were recorded
+	claims := []struct {
+		tag       string
+		createdAt time.Time
+		claimedAt *time.Time
+		updatedAt time.Time
+		owner     string
+	}{
+		{"tag-1", day(1), ptr(day(10)), day(10), "user-1"},
+		{"tag-2", day(2), ptr(day(5)), day(5), "user-1"},
+		{"tag-3", day(3), nil, day(7), "user-1"},
+		{"tag-4", day(4), ptr(day(12)), day(12), "user-2"},
+	}
+	itemIDs := map[string]primitive.ObjectID{}
+	for i, claim := range claims {
+		item := entity.ProductItem{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, ProductID: product.ID, ItemIndex: i + 1, TotalLike: i}
+		itemIDs[claim.tag] = item.ID
+		mapping := entity.Mapping{
+			BaseModel:      entity.BaseModel{ID: primitive.NewObjectID(), CreatedAt: claim.createdAt, UpdatedAt: claim.updatedAt},
+			ProductItemID:  item.ID,
+			TagID:          claim.tag,
+			OrganizationID: org.ID,
+			OwnerID:        claim.owner,
+			ClaimState:     entity.ClaimStateClaimed,
+			ClaimedAt:      claim.claimedAt,
+		}
+		if claim.tag == "tag-1" {
+			mapping.DigitalAssetID = asset.ID
+		}
+		items = append(items, item)
+		mappings = append(mappings, mapping)
+	}
+	insert(t, db, entity.ProductItem{}.CollectionName(), items...)
+	insert(t, db, entity.Mapping{}.CollectionName(), mappings...)
+
+	userID := "user-1"
+	page, total, err := r.GetCollection(&userID, &constant.Paging{Page: 1, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(*page) != 2 || (*page)[0].TagID != "tag-1" || (*page)[1].TagID != "tag-3" {
+		t.Fatalf("got %d items of %d: %+v", len(*page), total, *page)
+	}
+	first := (*page)[0]
+	if !first.ClaimedAt.Equal(day(10)) || first.ProductItemID != itemIDs["tag-1"] || first.ItemIndex != 1 {
+		t.Errorf("first item: %+v", first)
+	}
+	if first.Product.ID != product.ID || first.Product.ProductName != "Arabica" {
+		t.Errorf("product: %+v", first.Product)
+	}
+	if first.Organization.ID != org.ID || first.Organization.NameTag != "lej" || first.Organization.OrganizationName != "Lej" {
+		t.Errorf("organization: %+v", first.Organization)
+	}
+	if first.StoryLanguage != "en" {
+		t.Errorf("story language %q", first.StoryLanguage)
+	}
+	if first.NFT == nil || first.NFT.TokenID != 7 || first.NFT.TxHash != "0xhash" {
+		t.Errorf("token: %+v", first.NFT)
+	}
+	if (*page)[1].NFT != nil || !(*page)[1].ClaimedAt.Equal(day(7)) {
+		t.Errorf("item claimed before claim times were recorded: %+v", (*page)[1])
+	}
+
+	page, total, err = r.GetCollection(&userID, &constant.Paging{Page: 2, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(*page) != 1 || (*page)[0].TagID != "tag-2" {
+		t.Errorf("second page: %d items of %d: %+v", len(*page), total, *page)
+	}
+
+	item, err := r.GetCollectionItem(&userID, itemIDs["tag-2"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item == nil || item.TagID != "tag-2" || !item.ClaimedAt.Equal(day(5)) || item.Organization.NameTag != "lej" {
+		t.Errorf("collection item: %+v", item)
+	}
+	if item, err = r.GetCollectionItem(&userID, itemIDs["tag-4"]); err != nil || item != nil {
+		t.Errorf("item of another user: %+v %v", item, err)
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// WalletRepository struct
+type WalletRepository struct {
+	dbMongo *mongo.Database
+}
+
+// NewWalletRepository create repository
+func NewWalletRepository(dbMongo *mongo.Database) *WalletRepository {
+	return &WalletRepository{dbMongo: dbMongo}
+}
+
+func (r *WalletRepository) GetWalletProvisionings(userIDs []string) (*[]entity.WalletProvisioning, error) {
+	cursor, err := r.dbMongo.Collection(entity.WalletProvisioning{}.CollectionName()).Find(context.TODO(), bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, err
+	}
+
+	var provisionings []entity.WalletProvisioning
+	if err = cursor.All(context.TODO(), &provisionings); err != nil {
+		return nil, err
+	}
+
+	return &provisionings, nil
+}
+
+// RecordWalletProvisioning - stores the outcome of an attempt and counts it
+func (r *WalletRepository) RecordWalletProvisioning(provisioning *entity.WalletProvisioning) (*entity.WalletProvisioning, error) {
+	now := time.Now()
+	filter := bson.D{{Key: "user_id", Value: provisioning.UserID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "email", Value: provisioning.Email},
+			{Key: "status", Value: provisioning.Status},
+			{Key: "wallet_address", Value: provisioning.WalletAddress},
+			{Key: "idempotency_key", Value: provisioning.IdempotencyKey},
+			{Key: "error", Value: provisioning.Error},
+			{Key: "last_attempt_at", Value: now},
+			{Key: "updated_at", Value: now},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: "attempts", Value: 1}}},
+		{Key: "$setOnInsert", Value: bson.D{{Key: "created_at", Value: now}}},
+	}
+	option := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var recorded entity.WalletProvisioning
+	err := r.dbMongo.Collection(entity.WalletProvisioning{}.CollectionName()).FindOneAndUpdate(context.TODO(), filter, update, option).Decode(&recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recorded, nil
+}
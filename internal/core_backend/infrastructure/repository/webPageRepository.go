@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 
@@ -32,9 +33,10 @@ func (r *WebPageRepository) CreateWebPage(page *entity.WebPage) (*entity.WebPage
 	return page, nil
 }
 
-func (r *WebPageRepository) GetAllWebPages() (*[]entity.WebPage, error) {
+// GetAllWebPages the shared pages and the pages of the organizations in scope
+func (r *WebPageRepository) GetAllWebPages(scope *entity.AccessScope) (*[]entity.WebPage, error) {
 
-	cursor, err := r.dbMongo.Collection(WebPageCollectionName).Find(context.TODO(), bson.M{})
+	cursor, err := r.dbMongo.Collection(WebPageCollectionName).Find(context.TODO(), sharedScopeFilter(bson.M{}, "org_id", scope))
 	if err != nil {
 		return nil, err
 	}
@@ -62,29 +64,100 @@ func (r *WebPageRepository) GetWebPage(Id *string) (*entity.WebPage, error) {
 	return &webpage, nil
 }
 
-func (r *WebPageRepository) UpdateWebPage(page *entity.WebPage) (*entity.WebPage, error) {
+// GetWebPageInScope returns nil when the page is neither shared nor owned by an organization in scope
+func (r *WebPageRepository) GetWebPageInScope(Id *string, scope *entity.AccessScope) (*entity.WebPage, error) {
+	pageId, err := primitive.ObjectIDFromHex(*Id)
+	if err != nil {
+		return nil, err
+	}
+	var webpage entity.WebPage
+	filter := sharedScopeFilter(bson.M{"_id": pageId}, "org_id", scope)
+	err = r.dbMongo.Collection(webpage.CollectionName()).FindOne(context.TODO(), filter).Decode(&webpage)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &webpage, nil
+}
+
+// UpdateWebPage only updates a page owned by an organization in scope
+func (r *WebPageRepository) UpdateWebPage(page *entity.WebPage, scope *entity.AccessScope) (bool, error) {
 
-	_, err := r.dbMongo.Collection(page.CollectionName()).UpdateByID(
+	result, err := r.dbMongo.Collection(page.CollectionName()).UpdateOne(
 		context.TODO(),
-		page.ID,
+		scopeFilter(bson.M{"_id": page.ID}, "org_id", scope),
 		bson.M{"$set": &page},
 	)
 
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	return page, nil
+	return result.MatchedCount != 0, nil
 }
 
-func (r *WebPageRepository) DeleteWebPage(Id *string) (bool, error) {
+// DeleteWebPage only deletes a page owned by an organization in scope
+func (r *WebPageRepository) DeleteWebPage(Id *string, scope *entity.AccessScope) (bool, error) {
 
 	pageId, _ := primitive.ObjectIDFromHex(*Id)
-	_, err := r.dbMongo.Collection(WebPageCollectionName).DeleteOne(context.TODO(), bson.M{"_id": pageId})
+	result, err := r.dbMongo.Collection(WebPageCollectionName).DeleteOne(context.TODO(), scopeFilter(bson.M{"_id": pageId}, "org_id", scope))
 
 	if err != nil {
 		return false, err
 	}
 
-	return true, nil
+	return result.DeletedCount != 0, nil
+}
+
+// GetTemplatesUsingWebPage the templates with a page or a menu entry targeting the page, in every organization
+func (r *WebPageRepository) GetTemplatesUsingWebPage(pageID primitive.ObjectID) (*[]entity.Template, error) {
+	filter := bson.M{"$or": bson.A{bson.M{"pages.page_id": pageID}, bson.M{"menu.page_id": pageID}}}
+	cursor, err := r.dbMongo.Collection(entity.Template{}.CollectionName()).Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []entity.Template{}
+	if err := cursor.All(context.TODO(), &templates); err != nil {
+		return nil, err
+	}
+
+	return &templates, nil
+}
+
+// DeleteWebPageCascade deletes a page owned by an organization in scope and removes the pages and menu entries
+// of templates targeting it, in a transaction
+func (r *WebPageRepository) DeleteWebPageCascade(pageID primitive.ObjectID, scope *entity.AccessScope) (bool, error) {
+	session, err := r.dbMongo.Client().StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(context.TODO())
+
+	deleted, err := session.WithTransaction(context.TODO(), func(ctx mongo.SessionContext) (any, error) {
+		result, err := r.dbMongo.Collection(WebPageCollectionName).DeleteOne(ctx, scopeFilter(bson.M{"_id": pageID}, "org_id", scope))
+		if err != nil || result.DeletedCount == 0 {
+			return false, err
+		}
+		_, err = r.dbMongo.Collection(entity.Template{}.CollectionName()).UpdateMany(ctx,
+			bson.M{"$or": bson.A{bson.M{"pages.page_id": pageID}, bson.M{"menu.page_id": pageID}}},
+			bson.M{
+				"$pull": bson.M{"pages": bson.M{"page_id": pageID}, "menu": bson.M{"page_id": pageID}},
+				"$set":  bson.M{"updated_at": time.Now()},
+			},
+		)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return deleted.(bool), nil
 }
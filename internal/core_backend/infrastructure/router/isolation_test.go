@@ -0,0 +1,305 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"backend-service/internal/core_backend/api/middleware"
+	"backend-service/internal/core_backend/api/middleware/authentication"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/registry"
+)
+
+// tenantFixture the resources of the organization the caller is not an admin of
+type tenantFixture struct {
+	org          entity.Organization
+	template     entity.Template
+	webPage      entity.WebPage
+	product      entity.Product
+	productItem  entity.ProductItem
+	mapping      entity.Mapping
+	collection   entity.DigitalAssetCollection
+	deployment   entity.ContractDeployment
+	user         entity.User
+	role         entity.Role
+	binding      entity.RoleBinding
+	apiKey       entity.APIKey
+	author       entity.Author
+	otherOrgID   primitive.ObjectID
+	otherOrgName string
+}
+
+// orgAdmin authenticates every request as an admin holding every permission in one organization
+type orgAdmin struct {
+	orgID primitive.ObjectID
+}
+
+func (a orgAdmin) Authenticate(c *gin.Context) {
+	c.Set(authentication.USER_INFO_KEY, &entity.User{ID: "org-admin"})
+	c.Set(authentication.ROLE_GRANTS_KEY, []entity.RoleGrant{{
+		OrganizationID: a.orgID,
+		Role:           entity.Role{RoleName: "ORG_OWNER", Permissions: entity.AllPermissions},
+	}})
+	c.Next()
+}
+
+// TestAdminRoutesTenantIsolation calls every admin route on the resources of an organization
+// as an admin of another organization. It needs a MongoDB, given by MONGO_TEST_URI.
+func TestAdminRoutesTenantIsolation(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+	gin.SetMode(gin.TestMode)
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect(context.Background())
+	db := client.Database(fmt.Sprintf("tenant_isolation_%d", time.Now().UnixNano()))
+	defer db.Drop(context.Background())
+
+	f := seedTenant(t, db)
+	auth := orgAdmin{orgID: f.otherOrgID}
+	mdw := middleware.MidddlewareServices{AuthenMiddleware: &authentication.AuthenticationService{
+		AdminAuth:  auth,
+		UserAuth:   auth,
+		PubsubAuth: auth,
+	}}
+	engine := NewRouter(registry.NewInteractor(db, validator.New(), nil, nil, nil, nil).NewAppHandler(), mdw)
+
+	productID := f.product.ID.Hex()
+	itemID := f.productItem.ID.Hex()
+	templateID := f.template.ID.Hex()
+	pageID := f.webPage.ID.Hex()
+	collectionID := f.collection.ID.Hex()
+	orgID := f.org.ID.Hex()
+	authorID := f.author.ID.Hex()
+	cases := []struct {
+		method string
+		path   string
+		body   string
+		form   url.Values
+	}{
+		{method: http.MethodGet, path: "/admin/product/" + productID},
+		{method: http.MethodPut, path: "/admin/product/" + productID, body: `{"type":"coffee","product_name":"hijacked"}`},
+		{method: http.MethodPut, path: "/admin/product/" + productID, body: `{"type":"coffee","product_name":"hijacked","org_id":"` + f.otherOrgID.Hex() + `"}`},
+		{method: http.MethodDelete, path: "/admin/product/" + productID},
+		{method: http.MethodGet, path: "/admin/product/" + productID + "/draft"},
+		{method: http.MethodDelete, path: "/admin/product/" + productID + "/draft"},
+		{method: http.MethodPost, path: "/admin/product/" + productID + "/publish"},
+		{method: http.MethodGet, path: "/admin/product/" + productID + "/versions"},
+		{method: http.MethodGet, path: "/admin/product/" + productID + "/versions/1"},
+		{method: http.MethodPost, path: "/admin/product/" + productID + "/versions/1/rollback"},
+		{method: http.MethodGet, path: "/admin/product/" + productID + "/diff"},
+		{method: http.MethodPost, path: "/admin/product/" + productID + "/story/publish"},
+		{method: http.MethodPost, path: "/admin/product/clone", body: `{"product_id":"` + productID + `"}`},
+		{method: http.MethodPost, path: "/admin/product/" + productID + "/clone", body: `{"clone_template":true,"total_item":1}`},
+		{method: http.MethodPost, path: "/admin/product/" + productID + "/clone", body: `{"org_id":"` + f.otherOrgID.Hex() + `"}`},
+		{method: http.MethodGet, path: "/admin/product?org_tag_name=" + f.org.NameTag},
+		{method: http.MethodGet, path: "/admin/product/search?org_id=" + orgID},
+		{method: http.MethodGet, path: "/admin/template/" + templateID},
+		{method: http.MethodPost, path: "/admin/template/create", body: `{"org_id":"` + orgID + `","name":"hijacked"}`},
+		{method: http.MethodPut, path: "/admin/template/" + templateID, body: `{"name":"hijacked"}`},
+		{method: http.MethodPost, path: "/admin/web-page/create", body: `{"org_id":"` + orgID + `","name":"hijacked"}`},
+		{method: http.MethodPut, path: "/admin/web-page/" + pageID, body: `{"name":"hijacked"}`},
+		{method: http.MethodDelete, path: "/admin/web-page/" + pageID},
+		{method: http.MethodPost, path: "/admin/product-item/" + itemID + "/mint"},
+		{method: http.MethodGet, path: "/admin/product-item/" + itemID + "/story"},
+		{method: http.MethodPut, path: "/admin/product-item/" + itemID + "/claim-state", body: `{"state":"claimable","reason_code":"correction"}`},
+		{method: http.MethodGet, path: "/admin/product-item/" + itemID + "/claim-events"},
+		{method: http.MethodPost, path: "/admin/product-item/create", form: url.Values{"product_id": {productID}}},
+		{method: http.MethodPost, path: "/admin/product-item/create-multiple", form: url.Values{"product_id": {productID}, "num_item": {"1"}}},
+		{method: http.MethodGet, path: "/admin/product-item?product_id=" + productID},
+		{method: http.MethodGet, path: "/admin/product-item/organization/" + f.org.NameTag},
+		{method: http.MethodGet, path: "/admin/mapping?org_tag_name=" + f.org.NameTag},
+		{method: http.MethodGet, path: "/admin/mapping/product/" + productID},
+		{method: http.MethodPut, path: "/admin/mapping/" + f.mapping.TagID, body: `{"external_url":"https://hijacked.example"}`},
+		{method: http.MethodDelete, path: "/admin/mapping/" + f.mapping.TagID, body: `{"product_item_id":"` + itemID + `"}`},
+		{method: http.MethodGet, path: "/admin/organization/" + f.org.NameTag},
+		{method: http.MethodPut, path: "/admin/organization/" + orgID, form: url.Values{"org_name": {"hijacked"}}},
+		{method: http.MethodPost, path: "/admin/tag/create", form: url.Values{"tag_id": {"hijacked-tag"}, "org_id": {orgID}}},
+		{method: http.MethodGet, path: "/admin/digital-asset?org_tag_name=" + f.org.NameTag},
+		{method: http.MethodPut, path: "/admin/digital-asset/collection/" + collectionID + "/sync-metadata"},
+		{method: http.MethodPut, path: "/admin/digital-asset/collection/" + collectionID + "/metadata-settings", body: `{"revealed":true}`},
+		{method: http.MethodGet, path: "/admin/digital-asset/collection/" + collectionID + "/metadata-template"},
+		{method: http.MethodPut, path: "/admin/digital-asset/collection/" + collectionID + "/metadata-template", body: `{"name":"hijacked","image":"ipfs://hijacked"}`},
+		{method: http.MethodPost, path: "/admin/digital-asset/collection/" + collectionID + "/metadata-template/preview", body: `{"product_item_id":"` + itemID + `"}`},
+		{method: http.MethodPost, path: "/admin/digital-asset/collection/deploy", body: `{"org_id":"` + orgID + `","template":"erc721","chain_id":1,"name":"Hijacked","symbol":"HJK"}`},
+		{method: http.MethodGet, path: "/admin/digital-asset/collection/deployment/" + f.deployment.ID.Hex()},
+		{method: http.MethodGet, path: "/admin/user?org_id=" + orgID},
+		{method: http.MethodPost, path: "/admin/user/invite", body: `{"email":"hijacked@example.com","org_id":"` + orgID + `"}`},
+		{method: http.MethodPut, path: "/admin/user/" + f.user.ID + "/deactivate"},
+		{method: http.MethodPost, path: "/admin/role", body: `{"org_id":"` + orgID + `","role_name":"hijacker","permissions":["product:read"]}`},
+		{method: http.MethodPut, path: "/admin/role/" + f.role.ID.Hex(), body: `{"description":"hijacked","permissions":["product:read"]}`},
+		{method: http.MethodPost, path: "/admin/role/binding", body: `{"user_id":"org-admin","org_id":"` + orgID + `","role_name":"ORG_ADMIN"}`},
+		{method: http.MethodDelete, path: "/admin/role/binding/" + f.binding.ID.Hex()},
+		{method: http.MethodPost, path: "/admin/api-key", body: `{"org_id":"` + orgID + `","name":"hijacked","permissions":["product:read"]}`},
+		{method: http.MethodDelete, path: "/admin/api-key/" + f.apiKey.ID.Hex()},
+		{method: http.MethodPost, path: "/admin/author", body: `{"org_id":"` + orgID + `","phone":"hijacked"}`},
+		{method: http.MethodPut, path: "/admin/author/" + authorID, body: `{"phone":"hijacked"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			var req *http.Request
+			if tc.form != nil {
+				req = httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			} else {
+				req = httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusNotFound, rec.Body.String())
+			}
+		})
+	}
+
+	// Listings leave out the resources of the organization
+	for _, path := range []string{"/admin/role", "/admin/api-key", "/admin/author", "/admin/template/all", "/admin/web-page/all"} {
+		t.Run(http.MethodGet+" "+path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+			}
+			for _, id := range []primitive.ObjectID{f.role.ID, f.apiKey.ID, f.author.ID, f.template.ID, f.webPage.ID} {
+				if strings.Contains(rec.Body.String(), id.Hex()) {
+					t.Errorf("listed %s of the organization: %s", id.Hex(), rec.Body.String())
+				}
+			}
+		})
+	}
+
+	// Nothing of the organization changed
+	var product entity.Product
+	if err := db.Collection(product.CollectionName()).FindOne(context.Background(), bson.M{"_id": f.product.ID}).Decode(&product); err != nil {
+		t.Fatal(err)
+	}
+	if product.ProductName != f.product.ProductName || product.OrganizationID != f.org.ID || product.Status != f.product.Status {
+		t.Errorf("product was changed: %+v", product)
+	}
+	var page entity.WebPage
+	if err := db.Collection(page.CollectionName()).FindOne(context.Background(), bson.M{"_id": f.webPage.ID}).Decode(&page); err != nil {
+		t.Errorf("web page was deleted: %v", err)
+	}
+	var user entity.User
+	if err := db.Collection(user.CollectionName()).FindOne(context.Background(), bson.M{"_id": f.user.ID}).Decode(&user); err != nil {
+		t.Fatal(err)
+	}
+	if user.Status != f.user.Status {
+		t.Errorf("user was deactivated: %+v", user)
+	}
+	var template entity.Template
+	if err := db.Collection(template.CollectionName()).FindOne(context.Background(), bson.M{"_id": f.template.ID}).Decode(&template); err != nil {
+		t.Fatal(err)
+	}
+	if template.Name != f.template.Name {
+		t.Errorf("template was changed: %+v", template)
+	}
+	var binding entity.RoleBinding
+	if err := db.Collection(binding.CollectionName()).FindOne(context.Background(), bson.M{"_id": f.binding.ID}).Decode(&binding); err != nil {
+		t.Errorf("role binding was revoked: %v", err)
+	}
+	var key entity.APIKey
+	if err := db.Collection(key.CollectionName()).FindOne(context.Background(), bson.M{"_id": f.apiKey.ID}).Decode(&key); err != nil {
+		t.Fatal(err)
+	}
+	if key.RevokedAt != nil {
+		t.Errorf("api key was revoked: %+v", key)
+	}
+	var author entity.Author
+	if err := db.Collection(author.CollectionName()).FindOne(context.Background(), bson.M{"_id": f.author.ID}).Decode(&author); err != nil {
+		t.Fatal(err)
+	}
+	if author.Phone != f.author.Phone {
+		t.Errorf("author was changed: %+v", author)
+	}
+}
+
+func seedTenant(t *testing.T, db *mongo.Database) *tenantFixture {
+	t.Helper()
+	f := &tenantFixture{otherOrgID: primitive.NewObjectID(), otherOrgName: "other-org"}
+	f.org = entity.Organization{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationName: "Tenant", NameTag: "tenant-org"}
+	f.webPage = entity.WebPage{WebPageBase: entity.WebPageBase{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationID: f.org.ID, Name: "story"}}
+	f.template = entity.Template{
+		BaseModel:      entity.BaseModel{ID: primitive.NewObjectID()},
+		OrganizationID: f.org.ID,
+		Name:           "tenant template",
+		Pages:          []entity.TemplatePages{{PageID: f.webPage.ID}},
+	}
+	f.product = entity.Product{
+		BaseModel:      entity.BaseModel{ID: primitive.NewObjectID(), Status: "Active"},
+		Type:           "coffee",
+		ProductName:    "tenant product",
+		TemplateID:     f.template.ID,
+		OrganizationID: f.org.ID,
+	}
+	f.productItem = entity.ProductItem{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, ProductID: f.product.ID, OwnerID: "owner"}
+	f.mapping = entity.Mapping{
+		BaseModel:      entity.BaseModel{ID: primitive.NewObjectID()},
+		TagID:          "tenant-tag",
+		ProductItemID:  f.productItem.ID,
+		OrganizationID: f.org.ID,
+		OwnerID:        "owner",
+	}
+	f.collection = entity.DigitalAssetCollection{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationID: f.org.ID}
+	f.deployment = entity.ContractDeployment{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationID: f.org.ID}
+	f.user = entity.User{ID: "tenant-user", Email: "user@tenant.example.com", OrganizationID: f.org.ID, Status: "Active"}
+	f.role = entity.Role{
+		BaseModel:      entity.BaseModel{ID: primitive.NewObjectID()},
+		OrganizationID: f.org.ID,
+		RoleName:       "tenant-editor",
+		Permissions:    []entity.Permission{entity.PermissionProductRead},
+	}
+	f.binding = entity.RoleBinding{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, UserID: f.user.ID, OrganizationID: f.org.ID, RoleName: string(entity.ORG_ADMIN_ROLE)}
+	f.apiKey = entity.APIKey{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationID: f.org.ID, Name: "tenant key", Prefix: "tenant"}
+	f.author = entity.Author{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationID: f.org.ID, Phone: "0123"}
+	other := entity.Organization{BaseModel: entity.BaseModel{ID: f.otherOrgID}, OrganizationName: "Other", NameTag: f.otherOrgName}
+
+	documents := []struct {
+		collection string
+		document   any
+	}{
+		{f.org.CollectionName(), f.org},
+		{other.CollectionName(), other},
+		{f.webPage.CollectionName(), f.webPage},
+		{f.template.CollectionName(), f.template},
+		{f.product.CollectionName(), f.product},
+		{f.productItem.CollectionName(), f.productItem},
+		{f.mapping.CollectionName(), f.mapping},
+		{f.collection.CollectionName(), f.collection},
+		{f.deployment.CollectionName(), f.deployment},
+		{f.user.CollectionName(), f.user},
+		{f.role.CollectionName(), f.role},
+		{f.binding.CollectionName(), f.binding},
+		{f.apiKey.CollectionName(), f.apiKey},
+		{f.author.CollectionName(), f.author},
+	}
+	for _, d := range documents {
+		if _, err := db.Collection(d.collection).InsertOne(context.Background(), d.document); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return f
+}
@@ -6,6 +6,7 @@ import (
 	"backend-service/internal/core_backend/api/handler"
 	"backend-service/internal/core_backend/api/middleware"
 	_ "backend-service/internal/core_backend/docs"
+	"backend-service/internal/core_backend/entity"
 
 	"github.com/gin-gonic/gin"
 
@@ -32,6 +33,11 @@ import (
 // @name						Authorization
 // @description				Description for what is this security definition being used
 func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices) {
+	NewRouter(handler, mdw).Run(":8080")
+}
+
+// NewRouter registers every route of the API
+func NewRouter(handler handler.AppHandler, mdw middleware.MidddlewareServices) *gin.Engine {
 	router := gin.New()
 	router.Use(corsMiddleware())
 	router.Use(gin.Logger())
@@ -96,75 +102,148 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 			c.JSON(result.Code, result)
 		})
 		productItem.PUT("/:product_item_id/claim", mdw.AuthenMiddleware.UserAuth.Authenticate, func(c *gin.Context) {
-			result := handler.ProductItemHandler.ClaimItem(c)
+			result := handler.ClaimHandler.ClaimItem(c)
 			c.JSON(result.Code, result)
 		})
-		productItem.PUT("/:product_item_id/toggle-claimable", func(c *gin.Context) {
-			result := handler.ProductItemHandler.ToggleClaimableItem(c)
+		productItem.POST("/:product_item_id/like", mdw.AuthenMiddleware.UserAuth.Authenticate, func(c *gin.Context) {
+			result := handler.ProductItemHandler.LikeProductItem(c)
 			c.JSON(result.Code, result)
 		})
-		productItem.POST("/:product_item_id/like", func(c *gin.Context) {
-			result := handler.ProductItemHandler.LikeProductItem(c)
+	}
+
+	site := router.Group("/site")
+	{
+		site.GET("/product-item/:product_item_id", func(c *gin.Context) {
+			result := handler.SiteHandler.GetItemSite(c)
 			c.JSON(result.Code, result)
 		})
-		//Test: NFT Metatdata
-		productItem.GET("/metadata/:token_id", func(c *gin.Context) {
-			result := handler.ProductItemHandler.GetMetadata(c)
-			c.JSON(result.Code, result.Result)
+		site.GET("/tag/:tag_id", func(c *gin.Context) {
+			result := handler.SiteHandler.GetTagSite(c)
+			c.JSON(result.Code, result)
 		})
 	}
 
 	// Authenticate Part - authenticate and authorization required
 	adminGroup := router.Group("/admin")
-	adminGroup.Use(mdw.AuthenMiddleware.AdminAuth.Authenticate)
+	adminGroup.Use(mdw.AuthenMiddleware.AdminOrAPIKey)
+	// every admin route declares the permissions it requires
+	authorize := mdw.AuthenMiddleware.Authorize
 	{
 		productGroup := adminGroup.Group("/product")
 		{
-			productGroup.GET("", func(c *gin.Context) {
+			productGroup.GET("", authorize(entity.PermissionProductRead), func(c *gin.Context) {
 				result := handler.ProductHandler.GetAllProducts(c)
 				c.JSON(result.Code, result)
 			})
-			productGroup.POST("/create", func(c *gin.Context) {
+			productGroup.GET("/search", authorize(entity.PermissionProductRead), func(c *gin.Context) {
+				result := handler.ProductHandler.SearchProducts(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.POST("/create", authorize(entity.PermissionProductWrite), func(c *gin.Context) {
 				result := handler.ProductHandler.CreateProduct(c)
 				c.JSON(result.Code, result)
 			})
-			productGroup.POST("/clone", func(c *gin.Context) {
+			productGroup.POST("/clone", authorize(entity.PermissionProductWrite), func(c *gin.Context) {
 				result := handler.ProductHandler.CloneProductByID(c)
 				c.JSON(result.Code, result)
 			})
-			productGroup.GET("/:product_id", func(c *gin.Context) {
+			productGroup.POST("/:product_id/clone", authorize(entity.PermissionProductWrite, entity.PermissionTemplateWrite, entity.PermissionWebpageWrite, entity.PermissionProductItemWrite), func(c *gin.Context) {
+				result := handler.ProductHandler.DeepCloneProduct(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.GET("/:product_id", authorize(entity.PermissionProductRead), func(c *gin.Context) {
 				result := handler.ProductHandler.GetProductDetail(c)
 				c.JSON(result.Code, result)
 			})
-			productGroup.PUT("/:product_id", func(c *gin.Context) {
+			productGroup.PUT("/:product_id", authorize(entity.PermissionProductWrite), func(c *gin.Context) {
 				result := handler.ProductHandler.UpdateProductDetail(c)
 				c.JSON(result.Code, result)
 			})
-			productGroup.DELETE("/:product_id", func(c *gin.Context) {
+			productGroup.DELETE("/:product_id", authorize(entity.PermissionProductWrite), func(c *gin.Context) {
 				result := handler.ProductHandler.DeteleProductByID(c)
 				c.JSON(result.Code, result)
 			})
+			productGroup.GET("/:product_id/draft", authorize(entity.PermissionProductRead), func(c *gin.Context) {
+				result := handler.ProductHandler.GetProductDraft(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.DELETE("/:product_id/draft", authorize(entity.PermissionProductWrite), func(c *gin.Context) {
+				result := handler.ProductHandler.DiscardProductDraft(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.POST("/:product_id/publish", authorize(entity.PermissionProductPublish), func(c *gin.Context) {
+				result := handler.ProductHandler.PublishProductDraft(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.POST("/:product_id/story/publish", authorize(entity.PermissionProductPublish), func(c *gin.Context) {
+				result := handler.StoryHandler.PublishProductStories(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.GET("/:product_id/versions", authorize(entity.PermissionProductRead), func(c *gin.Context) {
+				result := handler.ProductHandler.GetProductVersions(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.GET("/:product_id/versions/:version", authorize(entity.PermissionProductRead), func(c *gin.Context) {
+				result := handler.ProductHandler.GetProductVersion(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.POST("/:product_id/versions/:version/rollback", authorize(entity.PermissionProductPublish), func(c *gin.Context) {
+				result := handler.ProductHandler.RollbackProduct(c)
+				c.JSON(result.Code, result)
+			})
+			productGroup.GET("/:product_id/diff", authorize(entity.PermissionProductRead), func(c *gin.Context) {
+				result := handler.ProductHandler.DiffProductVersions(c)
+				c.JSON(result.Code, result)
+			})
+		}
+
+		productTypeGroup := adminGroup.Group("/product-type")
+		{
+			productTypeGroup.GET("", authorize(entity.PermissionProductTypeRead), func(c *gin.Context) {
+				result := handler.ProductTypeHandler.GetProductTypes(c)
+				c.JSON(result.Code, result)
+			})
+			productTypeGroup.POST("", authorize(entity.PermissionProductTypeWrite), func(c *gin.Context) {
+				result := handler.ProductTypeHandler.CreateProductType(c)
+				c.JSON(result.Code, result)
+			})
+			productTypeGroup.GET("/:name", authorize(entity.PermissionProductTypeRead), func(c *gin.Context) {
+				result := handler.ProductTypeHandler.GetProductType(c)
+				c.JSON(result.Code, result)
+			})
+			productTypeGroup.GET("/:name/versions", authorize(entity.PermissionProductTypeRead), func(c *gin.Context) {
+				result := handler.ProductTypeHandler.GetProductTypeSchemas(c)
+				c.JSON(result.Code, result)
+			})
+			productTypeGroup.PUT("/:name", authorize(entity.PermissionProductTypeWrite), func(c *gin.Context) {
+				result := handler.ProductTypeHandler.UpdateProductType(c)
+				c.JSON(result.Code, result)
+			})
+			productTypeGroup.DELETE("/:name", authorize(entity.PermissionProductTypeWrite), func(c *gin.Context) {
+				result := handler.ProductTypeHandler.DeleteProductType(c)
+				c.JSON(result.Code, result)
+			})
 		}
 
 		mappingGroup := adminGroup.Group("/mapping")
 		{
-			mappingGroup.GET("", func(c *gin.Context) {
+			mappingGroup.GET("", authorize(entity.PermissionMappingRead), func(c *gin.Context) {
 				result := handler.MappingHandler.GetAllMapping(c)
 				c.JSON(result.Code, result)
 			})
-			mappingGroup.GET("/product/:product_id", func(c *gin.Context) {
+			mappingGroup.GET("/product/:product_id", authorize(entity.PermissionMappingRead), func(c *gin.Context) {
 				result := handler.MappingHandler.GetAllMappingForProduct(c)
 				c.JSON(result.Code, result)
 			})
-			mappingGroup.PUT("/:tag_id", func(c *gin.Context) {
+			mappingGroup.PUT("/:tag_id", authorize(entity.PermissionMappingWrite), func(c *gin.Context) {
 				result := handler.MappingHandler.UpdateMapping(c)
 				c.JSON(result.Code, result)
 			})
-			mappingGroup.DELETE("/:tag_id", func(c *gin.Context) {
+			mappingGroup.DELETE("/:tag_id", authorize(entity.PermissionMappingWrite), func(c *gin.Context) {
 				result := handler.MappingHandler.Unmap(c)
 				c.JSON(result.Code, result)
 			})
-			mappingGroup.POST("/batch/multiple-mapping", func(c *gin.Context) {
+			mappingGroup.POST("/batch/multiple-mapping", authorize(entity.PermissionMappingWrite), func(c *gin.Context) {
 				result := handler.MappingHandler.MultipleMappingWithSingleProduct(c)
 				c.JSON(result.Code, result)
 			})
@@ -172,23 +251,35 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 
 		businessProductItem := adminGroup.Group("/product-item")
 		{
-			businessProductItem.POST("/:product_item_id/mint", func(c *gin.Context) {
+			businessProductItem.POST("/:product_item_id/mint", authorize(entity.PermissionNFTMint), func(c *gin.Context) {
 				result := handler.ProductItemHandler.MintProductItem(c)
 				c.JSON(result.Code, result)
 			})
-			businessProductItem.POST("/create", func(c *gin.Context) {
+			businessProductItem.GET("/:product_item_id/story", authorize(entity.PermissionProductItemRead), func(c *gin.Context) {
+				result := handler.StoryHandler.GetStoryArtifact(c)
+				c.JSON(result.Code, result)
+			})
+			businessProductItem.PUT("/:product_item_id/claim-state", authorize(entity.PermissionMappingWrite), func(c *gin.Context) {
+				result := handler.ClaimHandler.ChangeClaimState(c)
+				c.JSON(result.Code, result)
+			})
+			businessProductItem.GET("/:product_item_id/claim-events", authorize(entity.PermissionMappingRead), func(c *gin.Context) {
+				result := handler.ClaimHandler.GetClaimEvents(c)
+				c.JSON(result.Code, result)
+			})
+			businessProductItem.POST("/create", authorize(entity.PermissionProductItemWrite), func(c *gin.Context) {
 				result := handler.ProductItemHandler.CreateProductItem(c)
 				c.JSON(result.Code, result)
 			})
-			businessProductItem.POST("/create-multiple", func(c *gin.Context) {
+			businessProductItem.POST("/create-multiple", authorize(entity.PermissionProductItemWrite), func(c *gin.Context) {
 				result := handler.ProductItemHandler.CreateMultipleProductItems(c)
 				c.JSON(result.Code, result)
 			})
-			businessProductItem.GET("", func(c *gin.Context) {
+			businessProductItem.GET("", authorize(entity.PermissionProductItemRead), func(c *gin.Context) {
 				result := handler.ProductItemHandler.GetAllProductItem(c)
 				c.JSON(result.Code, result)
 			})
-			businessProductItem.GET("/organization/:org_tag_name", func(c *gin.Context) {
+			businessProductItem.GET("/organization/:org_tag_name", authorize(entity.PermissionProductItemRead), func(c *gin.Context) {
 				result := handler.ProductItemHandler.GetAllProductItemInOrg(c)
 				c.JSON(result.Code, result)
 			})
@@ -196,19 +287,19 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 
 		organizationGroup := adminGroup.Group("/organization")
 		{
-			organizationGroup.PUT("/:org_id", func(c *gin.Context) {
+			organizationGroup.PUT("/:org_id", authorize(entity.PermissionOrganizationWrite), func(c *gin.Context) {
 				result := handler.OrganizationHandler.UpdateOrganization(c)
 				c.JSON(result.Code, result)
 			})
-			organizationGroup.POST("/create", func(c *gin.Context) {
+			organizationGroup.POST("/create", authorize(entity.PermissionOrganizationWrite), func(c *gin.Context) {
 				result := handler.OrganizationHandler.CreateOrganization(c)
 				c.JSON(result.Code, result)
 			})
-			organizationGroup.GET("", func(c *gin.Context) {
+			organizationGroup.GET("", authorize(entity.PermissionOrganizationRead), func(c *gin.Context) {
 				result := handler.OrganizationHandler.GetAllOrganizations(c)
 				c.JSON(result.Code, result)
 			})
-			organizationGroup.GET("/:org_tag_name", func(c *gin.Context) {
+			organizationGroup.GET("/:org_tag_name", authorize(entity.PermissionOrganizationRead), func(c *gin.Context) {
 				result := handler.OrganizationHandler.GetOrganization(c)
 				c.JSON(result.Code, result)
 			})
@@ -216,60 +307,80 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 
 		templateGroup := adminGroup.Group("template")
 		{
-			templateGroup.GET("/:template_id", func(c *gin.Context) {
+			templateGroup.GET("/:template_id", authorize(entity.PermissionTemplateRead), func(c *gin.Context) {
 				result := handler.TemplateHandler.GetTemplate(c)
 				c.JSON(result.Code, result)
 			})
-			templateGroup.PUT("/:template_id", func(c *gin.Context) {
+			templateGroup.PUT("/:template_id", authorize(entity.PermissionTemplateWrite), func(c *gin.Context) {
 				result := handler.TemplateHandler.UpdateTemplate(c)
 				c.JSON(result.Code, result)
 			})
-			templateGroup.GET("/all", func(c *gin.Context) {
+			templateGroup.GET("/all", authorize(entity.PermissionTemplateRead), func(c *gin.Context) {
 				result := handler.TemplateHandler.GetAllTemplates(c)
 				c.JSON(result.Code, result)
 			})
-			templateGroup.POST("/create", func(c *gin.Context) {
+			templateGroup.POST("/create", authorize(entity.PermissionTemplateWrite), func(c *gin.Context) {
 				result := handler.TemplateHandler.CreateTemplate(c)
 				c.JSON(result.Code, result)
 			})
+			templateGroup.GET("/dangling-references", authorize(entity.PermissionTemplateRead), func(c *gin.Context) {
+				result := handler.TemplateHandler.GetDanglingReferences(c)
+				c.JSON(result.Code, result)
+			})
 		}
 		pageGroup := adminGroup.Group("web-page")
 		{
-			pageGroup.GET("/all", func(c *gin.Context) {
+			pageGroup.GET("/all", authorize(entity.PermissionWebpageRead), func(c *gin.Context) {
 				result := handler.WebPageHandler.GetAllWebPages(c)
 				c.JSON(result.Code, result)
 			})
-			pageGroup.POST("/create", func(c *gin.Context) {
+			pageGroup.POST("/create", authorize(entity.PermissionWebpageWrite), func(c *gin.Context) {
 				result := handler.WebPageHandler.CreateWebPage(c)
 				c.JSON(result.Code, result)
 			})
-			pageGroup.PUT("/:webpage_id", func(c *gin.Context) {
+			pageGroup.PUT("/:webpage_id", authorize(entity.PermissionWebpageWrite), func(c *gin.Context) {
 				result := handler.WebPageHandler.UpdateWebPage(c)
 				c.JSON(result.Code, result)
 			})
-			pageGroup.DELETE("/:webpage_id", func(c *gin.Context) {
+			pageGroup.DELETE("/:webpage_id", authorize(entity.PermissionWebpageWrite), func(c *gin.Context) {
 				result := handler.WebPageHandler.DeleteWebPage(c)
 				c.JSON(result.Code, result)
 			})
 		}
 		tagManagementGroup := adminGroup.Group("/tag")
 		{
-			tagManagementGroup.POST("/create", func(c *gin.Context) {
+			tagManagementGroup.POST("/create", authorize(entity.PermissionTagWrite), func(c *gin.Context) {
 				result := handler.TagHandler.CreateTag(c)
 				c.JSON(result.Code, result)
 
 			})
-			tagManagementGroup.POST("/create/batch", func(c *gin.Context) {
+			tagManagementGroup.POST("/create/batch", authorize(entity.PermissionTagWrite), func(c *gin.Context) {
 				// Create multiple chip: chip number: from - to for organization
 			})
 		}
 		userGroup := adminGroup.Group("/user")
 		{
-			userGroup.PUT("/role", func(c *gin.Context) {
-				result := handler.UserHandler.UpdateRole(c)
+			userGroup.GET("", authorize(entity.PermissionUserRead), func(c *gin.Context) {
+				result := handler.UserHandler.SearchUsers(c)
+				c.JSON(result.Code, result)
+			})
+			userGroup.POST("/invite", authorize(entity.PermissionUserWrite, entity.PermissionRoleWrite), func(c *gin.Context) {
+				result := handler.UserHandler.InviteUser(c)
 				c.JSON(result.Code, result)
 			})
-			userGroup.PUT("/sync-wallet-address", func(c *gin.Context) {
+			userGroup.PUT("/:user_id/deactivate", authorize(entity.PermissionUserWrite), func(c *gin.Context) {
+				result := handler.UserHandler.DeactivateUser(c)
+				c.JSON(result.Code, result)
+			})
+			userGroup.PUT("/:user_id/reactivate", authorize(entity.PermissionUserWrite), func(c *gin.Context) {
+				result := handler.UserHandler.ReactivateUser(c)
+				c.JSON(result.Code, result)
+			})
+			userGroup.PUT("/:user_id/sync-claims", authorize(entity.PermissionUserWrite), func(c *gin.Context) {
+				result := handler.UserHandler.SyncUserClaims(c)
+				c.JSON(result.Code, result)
+			})
+			userGroup.PUT("/sync-wallet-address", authorize(entity.PermissionUserWrite), func(c *gin.Context) {
 				result := handler.UserHandler.SyncWalletAddress(c)
 				c.JSON(result.Code, result)
 			})
@@ -277,27 +388,99 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 
 		digitalAssetGroup := adminGroup.Group("/digital-asset")
 		{
-			digitalAssetGroup.GET("", func(c *gin.Context) {
+			digitalAssetGroup.GET("", authorize(entity.PermissionDigitalAssetRead), func(c *gin.Context) {
 				result := handler.DigitalAssetHandler.GetDigitalAssets(c)
 				c.JSON(result.Code, result.Result)
 			})
-			digitalAssetGroup.PUT("/sync-metadata", func(c *gin.Context) {
+			digitalAssetGroup.PUT("/sync-metadata", authorize(entity.PermissionDigitalAssetWrite), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.SyncAllDigitalAssetsMetadata(c)
+				c.JSON(result.Code, result.Result)
+			})
+			digitalAssetGroup.PUT("/collection/:collection_id/sync-metadata", authorize(entity.PermissionDigitalAssetWrite), func(c *gin.Context) {
 				result := handler.DigitalAssetHandler.SyncDigitalAssetsMetadata(c)
 				c.JSON(result.Code, result.Result)
 			})
+			digitalAssetGroup.PUT("/collection/:collection_id/metadata-settings", authorize(entity.PermissionDigitalAssetWrite), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.UpdateCollectionMetadata(c)
+				c.JSON(result.Code, result)
+			})
+			digitalAssetGroup.GET("/collection/:collection_id/metadata-template", authorize(entity.PermissionDigitalAssetRead), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.GetMetadataTemplate(c)
+				c.JSON(result.Code, result)
+			})
+			digitalAssetGroup.PUT("/collection/:collection_id/metadata-template", authorize(entity.PermissionDigitalAssetWrite), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.UpsertMetadataTemplate(c)
+				c.JSON(result.Code, result)
+			})
+			digitalAssetGroup.POST("/collection/:collection_id/metadata-template/preview", authorize(entity.PermissionDigitalAssetWrite), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.PreviewMetadataTemplate(c)
+				c.JSON(result.Code, result)
+			})
+			digitalAssetGroup.GET("/contract-templates", authorize(entity.PermissionDigitalAssetRead), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.GetContractTemplates(c)
+				c.JSON(result.Code, result)
+			})
+			digitalAssetGroup.POST("/collection/deploy", authorize(entity.PermissionNFTDeploy), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.DeployCollection(c)
+				c.JSON(result.Code, result)
+			})
+			digitalAssetGroup.GET("/collection/deployment/:deployment_id", authorize(entity.PermissionDigitalAssetRead), func(c *gin.Context) {
+				result := handler.DigitalAssetHandler.GetContractDeployment(c)
+				c.JSON(result.Code, result)
+			})
+		}
+
+		roleGroup := adminGroup.Group("/role")
+		{
+			roleGroup.GET("", authorize(entity.PermissionRoleRead), func(c *gin.Context) {
+				result := handler.RoleHandler.GetRoles(c)
+				c.JSON(result.Code, result)
+			})
+			roleGroup.POST("", authorize(entity.PermissionRoleWrite), func(c *gin.Context) {
+				result := handler.RoleHandler.CreateRole(c)
+				c.JSON(result.Code, result)
+			})
+			roleGroup.PUT("/:role_id", authorize(entity.PermissionRoleWrite), func(c *gin.Context) {
+				result := handler.RoleHandler.UpdateRolePermissions(c)
+				c.JSON(result.Code, result)
+			})
+			roleGroup.POST("/binding", authorize(entity.PermissionRoleWrite), func(c *gin.Context) {
+				result := handler.RoleHandler.AssignRole(c)
+				c.JSON(result.Code, result)
+			})
+			roleGroup.DELETE("/binding/:binding_id", authorize(entity.PermissionRoleWrite), func(c *gin.Context) {
+				result := handler.RoleHandler.RevokeRole(c)
+				c.JSON(result.Code, result)
+			})
+		}
+
+		apiKeyGroup := adminGroup.Group("/api-key")
+		{
+			apiKeyGroup.GET("", authorize(entity.PermissionAPIKeyRead), func(c *gin.Context) {
+				result := handler.APIKeyHandler.GetAPIKeys(c)
+				c.JSON(result.Code, result)
+			})
+			apiKeyGroup.POST("", authorize(entity.PermissionAPIKeyWrite), func(c *gin.Context) {
+				result := handler.APIKeyHandler.CreateAPIKey(c)
+				c.JSON(result.Code, result)
+			})
+			apiKeyGroup.DELETE("/:api_key_id", authorize(entity.PermissionAPIKeyWrite), func(c *gin.Context) {
+				result := handler.APIKeyHandler.RevokeAPIKey(c)
+				c.JSON(result.Code, result)
+			})
 		}
 
 		authorGroup := adminGroup.Group("/author")
 		{
-			authorGroup.GET("", func(c *gin.Context) {
+			authorGroup.GET("", authorize(entity.PermissionAuthorRead), func(c *gin.Context) {
 				result := handler.AuthorHandler.GetListAuthor(c)
 				c.JSON(result.Code, result.Result)
 			})
-			authorGroup.POST("", func(c *gin.Context) {
+			authorGroup.POST("", authorize(entity.PermissionAuthorWrite), func(c *gin.Context) {
 				result := handler.AuthorHandler.CreateAuthor(c)
 				c.JSON(result.Code, result.Result)
 			})
-			authorGroup.PUT("/:author_id", func(c *gin.Context) {
+			authorGroup.PUT("/:author_id", authorize(entity.PermissionAuthorWrite), func(c *gin.Context) {
 				result := handler.AuthorHandler.UpdateAuthor(c)
 				c.JSON(result.Code, result.Result)
 			})
@@ -319,6 +502,16 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 			c.JSON(result.Code, result.Result)
 		})
 
+		digitalAssetGroup.GET("collection/:collectionID/token/:token_id", func(c *gin.Context) {
+			result := handler.DigitalAssetHandler.GetTokenMetadata(c)
+			c.JSON(result.Code, result.Result)
+		})
+
+		digitalAssetGroup.GET("collection/:collectionID/contract", func(c *gin.Context) {
+			result := handler.DigitalAssetHandler.GetContractMetadata(c)
+			c.JSON(result.Code, result.Result)
+		})
+
 		digitalAssetGroup.GET(":org_tag_name/:token_id", func(c *gin.Context) {
 			result := handler.DigitalAssetHandler.GetDigitalMetadataWithID(c)
 			c.JSON(result.Code, result.Result)
@@ -356,6 +549,38 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 			result := handler.UserHandler.GetUserDetails(c)
 			c.JSON(result.Code, result)
 		})
+		userGroup.DELETE("", func(c *gin.Context) {
+			result := handler.UserHandler.DeleteAccount(c)
+			c.JSON(result.Code, result)
+		})
+		userGroup.GET("/collection", func(c *gin.Context) {
+			result := handler.UserHandler.GetCollection(c)
+			c.JSON(result.Code, result)
+		})
+		userGroup.GET("/collection/:product_item_id", func(c *gin.Context) {
+			result := handler.UserHandler.GetCollectionItem(c)
+			c.JSON(result.Code, result)
+		})
+		userGroup.GET("/export", func(c *gin.Context) {
+			result := handler.UserHandler.ExportUserData(c)
+			c.JSON(result.Code, result)
+		})
+		userGroup.POST("/wallet/nonce", func(c *gin.Context) {
+			result := handler.UserHandler.IssueWalletNonce(c)
+			c.JSON(result.Code, result)
+		})
+		userGroup.POST("/wallet/link", func(c *gin.Context) {
+			result := handler.UserHandler.LinkWallet(c)
+			c.JSON(result.Code, result)
+		})
+		userGroup.PUT("/wallet/mint-address", func(c *gin.Context) {
+			result := handler.UserHandler.SetMintWallet(c)
+			c.JSON(result.Code, result)
+		})
+		userGroup.DELETE("/wallet/:address", func(c *gin.Context) {
+			result := handler.UserHandler.UnlinkWallet(c)
+			c.JSON(result.Code, result)
+		})
 	}
 
 	competitionGroup := router.Group("/competition")
@@ -369,7 +594,8 @@ func Initialize(handler handler.AppHandler, mdw middleware.MidddlewareServices)
 			c.JSON(result.Code, result)
 		})
 	}
-	router.Run(":8080")
+
+	return router
 }
 
 func corsMiddleware() gin.HandlerFunc {
@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// KeystoreSigner signs with a key decrypted from an encrypted keystore JSON file (Web3 Secret Storage)
+type KeystoreSigner struct {
+	key *keystore.Key
+}
+
+// NewKeystoreSigner decrypts the keystore JSON with the passphrase
+func NewKeystoreSigner(keyJSON []byte, passphrase string) (*KeystoreSigner, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeystoreSigner{key: key}, nil
+}
+
+// NewKeystoreSignerFromFile reads the keystore JSON and the passphrase from files, as mounted secrets usually are
+func NewKeystoreSignerFromFile(keystorePath, passphrasePath string) (*KeystoreSigner, error) {
+	keyJSON, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := os.ReadFile(passphrasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeystoreSigner(keyJSON, strings.TrimRight(string(passphrase), "\r\n"))
+}
+
+// Address of the decrypted key
+func (s *KeystoreSigner) Address() common.Address {
+	return s.key.Address
+}
+
+// SignTx signs the transaction locally
+func (s *KeystoreSigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key.PrivateKey)
+}
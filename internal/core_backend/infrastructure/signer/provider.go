@@ -0,0 +1,101 @@
+package signer
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	config "backend-service/config/core_backend"
+	constant "backend-service/internal/core_backend/common"
+)
+
+// Provider resolves the signers of the configuration by name, decrypting each keystore only once
+type Provider struct {
+	mu      sync.Mutex
+	signers map[string]Signer
+}
+
+// NewProvider create signer provider
+func NewProvider() *Provider {
+	return &Provider{signers: map[string]Signer{}}
+}
+
+// GetSigner returns the signer configured under the name, the default keystore when the name is empty
+func (p *Provider) GetSigner(name string) (Signer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.signers[name]; ok {
+		return s, nil
+	}
+
+	cfg, err := signerConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.signers[name] = s
+
+	return s, nil
+}
+
+// signerConfig the configuration of the signer of the name
+func signerConfig(name string) (config.SignerConfig, error) {
+	if name == "" {
+		return config.SignerConfig{
+			Type:           constant.SignerTypeKeystore,
+			KeystoreFile:   config.C.Signer.DEFAULT_KEYSTORE_FILE,
+			PassphraseFile: config.C.Signer.DEFAULT_PASSPHRASE_FILE,
+		}, nil
+	}
+	for _, cfg := range config.Signers {
+		if cfg.Name == name {
+			return cfg, nil
+		}
+	}
+
+	return config.SignerConfig{}, errors.New("unknown signer: " + name)
+}
+
+func newSigner(cfg config.SignerConfig) (Signer, error) {
+	switch cfg.Type {
+	case constant.SignerTypeKeystore:
+		if cfg.KeystoreFile == "" {
+			return nil, errors.New("no keystore file configured for signer")
+		}
+		passphraseFile := cfg.PassphraseFile
+		if passphraseFile == "" {
+			passphraseFile = config.C.Signer.DEFAULT_PASSPHRASE_FILE
+		}
+		s, err := NewKeystoreSignerFromFile(resolveKeystorePath(cfg.KeystoreFile), resolveKeystorePath(passphraseFile))
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Address != "" && s.Address() != common.HexToAddress(cfg.Address) {
+			return nil, errors.New("keystore does not hold the configured signer address")
+		}
+		return s, nil
+	case constant.SignerTypeRemote:
+		if cfg.RemoteURL == "" || !common.IsHexAddress(cfg.Address) {
+			return nil, errors.New("remote signer requires a url and an address")
+		}
+		timeout := time.Duration(config.C.Signer.REMOTE_TIMEOUT_IN_SECOND) * time.Second
+		return NewRemoteSigner(cfg.RemoteURL, common.HexToAddress(cfg.Address), config.C.Signer.REMOTE_AUTH_TOKEN, timeout), nil
+	default:
+		return nil, errors.New("unknown signer type: " + cfg.Type)
+	}
+}
+
+// resolveKeystorePath keystore and passphrase files are looked up in the keystore directory unless absolute
+func resolveKeystorePath(path string) string {
+	if filepath.IsAbs(path) || config.C.Signer.KEYSTORE_DIR == "" {
+		return path
+	}
+
+	return filepath.Join(config.C.Signer.KEYSTORE_DIR, path)
+}
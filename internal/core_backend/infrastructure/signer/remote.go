@@ -0,0 +1,171 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// RemoteSigner delegates signing to an external signer (Clef, Web3Signer, a KMS proxy...)
+// through the `eth_signTransaction` JSON-RPC method
+type RemoteSigner struct {
+	url       string
+	address   common.Address
+	authToken string
+	client    *http.Client
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type signTransactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// NewRemoteSigner create a signer for the account held by the remote signer at url
+func NewRemoteSigner(url string, address common.Address, authToken string, timeout time.Duration) *RemoteSigner {
+	return &RemoteSigner{
+		url:       url,
+		address:   address,
+		authToken: authToken,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Address of the remote account
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx asks the remote signer to sign the transaction and checks the result is the requested transaction signed by the expected account
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := signTransactionArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: "eth_signTransaction", Params: []any{args}})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.authToken)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer responded with status %d", resp.StatusCode)
+	}
+
+	var rpcResp rpcResponse
+	if err = json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("remote signer error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	raw, err := decodeSignedTransaction(rpcResp.Result)
+	if err != nil {
+		return nil, err
+	}
+	signed := new(types.Transaction)
+	if err = signed.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return nil, err
+	}
+	if sender != s.address || !sameTransaction(signed, tx) {
+		return nil, errors.New("remote signer returned a transaction that does not match the request")
+	}
+
+	return signed, nil
+}
+
+// sameTransaction checks every field the remote signer could alter, the recipient, the value and the fees included
+func sameTransaction(signed *types.Transaction, tx *types.Transaction) bool {
+	if signed.Type() != tx.Type() || signed.Nonce() != tx.Nonce() || signed.Gas() != tx.Gas() || !bytes.Equal(signed.Data(), tx.Data()) {
+		return false
+	}
+	if (signed.To() == nil) != (tx.To() == nil) || (tx.To() != nil && *signed.To() != *tx.To()) {
+		return false
+	}
+
+	return signed.Value().Cmp(tx.Value()) == 0 &&
+		signed.GasPrice().Cmp(tx.GasPrice()) == 0 &&
+		signed.GasFeeCap().Cmp(tx.GasFeeCap()) == 0 &&
+		signed.GasTipCap().Cmp(tx.GasTipCap()) == 0
+}
+
+// decodeSignedTransaction accepts both the raw hex string (Web3Signer) and the {raw, tx} object (Clef)
+func decodeSignedTransaction(result json.RawMessage) (hexutil.Bytes, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+
+	var signResult struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &signResult); err != nil {
+		return nil, err
+	}
+	if len(signResult.Raw) == 0 {
+		return nil, errors.New("remote signer returned an empty transaction")
+	}
+
+	return signResult.Raw, nil
+}
@@ -0,0 +1,224 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config "backend-service/config/core_backend"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+func newTestKeystore(t *testing.T) ([]byte, common.Address) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	keyJSON, err := keystore.EncryptKey(key, testPassphrase, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	return keyJSON, key.Address
+}
+
+func newTestTx() *types.Transaction {
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(80001),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       300000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      []byte{0x40, 0xd0, 0x97, 0xc3},
+	})
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	keyJSON, address := newTestKeystore(t)
+	chainID := big.NewInt(80001)
+
+	t.Run(
+		"sign with decrypted key", func(t *testing.T) {
+			s, err := NewKeystoreSigner(keyJSON, testPassphrase)
+			require.NoError(t, err)
+			assert.Equal(t, address, s.Address())
+
+			signed, err := s.SignTx(context.Background(), newTestTx(), chainID)
+			require.NoError(t, err)
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err)
+			assert.Equal(t, address, sender)
+		},
+	)
+
+	t.Run(
+		"wrong passphrase", func(t *testing.T) {
+			_, err := NewKeystoreSigner(keyJSON, "wrong")
+			assert.ErrorIs(t, err, keystore.ErrDecrypt)
+		},
+	)
+
+	t.Run(
+		"load from files", func(t *testing.T) {
+			dir := t.TempDir()
+			keystorePath := filepath.Join(dir, "minter.json")
+			passphrasePath := filepath.Join(dir, "minter.pass")
+			require.NoError(t, os.WriteFile(keystorePath, keyJSON, 0600))
+			require.NoError(t, os.WriteFile(passphrasePath, []byte(testPassphrase+"\n"), 0600))
+
+			s, err := NewKeystoreSignerFromFile(keystorePath, passphrasePath)
+			require.NoError(t, err)
+			assert.Equal(t, address, s.Address())
+		},
+	)
+
+	t.Run(
+		"transact opts only sign for the signer address", func(t *testing.T) {
+			s, err := NewKeystoreSigner(keyJSON, testPassphrase)
+			require.NoError(t, err)
+			opts := NewTransactOpts(context.Background(), s, chainID)
+			assert.Equal(t, address, opts.From)
+
+			_, err = opts.Signer(common.HexToAddress("0x01"), newTestTx())
+			assert.ErrorIs(t, err, bind.ErrNotAuthorized)
+			_, err = opts.Signer(address, newTestTx())
+			assert.NoError(t, err)
+		},
+	)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	keyJSON, address := newTestKeystore(t)
+	local, err := NewKeystoreSigner(keyJSON, testPassphrase)
+	require.NoError(t, err)
+	chainID := big.NewInt(80001)
+	// tamper alters the transaction before the fake remote signs it
+	var tamper func(*types.DynamicFeeTx)
+
+	// fake remote signer answering eth_signTransaction the way Clef does
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var req struct {
+			Method string                `json:"method"`
+			Params []signTransactionArgs `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "eth_signTransaction", req.Method)
+
+		args := req.Params[0]
+		inner := &types.DynamicFeeTx{
+			ChainID:   args.ChainID.ToInt(),
+			Nonce:     uint64(args.Nonce),
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Gas:       uint64(args.Gas),
+			To:        args.To,
+			Value:     args.Value.ToInt(),
+			Data:      args.Data,
+		}
+		if tamper != nil {
+			tamper(inner)
+		}
+		signed, err := local.SignTx(r.Context(), types.NewTx(inner), chainID)
+		require.NoError(t, err)
+		raw, err := signed.MarshalBinary()
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  map[string]any{"raw": hexutil.Bytes(raw)},
+		})
+	}))
+	defer server.Close()
+
+	t.Run(
+		"sign through remote", func(t *testing.T) {
+			s := NewRemoteSigner(server.URL, address, "token", time.Second)
+			tx := newTestTx()
+			signed, err := s.SignTx(context.Background(), tx, chainID)
+			require.NoError(t, err)
+			assert.Equal(t, tx.Nonce(), signed.Nonce())
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err)
+			assert.Equal(t, address, sender)
+		},
+	)
+
+	t.Run(
+		"reject signature from another account", func(t *testing.T) {
+			s := NewRemoteSigner(server.URL, common.HexToAddress("0x01"), "token", time.Second)
+			_, err := s.SignTx(context.Background(), newTestTx(), chainID)
+			assert.Error(t, err)
+		},
+	)
+
+	t.Run(
+		"reject altered transaction", func(t *testing.T) {
+			attacker := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+			alterations := map[string]func(*types.DynamicFeeTx){
+				"recipient":    func(tx *types.DynamicFeeTx) { tx.To = &attacker },
+				"contract":     func(tx *types.DynamicFeeTx) { tx.To = nil },
+				"value":        func(tx *types.DynamicFeeTx) { tx.Value = big.NewInt(1) },
+				"gas":          func(tx *types.DynamicFeeTx) { tx.Gas++ },
+				"fee cap":      func(tx *types.DynamicFeeTx) { tx.GasFeeCap = big.NewInt(1000) },
+				"priority fee": func(tx *types.DynamicFeeTx) { tx.GasTipCap = big.NewInt(100) },
+			}
+			defer func() { tamper = nil }()
+			s := NewRemoteSigner(server.URL, address, "token", time.Second)
+			for name, alteration := range alterations {
+				tamper = alteration
+				_, err := s.SignTx(context.Background(), newTestTx(), chainID)
+				assert.Error(t, err, name)
+			}
+		},
+	)
+
+	t.Run(
+		"reject transaction of another type", func(t *testing.T) {
+			to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+			legacy := types.NewTx(&types.LegacyTx{Nonce: 7, GasPrice: big.NewInt(100), Gas: 300000, To: &to, Value: big.NewInt(0)})
+			s := NewRemoteSigner(server.URL, address, "token", time.Second)
+			_, err := s.SignTx(context.Background(), legacy, chainID)
+			assert.Error(t, err)
+		},
+	)
+}
+
+func TestProviderSignersByName(t *testing.T) {
+	keyJSON, address := newTestKeystore(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "minter.json"), keyJSON, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "minter.pass"), []byte(testPassphrase), 0600))
+	config.C.Signer.KEYSTORE_DIR = dir
+	config.Signers = []config.SignerConfig{{Name: "minter", Type: "keystore", KeystoreFile: "minter.json", PassphraseFile: "minter.pass"}}
+	t.Cleanup(func() { config.C.Signer.KEYSTORE_DIR, config.Signers = "", nil })
+
+	p := NewProvider()
+	s, err := p.GetSigner("minter")
+	require.NoError(t, err)
+	assert.Equal(t, address, s.Address())
+
+	_, err = p.GetSigner("attacker")
+	assert.EqualError(t, err, "unknown signer: attacker")
+}
@@ -0,0 +1,263 @@
+// Package siwe parses and verifies Sign-In with Ethereum (EIP-4361) messages
+package siwe
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+	nonceChars   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// Version the only message version defined by EIP-4361
+	Version = "1"
+)
+
+var (
+	ErrInvalidMessage   = errors.New("invalid SIWE message")
+	ErrInvalidSignature = errors.New("invalid SIWE signature")
+	ErrAddressMismatch  = errors.New("SIWE signature was not made by the message address")
+	ErrDomainMismatch   = errors.New("SIWE message domain does not match")
+	ErrURIMismatch      = errors.New("SIWE message URI is not on the expected origin")
+	ErrChainNotAllowed  = errors.New("SIWE message chain ID is not allowed")
+	ErrExpired          = errors.New("SIWE message has expired")
+	ErrNotYetValid      = errors.New("SIWE message is not valid yet")
+)
+
+// Message an EIP-4361 message
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// GenerateNonce returns a random alphanumeric nonce of 17 characters as suggested by EIP-4361
+func GenerateNonce() (string, error) {
+	nonce := make([]byte, 17)
+	for i := range nonce {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(nonceChars))))
+		if err != nil {
+			return "", err
+		}
+		nonce[i] = nonceChars[n.Int64()]
+	}
+
+	return string(nonce), nil
+}
+
+// ParseMessage parses the text a wallet signed
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMessage)
+	}
+	message := Message{Domain: strings.TrimSuffix(lines[0], headerSuffix)}
+	if !common.IsHexAddress(lines[1]) {
+		return nil, fmt.Errorf("%w: invalid address", ErrInvalidMessage)
+	}
+	message.Address = common.HexToAddress(lines[1])
+
+	i := 2
+	// Skip the blank lines around the optional statement
+	for ; i < len(lines) && !strings.HasPrefix(lines[i], "URI: "); i++ {
+		if lines[i] != "" {
+			if message.Statement != "" {
+				return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, lines[i])
+			}
+			message.Statement = lines[i]
+		}
+	}
+
+	inResources := false
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if inResources {
+			if strings.HasPrefix(line, "- ") {
+				message.Resources = append(message.Resources, strings.TrimPrefix(line, "- "))
+				continue
+			}
+			if line == "" {
+				continue
+			}
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, line)
+		}
+		if line == "Resources:" {
+			inResources = true
+			continue
+		}
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			if line == "" {
+				continue
+			}
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, line)
+		}
+		if err := message.setField(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if message.URI == "" || message.Version == "" || message.ChainID == 0 || message.Nonce == "" || message.IssuedAt.IsZero() {
+		return nil, fmt.Errorf("%w: missing required field", ErrInvalidMessage)
+	}
+	if message.Version != Version {
+		return nil, fmt.Errorf("%w: unsupported version %s", ErrInvalidMessage, message.Version)
+	}
+	if len(message.Nonce) < 8 {
+		return nil, fmt.Errorf("%w: nonce is too short", ErrInvalidMessage)
+	}
+
+	return &message, nil
+}
+
+func (m *Message) setField(key string, value string) error {
+	var err error
+	switch key {
+	case "URI":
+		m.URI = value
+	case "Version":
+		m.Version = value
+	case "Chain ID":
+		m.ChainID, err = strconv.ParseInt(value, 10, 64)
+	case "Nonce":
+		m.Nonce = value
+	case "Issued At":
+		m.IssuedAt, err = time.Parse(time.RFC3339, value)
+	case "Expiration Time":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, value)
+		m.ExpirationTime = &t
+	case "Not Before":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, value)
+		m.NotBefore = &t
+	case "Request ID":
+		m.RequestID = value
+	default:
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidMessage, key)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidMessage, key, err.Error())
+	}
+
+	return nil
+}
+
+// String renders the message in the EIP-4361 format
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address.Hex() + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + strconv.FormatInt(m.ChainID, 10) + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.Format(time.RFC3339))
+	if m.ExpirationTime != nil {
+		b.WriteString("\nExpiration Time: " + m.ExpirationTime.Format(time.RFC3339))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\nNot Before: " + m.NotBefore.Format(time.RFC3339))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) != 0 {
+		b.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			b.WriteString("\n- " + resource)
+		}
+	}
+
+	return b.String()
+}
+
+// Expected what the message must have been made for
+type Expected struct {
+	Domain string
+	// URI the origin (scheme and host) the message URI must be on
+	URI      string
+	ChainIDs []int64
+}
+
+// Validate checks the domain, URI and chain the message was made for and its validity window
+func (m *Message) Validate(expected Expected, now time.Time) error {
+	if !strings.EqualFold(m.Domain, expected.Domain) {
+		return ErrDomainMismatch
+	}
+	if !sameOrigin(m.URI, expected.URI) {
+		return ErrURIMismatch
+	}
+	if !slices.Contains(expected.ChainIDs, m.ChainID) {
+		return ErrChainNotAllowed
+	}
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return ErrExpired
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return ErrNotYetValid
+	}
+
+	return nil
+}
+
+func sameOrigin(uri string, origin string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	o, err := url.Parse(origin)
+	if err != nil || o.Host == "" {
+		return false
+	}
+
+	return strings.EqualFold(u.Scheme, o.Scheme) && strings.EqualFold(u.Host, o.Host)
+}
+
+// VerifySignature checks that the EIP-191 personal_sign signature of text was made by the message address.
+// Only externally owned accounts are supported, contract wallets (EIP-1271) are not.
+func VerifySignature(text string, message *Message, signature string) error {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return ErrInvalidSignature
+	}
+	// Wallets return v as 27/28, go-ethereum expects 0/1
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte(text)), sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if crypto.PubkeyToAddress(*publicKey) != message.Address {
+		return ErrAddressMismatch
+	}
+
+	return nil
+}
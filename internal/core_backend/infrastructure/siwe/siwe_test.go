@@ -0,0 +1,117 @@
+package siwe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMessage = `app.example.com wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+Link this wallet to your account.
+
+URI: https://app.example.com/wallet
+Version: 1
+Chain ID: 137
+Nonce: 32891756abcDEF
+Issued At: 2026-10-19T10:00:00Z
+Expiration Time: 2026-10-19T10:05:00Z
+Resources:
+- https://app.example.com/terms`
+
+func TestParseMessage(t *testing.T) {
+	message, err := ParseMessage(testMessage)
+	require.NoError(t, err)
+	assert.Equal(t, "app.example.com", message.Domain)
+	assert.Equal(t, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", message.Address.Hex())
+	assert.Equal(t, "Link this wallet to your account.", message.Statement)
+	assert.Equal(t, int64(137), message.ChainID)
+	assert.Equal(t, "32891756abcDEF", message.Nonce)
+	assert.Equal(t, []string{"https://app.example.com/terms"}, message.Resources)
+	assert.Equal(t, testMessage, message.String())
+
+}
+
+func TestValidate(t *testing.T) {
+	message, err := ParseMessage(testMessage)
+	require.NoError(t, err)
+	expected := Expected{Domain: "app.example.com", URI: "https://app.example.com", ChainIDs: []int64{1, 137}}
+	issuedAt := message.IssuedAt
+
+	tests := []struct {
+		name     string
+		expected Expected
+		now      time.Time
+		want     error
+	}{
+		{"valid", expected, issuedAt.Add(time.Minute), nil},
+		{"other domain", Expected{Domain: "evil.example.com", URI: expected.URI, ChainIDs: expected.ChainIDs}, issuedAt, ErrDomainMismatch},
+		{"other origin", Expected{Domain: expected.Domain, URI: "https://evil.example.com", ChainIDs: expected.ChainIDs}, issuedAt, ErrURIMismatch},
+		{"other scheme", Expected{Domain: expected.Domain, URI: "http://app.example.com", ChainIDs: expected.ChainIDs}, issuedAt, ErrURIMismatch},
+		{"no origin configured", Expected{Domain: expected.Domain, ChainIDs: expected.ChainIDs}, issuedAt, ErrURIMismatch},
+		{"chain not allowed", Expected{Domain: expected.Domain, URI: expected.URI, ChainIDs: []int64{1}}, issuedAt, ErrChainNotAllowed},
+		{"expired", expected, issuedAt.Add(10 * time.Minute), ErrExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := message.Validate(tt.expected, tt.now)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMessageWithoutStatement(t *testing.T) {
+	text := "app.example.com wants you to sign in with your Ethereum account:\n" +
+		"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2\n\n\n" +
+		"URI: https://app.example.com\nVersion: 1\nChain ID: 1\nNonce: abcdefgh12\nIssued At: 2026-10-19T10:00:00Z"
+	message, err := ParseMessage(text)
+	require.NoError(t, err)
+	assert.Empty(t, message.Statement)
+	assert.Equal(t, text, message.String())
+
+	_, err = ParseMessage("app.example.com wants you to sign in with your Ethereum account:\nnot-an-address\n")
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	nonce, err := GenerateNonce()
+	require.NoError(t, err)
+
+	message := &Message{
+		Domain:    "app.example.com",
+		Address:   crypto.PubkeyToAddress(key.PublicKey),
+		URI:       "https://app.example.com",
+		Version:   Version,
+		ChainID:   1,
+		Nonce:     nonce,
+		IssuedAt:  time.Now().UTC().Truncate(time.Second),
+		Statement: "Link this wallet to your account.",
+	}
+	text := message.String()
+	signature, err := crypto.Sign(accounts.TextHash([]byte(text)), key)
+	require.NoError(t, err)
+	// Sign the way wallets do, with v = 27/28
+	signature[crypto.RecoveryIDOffset] += 27
+
+	parsed, err := ParseMessage(text)
+	require.NoError(t, err)
+	assert.NoError(t, VerifySignature(text, parsed, hexutil.Encode(signature)))
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	parsed.Address = crypto.PubkeyToAddress(other.PublicKey)
+	assert.ErrorIs(t, VerifySignature(text, parsed, hexutil.Encode(signature)), ErrAddressMismatch)
+	assert.ErrorIs(t, VerifySignature(text, parsed, "0x1234"), ErrInvalidSignature)
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -54,3 +55,32 @@ func (c *GCPClient) UploadFile(file multipart.File, uploadPath, fileName, conten
 
 	return fmt.Sprintf("%s/%s/%s/%s", config.C.GCP.StorageDomain, c.bucketName, uploadPath, fileName), nil
 }
+
+// UploadObject writes the content to the object at objectPath, replacing the object when it exists
+func (c *GCPClient) UploadObject(objectPath string, content []byte, contentType, cacheControl string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	wc := c.client.Bucket(c.bucketName).Object(objectPath).NewWriter(ctx)
+	wc.ContentType = contentType
+	wc.CacheControl = cacheControl
+	if _, err := wc.Write(content); err != nil {
+		return "", fmt.Errorf("Writer.Write: %v", err)
+	}
+	if err := wc.Close(); err != nil {
+		return "", fmt.Errorf("Writer.Close: %v", err)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", config.C.GCP.StorageDomain, c.bucketName, objectPath), nil
+}
+
+// DeleteObject removes the object at objectPath, an object that does not exist is not an error
+func (c *GCPClient) DeleteObject(objectPath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	err := c.client.Bucket(c.bucketName).Object(objectPath).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("Object(%q).Delete: %v", objectPath, err)
+	}
+
+	return nil
+}
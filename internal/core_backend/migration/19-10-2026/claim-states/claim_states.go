@@ -0,0 +1,39 @@
+package claim_states
+
+import (
+	"context"
+	"log"
+
+	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrateClaimStates writes the claim state of the mappings written before claim states from their claimable flag.
+// Items with an owner are claimed, claimable ones claimable and the others unclaimable, the flag is removed.
+func MigrateClaimStates(database *mongo.Database) {
+	log.Println("Migrate the claimable flag of mappings into claim states")
+	col := database.Collection(entity.Mapping{}.CollectionName())
+	legacy := bson.M{"claim_state": bson.M{"$in": bson.A{nil, ""}}}
+	owned := bson.M{"owner_id": bson.M{"$nin": bson.A{nil, ""}}}
+
+	for _, step := range []struct {
+		state  string
+		filter bson.M
+	}{
+		{entity.ClaimStateClaimed, bson.M{"$and": bson.A{legacy, owned}}},
+		{entity.ClaimStateClaimable, bson.M{"$and": bson.A{legacy, bson.M{"claimable": true}}}},
+		{entity.ClaimStateUnclaimable, legacy},
+	} {
+		result, err := col.UpdateMany(context.TODO(), step.filter, bson.M{"$set": bson.M{"claim_state": step.state}})
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("%d mappings %s", result.ModifiedCount, step.state)
+	}
+
+	if _, err := col.UpdateMany(context.TODO(), bson.M{"claimable": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"claimable": ""}}); err != nil {
+		log.Fatal(err)
+	}
+}
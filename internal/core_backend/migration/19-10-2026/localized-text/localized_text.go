@@ -0,0 +1,149 @@
+package localized_text
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrateLocalizedTexts stores the localized texts of authors and templates with canonical BCP 47 tags.
+// Author names become documents of language to text, the names kept in the translation of authors move into them,
+// and the other translated fields are kept as text so they decode as translation.Translations.
+func MigrateLocalizedTexts(database *mongo.Database) {
+	log.Println("Migrate the localized texts of authors and templates")
+	migrateAuthors(database.Collection(entity.Author{}.CollectionName()))
+	migrateTemplateLanguages(database.Collection(entity.Template{}.CollectionName()))
+}
+
+func migrateAuthors(col *mongo.Collection) {
+	cursor, err := col.Find(context.TODO(), bson.M{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var authors []bson.M
+	if err = cursor.All(context.TODO(), &authors); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, author := range authors {
+		id := author["_id"].(primitive.ObjectID)
+		name := map[string]string{}
+		switch value := author["name"].(type) {
+		case string:
+			// Names written before they were localized are in the default language
+			if value != "" {
+				name[translation.DefaultLanguages[0]] = value
+			}
+		case bson.M:
+			for tag, text := range value {
+				if canonical, ok := canonicalTag(id, tag); ok {
+					if s, ok := text.(string); ok && s != "" {
+						name[canonical] = s
+					}
+				}
+			}
+		}
+
+		translations := translation.Translations{}
+		if value, ok := author["translation"].(bson.M); ok {
+			for tag, fields := range value {
+				canonical, ok := canonicalTag(id, tag)
+				if !ok {
+					continue
+				}
+				fieldMap, ok := fields.(bson.M)
+				if !ok {
+					continue
+				}
+				for field, text := range fieldMap {
+					s, ok := toText(text)
+					if !ok {
+						log.Printf("Author %s: translation %s.%s is not a text, dropped", id.Hex(), tag, field)
+						continue
+					}
+					if s == "" {
+						continue
+					}
+					if field == "name" {
+						if name[canonical] == "" {
+							name[canonical] = s
+						}
+						continue
+					}
+					if translations[canonical] == nil {
+						translations[canonical] = map[string]string{}
+					}
+					translations[canonical][field] = s
+				}
+			}
+		}
+
+		update := bson.M{"$set": bson.M{"name": name}}
+		if len(translations) == 0 {
+			update["$unset"] = bson.M{"translation": ""}
+		} else {
+			update["$set"].(bson.M)["translation"] = translations
+		}
+		if _, err := col.UpdateByID(context.TODO(), id, update); err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("Migrated %d authors", len(authors))
+}
+
+func migrateTemplateLanguages(col *mongo.Collection) {
+	cursor, err := col.Find(context.TODO(), bson.M{"languages": bson.M{"$type": "array"}})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var templates []struct {
+		ID        primitive.ObjectID `bson:"_id"`
+		Languages []string           `bson:"languages"`
+	}
+	if err = cursor.All(context.TODO(), &templates); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, template := range templates {
+		languages := []string{}
+		for _, tag := range template.Languages {
+			if canonical, ok := canonicalTag(template.ID, tag); ok {
+				languages = append(languages, canonical)
+			}
+		}
+		if _, err := col.UpdateByID(context.TODO(), template.ID, bson.M{"$set": bson.M{"languages": languages}}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("Migrated the languages of %d templates", len(templates))
+}
+
+func canonicalTag(id primitive.ObjectID, tag string) (string, bool) {
+	canonical, err := translation.ParseLanguage(tag)
+	if err != nil {
+		log.Printf("Document %s: %s, dropped", id.Hex(), err)
+		return "", false
+	}
+
+	return canonical, true
+}
+
+func toText(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int32, int64, float64, bool:
+		return fmt.Sprint(v), true
+	case nil:
+		return "", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	claim_states "backend-service/internal/core_backend/migration/19-10-2026/claim-states"
+	localized_text "backend-service/internal/core_backend/migration/19-10-2026/localized-text"
+	metadata_template "backend-service/internal/core_backend/migration/19-10-2026/metadata-template"
+	product_types "backend-service/internal/core_backend/migration/19-10-2026/product-types"
+	product_versions "backend-service/internal/core_backend/migration/19-10-2026/product-versions"
+	pubsub_messages "backend-service/internal/core_backend/migration/19-10-2026/pubsub-messages"
+	role_bindings "backend-service/internal/core_backend/migration/19-10-2026/role-bindings"
+	sync_block "backend-service/internal/core_backend/migration/19-10-2026/sync-block"
+	tenant_ownership "backend-service/internal/core_backend/migration/19-10-2026/tenant-ownership"
+	webpage_blocks "backend-service/internal/core_backend/migration/19-10-2026/webpage-blocks"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	SOURCE_DB = ""
+	MONGO_URI = ""
+	// chain the contracts listened to before multi chain support are deployed on
+	CHAIN_ID = 80001
+)
+
+func main() {
+	// Connect to the MongoDB instance
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(MONGO_URI))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Disconnect(context.Background())
+
+	SourceDB := client.Database(SOURCE_DB)
+
+	log.Println("Data migration starting...")
+
+	//Comment if you don't want to migrate specific database
+	metadata_template.SeedMetadataTemplates(SourceDB)
+	sync_block.MigrateSyncBlock(SourceDB, CHAIN_ID)
+	tenant_ownership.BackfillTenantOwnership(SourceDB)
+	pubsub_messages.CreatePubsubMessageIndexes(SourceDB)
+	role_bindings.BackfillRoleBindings(SourceDB)
+	product_types.SeedProductTypes(SourceDB)
+	localized_text.MigrateLocalizedTexts(SourceDB)
+	product_versions.BackfillProductVersions(SourceDB)
+	webpage_blocks.MigrateWebPageBlocks(SourceDB)
+	claim_states.MigrateClaimStates(SourceDB)
+
+	log.Println("Data migration complete.")
+}
@@ -0,0 +1,98 @@
+package metadata_template
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// templates reproduce the metadata that used to be hard-coded per organization in digitalAsset.ConstructMetadata
+var templates = map[string]entity.MetadataTemplate{
+	"lej": {
+		Name:        "{{product.product_name}} #{{item.item_index}}",
+		Image:       "{{product.image.url}}",
+		ExternalURL: "https://nomion.io/",
+		Attributes: []entity.MetadataTemplateAttribute{
+			{TraitType: "Origin", Value: "{{product.origin}}"},
+			{TraitType: "Farm", Value: "{{attribute.farm_name}}"},
+			{TraitType: "Preprocessed Type", Value: "{{attribute.process}}"},
+			{TraitType: "Acidity", Value: "{{attribute.acidity}}"},
+			{TraitType: "Bitter", Value: "{{attribute.bitter}}"},
+			{TraitType: "Sweet", Value: "{{attribute.sweet}}"},
+		},
+	},
+	"da-non-nuoc": {
+		Name:         "{{product.product_name}}",
+		Description:  "{{attribute.translation.vi.description | strip_html}}",
+		Image:        "{{product.image.url}}",
+		AnimationURL: "{{product.three_dimension.url}}",
+		ExternalURL:  "https://nomion.io/",
+		Attributes: []entity.MetadataTemplateAttribute{
+			{TraitType: "Tác Giả", Value: "{{attribute.craftsman.name}}"},
+			{TraitType: "Cuộc Thi", Value: "Đá Non Nước 2023"},
+			{TraitType: "Chất Liệu", Value: "{{attribute.stone.translation.vi.name}}"},
+			{TraitType: "Kích thước", Value: "{{attribute.sculpture_size}}"},
+		},
+	},
+	"astronaut": {
+		Name:         "{{product.product_name}}",
+		Image:        "{{product.video.thumbnail_url}}",
+		AnimationURL: "{{product.video.url}}",
+		ExternalURL:  "https://nomion.io/",
+		Attributes: []entity.MetadataTemplateAttribute{
+			// The owner was the product name without its first word, such as "Astronaut Neil" for Neil
+			{TraitType: "Owner", Value: "{{product.product_name | skip_first_word}}"},
+		},
+	},
+}
+
+func SeedMetadataTemplates(database *mongo.Database) {
+	log.Println("Create the metadata template of the lej, da-non-nuoc and astronaut collections")
+	orgCol := database.Collection(entity.Organization{}.CollectionName())
+	collectionCol := database.Collection(entity.DigitalAssetCollection{}.CollectionName())
+	templateCol := database.Collection(entity.MetadataTemplate{}.CollectionName())
+
+	for orgTagName, template := range templates {
+		var org entity.Organization
+		err := orgCol.FindOne(context.TODO(), bson.D{{Key: "org_tag_name", Value: orgTagName}}).Decode(&org)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Println("Organization", orgTagName, "not found, skipped")
+			continue
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var collection entity.DigitalAssetCollection
+		err = collectionCol.FindOne(context.TODO(), bson.D{{Key: "org_id", Value: org.ID}}).Decode(&collection)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Println("Organization", orgTagName, "has no digital asset collection, skipped")
+			continue
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		template.CollectionID = collection.ID
+		template.Status = common.StatusActive
+		template.CreatedAt = time.Now()
+		template.UpdatedAt = time.Now()
+		_, err = templateCol.ReplaceOne(
+			context.TODO(),
+			bson.D{{Key: "collection_id", Value: collection.ID}},
+			template,
+			options.Replace().SetUpsert(true))
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println("Metadata template of", orgTagName, "saved")
+	}
+}
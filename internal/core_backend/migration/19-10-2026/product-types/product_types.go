@@ -0,0 +1,179 @@
+package product_types
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/jsonschema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// media the schema of entity.Media, shared by the seeded types
+const media = `{"type": "object", "properties": {"url": {"type": "string"}, "type": {"type": "string"}, "thumbnail_url": {"type": "string"}}}`
+
+// schemas reproduce the attribute structs that used to be hard-coded per type in entity.Product.ParseAttribute.
+// They constrain the known fields only, the attributes stored before may hold more.
+var schemas = map[string]struct {
+	displayName string
+	schema      string
+}{
+	"coffee": {"Coffee", `{
+		"type": "object",
+		"properties": {
+			"farm_name": {"type": "string"},
+			"farm_video": {"$ref": "#/$defs/media"},
+			"farm_image": {"$ref": "#/$defs/media"},
+			"farm_height": {"type": "string"},
+			"farm_area": {"type": "string"},
+			"varietal": {"type": "string"},
+			"process": {"type": "string"},
+			"country_name": {"type": "string"},
+			"country_video": {"$ref": "#/$defs/media"},
+			"country_image": {"$ref": "#/$defs/media"},
+			"brewing_time": {"type": "string"},
+			"link_buy_product": {"type": "string"},
+			"acidity": {"type": "string"},
+			"bitter": {"type": "string"},
+			"sweet": {"type": "string"},
+			"translation": {"type": "object"}
+		},
+		"$defs": {"media": ` + media + `}
+	}`},
+	"sculpture": {"Sculpture", `{
+		"type": "object",
+		"properties": {
+			"contact_name": {"type": "string"},
+			"sculpture_rank": {"type": "string"},
+			"sculpture_pedestal_size": {"type": "string"},
+			"sculpture_size": {"type": "string"},
+			"sculpture_time": {"type": "string"},
+			"sculpture_weight": {"type": "string"},
+			"sculpture_height": {"type": "string"},
+			"sculpture_length": {"type": "string"},
+			"sculpture_width": {"type": "string"},
+			"village": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"translation": {"type": "object"},
+					"location_video": {"$ref": "#/$defs/media"}
+				}
+			},
+			"craftsman": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"experience_year": {"type": "string"},
+					"artworks_count": {"type": "string"},
+					"phone": {"type": "string"},
+					"email": {"type": "string"},
+					"avatar": {"$ref": "#/$defs/media"},
+					"description": {"type": "string"}
+				}
+			},
+			"stone": {
+				"type": "object",
+				"properties": {
+					"origin": {"type": "string"},
+					"image": {"$ref": "#/$defs/media"},
+					"translation": {"type": "object"}
+				}
+			},
+			"processes": {
+				"type": ["array", "null"],
+				"items": {
+					"type": "object",
+					"properties": {
+						"image_url": {"type": "string"},
+						"description": {
+							"type": "object",
+							"properties": {"en": {"type": "string"}, "vi": {"type": "string"}}
+						}
+					}
+				}
+			},
+			"translation": {"type": "object"}
+		},
+		"$defs": {"media": ` + media + `}
+	}`},
+	"astronaut": {"Astronaut", `{"type": "object"}`},
+	"ortho":     {"Ortho", `{"type": "object"}`},
+}
+
+// SeedProductTypes creates the indexes of product types and the types products were limited to before,
+// and records the products of these types as validated against the first version of their schema
+func SeedProductTypes(database *mongo.Database) {
+	log.Println("Create the coffee, sculpture, astronaut and ortho product types")
+	typeCol := database.Collection(entity.ProductType{}.CollectionName())
+	schemaCol := database.Collection(entity.ProductTypeSchema{}.CollectionName())
+	productCol := database.Collection(entity.Product{}.CollectionName())
+
+	_, err := typeCol.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, err = schemaCol.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "type_name", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for name, seed := range schemas {
+		if _, err := jsonschema.Compile([]byte(seed.schema)); err != nil {
+			log.Fatalf("Schema of %s: %s", name, err)
+		}
+		var compact json.RawMessage
+		if compact, err = compactJSON(seed.schema); err != nil {
+			log.Fatal(err)
+		}
+
+		count, err := typeCol.CountDocuments(context.TODO(), bson.M{"name": name})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if count != 0 {
+			log.Printf("Product type %s exists already", name)
+			continue
+		}
+
+		productType := entity.ProductType{Name: name, DisplayName: seed.displayName, Version: 1, Schema: compact}
+		productType.SetTime()
+		productType.SetStatus(common.StatusActive)
+		if _, err = typeCol.InsertOne(context.TODO(), productType); err != nil {
+			log.Fatal(err)
+		}
+		version := entity.ProductTypeSchema{TypeName: name, Version: 1, Schema: compact}
+		version.SetTime()
+		if _, err = schemaCol.InsertOne(context.TODO(), version); err != nil {
+			log.Fatal(err)
+		}
+
+		result, err := productCol.UpdateMany(context.TODO(),
+			bson.M{"type": name, "type_version": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"type_version": 1}})
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Created product type %s, %d products recorded at version 1", name, result.ModifiedCount)
+	}
+}
+
+func compactJSON(raw string) (json.RawMessage, error) {
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
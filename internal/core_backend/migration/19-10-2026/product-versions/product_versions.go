@@ -0,0 +1,58 @@
+package product_versions
+
+import (
+	"context"
+	"log"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BackfillProductVersions creates the unique index of product versions, which also keeps products to a single draft,
+// and records the live content of the products created before versioning as their version 1
+func BackfillProductVersions(database *mongo.Database) {
+	log.Println("Record the content of existing products as their first version")
+	versionCol := database.Collection(entity.ProductVersion{}.CollectionName())
+	productCol := database.Collection(entity.Product{}.CollectionName())
+
+	_, err := versionCol.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cursor, err := productCol.Find(context.TODO(), bson.M{"published_version": bson.M{"$in": bson.A{0, nil}}})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var products []entity.Product
+	if err = cursor.All(context.TODO(), &products); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, product := range products {
+		product.PublishedVersion = 1
+		publishedAt := product.UpdatedAt
+		version := entity.ProductVersion{
+			BaseModel:      entity.BaseModel{Status: common.ProductVersionPublished, CreatedAt: product.CreatedAt, UpdatedAt: product.UpdatedAt},
+			ProductID:      product.ID,
+			OrganizationID: product.OrganizationID,
+			Version:        1,
+			Content:        product,
+			PublishedAt:    &publishedAt,
+		}
+		if _, err := versionCol.InsertOne(context.TODO(), version); err != nil && !mongo.IsDuplicateKeyError(err) {
+			log.Fatal(err)
+		}
+		if _, err := productCol.UpdateByID(context.TODO(), product.ID, bson.M{"$set": bson.M{"published_version": 1}}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("Recorded %d products at version 1", len(products))
+}
@@ -0,0 +1,25 @@
+package pubsub_messages
+
+import (
+	"context"
+	"log"
+
+	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreatePubsubMessageIndexes removes received push messages once their replay window is over
+func CreatePubsubMessageIndexes(database *mongo.Database) {
+	log.Println("Create the TTL index of pubsub messages")
+
+	_, err := database.Collection(entity.PubsubMessage{}.CollectionName()).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,67 @@
+package role_bindings
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BackfillRoleBindings binds the SUPER_ADMIN and ORG_ADMIN roles of the users holding them as legacy role claim.
+// Role claims are synced from the role bindings since, the users would lose their role otherwise.
+func BackfillRoleBindings(database *mongo.Database) {
+	log.Println("Backfill the role bindings of the users holding a legacy role claim")
+
+	filter := bson.M{"role": bson.M{"$in": bson.A{string(entity.SUPER_ADMIN_ROLE), string(entity.ORG_ADMIN_ROLE)}}}
+	cursor, err := database.Collection(entity.User{}.CollectionName()).Find(context.TODO(), filter)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var users []entity.User
+	if err = cursor.All(context.TODO(), &users); err != nil {
+		log.Fatal(err)
+	}
+
+	bindingCol := database.Collection(entity.RoleBinding{}.CollectionName())
+	orgCol := database.Collection(entity.Organization{}.CollectionName())
+	now := time.Now()
+	for _, user := range users {
+		binding := entity.RoleBinding{UserID: user.ID, RoleName: user.Role}
+		if user.Role == string(entity.ORG_ADMIN_ROLE) {
+			binding.OrganizationID = user.OrganizationID
+			if binding.OrganizationID.IsZero() && user.Organization != "" {
+				var org entity.Organization
+				err = orgCol.FindOne(context.TODO(), bson.M{"org_tag_name": user.Organization}).Decode(&org)
+				if err != nil && err != mongo.ErrNoDocuments {
+					log.Fatal(err)
+				}
+				binding.OrganizationID = org.ID
+			}
+			if binding.OrganizationID.IsZero() {
+				log.Printf("Skip user %s: ORG_ADMIN without organization", user.ID)
+				continue
+			}
+		}
+
+		_, err = bindingCol.UpdateOne(context.TODO(),
+			bson.D{
+				{Key: "user_id", Value: binding.UserID},
+				{Key: "org_id", Value: binding.OrganizationID},
+				{Key: "role_name", Value: binding.RoleName},
+			},
+			bson.D{
+				{Key: "$set", Value: bson.D{{Key: "updated_at", Value: now}}},
+				{Key: "$setOnInsert", Value: bson.D{{Key: "created_at", Value: now}}},
+			},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
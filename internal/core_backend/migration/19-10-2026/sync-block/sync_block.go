@@ -0,0 +1,42 @@
+package sync_block
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MigrateSyncBlock moves the single `blockchain` sync block variable to the per chain variable of chainID
+func MigrateSyncBlock(database *mongo.Database, chainID int64) {
+	log.Println("Move the last synced block to the variable of chain", chainID)
+	variableCol := database.Collection(entity.Variable{}.CollectionName())
+
+	var variable entity.Variable
+	err := variableCol.FindOne(context.TODO(), bson.D{{Key: "_id", Value: "blockchain"}}).Decode(&variable)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		log.Println("No legacy sync block variable, skipped")
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = variableCol.UpdateOne(
+		context.TODO(),
+		bson.D{{Key: "_id", Value: entity.SyncBlockVariableID(chainID)}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "last_sync_block_number", Value: variable.LastSyncBlockNumber}}}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err = variableCol.DeleteOne(context.TODO(), bson.D{{Key: "_id", Value: "blockchain"}}); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,111 @@
+package tenant_ownership
+
+import (
+	"context"
+	"log"
+
+	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BackfillTenantOwnership sets the organization of templates, web pages and authors from the products using them.
+// A template or author used by the products of a single organization is owned by that organization, and so are
+// the template's pages unless another organization's template uses them too. Everything else stays shared.
+func BackfillTenantOwnership(database *mongo.Database) {
+	log.Println("Backfill the organization of templates, web pages and authors")
+
+	cursor, err := database.Collection(entity.Product{}.CollectionName()).Find(context.TODO(), bson.M{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var products []entity.Product
+	if err = cursor.All(context.TODO(), &products); err != nil {
+		log.Fatal(err)
+	}
+
+	templateOrgs := map[primitive.ObjectID]map[primitive.ObjectID]bool{}
+	authorOrgs := map[primitive.ObjectID]map[primitive.ObjectID]bool{}
+	for _, product := range products {
+		if product.OrganizationID.IsZero() {
+			continue
+		}
+		if !product.TemplateID.IsZero() {
+			if templateOrgs[product.TemplateID] == nil {
+				templateOrgs[product.TemplateID] = map[primitive.ObjectID]bool{}
+			}
+			templateOrgs[product.TemplateID][product.OrganizationID] = true
+		}
+		if !product.AuthorID.IsZero() {
+			if authorOrgs[product.AuthorID] == nil {
+				authorOrgs[product.AuthorID] = map[primitive.ObjectID]bool{}
+			}
+			authorOrgs[product.AuthorID][product.OrganizationID] = true
+		}
+	}
+
+	templateCol := database.Collection(entity.Template{}.CollectionName())
+	cursor, err = templateCol.Find(context.TODO(), bson.M{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var templates []entity.Template
+	if err = cursor.All(context.TODO(), &templates); err != nil {
+		log.Fatal(err)
+	}
+
+	pageOrgs := map[primitive.ObjectID]map[primitive.ObjectID]bool{}
+	for _, template := range templates {
+		orgs := templateOrgs[template.ID]
+		for _, page := range template.Pages {
+			if pageOrgs[page.PageID] == nil {
+				pageOrgs[page.PageID] = map[primitive.ObjectID]bool{}
+			}
+			for orgID := range orgs {
+				pageOrgs[page.PageID][orgID] = true
+			}
+			// A page of a shared template stays shared
+			if len(orgs) != 1 {
+				pageOrgs[page.PageID][primitive.NilObjectID] = true
+			}
+		}
+		if !template.OrganizationID.IsZero() || len(orgs) != 1 {
+			continue
+		}
+		if _, err := templateCol.UpdateByID(context.TODO(), template.ID, bson.M{"$set": bson.M{"org_id": soleOrganization(orgs)}}); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	pageCol := database.Collection(entity.WebPage{}.CollectionName())
+	for pageID, orgs := range pageOrgs {
+		if len(orgs) != 1 || orgs[primitive.NilObjectID] {
+			continue
+		}
+		filter := bson.M{"_id": pageID, "org_id": bson.M{"$exists": false}}
+		if _, err := pageCol.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"org_id": soleOrganization(orgs)}}); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	authorCol := database.Collection(entity.Author{}.CollectionName())
+	for authorID, orgs := range authorOrgs {
+		if len(orgs) != 1 {
+			continue
+		}
+		filter := bson.M{"_id": authorID, "org_id": bson.M{"$exists": false}}
+		if _, err := authorCol.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"org_id": soleOrganization(orgs)}}); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func soleOrganization(orgs map[primitive.ObjectID]bool) primitive.ObjectID {
+	for orgID := range orgs {
+		return orgID
+	}
+
+	return primitive.NilObjectID
+}
@@ -0,0 +1,235 @@
+package webpage_blocks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StoryPageType the type of the pages whose attributes are migrated into blocks
+const StoryPageType = "story"
+
+var (
+	imageExtensions   = []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".svg"}
+	model3DExtensions = []string{".glb", ".gltf", ".usdz"}
+)
+
+// MigrateWebPageBlocks writes the blocks of the story pages without blocks from their attributes.
+// Texts of the language documents, {"vi": {...}, "en": {...}}, become rich texts, a fooTitle with its fooDescription,
+// video URLs become videos with the thumbnail of the same name, image URLs a gallery, 3D model URLs 3D models
+// and latitude with longitude a map location. Attributes are kept, the ones no block was made from are logged.
+func MigrateWebPageBlocks(database *mongo.Database) {
+	log.Println("Migrate the attributes of story pages into blocks")
+	col := database.Collection(entity.WebPage{}.CollectionName())
+	cursor, err := col.Find(context.TODO(), bson.M{"type": StoryPageType, "blocks": nil})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var pages []struct {
+		ID         primitive.ObjectID `bson:"_id"`
+		Attributes any                `bson:"attributes"`
+	}
+	if err = cursor.All(context.TODO(), &pages); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, page := range pages {
+		blocks, skipped := BlocksFromAttributes(attributesOf(page.Attributes))
+		for _, key := range skipped {
+			log.Printf("Webpage %s: attribute %s kept without block", page.ID.Hex(), key)
+		}
+		valid := []entity.WebPageBlock{}
+		for _, block := range blocks {
+			if err := block.Validate(); err != nil {
+				log.Printf("Webpage %s: %s block dropped, %s", page.ID.Hex(), block.Type, err)
+				continue
+			}
+			valid = append(valid, block)
+		}
+		if _, err := col.UpdateByID(context.TODO(), page.ID, bson.M{"$set": bson.M{"blocks": valid}}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("Migrated %d story pages", len(pages))
+}
+
+// attributesOf the attributes of a page, pages written before attributes were a document hold a list of documents
+func attributesOf(value any) map[string]any {
+	attributes := map[string]any{}
+	switch v := value.(type) {
+	case primitive.D:
+		for _, e := range v {
+			attributes[e.Key] = e.Value
+		}
+	case primitive.M:
+		for key, item := range v {
+			attributes[key] = item
+		}
+	case primitive.A:
+		for _, item := range v {
+			for key, value := range attributesOf(item) {
+				attributes[key] = value
+			}
+		}
+	}
+
+	return attributes
+}
+
+// BlocksFromAttributes the blocks made from the attributes, in a stable order, and the keys of the attributes left out
+func BlocksFromAttributes(attributes map[string]any) ([]entity.WebPageBlock, []string) {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	texts := map[string]map[string]string{}
+	used := map[string]bool{}
+	for _, key := range keys {
+		language, err := translation.ParseLanguage(key)
+		fields := attributesOf(attributes[key])
+		if err != nil || len(fields) == 0 {
+			continue
+		}
+		used[key] = true
+		for field, value := range fields {
+			if text, ok := value.(string); ok && text != "" {
+				if texts[field] == nil {
+					texts[field] = map[string]string{}
+				}
+				texts[field][language] = text
+			}
+		}
+	}
+
+	var blocks []entity.WebPageBlock
+	blocks = append(blocks, richTextBlocks(texts)...)
+
+	gallery := &entity.ImageGalleryBlock{}
+	for _, key := range keys {
+		link, ok := attributes[key].(string)
+		if used[key] || !ok || !isURL(link) {
+			continue
+		}
+		lower := strings.ToLower(key)
+		switch {
+		case strings.Contains(lower, "thumbnail"):
+			// read with the video of the same name
+		case strings.Contains(lower, "video"):
+			used[key] = true
+			thumbnailKey := strings.TrimSuffix(key, "Url") + "ThumbnailUrl"
+			if key == "video" {
+				thumbnailKey = "videoThumbnail"
+			}
+			thumbnail, _ := attributes[thumbnailKey].(string)
+			if thumbnail != "" {
+				used[thumbnailKey] = true
+			}
+			blocks = append(blocks, entity.WebPageBlock{Type: entity.BlockTypeVideo, Video: &entity.VideoBlock{URL: link, ThumbnailURL: thumbnail}})
+		case hasExtension(link, model3DExtensions):
+			used[key] = true
+			blocks = append(blocks, entity.WebPageBlock{Type: entity.BlockTypeModel3D, Model3D: &entity.Model3DBlock{URL: link}})
+		case hasExtension(link, imageExtensions) || strings.Contains(lower, "image"):
+			used[key] = true
+			gallery.Images = append(gallery.Images, entity.GalleryImage{URL: link})
+		}
+	}
+	if len(gallery.Images) > 0 {
+		blocks = append(blocks, entity.WebPageBlock{Type: entity.BlockTypeImageGallery, ImageGallery: gallery})
+	}
+
+	latitude, latOK := toFloat(attributes["latitude"])
+	longitude, lngOK := toFloat(attributes["longitude"])
+	if latOK && lngOK {
+		used["latitude"], used["longitude"] = true, true
+		blocks = append(blocks, entity.WebPageBlock{Type: entity.BlockTypeMapLocation, MapLocation: &entity.MapLocationBlock{Latitude: latitude, Longitude: longitude}})
+	}
+
+	for i := range blocks {
+		blocks[i].ID = primitive.NewObjectID().Hex()
+	}
+	var skipped []string
+	for _, key := range keys {
+		if !used[key] {
+			skipped = append(skipped, key)
+		}
+	}
+
+	return blocks, skipped
+}
+
+// richTextBlocks a rich text of each text field, titled by the fooTitle field of a fooDescription field
+func richTextBlocks(texts map[string]map[string]string) []entity.WebPageBlock {
+	fields := make([]string, 0, len(texts))
+	for field := range texts {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var blocks []entity.WebPageBlock
+	for _, field := range fields {
+		prefix, isTitle := strings.CutSuffix(field, "Title")
+		if isTitle && texts[prefix+"Description"] != nil {
+			continue
+		}
+		content := &entity.RichTextBlock{Body: localized(texts[field])}
+		if prefix, ok := strings.CutSuffix(field, "Description"); ok {
+			content.Title = localized(texts[prefix+"Title"])
+		}
+		blocks = append(blocks, entity.WebPageBlock{Type: entity.BlockTypeRichText, RichText: content})
+	}
+
+	return blocks
+}
+
+func localized(texts map[string]string) translation.LocalizedString {
+	ls, _ := translation.NewLocalizedString(texts)
+	return ls
+}
+
+func isURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func hasExtension(link string, extensions []string) bool {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(parsed.Path))
+	for _, known := range extensions {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		var f float64
+		_, err := fmt.Sscan(v, &f)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package main
+
+import "backend-service/internal/core_backend/entity"
+
+// The attributes of the product types of the time, product types are data since and these types are gone from entity
+
+type AttributeCoffee struct {
+	FarmName       string         `bson:"farm_name" json:"farm_name"`
+	FarmVideo      entity.Media   `bson:"farm_video" json:"farm_video"`
+	FarmImage      entity.Media   `bson:"farm_image" json:"farm_image"`
+	FarmHeight     string         `bson:"farm_height" json:"farm_height"`
+	FarmArea       string         `bson:"farm_area" json:"farm_area"`
+	Varietal       string         `bson:"varietal" json:"varietal"`
+	Process        string         `bson:"process" json:"process"`
+	CountryName    string         `bson:"country_name" json:"country_name"`
+	CountryVideo   entity.Media   `bson:"country_video" json:"country_video"`
+	CountryImage   entity.Media   `bson:"country_image" json:"country_image"`
+	BrewingTime    string         `bson:"brewing_time" json:"brewing_time"`
+	LinkBuyProduct string         `bson:"link_buy_product" json:"link_buy_product"`
+	Acidity        string         `bson:"acidity" json:"acidity"`
+	Bitter         string         `bson:"bitter" json:"bitter"`
+	Sweet          string         `bson:"sweet" json:"sweet"`
+	Translation    map[string]any `bson:"translation" json:"translation"`
+}
+
+type AttributeSculpture struct {
+	ContactName           string `bson:"contact_name" json:"contact_name"`
+	SculptureRank         string `bson:"sculpture_rank" json:"sculpture_rank"`
+	SculpturePedestalSize string `bson:"sculpture_pedestal_size" json:"sculpture_pedestal_size"`
+	SculptureSize         string `bson:"sculpture_size" json:"sculpture_size"`
+	SculptureTime         string `bson:"sculpture_time" json:"sculpture_time"`
+	SculptureWeight       string `bson:"sculpture_weight" json:"sculpture_weight"`
+	// remove 3 lines below
+	SculptureHeight string `bson:"sculpture_height" json:"sculpture_height"`
+	SculptureLength string `bson:"sculpture_length" json:"sculpture_length"`
+	SculptureWidth  string `bson:"sculpture_width" json:"sculpture_width"`
+	//Description     string         `bson:"description" json:"description"`
+	Village     Village        `bson:"village" json:"village"`
+	Craftsman   Craftsman      `bson:"craftsman" json:"craftsman"`
+	Stone       Stone          `bson:"stone" json:"stone"`
+	Processes   []Process      `bson:"processes" json:"processes"`
+	Translation map[string]any `bson:"translation" json:"translation"`
+}
+
+type Craftsman struct {
+	Name           string       `bson:"name" json:"name"`
+	ExperienceYear string       `bson:"experience_year" json:"experience_year"`
+	ArtworksCount  string       `bson:"artworks_count" json:"artworks_count"`
+	Phone          string       `bson:"phone" json:"phone"`
+	Email          string       `bson:"email" json:"email"`
+	Avatar         entity.Media `bson:"avatar" json:"avatar"`
+	Description    string       `bson:"description" json:"description"`
+}
+
+type Process struct {
+	ImageURL    string `bson:"image_url" json:"image_url"`
+	Description struct {
+		EN string `bson:"en" json:"en"`
+		VI string `bson:"vi" json:"vi"`
+	} `bson:"description" json:"description"`
+}
+
+type Stone struct {
+	//Name       string `bson:"name" json:"name"`
+	Origin string `bson:"origin" json:"origin"`
+	//Clarity    string `bson:"clarity" json:"clarity"`
+	//Rarity     string `bson:"rarity" json:"rarity"`
+	//Properties string `bson:"properties" json:"properties"`
+	//Color      string `bson:"color" json:"color"`
+	Image       entity.Media   `bson:"image" json:"image"`
+	Translation map[string]any `bson:"translation" json:"translation"`
+}
+
+type Village struct {
+	Name          string         `bson:"name" json:"name"`
+	Translation   map[string]any `bson:"translation" json:"translation"`
+	LocationVideo entity.Media   `bson:"location_video" json:"location_video"`
+}
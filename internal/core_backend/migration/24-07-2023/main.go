@@ -117,7 +117,7 @@ func migrateWebpageToProduct(oldDatabase, newDatabase *mongo.Database) (string,
 			)
 
 			newProduct.Type = "coffee"
-			coffeeAttribute := entity.AttributeCoffee{
+			coffeeAttribute := AttributeCoffee{
 				FarmName: oldProduct.FarmName,
 				Varietal: oldProduct.Varietal,
 				Process:  oldProduct.Process,
@@ -278,7 +278,7 @@ func migrateWebpageToProduct(oldDatabase, newDatabase *mongo.Database) (string,
 		case "da-non-nuoc":
 			newProduct.Type = "sculpture"
 			newProduct.ThreeDimension = entity.Media{Type: "3D"}
-			var stoneAttribute entity.AttributeSculpture
+			var stoneAttribute AttributeSculpture
 			for _, page := range template.Pages {
 				var pageAttribute entity.WebPage
 				err = oldDatabase.Collection("webpages").FindOne(context.TODO(), bson.M{"_id": page.PageID}).Decode(&pageAttribute)
@@ -301,7 +301,7 @@ func migrateWebpageToProduct(oldDatabase, newDatabase *mongo.Database) (string,
 					}
 				case "craft_village":
 					mapVillageName := pageAttribute.Attributes[3]["villageName"].(map[string]interface{})
-					stoneAttribute.Village = entity.Village{
+					stoneAttribute.Village = Village{
 						Name: fmt.Sprintf("%v", mapVillageName["vi"]),
 						LocationVideo: entity.Media{
 							Type: "video",
@@ -310,7 +310,7 @@ func migrateWebpageToProduct(oldDatabase, newDatabase *mongo.Database) (string,
 					}
 				case "craftsmen":
 					var (
-						craftsman         entity.Craftsman
+						craftsman         Craftsman
 						craftsmanAttCount int
 					)
 
@@ -354,7 +354,7 @@ func migrateWebpageToProduct(oldDatabase, newDatabase *mongo.Database) (string,
 					stoneAttribute.Craftsman = craftsman
 				case "stone_info":
 					var (
-						stoneInfo entity.Stone
+						stoneInfo Stone
 						count     int
 					)
 					for _, att := range pageAttribute.Attributes {
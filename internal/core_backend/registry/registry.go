@@ -7,19 +7,27 @@ import (
 
 	"backend-service/internal/core_backend/api/handler"
 	"backend-service/internal/core_backend/api/middleware"
+	"backend-service/internal/core_backend/infrastructure/blockchain"
 	"backend-service/internal/core_backend/infrastructure/callers"
-	"backend-service/internal/core_backend/infrastructure/firebase"
+	"backend-service/internal/core_backend/infrastructure/custodial"
+	"backend-service/internal/core_backend/infrastructure/identity"
+	"backend-service/internal/core_backend/infrastructure/signer"
 	"backend-service/internal/core_backend/infrastructure/storage"
 	validation "backend-service/internal/core_backend/infrastructure/validator"
 	"backend-service/internal/core_backend/usecase/nft"
+	"backend-service/internal/core_backend/usecase/story"
+	"backend-service/internal/core_backend/usecase/wallet"
 )
 
 type interactor struct {
 	mongo     *mongo.Database
 	validator *validator.Validate
 	caller    *callers.Caller
-	firebase  *firebase.FirebaseClient
+	identity  identity.Provider
 	gStorage  *storage.GCPClient
+	chains    *blockchain.ChainPool
+	signers   *signer.Provider
+	wallets   custodial.Provider
 }
 
 // Interactor Interactor interface
@@ -27,11 +35,14 @@ type Interactor interface {
 	NewAppHandler() handler.AppHandler
 	NewMiddlewareServices() middleware.MidddlewareServices
 	NewNFTGlobalService() *nft.Service
+	NewWalletGlobalService() *wallet.Service
+	NewStoryGlobalService() *story.Service
+	EnsureIndexes() error
 }
 
 // NewInteractor Constructs new interactor
-func NewInteractor(mongo *mongo.Database, v *validator.Validate, c *callers.Caller, fb *firebase.FirebaseClient, gs *storage.GCPClient) Interactor {
-	return &interactor{mongo: mongo, validator: v, caller: c, firebase: fb, gStorage: gs}
+func NewInteractor(mongo *mongo.Database, v *validator.Validate, c *callers.Caller, ip identity.Provider, gs *storage.GCPClient, cp *blockchain.ChainPool) Interactor {
+	return &interactor{mongo: mongo, validator: v, caller: c, identity: ip, gStorage: gs, chains: cp, signers: signer.NewProvider(), wallets: custodial.NewProvider()}
 }
 
 // NewAppHandler register all app handler
@@ -46,12 +57,18 @@ func (i *interactor) NewAppHandler() handler.AppHandler {
 		TagHandler:          i.NewTagHandler(),
 		WebPageHandler:      i.NewWebPageHandler(),
 		ProductHandler:      i.NewProductHandler(),
+		ProductTypeHandler:  i.NewProductTypeHandler(),
 		OrganizationHandler: i.NewOrganizationHandler(),
 		SessionHandler:      i.NewSessionHandler(),
 		UploadHandler:       i.NewUploadHandler(),
 		TemplateHandler:     i.NewTemplateHandler(),
 		PubsubHandler:       i.NewPubsubHandler(),
 		AuthorHandler:       i.NewAuthorHandler(),
+		RoleHandler:         i.NewRoleHandler(),
+		APIKeyHandler:       i.NewAPIKeyHandler(),
+		SiteHandler:         i.NewSiteHandler(),
+		StoryHandler:        i.NewStoryHandler(),
+		ClaimHandler:        i.NewClaimHandler(),
 	}
 }
 
@@ -64,10 +81,38 @@ func (i *interactor) NewCaller() *callers.Caller {
 	return callers.NewCaller()
 }
 
+// EnsureIndexes creates the indexes the services query with, run at startup
+func (i *interactor) EnsureIndexes() error {
+	if err := i.NewProductRepository().EnsureProductIndexes(); err != nil {
+		return err
+	}
+	if err := i.NewStoryRepository().EnsureStoryIndexes(); err != nil {
+		return err
+	}
+	if err := i.NewNFTRepository().EnsureCollectionIndexes(); err != nil {
+		return err
+	}
+	if err := i.NewMappingRepository().EnsureClaimIndexes(); err != nil {
+		return err
+	}
+	if err := i.NewUserRepository().EnsureWalletIndexes(); err != nil {
+		return err
+	}
+	return i.NewProductItemRepository().EnsureLikeIndexes()
+}
+
 func (i *interactor) NewMiddlewareServices() middleware.MidddlewareServices {
-	return middleware.NewMiddlewareServices(i.firebase, i.NewProductItemRepository(), i.NewProductRepository())
+	return middleware.NewMiddlewareServices(i.identity, i.NewProductItemRepository(), i.NewProductRepository(), i.NewRoleService(), i.NewUserService(), i.NewAPIKeyService())
 }
 
 func (i *interactor) NewNFTGlobalService() *nft.Service {
 	return i.NewNFTService()
 }
+
+func (i *interactor) NewWalletGlobalService() *wallet.Service {
+	return i.NewWalletService()
+}
+
+func (i *interactor) NewStoryGlobalService() *story.Service {
+	return i.NewStoryService()
+}
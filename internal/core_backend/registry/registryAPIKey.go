@@ -0,0 +1,23 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/api/handler"
+	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/apiKey"
+)
+
+// API Key API
+// NewAPIKeyRepository new api key repository
+func (i *interactor) NewAPIKeyRepository() *repository.APIKeyRepository {
+	return repository.NewAPIKeyRepository(i.mongo)
+}
+
+// NewAPIKeyService new api key service
+func (i *interactor) NewAPIKeyService() *apiKey.Service {
+	return apiKey.NewService(i.NewAPIKeyRepository())
+}
+
+// NewAPIKeyHandler
+func (i *interactor) NewAPIKeyHandler() handler.APIKeyHandler {
+	return handler.NewAPIKeyHandler(i.NewAPIKeyService(), i.NewCustomValidator())
+}
@@ -0,0 +1,16 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/api/handler"
+	"backend-service/internal/core_backend/usecase/claim"
+)
+
+// NewClaimService new claim service
+func (i *interactor) NewClaimService() *claim.Service {
+	return claim.NewService(i.NewMappingRepository(), i.NewProductItemRepository(), i.NewProductRepository())
+}
+
+// NewClaimHandler
+func (i *interactor) NewClaimHandler() handler.ClaimHandler {
+	return handler.NewClaimHandler(i.NewClaimService(), i.NewUserService(), i.NewCustomValidator())
+}
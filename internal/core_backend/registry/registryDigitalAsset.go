@@ -25,5 +25,5 @@ func (i *interactor) NewDigitalAssetPresenter() presenter.ConvertDigitalAsset {
 
 // NewDigitalAssetHandler
 func (i *interactor) NewDigitalAssetHandler() handler.DigitalAssetHandler {
-	return handler.NewDigitalAssetHandler(i.NewDigitalAssetCollectionService(), i.NewDigitalAssetService(), i.NewMappingService(), i.NewUserService(), i.NewProductItemService(), i.NewProductService(), i.NewOrganizationService(), i.NewTemplateService(), i.NewDigitalAssetPresenter(), i.NewCustomValidator())
+	return handler.NewDigitalAssetHandler(i.NewDigitalAssetCollectionService(), i.NewDigitalAssetService(), i.NewMappingService(), i.NewUserService(), i.NewProductItemService(), i.NewProductService(), i.NewOrganizationService(), i.NewTemplateService(), i.NewMetadataTemplateService(), i.NewNFTService(), i.NewDigitalAssetPresenter(), i.NewCustomValidator())
 }
@@ -0,0 +1,17 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/metadataTemplate"
+)
+
+// MetadataTemplate API
+// NewMetadataTemplateRepository new metadata template repository
+func (i *interactor) NewMetadataTemplateRepository() *repository.MetadataTemplateRepository {
+	return repository.NewMetadataTemplateRepository(i.mongo)
+}
+
+// NewMetadataTemplateService new metadata template service
+func (i *interactor) NewMetadataTemplateService() *metadataTemplate.Service {
+	return metadataTemplate.NewService(i.NewMetadataTemplateRepository())
+}
@@ -13,5 +13,5 @@ func (i *interactor) NewNFTRepository() *repository.NFTRepository {
 
 // NewNFTService new dummy service
 func (i *interactor) NewNFTService() *nft.Service {
-	return nft.NewService(i.NewNFTRepository())
+	return nft.NewService(i.NewNFTRepository(), i.chains, i.signers)
 }
@@ -25,5 +25,5 @@ func (i *interactor) NewProductPresenter() presenter.ConvertProduct {
 
 // NewProductHandler
 func (i *interactor) NewProductHandler() handler.ProductHandler {
-	return handler.NewProductHandler(i.NewMappingService(), i.NewOrganizationService(), i.NewWebPageService(), i.NewProductService(), i.NewProductItemService(), i.NewTemplateService(), i.NewProductPresenter(), i.NewCustomValidator())
+	return handler.NewProductHandler(i.NewMappingService(), i.NewOrganizationService(), i.NewWebPageService(), i.NewProductService(), i.NewProductItemService(), i.NewProductTypeService(), i.NewTemplateService(), i.NewProductPresenter(), i.NewCustomValidator())
 }
@@ -0,0 +1,23 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/api/handler"
+	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/productType"
+)
+
+// Product Type API
+// NewProductTypeRepository new product type repository
+func (i *interactor) NewProductTypeRepository() *repository.ProductTypeRepository {
+	return repository.NewProductTypeRepository(i.mongo)
+}
+
+// NewProductTypeService new product type service
+func (i *interactor) NewProductTypeService() *productType.Service {
+	return productType.NewService(i.NewProductTypeRepository())
+}
+
+// NewProductTypeHandler
+func (i *interactor) NewProductTypeHandler() handler.ProductTypeHandler {
+	return handler.NewProductTypeHandler(i.NewProductTypeService(), i.NewCustomValidator())
+}
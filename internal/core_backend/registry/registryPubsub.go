@@ -2,9 +2,22 @@ package registry
 
 import (
 	"backend-service/internal/core_backend/api/handler"
+	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/pubsubMessage"
 )
 
+// Pubsub API
+// NewPubsubMessageRepository new pubsub message repository
+func (i *interactor) NewPubsubMessageRepository() *repository.PubsubMessageRepository {
+	return repository.NewPubsubMessageRepository(i.mongo)
+}
+
+// NewPubsubMessageService new pubsub message service
+func (i *interactor) NewPubsubMessageService() *pubsubMessage.Service {
+	return pubsubMessage.NewService(i.NewPubsubMessageRepository())
+}
+
 // NewPubsubHandler
 func (i *interactor) NewPubsubHandler() handler.PubsubHandler {
-	return handler.NewPubsubHandler(i.NewUserService(), i.NewCustomValidator())
+	return handler.NewPubsubHandler(i.NewUserService(), i.NewWalletService(), i.NewPubsubMessageService(), i.NewCustomValidator())
 }
@@ -0,0 +1,23 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/api/handler"
+	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/role"
+)
+
+// Role API
+// NewRoleRepository new role repository
+func (i *interactor) NewRoleRepository() *repository.RoleRepository {
+	return repository.NewRoleRepository(i.mongo)
+}
+
+// NewRoleService new role service
+func (i *interactor) NewRoleService() *role.Service {
+	return role.NewService(i.NewRoleRepository())
+}
+
+// NewRoleHandler
+func (i *interactor) NewRoleHandler() handler.RoleHandler {
+	return handler.NewRoleHandler(i.NewRoleService(), i.NewUserService(), i.NewCustomValidator())
+}
@@ -24,5 +24,5 @@ func (i *interactor) NewScanPresenter() presenter.ConvertScan {
 
 // NewScanHandler
 func (i *interactor) NewScanHandler() handler.ScanHandler {
-	return handler.NewScanHandler(i.NewSessionService(), i.NewOrganizationService(), i.NewVerificationService(), i.NewProductService(), i.NewProductItemService(), i.NewTagService(), i.NewScanService(), i.NewMappingService(), i.NewTemplateService(), i.NewScanPresenter(), i.NewCustomValidator())
+	return handler.NewScanHandler(i.NewSessionService(), i.NewOrganizationService(), i.NewVerificationService(), i.NewProductService(), i.NewProductItemService(), i.NewTagService(), i.NewScanService(), i.NewMappingService(), i.NewTemplateService(), i.NewStoryService(), i.NewScanPresenter(), i.NewCustomValidator())
 }
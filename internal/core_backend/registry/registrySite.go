@@ -0,0 +1,16 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/api/handler"
+	"backend-service/internal/core_backend/usecase/site"
+)
+
+// NewSiteService new site service
+func (i *interactor) NewSiteService() *site.Service {
+	return site.NewService(i.NewMappingRepository(), i.NewProductItemRepository(), i.NewTemplateRepository())
+}
+
+// NewSiteHandler
+func (i *interactor) NewSiteHandler() handler.SiteHandler {
+	return handler.NewSiteHandler(i.NewSiteService())
+}
@@ -0,0 +1,22 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/api/handler"
+	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/story"
+)
+
+// NewStoryRepository new story repository
+func (i *interactor) NewStoryRepository() *repository.StoryRepository {
+	return repository.NewStoryRepository(i.mongo)
+}
+
+// NewStoryService new story service
+func (i *interactor) NewStoryService() *story.Service {
+	return story.NewService(i.NewStoryRepository(), i.NewProductItemRepository(), i.NewTemplateRepository(), i.gStorage)
+}
+
+// NewStoryHandler
+func (i *interactor) NewStoryHandler() handler.StoryHandler {
+	return handler.NewStoryHandler(i.NewStoryService(), i.NewProductService(), i.NewProductItemService())
+}
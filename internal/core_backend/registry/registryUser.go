@@ -15,7 +15,7 @@ func (i *interactor) NewUserRepository() *repository.UserRepository {
 
 // NewUserService new user service
 func (i *interactor) NewUserService() *user.Service {
-	return user.NewService(i.firebase, i.NewUserRepository(), i.NewOrganizationRepository())
+	return user.NewService(i.identity, i.NewUserRepository(), i.NewOrganizationRepository())
 }
 
 // NewUserPresenter
@@ -25,5 +25,5 @@ func (i *interactor) NewUserPresenter() presenter.ConvertUser {
 
 // NewUserHandler
 func (i *interactor) NewUserHandler() handler.UserHandler {
-	return handler.NewUserHandler(i.NewUserService(), i.NewOrganizationService(), i.NewUserPresenter(), i.NewCustomValidator())
+	return handler.NewUserHandler(i.NewUserService(), i.NewOrganizationService(), i.NewWalletService(), i.NewRoleService(), i.NewUserPresenter(), i.NewCustomValidator())
 }
@@ -0,0 +1,17 @@
+package registry
+
+import (
+	"backend-service/internal/core_backend/infrastructure/repository"
+	"backend-service/internal/core_backend/usecase/wallet"
+)
+
+// Wallet API
+// NewWalletRepository new wallet repository
+func (i *interactor) NewWalletRepository() *repository.WalletRepository {
+	return repository.NewWalletRepository(i.mongo)
+}
+
+// NewWalletService new wallet service
+func (i *interactor) NewWalletService() *wallet.Service {
+	return wallet.NewService(i.NewWalletRepository(), i.NewUserRepository(), i.wallets)
+}
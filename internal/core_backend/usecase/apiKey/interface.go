@@ -0,0 +1,34 @@
+package apiKey
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// APIKey interface
+type APIKey interface {
+	// Interface for repository
+	CreateAPIKey(key *entity.APIKey) (*entity.APIKey, error)
+	GetAPIKeyByPrefix(prefix string) (*entity.APIKey, error)
+	GetAPIKeys(scope *entity.AccessScope) (*[]entity.APIKey, error)
+	GetAPIKeyInScope(keyID *string, scope *entity.AccessScope) (*entity.APIKey, error)
+	RevokeAPIKey(keyID primitive.ObjectID, at time.Time) (bool, error)
+	TouchAPIKey(keyID primitive.ObjectID, at time.Time) error
+}
+
+// Repository interface
+type Repository interface {
+	APIKey
+}
+
+// UseCase interface
+type UseCase interface {
+	// Interface for usecase - service
+	GetAPIKeys(scope *entity.AccessScope) (*[]entity.APIKey, int, error)
+	CreateAPIKey(grants []entity.RoleGrant, key *entity.APIKey) (*entity.APIKey, string, int, error)
+	RevokeAPIKey(scope *entity.AccessScope, keyID *string) (bool, int, error)
+	Authenticate(key string) (*entity.APIKey, int, error)
+}
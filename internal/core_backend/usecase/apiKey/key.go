@@ -0,0 +1,56 @@
+package apiKey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Keys read phk_<prefix id>_<secret>. The prefix, phk_<prefix id>, is stored in clear to find the key.
+const (
+	keyScheme      = "phk"
+	prefixIDLength = 8
+	secretLength   = 32
+)
+
+var errMalformedKey = errors.New("malformed api key")
+
+// generateKey returns a new key and its prefix
+func generateKey() (key string, prefix string, err error) {
+	buf := make([]byte, prefixIDLength+secretLength)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	prefix = keyScheme + "_" + hex.EncodeToString(buf[:prefixIDLength])
+
+	return prefix + "_" + hex.EncodeToString(buf[prefixIDLength:]), prefix, nil
+}
+
+// parsePrefix returns the prefix of a well formed key
+func parsePrefix(key string) (string, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyScheme ||
+		len(parts[1]) != 2*prefixIDLength || len(parts[2]) != 2*secretLength {
+		return "", errMalformedKey
+	}
+	for _, part := range parts[1:] {
+		if _, err := hex.DecodeString(part); err != nil {
+			return "", errMalformedKey
+		}
+	}
+
+	return keyScheme + "_" + parts[1], nil
+}
+
+// hashKey the stored form of a key. Keys carry 256 random bits, a fast hash is enough.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func matchesHash(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashKey(key)), []byte(hash)) == 1
+}
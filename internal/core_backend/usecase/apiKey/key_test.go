@@ -0,0 +1,70 @@
+package apiKey
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	key, prefix, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key, prefix+"_") {
+		t.Fatalf("key %q does not start with its prefix %q", key, prefix)
+	}
+
+	parsed, err := parsePrefix(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != prefix {
+		t.Errorf("got prefix %q, want %q", parsed, prefix)
+	}
+
+	other, _, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == key {
+		t.Error("two generated keys are equal")
+	}
+}
+
+func TestParsePrefixRejectsMalformedKeys(t *testing.T) {
+	key, _, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, malformed := range []string{
+		"",
+		"Bearer " + key,
+		strings.Replace(key, "phk_", "sk_", 1),
+		key[:len(key)-1],
+		key + "0",
+		key[:len(key)-1] + "z",
+		strings.Replace(key, "_", "-", 1),
+	} {
+		if _, err := parsePrefix(malformed); err == nil {
+			t.Errorf("parsePrefix(%q) succeeded", malformed)
+		}
+	}
+}
+
+func TestMatchesHash(t *testing.T) {
+	key, _, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := hashKey(key)
+	if strings.Contains(hash, key) {
+		t.Fatal("hash contains the key")
+	}
+	if !matchesHash(key, hash) {
+		t.Error("key does not match its hash")
+	}
+	other, _, _ := generateKey()
+	if matchesHash(other, hash) {
+		t.Error("another key matches the hash")
+	}
+}
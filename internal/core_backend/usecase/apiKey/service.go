@@ -0,0 +1,119 @@
+package apiKey
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+)
+
+// lastUsedPrecision how stale the last used time of a key may be, so keys are not written on every request
+const lastUsedPrecision = time.Minute
+
+// Service struct
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewService create service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+		now:  time.Now,
+	}
+}
+
+// GetAPIKeys lists the keys of the organizations in scope
+func (s *Service) GetAPIKeys(scope *entity.AccessScope) (*[]entity.APIKey, int, error) {
+	keys, err := s.repo.GetAPIKeys(scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return keys, http.StatusOK, nil
+}
+
+// CreateAPIKey creates a key of an organization and returns it with its plain value, which is never shown again.
+// The creator must hold every permission of the key in the organization.
+func (s *Service) CreateAPIKey(grants []entity.RoleGrant, key *entity.APIKey) (*entity.APIKey, string, int, error) {
+	if key.OrganizationID.IsZero() {
+		return nil, "", http.StatusBadRequest, errors.New(common.MessageErrorAPIKeyNeedsOrganization)
+	}
+	for _, permission := range key.Permissions {
+		if !permission.IsValid() {
+			return nil, "", http.StatusBadRequest, errors.New(common.MessageErrorInvalidPermission + ": " + string(permission))
+		}
+		if !entity.IsAPIKeyPermission(permission) {
+			return nil, "", http.StatusBadRequest, errors.New(common.MessageErrorAPIKeyPermission + ": " + string(permission))
+		}
+	}
+	scope := entity.NewAccessScope("", grants, append([]entity.Permission{entity.PermissionAPIKeyWrite}, key.Permissions...)...)
+	if !scope.CanAccess(key.OrganizationID) {
+		return nil, "", http.StatusForbidden, errors.New(common.MessageErrorPermissionNotHeld)
+	}
+	now := s.now()
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+		return nil, "", http.StatusBadRequest, errors.New(common.MessageErrorAPIKeyExpiry)
+	}
+
+	plain, prefix, err := generateKey()
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	key.Prefix = prefix
+	key.Hash = hashKey(plain)
+	key.Status = common.StatusActive
+	key.SetTime()
+	key, err = s.repo.CreateAPIKey(key)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+
+	return key, plain, http.StatusOK, nil
+}
+
+// RevokeAPIKey revokes a key of an organization in scope, it is refused from then on
+func (s *Service) RevokeAPIKey(scope *entity.AccessScope, keyID *string) (bool, int, error) {
+	key, err := s.repo.GetAPIKeyInScope(keyID, scope)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+	if key == nil {
+		return false, http.StatusNotFound, errors.New(common.MessageErrorAPIKeyNotFound)
+	}
+
+	if _, err = s.repo.RevokeAPIKey(key.ID, s.now()); err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+
+	return true, http.StatusOK, nil
+}
+
+// Authenticate returns the usable key matching the plain value and records its use
+func (s *Service) Authenticate(plain string) (*entity.APIKey, int, error) {
+	prefix, err := parsePrefix(plain)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errors.New(common.MessageErrorInvalidAPIKey)
+	}
+	key, err := s.repo.GetAPIKeyByPrefix(prefix)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	now := s.now()
+	if key == nil || !matchesHash(plain, key.Hash) || !key.IsUsable(now) {
+		return nil, http.StatusUnauthorized, errors.New(common.MessageErrorInvalidAPIKey)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedPrecision {
+		if err := s.repo.TouchAPIKey(key.ID, now); err != nil {
+			logger.LogError("Got error while recording api key use: " + err.Error())
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, http.StatusOK, nil
+}
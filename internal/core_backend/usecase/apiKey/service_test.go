@@ -0,0 +1,191 @@
+package apiKey
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// apiKeys the keys created by the tests, touched counts the uses Authenticate records
+type apiKeys struct {
+	Repository
+	keys    map[primitive.ObjectID]*entity.APIKey
+	touched int
+}
+
+func newMemoryRepository() *apiKeys {
+	return &apiKeys{keys: map[primitive.ObjectID]*entity.APIKey{}}
+}
+
+func (r *apiKeys) CreateAPIKey(key *entity.APIKey) (*entity.APIKey, error) {
+	key.ID = primitive.NewObjectID()
+	stored := *key
+	r.keys[key.ID] = &stored
+	return key, nil
+}
+
+func (r *apiKeys) GetAPIKeyByPrefix(prefix string) (*entity.APIKey, error) {
+	for _, key := range r.keys {
+		if key.Prefix == prefix {
+			found := *key
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *apiKeys) GetAPIKeyInScope(keyID *string, scope *entity.AccessScope) (*entity.APIKey, error) {
+	id, _ := primitive.ObjectIDFromHex(*keyID)
+	key, ok := r.keys[id]
+	if !ok || !scope.CanAccess(key.OrganizationID) {
+		return nil, nil
+	}
+	found := *key
+	return &found, nil
+}
+
+func (r *apiKeys) RevokeAPIKey(keyID primitive.ObjectID, at time.Time) (bool, error) {
+	key, ok := r.keys[keyID]
+	if !ok || key.RevokedAt != nil {
+		return false, nil
+	}
+	key.RevokedAt = &at
+	return true, nil
+}
+
+func (r *apiKeys) TouchAPIKey(keyID primitive.ObjectID, at time.Time) error {
+	r.touched++
+	r.keys[keyID].LastUsedAt = &at
+	return nil
+}
+
+func orgAdminGrants(orgID primitive.ObjectID) []entity.RoleGrant {
+	return []entity.RoleGrant{{OrganizationID: orgID, Role: entity.SystemRoles[string(entity.ORG_ADMIN_ROLE)]}}
+}
+
+func TestCreateAPIKeyChecksPermissions(t *testing.T) {
+	orgID := primitive.NewObjectID()
+	service := NewService(newMemoryRepository())
+	past := time.Now().Add(-time.Hour)
+
+	cases := []struct {
+		name string
+		key  entity.APIKey
+		code int
+	}{
+		{"usable", entity.APIKey{OrganizationID: orgID, Permissions: []entity.Permission{entity.PermissionProductWrite}}, http.StatusOK},
+		{"no organization", entity.APIKey{Permissions: []entity.Permission{entity.PermissionProductWrite}}, http.StatusBadRequest},
+		{"unknown permission", entity.APIKey{OrganizationID: orgID, Permissions: []entity.Permission{"product:everything"}}, http.StatusBadRequest},
+		{"key management", entity.APIKey{OrganizationID: orgID, Permissions: []entity.Permission{entity.PermissionAPIKeyWrite}}, http.StatusBadRequest},
+		{"role management", entity.APIKey{OrganizationID: orgID, Permissions: []entity.Permission{entity.PermissionRoleWrite}}, http.StatusBadRequest},
+		{"several permissions", entity.APIKey{OrganizationID: orgID, Permissions: []entity.Permission{entity.PermissionNFTMint, entity.PermissionProductRead}}, http.StatusOK},
+		{"other organization", entity.APIKey{OrganizationID: primitive.NewObjectID(), Permissions: []entity.Permission{entity.PermissionProductRead}}, http.StatusForbidden},
+		{"expired", entity.APIKey{OrganizationID: orgID, Permissions: []entity.Permission{entity.PermissionProductRead}, ExpiresAt: &past}, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := tc.key
+			created, plain, code, err := service.CreateAPIKey(orgAdminGrants(orgID), &key)
+			if code != tc.code {
+				t.Fatalf("got status %d (%v), want %d", code, err, tc.code)
+			}
+			if code != http.StatusOK {
+				return
+			}
+			if plain == "" || created.Hash == "" || created.Hash == plain {
+				t.Fatalf("key stored in clear or missing: %+v", created)
+			}
+		})
+	}
+}
+
+func TestCreateAPIKeyRefusesPermissionsTheCreatorLacks(t *testing.T) {
+	orgID := primitive.NewObjectID()
+	service := NewService(newMemoryRepository())
+	grants := []entity.RoleGrant{{OrganizationID: orgID, Role: entity.Role{Permissions: []entity.Permission{entity.PermissionAPIKeyWrite, entity.PermissionProductRead}}}}
+
+	key := entity.APIKey{OrganizationID: orgID, Permissions: []entity.Permission{entity.PermissionProductWrite}}
+	if _, _, code, _ := service.CreateAPIKey(grants, &key); code != http.StatusForbidden {
+		t.Fatalf("got status %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	orgID := primitive.NewObjectID()
+	repo := newMemoryRepository()
+	service := NewService(repo)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	expiresAt := now.Add(time.Hour)
+	key, plain, _, err := service.CreateAPIKey(orgAdminGrants(orgID), &entity.APIKey{
+		OrganizationID: orgID,
+		Permissions:    []entity.Permission{entity.PermissionProductWrite},
+		ExpiresAt:      &expiresAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticated, _, err := service.Authenticate(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grant := authenticated.Grant()
+	if grant.OrganizationID != orgID || !grant.Role.HasPermissions(entity.PermissionProductWrite) || grant.Role.HasPermissions(entity.PermissionProductRead) {
+		t.Errorf("unexpected grant %+v", grant)
+	}
+
+	// Uses within lastUsedPrecision are recorded once
+	if _, _, err := service.Authenticate(plain); err != nil {
+		t.Fatal(err)
+	}
+	if repo.touched != 1 {
+		t.Errorf("key touched %d times, want 1", repo.touched)
+	}
+
+	other, _, _ := generateKey()
+	forged := key.Prefix + other[len(key.Prefix):]
+	for _, refused := range []string{"", "not-a-key", other, forged} {
+		if _, code, _ := service.Authenticate(refused); code != http.StatusUnauthorized {
+			t.Errorf("Authenticate(%q) got status %d, want %d", refused, code, http.StatusUnauthorized)
+		}
+	}
+
+	now = expiresAt
+	if _, code, _ := service.Authenticate(plain); code != http.StatusUnauthorized {
+		t.Errorf("expired key got status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	now = expiresAt.Add(-time.Minute)
+	keyID := key.ID.Hex()
+	scope := entity.NewAccessScope("", orgAdminGrants(orgID), entity.PermissionAPIKeyWrite)
+	if _, _, err := service.RevokeAPIKey(scope, &keyID); err != nil {
+		t.Fatal(err)
+	}
+	if _, code, _ := service.Authenticate(plain); code != http.StatusUnauthorized {
+		t.Errorf("revoked key got status %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestRevokeAPIKeyOfAnotherOrganization(t *testing.T) {
+	orgID := primitive.NewObjectID()
+	service := NewService(newMemoryRepository())
+	key, _, _, err := service.CreateAPIKey(orgAdminGrants(orgID), &entity.APIKey{
+		OrganizationID: orgID,
+		Permissions:    []entity.Permission{entity.PermissionProductRead},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyID := key.ID.Hex()
+	scope := entity.NewAccessScope("", orgAdminGrants(primitive.NewObjectID()), entity.PermissionAPIKeyWrite)
+	if _, code, _ := service.RevokeAPIKey(scope, &keyID); code != http.StatusNotFound {
+		t.Fatalf("got status %d, want %d", code, http.StatusNotFound)
+	}
+}
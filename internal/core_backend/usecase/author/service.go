@@ -7,9 +7,10 @@ import (
 // Author interface
 type Author interface {
 	CreateAuthor(author *entity.Author) (*entity.Author, error)
-	GetListAuthor() (*[]entity.Author, error)
+	GetListAuthor(scope *entity.AccessScope) (*[]entity.Author, error)
 	GetAuthorByID(authorID *string) (*entity.Author, error)
-	UpdateAuthor(author *entity.Author) (*entity.Author, error)
+	GetAuthorInScope(authorID *string, scope *entity.AccessScope) (*entity.Author, error)
+	UpdateAuthor(author *entity.Author, scope *entity.AccessScope) (*entity.Author, error)
 }
 
 // Repository interface
@@ -20,7 +21,7 @@ type Repository interface {
 // UseCase interface
 type UseCase interface {
 	CreateAuthor(author *entity.Author) (*entity.Author, int, error)
-	GetListAuthor() (*[]entity.Author, int, error)
+	GetListAuthor(scope *entity.AccessScope) (*[]entity.Author, int, error)
 	GetAuthorDetail(authorID *string) (*entity.Author, int, error)
-	UpdateAuthor(authorID *string, author *entity.Author) (*entity.Author, int, error)
+	UpdateAuthor(scope *entity.AccessScope, authorID *string, author *entity.Author) (*entity.Author, int, error)
 }
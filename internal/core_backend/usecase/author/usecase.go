@@ -32,9 +32,9 @@ func (s *Service) CreateAuthor(author *entity.Author) (*entity.Author, int, erro
 	return insertedAuthor, http.StatusOK, nil
 }
 
-// GetListAuthor -
-func (s *Service) GetListAuthor() (*[]entity.Author, int, error) {
-	listAuthor, err := s.repo.GetListAuthor()
+// GetListAuthor the shared authors and the authors of the organizations in scope
+func (s *Service) GetListAuthor(scope *entity.AccessScope) (*[]entity.Author, int, error) {
+	listAuthor, err := s.repo.GetListAuthor(scope)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -57,10 +57,23 @@ func (s *Service) GetAuthorDetail(authorID *string) (*entity.Author, int, error)
 	return author, http.StatusOK, nil
 }
 
-// UpdateAuthor -
-func (s *Service) UpdateAuthor(authorID *string, author *entity.Author) (*entity.Author, int, error) {
+// UpdateAuthor replaces an author of an organization in scope, shared authors are only changed by admins of all organizations
+func (s *Service) UpdateAuthor(scope *entity.AccessScope, authorID *string, author *entity.Author) (*entity.Author, int, error) {
+	stored, err := s.repo.GetAuthorInScope(authorID, scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if stored == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorAuthorNotFound)
+	}
+	if stored.OrganizationID.IsZero() && !scope.AllOrganizations {
+		return nil, http.StatusForbidden, errors.New(common.MessageErrorSharedResource)
+	}
+
+	// An author stays in its organization
+	author.OrganizationID = stored.OrganizationID
 	author.SetTime().SetID(authorID)
-	insertedAuthor, err := s.repo.UpdateAuthor(author)
+	insertedAuthor, err := s.repo.UpdateAuthor(author, scope)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
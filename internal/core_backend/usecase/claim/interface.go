@@ -0,0 +1,38 @@
+package claim
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/entity"
+)
+
+// Claim interface
+type Claim interface {
+	GetMappingWithProductItemID(productItemID *string) (*entity.Mapping, error)
+	GetMappingWithProductItemIDInScope(productItemID *string, scope *entity.AccessScope) (*entity.Mapping, error)
+	ChangeClaimState(storedState string, event *entity.ClaimEvent) (bool, error)
+	GetClaimEvents(productItemID primitive.ObjectID) (*[]entity.ClaimEvent, error)
+}
+
+// ProductItem interface
+type ProductItem interface {
+	GetDetailProductItemByID(*string) (*entity.ProductItem, error)
+}
+
+// Product interface
+type Product interface {
+	GetProductByID(productID *string) (*entity.Product, error)
+}
+
+// Repository interface
+type Repository interface {
+	Claim
+}
+
+// UseCase interface
+type UseCase interface {
+	ChangeClaimState(scope *entity.AccessScope, productItemID *string, req *request.ClaimStateRequest, actorID string) (*entity.ClaimEvent, int, error)
+	ClaimItem(req *request.SetOwnerRequest) (bool, int, error)
+	GetClaimEvents(scope *entity.AccessScope, productItemID *string) (*[]entity.ClaimEvent, int, error)
+}
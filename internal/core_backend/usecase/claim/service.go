@@ -0,0 +1,156 @@
+package claim
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+)
+
+// Service moves product items between claim states and keeps the audit trail of each change.
+// Admins change the state of the items of their organizations, users claim claimable items.
+type Service struct {
+	repo     Repository
+	items    ProductItem
+	products Product
+}
+
+// NewService create service
+func NewService(r Repository, pi ProductItem, p Product) *Service {
+	return &Service{
+		repo:     r,
+		items:    pi,
+		products: p,
+	}
+}
+
+// ChangeClaimState moves the item to the state of the request for the reason of the request. Making it claimable
+// opens the claim window of its product, making it claimable or unclaimable releases its owner.
+func (s *Service) ChangeClaimState(scope *entity.AccessScope, productItemID *string, req *request.ClaimStateRequest, actorID string) (*entity.ClaimEvent, int, error) {
+	mapping, err := s.repo.GetMappingWithProductItemIDInScope(productItemID, scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if mapping == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorMappingNotFound)
+	}
+
+	now := time.Now()
+	if !mapping.CanChangeClaimState(req.State, now) {
+		return nil, http.StatusBadRequest, errors.New(common.MessageErrorClaimTransition + ": " + mapping.ClaimStateAt(now) + " to " + req.State)
+	}
+	event := &entity.ClaimEvent{
+		ProductItemID:   mapping.ProductItemID,
+		OrganizationID:  mapping.OrganizationID,
+		FromState:       mapping.ClaimStateAt(now),
+		ToState:         req.State,
+		PreviousOwnerID: mapping.OwnerID,
+		OwnerID:         mapping.OwnerID,
+		ReasonCode:      req.ReasonCode,
+		Note:            req.Note,
+		ActorID:         actorID,
+		CreatedAt:       now,
+	}
+	switch req.State {
+	case entity.ClaimStateClaimable:
+		event.OwnerID = ""
+		window, err := s.claimWindow(mapping.ProductItemID.Hex())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if window > 0 {
+			until := now.Add(time.Duration(window) * time.Hour)
+			event.ClaimableUntil = &until
+		}
+	case entity.ClaimStateUnclaimable:
+		event.OwnerID = ""
+	}
+
+	if code, err := s.changeClaimState(mapping, event); err != nil {
+		return nil, code, err
+	}
+
+	return event, http.StatusOK, nil
+}
+
+// ClaimItem makes the user of the request the owner of the item while it is claimable
+func (s *Service) ClaimItem(req *request.SetOwnerRequest) (bool, int, error) {
+	mapping, err := s.repo.GetMappingWithProductItemID(&req.ProductItemID)
+	if err != nil {
+		logger.LogError("got error when setting onwer for item: " + err.Error())
+		return false, http.StatusInternalServerError, err
+	}
+	now := time.Now()
+	if mapping == nil || !mapping.IsClaimableAt(now) {
+		logger.LogInfo(common.MessageErrorNotAbleToClaim)
+		return false, http.StatusBadRequest, errors.New(common.MessageErrorNotAbleToClaim)
+	}
+
+	event := &entity.ClaimEvent{
+		ProductItemID:   mapping.ProductItemID,
+		OrganizationID:  mapping.OrganizationID,
+		FromState:       entity.ClaimStateClaimable,
+		ToState:         entity.ClaimStateClaimed,
+		PreviousOwnerID: mapping.OwnerID,
+		OwnerID:         req.OwnerID,
+		ReasonCode:      common.ClaimReasonClaimed,
+		ActorID:         req.OwnerID,
+		CreatedAt:       now,
+	}
+	if code, err := s.changeClaimState(mapping, event); err != nil {
+		return false, code, err
+	}
+
+	return true, http.StatusOK, nil
+}
+
+// GetClaimEvents the claim events of the item, newest first
+func (s *Service) GetClaimEvents(scope *entity.AccessScope, productItemID *string) (*[]entity.ClaimEvent, int, error) {
+	mapping, err := s.repo.GetMappingWithProductItemIDInScope(productItemID, scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if mapping == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorMappingNotFound)
+	}
+	events, err := s.repo.GetClaimEvents(mapping.ProductItemID)
+	if err != nil {
+		logger.LogError("Got error while getting claim events: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return events, http.StatusOK, nil
+}
+
+// changeClaimState applies the event to the mapping as it was read, returns 409 when it changed since
+func (s *Service) changeClaimState(mapping *entity.Mapping, event *entity.ClaimEvent) (int, error) {
+	changed, err := s.repo.ChangeClaimState(mapping.ClaimState, event)
+	if err != nil {
+		logger.LogError("Got error while changing claim state: " + err.Error())
+		return http.StatusInternalServerError, err
+	}
+	if !changed {
+		return http.StatusConflict, errors.New(common.MessageErrorClaimStateChanged)
+	}
+
+	return http.StatusOK, nil
+}
+
+// claimWindow the hours items of the product of the item stay claimable, 0 when there is no limit
+func (s *Service) claimWindow(productItemID string) (int, error) {
+	item, err := s.items.GetDetailProductItemByID(&productItemID)
+	if err != nil || item == nil {
+		return 0, err
+	}
+	productID := item.ProductID.Hex()
+	product, err := s.products.GetProductByID(&productID)
+	if err != nil {
+		return 0, err
+	}
+
+	return product.ClaimWindowInHours, nil
+}
@@ -0,0 +1,136 @@
+package claim
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+)
+
+// claimableItem the single tag of the claim tests with its item, product and claim events.
+// meanwhile runs before a change, as a request changing the item at the same time.
+type claimableItem struct {
+	Repository
+	mapping   entity.Mapping
+	item      entity.ProductItem
+	product   entity.Product
+	events    []entity.ClaimEvent
+	meanwhile func()
+}
+
+func (r *claimableItem) GetMappingWithProductItemID(productItemID *string) (*entity.Mapping, error) {
+	if *productItemID != r.mapping.ProductItemID.Hex() {
+		return nil, nil
+	}
+	mapping := r.mapping
+	return &mapping, nil
+}
+
+func (r *claimableItem) GetMappingWithProductItemIDInScope(productItemID *string, scope *entity.AccessScope) (*entity.Mapping, error) {
+	if !scope.CanAccess(r.mapping.OrganizationID) {
+		return nil, nil
+	}
+	return r.GetMappingWithProductItemID(productItemID)
+}
+
+func (r *claimableItem) ChangeClaimState(storedState string, event *entity.ClaimEvent) (bool, error) {
+	if r.meanwhile != nil {
+		r.meanwhile()
+		r.meanwhile = nil
+	}
+	if r.mapping.ClaimState != storedState || r.mapping.OwnerID != event.PreviousOwnerID {
+		return false, nil
+	}
+	r.mapping.ClaimState, r.mapping.OwnerID, r.mapping.ClaimableUntil = event.ToState, event.OwnerID, event.ClaimableUntil
+	r.events = append([]entity.ClaimEvent{*event}, r.events...)
+	return true, nil
+}
+
+func (r *claimableItem) GetDetailProductItemByID(productItemID *string) (*entity.ProductItem, error) {
+	item := r.item
+	return &item, nil
+}
+
+func (r *claimableItem) GetProductByID(productID *string) (*entity.Product, error) {
+	product := r.product
+	return &product, nil
+}
+
+// newClaimRepository an unclaimable item of a product whose items stay claimable for a day
+func newClaimRepository() (*claimableItem, *entity.AccessScope, string) {
+	orgID := primitive.NewObjectID()
+	repo := &claimableItem{product: entity.Product{ClaimWindowInHours: 24}}
+	repo.product.ID = primitive.NewObjectID()
+	repo.item.ID = primitive.NewObjectID()
+	repo.item.ProductID = repo.product.ID
+	repo.mapping = entity.Mapping{ProductItemID: repo.item.ID, OrganizationID: orgID, ClaimState: entity.ClaimStateUnclaimable}
+	return repo, &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}}, repo.item.ID.Hex()
+}
+
+func TestClaimWindow(t *testing.T) {
+	repo, scope, itemID := newClaimRepository()
+	service := NewService(repo, repo, repo)
+
+	event, _, err := service.ChangeClaimState(scope, &itemID, &request.ClaimStateRequest{State: entity.ClaimStateClaimable, ReasonCode: common.ClaimReasonSale}, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ClaimableUntil == nil || time.Until(*event.ClaimableUntil) < 23*time.Hour {
+		t.Fatalf("ClaimableUntil = %v, want the claim window of the product", event.ClaimableUntil)
+	}
+	if ok, _, err := service.ClaimItem(&request.SetOwnerRequest{ProductItemID: itemID, OwnerID: "mai"}); !ok || err != nil {
+		t.Fatalf("ClaimItem() = %v, %v, want the item claimed within its window", ok, err)
+	}
+	if repo.mapping.ClaimState != entity.ClaimStateClaimed || repo.mapping.OwnerID != "mai" {
+		t.Errorf("mapping = %+v, want the item claimed by mai", repo.mapping)
+	}
+	if latest := repo.events[0]; latest.ReasonCode != common.ClaimReasonClaimed || latest.ActorID != "mai" || len(repo.events) != 2 {
+		t.Errorf("events = %+v, want the claim recorded after the admin change", repo.events)
+	}
+
+	// A second window that closed before the item was claimed
+	if _, _, err := service.ChangeClaimState(scope, &itemID, &request.ClaimStateRequest{State: entity.ClaimStateClaimable, ReasonCode: common.ClaimReasonOwnerRequest}, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if repo.mapping.OwnerID != "" {
+		t.Errorf("owner = %q, want the owner released when the item is claimable again", repo.mapping.OwnerID)
+	}
+	closed := time.Now().Add(-time.Minute)
+	repo.mapping.ClaimableUntil = &closed
+	if _, code, _ := service.ClaimItem(&request.SetOwnerRequest{ProductItemID: itemID, OwnerID: "lan"}); code != http.StatusBadRequest {
+		t.Errorf("ClaimItem() after the window = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestChangeClaimStateRejected(t *testing.T) {
+	repo, scope, itemID := newClaimRepository()
+	service := NewService(repo, repo, repo)
+
+	claimed := &request.ClaimStateRequest{State: entity.ClaimStateClaimed, ReasonCode: common.ClaimReasonCorrection}
+	if _, code, _ := service.ChangeClaimState(scope, &itemID, claimed, "admin"); code != http.StatusBadRequest {
+		t.Errorf("claimed without an owner = %d, want %d", code, http.StatusBadRequest)
+	}
+	locked := &request.ClaimStateRequest{State: entity.ClaimStateLocked, ReasonCode: common.ClaimReasonFraud}
+	other := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{primitive.NewObjectID()}}
+	if _, code, _ := service.ChangeClaimState(other, &itemID, locked, "admin"); code != http.StatusNotFound {
+		t.Errorf("item of another organization = %d, want %d", code, http.StatusNotFound)
+	}
+
+	// The item is claimed between the read and the lock
+	repo.mapping.ClaimState = entity.ClaimStateClaimable
+	repo.meanwhile = func() { repo.mapping.ClaimState, repo.mapping.OwnerID = entity.ClaimStateClaimed, "mai" }
+	if _, code, _ := service.ChangeClaimState(scope, &itemID, locked, "admin"); code != http.StatusConflict {
+		t.Errorf("lock of an item claimed meanwhile = %d, want %d", code, http.StatusConflict)
+	}
+	if _, code, _ := service.ChangeClaimState(scope, &itemID, locked, "admin"); code != http.StatusOK || repo.mapping.OwnerID != "mai" {
+		t.Errorf("lock = %d, owner %q, want the claimed item locked with its owner", code, repo.mapping.OwnerID)
+	}
+	if len(repo.events) != 1 || repo.events[0].FromState != entity.ClaimStateClaimed {
+		t.Errorf("events = %+v, want only the lock recorded", repo.events)
+	}
+}
@@ -15,7 +15,8 @@ type DigitalAsset interface {
 	GetDigitalAssetByTokenID(collectionID *string, tokenID *int) (*entity.DigitalAsset, error)
 	GetAllActiveDigitalAssets() (*[]entity.DigitalAsset, error)
 	GetActiveDigitalAssetByCollectionID(collectionID *string) (*[]entity.DigitalAsset, error)
-	GetDigitalAssetsProductAggregate() (*[]entity.DigitalAssetProductAggregate, error)
+	GetDigitalAssetsProductAggregate(collectionID *string) (*[]entity.DigitalAssetProductAggregate, error)
+	GetMetadataSourceByProductItemID(pItemID *string) (*entity.MetadataSource, error)
 }
 
 // Repository interface
@@ -34,6 +35,7 @@ type UseCase interface {
 	GetDigitalAssetByTokenID(collectionID *string, tokenID *int) (*entity.DigitalAsset, int, error)
 	GetAllActiveDigitalAssets() (*[]entity.DigitalAsset, int, error)
 	GetActiveDigitalAssetByCollectionID(collectionID *string) (*[]entity.DigitalAsset, int, error)
-	GetDigitalAssetsProductAggregate() (*[]entity.DigitalAssetProductAggregate, int, error)
-	ConstructMetadata(int, *string, *entity.Product) *entity.Metadata
+	GetDigitalAssetsProductAggregate(collectionID *string) (*[]entity.DigitalAssetProductAggregate, int, error)
+	GetMetadataSourceByProductItemID(pItemID *string) (*entity.MetadataSource, int, error)
+	ConstructMetadata(*entity.MetadataTemplate, *entity.MetadataSource) *entity.Metadata
 }
@@ -1,10 +1,10 @@
 package digitalAsset
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
-	"strings"
 
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
 )
 
@@ -91,85 +91,28 @@ func (s *Service) GetActiveDigitalAssetByCollectionID(collectionID *string) (*[]
 	return digitalAssets, http.StatusOK, nil
 }
 
-func (s *Service) GetDigitalAssetsProductAggregate() (*[]entity.DigitalAssetProductAggregate, int, error) {
-	aggregations, err := s.repo.GetDigitalAssetsProductAggregate()
+func (s *Service) GetDigitalAssetsProductAggregate(collectionID *string) (*[]entity.DigitalAssetProductAggregate, int, error) {
+	aggregations, err := s.repo.GetDigitalAssetsProductAggregate(collectionID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
-	for i := range *aggregations {
-		(*aggregations)[i].Product.ParseAttribute()
-	}
 
 	return aggregations, http.StatusOK, nil
 }
 
-func (s *Service) ConstructMetadata(itemIndex int, orgTagName *string, product *entity.Product) *entity.Metadata {
-	var metadata = entity.Metadata{
-		ExternalURL: "https://nomion.io/",
-		Image:       product.Image.URL,
+func (s *Service) GetMetadataSourceByProductItemID(pItemID *string) (*entity.MetadataSource, int, error) {
+	source, err := s.repo.GetMetadataSourceByProductItemID(pItemID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
-
-	switch *orgTagName {
-	case "lej":
-		metadata.Name = product.ProductName + " #" + strconv.Itoa(itemIndex)
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Origin",
-			Value:     product.Origin,
-		})
-		attribute := product.Attribute.(entity.AttributeCoffee)
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Farm",
-			Value:     attribute.FarmName,
-		})
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Preprocessed Type",
-			Value:     attribute.Process,
-		})
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Acidity",
-			Value:     attribute.Acidity,
-		})
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Bitter",
-			Value:     attribute.Bitter,
-		})
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Sweet",
-			Value:     attribute.Sweet,
-		})
-	case "da-non-nuoc":
-		metadata.Name = product.ProductName
-		attribute := product.Attribute.(entity.AttributeSculpture)
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Tác Giả",
-			Value:     attribute.Craftsman.Name,
-		})
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Cuộc Thi",
-			Value:     "Đá Non Nước 2023",
-		})
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Chất Liệu",
-			// TODO
-			Value: "attribute.Stone.Name",
-		})
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Kích thước",
-			Value:     attribute.SculptureSize,
-		})
-		// TODO:
-		metadata.Description = "attribute.Description"
-		metadata.AnimationURL = product.ThreeDimension.URL
-	case "astronaut":
-		metadata.Name = product.ProductName
-		metadata.AnimationURL = product.Video.URL
-		metadata.Image = product.Video.ThumbnailURL
-		metadata.Attributes = append(metadata.Attributes, entity.MetadataAttribute{
-			TraitType: "Owner",
-			Value:     strings.SplitN(product.ProductName, " ", 2)[1],
-		})
-	default:
-		metadata.Name = product.ProductName + " #" + strconv.Itoa(itemIndex)
+	if source == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorProductItemNotFound)
 	}
-	return &metadata
+
+	return source, http.StatusOK, nil
+}
+
+// ConstructMetadata renders the metadata of a digital asset from its collection's template
+func (s *Service) ConstructMetadata(template *entity.MetadataTemplate, source *entity.MetadataSource) *entity.Metadata {
+	return template.Render(source)
 }
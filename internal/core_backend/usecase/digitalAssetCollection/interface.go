@@ -9,6 +9,9 @@ type DigitalAssetCollection interface {
 	// Interface for repository
 	GetCollectionByOrgID(orgID *string) (*entity.DigitalAssetCollection, error)
 	GetCollectionByID(cID *string) (*entity.DigitalAssetCollection, error)
+	GetCollectionInScope(cID *string, scope *entity.AccessScope) (*entity.DigitalAssetCollection, error)
+	GetCollectionsInScope(scope *entity.AccessScope) (*[]entity.DigitalAssetCollection, error)
+	UpdateCollectionMetadata(dac *entity.DigitalAssetCollection) (bool, error)
 }
 
 // Repository interface
@@ -21,4 +24,7 @@ type UseCase interface {
 	// Interface for usecase - service
 	GetCollectionByOrgID(orgID *string) (*entity.DigitalAssetCollection, int, error)
 	GetCollectionByID(cID *string) (*entity.DigitalAssetCollection, int, error)
+	GetCollectionInScope(scope *entity.AccessScope, cID *string) (*entity.DigitalAssetCollection, int, error)
+	GetCollectionsInScope(scope *entity.AccessScope) (*[]entity.DigitalAssetCollection, int, error)
+	UpdateCollectionMetadata(dac *entity.DigitalAssetCollection) (bool, int, error)
 }
@@ -1,8 +1,10 @@
 package digitalAssetCollection
 
 import (
+	"errors"
 	"net/http"
 
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
 )
 
@@ -24,6 +26,9 @@ func (s *Service) GetCollectionByOrgID(orgID *string) (*entity.DigitalAssetColle
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	if digitalAssetCollection == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorCollectionNotFound)
+	}
 
 	return digitalAssetCollection, http.StatusOK, nil
 }
@@ -33,5 +38,43 @@ func (s *Service) GetCollectionByID(cID *string) (*entity.DigitalAssetCollection
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	if dac == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorCollectionNotFound)
+	}
+	return dac, http.StatusOK, nil
+}
+
+// GetCollectionInScope returns 404 when the collection does not belong to an organization of the access scope
+func (s *Service) GetCollectionInScope(scope *entity.AccessScope, cID *string) (*entity.DigitalAssetCollection, int, error) {
+	dac, err := s.repo.GetCollectionInScope(cID, scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if dac == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorCollectionNotFound)
+	}
 	return dac, http.StatusOK, nil
 }
+
+// GetCollectionsInScope returns the collections of the organizations in the access scope
+func (s *Service) GetCollectionsInScope(scope *entity.AccessScope) (*[]entity.DigitalAssetCollection, int, error) {
+	collections, err := s.repo.GetCollectionsInScope(scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return collections, http.StatusOK, nil
+}
+
+// UpdateCollectionMetadata updates the reveal flag, placeholder and contract level metadata
+func (s *Service) UpdateCollectionMetadata(dac *entity.DigitalAssetCollection) (bool, int, error) {
+	dac.SetTime()
+	ok, err := s.repo.UpdateCollectionMetadata(dac)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+	if !ok {
+		return false, http.StatusNotFound, errors.New(common.MessageErrorCollectionNotFound)
+	}
+
+	return true, http.StatusOK, nil
+}
@@ -10,12 +10,14 @@ import (
 // Mapping interface
 type Mapping interface {
 	// Interface for repository
-	GetAllMapping() (*[]entity.Mapping, error)
+	GetMappings(scope *entity.AccessScope, orgID *primitive.ObjectID) (*[]entity.Mapping, error)
 	GetAllMappingForProduct(*string, *string) (*[]entity.Mapping, error)
 	GetAllMappingInOrg(orgID *primitive.ObjectID) (*[]entity.Mapping, error)
 	UpsertMapping(mapping *entity.Mapping) (bool, error)
 	GetMappingWithTagID(tagID *string) (*entity.Mapping, error)
+	GetMappingWithTagIDInScope(tagID *string, scope *entity.AccessScope) (*entity.Mapping, error)
 	GetMappingWithProductItemID(productItemID *string) (*entity.Mapping, error)
+	GetMappingWithProductItemIDInScope(productItemID *string, scope *entity.AccessScope) (*entity.Mapping, error)
 	UpdateMapping(*string, *request.UpdateMappingRequest) (bool, error)
 	Unmap(*string) (bool, error)
 	GetMappingByDigitalAsset(digitalAssetID *string) (*entity.Mapping, error)
@@ -30,11 +32,14 @@ type Repository interface {
 type UseCase interface {
 	// Interface for usecase - service
 	GetAllMappingInOrg(orgID *string) (*[]entity.Mapping, int, error)
+	GetMappings(scope *entity.AccessScope, orgID *string) (*[]entity.Mapping, int, error)
 	GetAllMappingForProduct(*string, *string) (*[]entity.Mapping, int, error)
 	InitMapping(tagID, orgID *string) (bool, int, error)
 	UpdateMapping(*string, *request.UpdateMappingRequest) (bool, int, error)
 	Unmap(*string) (bool, int, error)
 	GetMappingWithTagID(tagID *string) (*entity.Mapping, int, error)
+	GetMappingWithTagIDInScope(scope *entity.AccessScope, tagID *string) (*entity.Mapping, int, error)
+	GetMappingWithProductItemIDInScope(scope *entity.AccessScope, productItemID *string) (*entity.Mapping, int, error)
 	IsProductItemIDMapped(productItemID *string) (bool, int, error)
 	GetMappingWithProductItemID(productItemID *string) (*entity.Mapping, int, error)
 	GetMappingByDigitalAsset(digitalAssetID *string) (*entity.Mapping, int, error)
@@ -1,9 +1,11 @@
 package mapping
 
 import (
+	"errors"
 	"net/http"
 
 	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/common/logger"
 	"backend-service/internal/core_backend/entity"
 
@@ -24,26 +26,34 @@ func NewService(r Repository) *Service {
 
 // GetAllMappingInOrg
 func (s *Service) GetAllMappingInOrg(orgID *string) (*[]entity.Mapping, int, error) {
-	var mappingList *[]entity.Mapping
-	if len(*orgID) != 0 {
-		oID, err := primitive.ObjectIDFromHex(*orgID)
-		if err != nil {
-			logger.LogError("Got error while parsing organization: " + err.Error())
-			return nil, http.StatusInternalServerError, err
-		}
-		mappingList, err = s.repo.GetAllMappingInOrg(&oID)
-		if err != nil {
-			logger.LogError("Got error while getting mapping: " + err.Error())
-			return nil, http.StatusInternalServerError, err
-		}
-	} else {
-		result, err := s.repo.GetAllMapping()
+	oID, err := primitive.ObjectIDFromHex(*orgID)
+	if err != nil {
+		logger.LogError("Got error while parsing organization: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	mappingList, err := s.repo.GetAllMappingInOrg(&oID)
+	if err != nil {
+		logger.LogError("Got error while getting mapping: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return mappingList, http.StatusOK, nil
+}
+
+// GetMappings returns the mappings of the access scope, narrowed to one organization when orgID is not empty
+func (s *Service) GetMappings(scope *entity.AccessScope, orgID *string) (*[]entity.Mapping, int, error) {
+	var oID *primitive.ObjectID
+	if orgID != nil && len(*orgID) != 0 {
+		id, err := primitive.ObjectIDFromHex(*orgID)
 		if err != nil {
-			logger.LogError("Got error while getting mapping: " + err.Error())
-			return nil, http.StatusInternalServerError, err
+			return nil, http.StatusBadRequest, err
 		}
-
-		mappingList = result
+		oID = &id
+	}
+	mappingList, err := s.repo.GetMappings(scope, oID)
+	if err != nil {
+		logger.LogError("Got error while getting mapping: " + err.Error())
+		return nil, http.StatusInternalServerError, err
 	}
 
 	return mappingList, http.StatusOK, nil
@@ -59,6 +69,33 @@ func (s *Service) GetMappingWithTagID(tagID *string) (*entity.Mapping, int, erro
 	return mapping, http.StatusOK, nil
 }
 
+// GetMappingWithTagIDInScope returns 404 when the tag is not mapped in an organization of the access scope
+func (s *Service) GetMappingWithTagIDInScope(scope *entity.AccessScope, tagID *string) (*entity.Mapping, int, error) {
+	mapping, err := s.repo.GetMappingWithTagIDInScope(tagID, scope)
+	if err != nil {
+		logger.LogError("Got error while getting mapping: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	if mapping == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorMappingNotFound)
+	}
+
+	return mapping, http.StatusOK, nil
+}
+
+// GetMappingWithProductItemIDInScope returns 404 when the product item is not mapped in an organization of the access scope
+func (s *Service) GetMappingWithProductItemIDInScope(scope *entity.AccessScope, productItemID *string) (*entity.Mapping, int, error) {
+	mapping, err := s.repo.GetMappingWithProductItemIDInScope(productItemID, scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if mapping == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorMappingNotFound)
+	}
+
+	return mapping, http.StatusOK, nil
+}
+
 func (s *Service) InitMapping(tagID, orgID *string) (bool, int, error) {
 	oID, err := primitive.ObjectIDFromHex(*orgID)
 	if err != nil {
@@ -0,0 +1,24 @@
+package metadataTemplate
+
+import (
+	"backend-service/internal/core_backend/entity"
+)
+
+// MetadataTemplate interface
+type MetadataTemplate interface {
+	// Interface for repository
+	GetTemplateByCollectionID(collectionID *string) (*entity.MetadataTemplate, error)
+	UpsertTemplate(template *entity.MetadataTemplate) (*entity.MetadataTemplate, error)
+}
+
+// Repository interface
+type Repository interface {
+	MetadataTemplate
+}
+
+// UseCase interface
+type UseCase interface {
+	// Interface for usecase - service
+	GetTemplateByCollectionID(collectionID *string) (*entity.MetadataTemplate, int, error)
+	UpsertTemplate(template *entity.MetadataTemplate) (*entity.MetadataTemplate, int, error)
+}
@@ -0,0 +1,61 @@
+package metadataTemplate
+
+import (
+	"errors"
+	"net/http"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/helper"
+	"backend-service/internal/core_backend/entity"
+)
+
+// Service struct
+type Service struct {
+	repo Repository
+}
+
+// NewService create service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// GetTemplateByCollectionID returns the metadata template of a collection, 404 when it has none
+func (s *Service) GetTemplateByCollectionID(collectionID *string) (*entity.MetadataTemplate, int, error) {
+	template, err := s.repo.GetTemplateByCollectionID(collectionID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if template == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorMetadataTemplateNotFound)
+	}
+
+	return template, http.StatusOK, nil
+}
+
+// UpsertTemplate validates the placeholders of a template then stores it
+func (s *Service) UpsertTemplate(template *entity.MetadataTemplate) (*entity.MetadataTemplate, int, error) {
+	fields := []string{template.Name, template.Description, template.Image, template.AnimationURL, template.ExternalURL}
+	for _, attribute := range template.Attributes {
+		fields = append(fields, attribute.Value)
+	}
+	for _, field := range fields {
+		for _, path := range helper.FindPlaceholders(field) {
+			if !entity.IsMetadataPlaceholderRoot(path) {
+				return nil, http.StatusBadRequest, errors.New(common.MessageErrorInvalidPlaceholder + ": " + path)
+			}
+		}
+	}
+
+	template.SetTime()
+	if template.Status == "" {
+		template.SetStatus(common.StatusActive)
+	}
+	template, err := s.repo.UpsertTemplate(template)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return template, http.StatusOK, nil
+}
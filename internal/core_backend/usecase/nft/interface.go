@@ -2,14 +2,25 @@ package nft
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/contracts"
+	"backend-service/internal/core_backend/entity"
 )
 
 // NFT interface
 type NFT interface {
-	GetLastSyncBlock() (int64, error)
-	UpdateLastSyncBlock(uint64) (bool, error)
-	GetListContractAddresses() (*[]common.Address, error)
+	GetLastSyncBlock(chainID int64) (int64, error)
+	UpdateLastSyncBlock(chainID int64, blockNumber uint64) (bool, error)
+	GetListContractAddresses(chainID int64) (*[]common.Address, error)
+	GetCollectionChainIDs() ([]int64, error)
 	UpdateMintedDigitalAssets(*string, *string, int64) (bool, error)
+	CreateContractDeployment(*entity.ContractDeployment) (*entity.ContractDeployment, error)
+	UpdateContractDeploymentStatus(deploymentID primitive.ObjectID, status string, errMessage string) (bool, error)
+	UpdateContractDeploymentTx(*entity.ContractDeployment) (bool, error)
+	GetContractDeploymentByID(deploymentID *string) (*entity.ContractDeployment, error)
+	GetPendingContractDeployments() (*[]entity.ContractDeployment, error)
+	CreateCollection(*entity.DigitalAssetCollection) (bool, error)
 }
 
 // Repository interface
@@ -20,9 +31,14 @@ type Repository interface {
 // UseCase interface
 type UseCase interface {
 	// Interface for usecase - service
-	DeployContract()
-	Mint(*string, *string) (string, int, error)
+	ListContractTemplates() []contracts.Template
+	DeployCollection(*entity.ContractDeployment) (*entity.ContractDeployment, int, error)
+	GetContractDeploymentByID(deploymentID *string) (*entity.ContractDeployment, int, error)
+	WatchDeployment(*entity.ContractDeployment)
+	ResumePendingDeployments()
+	Mint(*entity.DigitalAssetCollection, *string) (string, int, error)
 	ListenEvent()
-	SyncUnreadEvents(lastSyncBlock int64, toBlock int64)
-	WatchTransaction(txHash *string)
+	SyncUnreadEvents(chainID int64, lastSyncBlock int64, toBlock int64)
+	WatchTransaction(chainID int64, txHash *string)
+	VerifyCollectionChains() error
 }
@@ -5,9 +5,10 @@ import (
 	constant "backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/common/logger"
 	"backend-service/internal/core_backend/contracts"
-	"backend-service/internal/core_backend/contracts/astronaut_nft"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/blockchain"
+	"backend-service/internal/core_backend/infrastructure/signer"
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"log"
@@ -20,8 +21,8 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
@@ -31,103 +32,279 @@ const (
 
 // Service struct
 type Service struct {
-	repo   Repository
-	client *ethclient.Client
+	repo    Repository
+	chains  *blockchain.ChainPool
+	signers *signer.Provider
 }
 
 // NewService create service
-func NewService(r Repository) *Service {
-	s := Service{
-		repo: r,
+func NewService(r Repository, cp *blockchain.ChainPool, sp *signer.Provider) *Service {
+	return &Service{
+		repo:    r,
+		chains:  cp,
+		signers: sp,
 	}
-	s.client = Init()
-	return &s
 }
 
-func Init() *ethclient.Client {
-	client, err := ethclient.Dial(config.C.NFT.BASE_NET_URL)
+// VerifyCollectionChains reports collections whose chain ID has no configured RPC endpoint.
+// Configured chains that are unreachable are dialed again when used.
+func (s *Service) VerifyCollectionChains() error {
+	chainIDs, err := s.repo.GetCollectionChainIDs()
 	if err != nil {
-		log.Println(err)
+		return err
+	}
+	for _, chainID := range chainIDs {
+		if !s.chains.Configured(chainID) {
+			return fmt.Errorf("digital asset collections are deployed on an unconfigured chain: chain ID %d", chainID)
+		}
 	}
-	return client
+
+	return nil
 }
 
-func (s *Service) DeployContract() {
-	privateKey, err := crypto.HexToECDSA(config.C.NFT.PRIVATE_KEY)
+// newTransactOpts prepares the nonce and fees of a transaction sent by the signer on the chain
+func (s *Service) newTransactOpts(chain *blockchain.Chain, sign signer.Signer, gasLimit uint64) (*bind.TransactOpts, error) {
+	nonce, err := chain.Client.PendingNonceAt(context.Background(), sign.Address())
 	if err != nil {
-		log.Println(err)
+		return nil, err
 	}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Println("error casting public key to ECDSA")
+	auth := signer.NewTransactOpts(context.Background(), sign, chain.ID())
+	auth.Nonce = big.NewInt(int64(nonce))
+	auth.Value = big.NewInt(0) // in wei
+	auth.GasLimit = gasLimit   // in units
+	if err = chain.SetGasPrice(context.Background(), auth); err != nil {
+		return nil, err
 	}
 
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := s.client.PendingNonceAt(context.Background(), fromAddress)
+	return auth, nil
+}
+
+// ListContractTemplates returns the contract templates a collection can be deployed from
+func (s *Service) ListContractTemplates() []contracts.Template {
+	return contracts.ListTemplates()
+}
+
+// DeployCollection records the deployment as pending, which reserves the organization, then sends the deployment transaction.
+// The collection is created by WatchDeployment once the transaction is confirmed.
+func (s *Service) DeployCollection(deployment *entity.ContractDeployment) (*entity.ContractDeployment, int, error) {
+	template, err := contracts.GetTemplate(deployment.Template)
 	if err != nil {
-		log.Println(err)
+		return nil, http.StatusBadRequest, err
+	}
+	if !template.Available {
+		return nil, http.StatusBadRequest, errors.New(constant.MessageErrorTemplateNotAvailable)
+	}
+	if !template.Configurable && (deployment.RoyaltyReceiver != "" || deployment.RoyaltyFeeNumerator != 0) {
+		return nil, http.StatusBadRequest, errors.New(constant.MessageErrorTemplateNotConfigurable)
 	}
 
-	gasPrice, err := s.client.SuggestGasPrice(context.Background())
+	chain, err := s.chains.Get(deployment.ChainID)
 	if err != nil {
-		log.Println(err)
+		return nil, http.StatusBadRequest, err
 	}
 
-	auth := bind.NewKeyedTransactor(privateKey)
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)       // in wei
-	auth.GasLimit = DEPLOY_GAS_LIMIT // in units
-	auth.GasPrice = gasPrice
-
-	// baseURL := "https://backend-staging.kyokai.vn/digital-asset/lej/"
-	// address, tx, _, err := lej_nft.DeployLejNft(auth, s.client, baseURL)
-	baseURL := "https://backend-dev.kyokai.vn/digital-asset/astronaut/"
-	address, tx, _, err := astronaut_nft.DeployAstronautNft(auth, s.client, baseURL)
+	sign, err := s.signers.GetSigner(deployment.SignerName)
 	if err != nil {
-		log.Println(err)
+		logger.LogError(err.Error())
+		return nil, http.StatusBadRequest, err
+	}
+
+	// The collection ID is reserved up front so the token and contract URIs can point at it
+	deployment.CollectionID = primitive.NewObjectID()
+	collectionURL := strings.TrimRight(config.C.Domains.BackendDomain, "/") + "/digital-asset/collection/" + deployment.CollectionID.Hex()
+	if deployment.BaseURI == "" {
+		deployment.BaseURI = collectionURL + "/token/"
+	}
+
+	// The unique index on the reserved organization refuses a second deployment of the organization
+	deployment.Status = constant.StatusTxPending
+	deployment.ReservedOrgID = &deployment.OrganizationID
+	deployment.DeployerAddress = sign.Address().Hex()
+	deployment, err = s.repo.CreateContractDeployment(deployment)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, http.StatusConflict, errors.New(constant.MessageErrorOrganizationHasCollection)
+		}
+		logger.LogError(err.Error())
+		return nil, http.StatusInternalServerError, err
 	}
 
-	log.Println("Contract Address: " + address.Hex())
-	log.Println(tx.Hash().Hex())
+	auth, err := s.newTransactOpts(chain, sign, DEPLOY_GAS_LIMIT)
+	if err != nil {
+		s.failDeployment(deployment, err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	address, tx, err := template.Deploy(auth, chain.Client, &contracts.DeployParams{
+		Name:                deployment.Name,
+		Symbol:              deployment.Symbol,
+		BaseURI:             deployment.BaseURI,
+		ContractURI:         collectionURL + "/contract",
+		RoyaltyReceiver:     common.HexToAddress(deployment.RoyaltyReceiver),
+		RoyaltyFeeNumerator: deployment.RoyaltyFeeNumerator,
+	})
+	if err != nil {
+		logger.LogError(fmt.Sprintf("[EVM] - Fail to deploy %s on %s: %s", template.Name, chain.Config.Name, err.Error()))
+		s.failDeployment(deployment, err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	deployment.ContractAddress = strings.ToLower(address.Hex())
+	deployment.TxHash = tx.Hash().Hex()
+	if _, err = s.repo.UpdateContractDeploymentTx(deployment); err != nil {
+		logger.LogError("Cannot record transaction " + deployment.TxHash + " of deployment " + deployment.ID.Hex() + ": " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	go s.WatchDeployment(deployment)
+
+	return deployment, http.StatusOK, nil
+}
+
+// GetContractDeploymentByID returns a deployment with its current status
+func (s *Service) GetContractDeploymentByID(deploymentID *string) (*entity.ContractDeployment, int, error) {
+	deployment, err := s.repo.GetContractDeploymentByID(deploymentID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if deployment == nil {
+		return nil, http.StatusNotFound, errors.New(constant.MessageErrorContractDeploymentNotFound)
+	}
+
+	return deployment, http.StatusOK, nil
 }
 
-func (s *Service) Mint(contractAdd *string, ownerAdd *string) (string, int, error) {
-	privateKey, err := crypto.HexToECDSA(config.C.NFT.PRIVATE_KEY)
+// WatchDeployment waits for the deployment transaction to be confirmed then creates the collection of the organization,
+// a deployment that still cannot be settled after the retries fails and releases its organization
+func (s *Service) WatchDeployment(deployment *entity.ContractDeployment) {
+	// The service stopped before the transaction was sent
+	if deployment.TxHash == "" {
+		s.failDeployment(deployment, "deployment transaction was not sent")
+		return
+	}
+
+	chain, err := s.chains.Get(deployment.ChainID)
 	if err != nil {
+		s.failDeployment(deployment, err.Error())
+		return
+	}
+
+	// A transaction still not found after the polling is given up, other RPC errors are retried
+	var receipt *types.Receipt
+	err = retryWithBackoff(func() error {
+		var err error
+		receipt, err = s.GetTransactionReceipt(chain, deployment.TxHash)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			logger.LogError(fmt.Sprintf("[EVM] - Fail to get deployment receipt for %s: %s", deployment.TxHash, err.Error()))
+		}
+		return err
+	}, func(err error) bool { return !errors.Is(err, ethereum.NotFound) })
+	if err != nil {
+		s.failDeployment(deployment, err.Error())
+		return
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		s.failDeployment(deployment, "deployment transaction reverted")
+		return
+	}
+
+	template, _ := contracts.GetTemplate(deployment.Template)
+	collection := entity.DigitalAssetCollection{
+		Name:            deployment.Name,
+		Chain:           chain.Config.Name,
+		ChainID:         int(deployment.ChainID),
+		ContractAddress: strings.ToLower(receipt.ContractAddress.Hex()),
+		Standard:        template.Standard,
+		OrganizationID:  deployment.OrganizationID,
+		ContractMetadata: entity.ContractMetadata{
+			Name:                 deployment.Name,
+			SellerFeeBasisPoints: int(deployment.RoyaltyFeeNumerator),
+			FeeRecipient:         deployment.RoyaltyReceiver,
+		},
+		SignerName: deployment.SignerName,
+	}
+	collection.ID = deployment.CollectionID
+	collection.Status = constant.StatusActive
+	// The collection keeps the deployment's ID, a duplicate key means an earlier attempt created it
+	err = retryWithBackoff(func() error {
+		_, err := s.repo.CreateCollection(&collection)
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			logger.LogError("Cannot create collection of deployment " + deployment.ID.Hex() + ": " + err.Error())
+			return err
+		}
+		return nil
+	}, func(error) bool { return true })
+	if err != nil {
+		s.failDeployment(deployment, err.Error())
+		return
+	}
+
+	err = retryWithBackoff(func() error {
+		_, err := s.repo.UpdateContractDeploymentStatus(deployment.ID, constant.StatusTxSuccess, "")
+		return err
+	}, func(error) bool { return true })
+	if err != nil {
+		logger.LogError("Cannot record success of deployment " + deployment.ID.Hex() + ": " + err.Error())
+	}
+	log.Println("Collection " + collection.ID.Hex() + " deployed at " + collection.ContractAddress)
+}
+
+// ResumePendingDeployments watches again the deployments left pending by a restart
+func (s *Service) ResumePendingDeployments() {
+	deployments, err := s.repo.GetPendingContractDeployments()
+	if err != nil {
+		logger.LogError("Cannot get pending contract deployments: " + err.Error())
+		return
+	}
+	for i := range *deployments {
+		go s.WatchDeployment(&(*deployments)[i])
+	}
+}
+
+// retryWithBackoff runs the step until it succeeds or fails with an error that is not retryable,
+// waiting twice as long after each attempt, up to WATCH_TRANSACTION_MAX_RETRY attempts
+func retryWithBackoff(step func() error, retryable func(error) bool) error {
+	delay := time.Duration(config.C.NFT.WATCH_TRANSACTION_INTERVAL_TIME) * time.Second
+	err := step()
+	for attempt := 1; err != nil && retryable(err) && attempt < config.C.NFT.WATCH_TRANSACTION_MAX_RETRY; attempt++ {
+		time.Sleep(delay)
+		delay *= 2
+		err = step()
+	}
+	return err
+}
+
+func (s *Service) failDeployment(deployment *entity.ContractDeployment, reason string) {
+	logger.LogError("Contract deployment " + deployment.ID.Hex() + " failed: " + reason)
+	if _, err := s.repo.UpdateContractDeploymentStatus(deployment.ID, constant.StatusTxFailure, reason); err != nil {
 		logger.LogError(err.Error())
 	}
+}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		err = errors.New("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
+// Mint mints a token of the collection to the owner on the collection's chain, signed by the collection's signer
+func (s *Service) Mint(collection *entity.DigitalAssetCollection, ownerAdd *string) (string, int, error) {
+	chain, err := s.chains.Get(int64(collection.ChainID))
+	if err != nil {
 		logger.LogError(err.Error())
 		return "", http.StatusInternalServerError, err
 	}
 
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := s.client.PendingNonceAt(context.Background(), fromAddress)
+	sign, err := s.signers.GetSigner(collection.SignerName)
 	if err != nil {
 		logger.LogError(err.Error())
 		return "", http.StatusInternalServerError, err
 	}
 
-	gasPrice, err := s.client.SuggestGasPrice(context.Background())
+	auth, err := s.newTransactOpts(chain, sign, MINT_GAS_LIMIT)
 	if err != nil {
 		logger.LogError(err.Error())
 		return "", http.StatusInternalServerError, err
 	}
 
-	auth := bind.NewKeyedTransactor(privateKey)
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)     // in wei
-	auth.GasLimit = MINT_GAS_LIMIT // in units
-	auth.GasPrice = gasPrice
-
+	contractAdd := collection.ContractAddress
 	ownerAddress := common.HexToAddress(*ownerAdd)
-	tx, err := contracts.SafeMint(contractAdd, s.client, auth, &ownerAddress)
+	tx, err := contracts.SafeMint(&contractAdd, chain.Client, auth, &ownerAddress)
 	if err != nil {
 		logger.LogError(err.Error())
 		return "", http.StatusInternalServerError, err
@@ -136,24 +313,30 @@ func (s *Service) Mint(contractAdd *string, ownerAdd *string) (string, int, erro
 	return tx.Hash().Hex(), http.StatusOK, nil
 }
 
-func (s *Service) SyncUnreadEvents(lastSyncBlock int64, toBlock int64) {
-	addresses, err := s.repo.GetListContractAddresses()
+func (s *Service) SyncUnreadEvents(chainID int64, lastSyncBlock int64, toBlock int64) {
+	chain, err := s.chains.Get(chainID)
+	if err != nil {
+		logger.LogError(err.Error())
+		return
+	}
+	addresses, err := s.repo.GetListContractAddresses(chainID)
 	if err != nil {
 		log.Println(err)
+		return
 	}
 	query := ethereum.FilterQuery{
 		FromBlock: big.NewInt(lastSyncBlock),
 		ToBlock:   big.NewInt(toBlock),
 		Addresses: *addresses,
 	}
-	logs, err := s.client.FilterLogs(context.Background(), query)
+	logs, err := chain.Client.FilterLogs(context.Background(), query)
 	if err != nil {
 		logger.LogError(err.Error())
 		return
 	}
 	for _, vLog := range logs {
 		contractAdd := strings.ToLower(vLog.Address.Hex())
-		logTransfer, err := contracts.ParseTransfer(&contractAdd, s.client, vLog)
+		logTransfer, err := contracts.ParseTransfer(&contractAdd, chain.Client, vLog)
 		if err != nil {
 			log.Println(err)
 		}
@@ -178,15 +361,23 @@ func (s *Service) SyncUnreadEvents(lastSyncBlock int64, toBlock int64) {
 		}
 
 	}
-	ok, err := s.repo.UpdateLastSyncBlock(uint64(toBlock))
+	ok, err := s.repo.UpdateLastSyncBlock(chainID, uint64(toBlock))
 	if err != nil || !ok {
 		logger.LogError("Cannot update last sync block")
 		return
 	}
 }
 
+// ListenEvent listens to the Transfer events of every configured chain side by side
 func (s *Service) ListenEvent() {
-	addresses, err := s.repo.GetListContractAddresses()
+	for _, chain := range s.chains.Chains() {
+		go s.listenChainEvents(chain)
+	}
+}
+
+func (s *Service) listenChainEvents(chain *blockchain.Chain) {
+	chainID := chain.Config.ChainID
+	addresses, err := s.repo.GetListContractAddresses(chainID)
 	if err != nil {
 		logger.LogError("Cannot get list of contract addresses")
 		return
@@ -196,12 +387,13 @@ func (s *Service) ListenEvent() {
 			Addresses: *addresses,
 		}
 		logs := make(chan types.Log)
-		sub, err := s.client.SubscribeFilterLogs(context.Background(), query, logs)
+		sub, err := chain.Client.SubscribeFilterLogs(context.Background(), query, logs)
 		if err != nil {
-			log.Println(err)
+			logger.LogError(fmt.Sprintf("[EVM] - Cannot subscribe to logs of %s: %s", chain.Config.Name, err.Error()))
+			return
 		}
 		isSync := false
-		lastBlockNumber, err := s.repo.GetLastSyncBlock()
+		lastBlockNumber, err := s.repo.GetLastSyncBlock(chainID)
 		if err != nil {
 			log.Println(err)
 		}
@@ -212,11 +404,11 @@ func (s *Service) ListenEvent() {
 				log.Println(err)
 			case vLog := <-logs:
 				if !isSync {
-					s.SyncUnreadEvents(lastBlockNumber, int64(vLog.BlockNumber)-1)
+					s.SyncUnreadEvents(chainID, lastBlockNumber, int64(vLog.BlockNumber)-1)
 					isSync = true
 				}
 				contractAdd := strings.ToLower(vLog.Address.Hex())
-				logTransfer, err := contracts.ParseTransfer(&contractAdd, s.client, vLog)
+				logTransfer, err := contracts.ParseTransfer(&contractAdd, chain.Client, vLog)
 				if err != nil {
 					log.Println(err)
 				}
@@ -238,15 +430,22 @@ func (s *Service) ListenEvent() {
 				if !ok {
 					logger.LogError("Failed update digital assets minted with txhash " + vLog.TxHash.Hex())
 				}
-				_, _ = s.repo.UpdateLastSyncBlock(uint64(vLog.BlockNumber))
+				_, _ = s.repo.UpdateLastSyncBlock(chainID, uint64(vLog.BlockNumber))
 			}
 		}
 	}
 }
 
-func (s *Service) WatchTransaction(txHash *string) {
+// WatchTransaction waits for the mint transaction to be confirmed on its chain then updates the digital asset
+func (s *Service) WatchTransaction(chainID int64, txHash *string) {
+	chain, err := s.chains.Get(chainID)
+	if err != nil {
+		logger.LogError(err.Error())
+		return
+	}
+
 	status := constant.StatusTxPending
-	txReceipt, err := s.GetTransactionReceipt(*txHash)
+	txReceipt, err := s.GetTransactionReceipt(chain, *txHash)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("[EVM] - Fail to get transaction receipt for %s: %s", *txHash, err.Error()))
 	} else {
@@ -255,7 +454,7 @@ func (s *Service) WatchTransaction(txHash *string) {
 		if txReceipt.Status == 1 {
 			status = constant.StatusTxSuccess
 			contractAdd := strings.ToLower(vLog.Address.Hex())
-			logTransfer, err := contracts.ParseTransfer(&contractAdd, s.client, *vLog)
+			logTransfer, err := contracts.ParseTransfer(&contractAdd, chain.Client, *vLog)
 			if err != nil {
 				log.Println(err)
 			}
@@ -281,20 +480,22 @@ func (s *Service) WatchTransaction(txHash *string) {
 	}
 }
 
-func (s *Service) GetTransactionReceipt(txHash string) (*types.Receipt, error) {
+// GetTransactionReceipt polls the receipt then waits for the chain's confirmations
+func (s *Service) GetTransactionReceipt(chain *blockchain.Chain, txHash string) (*types.Receipt, error) {
 	var (
-		receipt *types.Receipt
-		err     error
-		retry   = 0
-		hash    = common.HexToHash(txHash)
+		receipt  *types.Receipt
+		err      error
+		retry    = 0
+		hash     = common.HexToHash(txHash)
+		interval = time.Duration(config.C.NFT.WATCH_TRANSACTION_INTERVAL_TIME) * time.Second
 	)
 
 	for retry < config.C.NFT.WATCH_TRANSACTION_MAX_RETRY {
-		receipt, err = s.client.TransactionReceipt(context.Background(), hash)
+		receipt, err = chain.Client.TransactionReceipt(context.Background(), hash)
 		if errors.Is(err, ethereum.NotFound) {
 			retry++
 			if retry < config.C.NFT.WATCH_TRANSACTION_MAX_RETRY {
-				time.Sleep(time.Duration(config.C.NFT.WATCH_TRANSACTION_INTERVAL_TIME) * time.Second)
+				time.Sleep(interval)
 				continue
 			}
 		}
@@ -303,6 +504,17 @@ func (s *Service) GetTransactionReceipt(txHash string) (*types.Receipt, error) {
 	if errors.Is(err, ethereum.NotFound) {
 		logger.LogError("Couldn't get transaction status after WATCH_TRANSACTION_MAX_RETRY retries!")
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	if chain.Config.Confirmations > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.C.NFT.WATCH_TRANSACTION_MAX_RETRY)*interval)
+		defer cancel()
+		if err = chain.WaitForConfirmations(ctx, receipt, interval); err != nil {
+			return nil, err
+		}
+	}
 
-	return receipt, err
+	return receipt, nil
 }
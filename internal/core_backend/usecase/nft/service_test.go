@@ -0,0 +1,348 @@
+package nft
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	config "backend-service/config/core_backend"
+	constant "backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/blockchain"
+	"backend-service/internal/core_backend/infrastructure/signer"
+)
+
+// deploymentRepository keeps the deployments and the collections they create, one deployment reserving each organization
+type deploymentRepository struct {
+	Repository
+	mu          sync.Mutex
+	deployments map[primitive.ObjectID]entity.ContractDeployment
+	collections map[primitive.ObjectID]entity.DigitalAssetCollection
+	// failCollections is the number of collection creations failing before one succeeds
+	failCollections int
+}
+
+func (r *deploymentRepository) CreateContractDeployment(deployment *entity.ContractDeployment) (*entity.ContractDeployment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.deployments {
+		if stored.ReservedOrgID != nil && *stored.ReservedOrgID == *deployment.ReservedOrgID {
+			return nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+		}
+	}
+	deployment.ID = primitive.NewObjectID()
+	r.deployments[deployment.ID] = *deployment
+	return deployment, nil
+}
+
+func (r *deploymentRepository) UpdateContractDeploymentStatus(deploymentID primitive.ObjectID, status string, errMessage string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deployment := r.deployments[deploymentID]
+	deployment.Status, deployment.Error = status, errMessage
+	if status == constant.StatusTxFailure {
+		deployment.ReservedOrgID = nil
+	}
+	r.deployments[deploymentID] = deployment
+	return true, nil
+}
+
+func (r *deploymentRepository) UpdateContractDeploymentTx(deployment *entity.ContractDeployment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.deployments[deployment.ID]
+	stored.ContractAddress, stored.TxHash = deployment.ContractAddress, deployment.TxHash
+	r.deployments[deployment.ID] = stored
+	return true, nil
+}
+
+func (r *deploymentRepository) CreateCollection(collection *entity.DigitalAssetCollection) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failCollections > 0 {
+		r.failCollections--
+		return false, errors.New("server selection timeout")
+	}
+	r.collections[collection.ID] = *collection
+	return true, nil
+}
+
+func (r *deploymentRepository) deployment(deploymentID primitive.ObjectID) entity.ContractDeployment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.deployments[deploymentID]
+}
+
+func (r *deploymentRepository) collection(collectionID primitive.ObjectID) (entity.DigitalAssetCollection, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	collection, ok := r.collections[collectionID]
+	return collection, ok
+}
+
+// testNode answers the JSON-RPC calls of a deployment, every transaction it accepts is mined successfully
+type testNode struct {
+	mu       sync.Mutex
+	sent     []*types.Transaction
+	failSend bool
+	// failReceipts is the number of receipt requests answered with an error before the receipt is returned
+	failReceipts int
+}
+
+func (n *testNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var call struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var result any
+	switch call.Method {
+	case "eth_chainId":
+		result = "0x539"
+	case "eth_getTransactionCount":
+		result = hexutil.Uint64(len(n.sent))
+	case "eth_gasPrice":
+		result = "0x3b9aca00"
+	case "eth_sendRawTransaction":
+		if n.failSend {
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "error": map[string]any{"code": -32000, "message": "insufficient funds for gas"}})
+			return
+		}
+		var raw hexutil.Bytes
+		json.Unmarshal(call.Params[0], &raw)
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		n.sent = append(n.sent, tx)
+		result = tx.Hash()
+	case "eth_getTransactionReceipt":
+		if n.failReceipts > 0 {
+			n.failReceipts--
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "error": map[string]any{"code": -32603, "message": "upstream unavailable"}})
+			return
+		}
+		var hash common.Hash
+		json.Unmarshal(call.Params[0], &hash)
+		for _, tx := range n.sent {
+			if tx.Hash() != hash {
+				continue
+			}
+			from, _ := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+			result = map[string]any{
+				"status":            "0x1",
+				"cumulativeGasUsed": "0x5208",
+				"gasUsed":           "0x5208",
+				"logsBloom":         hexutil.Bytes(make([]byte, types.BloomByteLength)),
+				"logs":              []any{},
+				"transactionHash":   hash,
+				"contractAddress":   crypto.CreateAddress(from, tx.Nonce()),
+				"blockNumber":       "0x1",
+			}
+		}
+	default:
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "error": map[string]any{"code": -32601, "message": "unexpected call " + call.Method}})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "result": result})
+}
+
+func (n *testNode) sentCount() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.sent)
+}
+
+// newDeployService a service deploying on a test node with a default keystore signer
+func newDeployService(t *testing.T) (*Service, *deploymentRepository, *testNode) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &keystore.Key{Id: uuid.New(), Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	keyJSON, err := keystore.EncryptKey(key, "deployer", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(dir, "deployer.json"), keyJSON, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, "deployer.pass"), []byte("deployer"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := config.C
+	config.C.Signer.KEYSTORE_DIR = dir
+	config.C.Signer.DEFAULT_KEYSTORE_FILE = "deployer.json"
+	config.C.Signer.DEFAULT_PASSPHRASE_FILE = "deployer.pass"
+	config.C.NFT.WATCH_TRANSACTION_MAX_RETRY = 1
+	config.C.NFT.WATCH_TRANSACTION_INTERVAL_TIME = 0
+	config.C.NFT.CONTRACT_ARTIFACTS_DIR = dir
+	config.C.Domains.BackendDomain = "https://backend.test"
+	t.Cleanup(func() { config.C = previous })
+
+	node := &testNode{}
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+	chains, err := blockchain.NewChainPool([]config.ChainConfig{{Name: "test", ChainID: 1337, RPCURL: server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &deploymentRepository{
+		deployments: map[primitive.ObjectID]entity.ContractDeployment{},
+		collections: map[primitive.ObjectID]entity.DigitalAssetCollection{},
+	}
+
+	return NewService(repo, chains, signer.NewProvider()), repo, node
+}
+
+// waitForStatus waits for WatchDeployment to settle the deployment
+func waitForStatus(t *testing.T, repo *deploymentRepository, deploymentID primitive.ObjectID, status string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.deployment(deploymentID).Status != status {
+		if time.Now().After(deadline) {
+			t.Fatalf("deployment %s: got status %q, want %q", deploymentID.Hex(), repo.deployment(deploymentID).Status, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDeployCollection(t *testing.T) {
+	s, repo, node := newDeployService(t)
+	orgID := primitive.NewObjectID()
+
+	deployment, _, err := s.DeployCollection(&entity.ContractDeployment{OrganizationID: orgID, Template: "lej", ChainID: 1337, Name: "LeJ"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.sentCount() != 1 || deployment.TxHash == "" || deployment.ContractAddress == "" {
+		t.Errorf("sent %d transactions, recorded %q at %q", node.sentCount(), deployment.TxHash, deployment.ContractAddress)
+	}
+	if !strings.HasSuffix(deployment.BaseURI, "/digital-asset/collection/"+deployment.CollectionID.Hex()+"/token/") {
+		t.Errorf("base URI %q", deployment.BaseURI)
+	}
+
+	// The organization is reserved while its deployment is pending, no transaction is sent for a second one
+	if _, code, _ := s.DeployCollection(&entity.ContractDeployment{OrganizationID: orgID, Template: "lej", ChainID: 1337, Name: "LeJ"}); code != http.StatusConflict {
+		t.Errorf("second deployment of the organization: got %d", code)
+	}
+	if node.sentCount() != 1 {
+		t.Errorf("second deployment sent a transaction")
+	}
+
+	waitForStatus(t, repo, deployment.ID, constant.StatusTxSuccess)
+	collection, ok := repo.collection(deployment.CollectionID)
+	if !ok || collection.OrganizationID != orgID || collection.ContractAddress != deployment.ContractAddress || collection.ChainID != 1337 {
+		t.Errorf("collection of the confirmed deployment: %+v", collection)
+	}
+	if _, code, _ := s.DeployCollection(&entity.ContractDeployment{OrganizationID: orgID, Template: "lej", ChainID: 1337, Name: "LeJ"}); code != http.StatusConflict {
+		t.Errorf("deployment of an organization with a collection: got %d", code)
+	}
+}
+
+func TestDeployCollectionFailure(t *testing.T) {
+	s, repo, node := newDeployService(t)
+	orgID := primitive.NewObjectID()
+
+	node.failSend = true
+	if _, code, _ := s.DeployCollection(&entity.ContractDeployment{OrganizationID: orgID, Template: "lej", ChainID: 1337, Name: "LeJ"}); code != http.StatusInternalServerError {
+		t.Fatalf("rejected transaction: got %d", code)
+	}
+	for _, deployment := range repo.deployments {
+		if deployment.Status != constant.StatusTxFailure || deployment.ReservedOrgID != nil {
+			t.Errorf("rejected deployment is %q reserving %v", deployment.Status, deployment.ReservedOrgID)
+		}
+	}
+
+	// A failed deployment releases the organization
+	node.failSend = false
+	deployment, _, err := s.DeployCollection(&entity.ContractDeployment{OrganizationID: orgID, Template: "lej", ChainID: 1337, Name: "LeJ"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, repo, deployment.ID, constant.StatusTxSuccess)
+
+	// Deployments left without a transaction by a restart fail instead of being watched forever
+	unsent := &entity.ContractDeployment{OrganizationID: primitive.NewObjectID()}
+	unsent.Status, unsent.ReservedOrgID = constant.StatusTxPending, &unsent.OrganizationID
+	unsent, _ = repo.CreateContractDeployment(unsent)
+	s.WatchDeployment(unsent)
+	if stored := repo.deployment(unsent.ID); stored.Status != constant.StatusTxFailure || stored.ReservedOrgID != nil {
+		t.Errorf("deployment without a transaction is %q", stored.Status)
+	}
+}
+
+func TestWatchDeploymentRetries(t *testing.T) {
+	s, repo, node := newDeployService(t)
+	config.C.NFT.WATCH_TRANSACTION_MAX_RETRY = 3
+
+	// Transient receipt and collection errors are retried until the deployment succeeds
+	node.failReceipts, repo.failCollections = 2, 2
+	deployment, _, err := s.DeployCollection(&entity.ContractDeployment{OrganizationID: primitive.NewObjectID(), Template: "lej", ChainID: 1337, Name: "LeJ"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, repo, deployment.ID, constant.StatusTxSuccess)
+	if _, ok := repo.collection(deployment.CollectionID); !ok {
+		t.Errorf("collection of the retried deployment was not created")
+	}
+
+	// A collection that still cannot be created fails the deployment and releases the organization
+	repo.mu.Lock()
+	repo.failCollections = 3
+	repo.mu.Unlock()
+	deployment, _, err = s.DeployCollection(&entity.ContractDeployment{OrganizationID: primitive.NewObjectID(), Template: "lej", ChainID: 1337, Name: "LeJ"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, repo, deployment.ID, constant.StatusTxFailure)
+	if stored := repo.deployment(deployment.ID); stored.ReservedOrgID != nil {
+		t.Errorf("failed deployment still reserves %v", stored.ReservedOrgID)
+	}
+}
+
+func TestDeployCollectionRefusedTemplates(t *testing.T) {
+	s, _, node := newDeployService(t)
+
+	checks := map[string]*entity.ContractDeployment{
+		"unknown template":         {OrganizationID: primitive.NewObjectID(), Template: "unknown", ChainID: 1337},
+		"template not built":       {OrganizationID: primitive.NewObjectID(), Template: "phygital", ChainID: 1337},
+		"royalty of a legacy one":  {OrganizationID: primitive.NewObjectID(), Template: "lej", ChainID: 1337, RoyaltyFeeNumerator: 500},
+		"chain is not configured":  {OrganizationID: primitive.NewObjectID(), Template: "lej", ChainID: 1},
+		"signer is not configured": {OrganizationID: primitive.NewObjectID(), Template: "lej", ChainID: 1337, SignerName: "attacker"},
+	}
+	for name, deployment := range checks {
+		if _, code, _ := s.DeployCollection(deployment); code != http.StatusBadRequest {
+			t.Errorf("%s: got %d", name, code)
+		}
+	}
+	if node.sentCount() != 0 {
+		t.Errorf("refused deployments sent %d transactions", node.sentCount())
+	}
+}
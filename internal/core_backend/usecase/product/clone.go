@@ -0,0 +1,167 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+)
+
+// DeepCloneProduct copies the product as version 1 of a new product, with its template and the webpages of the template
+// when asked to, and new items. References between the copies are rewritten to their new IDs, and the copies are inserted
+// all together or not at all.
+func (s *Service) DeepCloneProduct(scope *entity.AccessScope, productID *string, options *entity.ProductCloneOptions) (*entity.ProductCloneResult, int, error) {
+	source, code, err := s.GetProductInScope(scope, productID)
+	if err != nil {
+		return nil, code, err
+	}
+	orgID := options.OrganizationID
+	if orgID.IsZero() {
+		orgID = source.OrganizationID
+	}
+	if !scope.CanAccess(orgID) {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorOrgNotFound)
+	}
+
+	ids := map[primitive.ObjectID]primitive.ObjectID{}
+	clone := &entity.ProductClone{}
+	templateID := source.TemplateID
+	if !templateID.IsZero() {
+		if options.CloneTemplate {
+			if code, err := s.cloneTemplate(scope, templateID, orgID, clone, ids); err != nil {
+				return nil, code, err
+			}
+			templateID = clone.Template.ID
+		} else if code, err := s.checkTemplateKept(templateID, orgID); err != nil {
+			return nil, code, err
+		}
+	}
+
+	product := *source
+	product.Renew()
+	product.ID = primitive.NewObjectID()
+	ids[source.ID] = product.ID
+	product.SetStatus(common.StatusActive)
+	product.OrganizationID = orgID
+	product.TemplateID = templateID
+	product.ProductName += "_Copy"
+	product.TotalItem = options.TotalItem
+	product.RatingScore = 0
+	product.PublishedVersion = 1
+	clone.Product = product
+
+	clone.Version = entity.ProductVersion{
+		ProductID:      product.ID,
+		OrganizationID: orgID,
+		Version:        1,
+		Content:        product,
+		PublishedAt:    &product.CreatedAt,
+	}
+	clone.Version.SetTime()
+	clone.Version.SetStatus(common.ProductVersionPublished)
+
+	itemIDs := make([]primitive.ObjectID, 0, options.TotalItem)
+	for i := 0; i < options.TotalItem; i++ {
+		item := entity.ProductItem{
+			BaseModel: entity.BaseModel{ID: primitive.NewObjectID(), Status: common.StatusActive},
+			ProductID: product.ID,
+			ItemIndex: i + 1,
+		}
+		item.SetTime()
+		clone.Items = append(clone.Items, item)
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	if err = s.repo.InsertProductClone(clone); err != nil {
+		logger.LogError("Got error while inserting clone of product " + source.ID.Hex() + ": " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result := &entity.ProductCloneResult{Product: product, IDMap: map[string]string{}, ItemIDs: itemIDs}
+	for original, copied := range ids {
+		result.IDMap[original.Hex()] = copied.Hex()
+	}
+
+	return result, http.StatusOK, nil
+}
+
+// cloneTemplate adds to the clone a copy of the template and of every webpage it references, owned by the organization
+func (s *Service) cloneTemplate(scope *entity.AccessScope, templateID, orgID primitive.ObjectID, clone *entity.ProductClone, ids map[primitive.ObjectID]primitive.ObjectID) (int, error) {
+	template, err := s.repo.GetTemplateToClone(templateID, scope)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if template == nil {
+		return http.StatusNotFound, errors.New(common.MessageErrorTemplateNotFound)
+	}
+
+	pageIDs := []primitive.ObjectID{}
+	referenced := map[primitive.ObjectID]bool{}
+	for _, pageID := range templatePageIDs(template) {
+		if !pageID.IsZero() && !referenced[pageID] {
+			referenced[pageID] = true
+			pageIDs = append(pageIDs, pageID)
+		}
+	}
+	pages, err := s.repo.GetWebPagesToClone(pageIDs, scope)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if len(*pages) != len(pageIDs) {
+		return http.StatusNotFound, errors.New(common.MessageErrorTemplatePageMissing)
+	}
+
+	for _, page := range *pages {
+		original := page.ID
+		page.Renew()
+		page.ID = primitive.NewObjectID()
+		page.OrganizationID = orgID
+		ids[original] = page.ID
+		clone.WebPages = append(clone.WebPages, page)
+	}
+
+	template.Renew()
+	template.ID = primitive.NewObjectID()
+	ids[templateID] = template.ID
+	template.OrganizationID = orgID
+	template.Name += "_Copy"
+	for i := range template.Pages {
+		template.Pages[i].PageID = ids[template.Pages[i].PageID]
+	}
+	for i := range template.Menu {
+		template.Menu[i].PageID = ids[template.Menu[i].PageID]
+	}
+	clone.Template = template
+
+	return http.StatusOK, nil
+}
+
+// checkTemplateKept a clone keeping the template of the product must be in an organization the template is shared with
+func (s *Service) checkTemplateKept(templateID, orgID primitive.ObjectID) (int, error) {
+	template, err := s.repo.GetTemplateToClone(templateID, &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if template == nil {
+		return http.StatusBadRequest, errors.New(common.MessageErrorTemplateOfOtherOrg)
+	}
+
+	return http.StatusOK, nil
+}
+
+// templatePageIDs the webpages the pages and the menu of the template reference, zero for menu entries without a page
+func templatePageIDs(template *entity.Template) []primitive.ObjectID {
+	pageIDs := make([]primitive.ObjectID, 0, len(template.Pages)+len(template.Menu))
+	for _, page := range template.Pages {
+		pageIDs = append(pageIDs, page.PageID)
+	}
+	for _, menu := range template.Menu {
+		pageIDs = append(pageIDs, menu.PageID)
+	}
+
+	return pageIDs
+}
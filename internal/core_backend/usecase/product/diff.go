@@ -0,0 +1,76 @@
+package product
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// systemFields the fields of a product that are not its content, they differ between versions without an editor changing them
+var systemFields = []string{"id", "status", "created_at", "updated_at", "total_item", "rating_score", "published_version"}
+
+// diffContent the fields of the content that differ, by JSON path in sorted order.
+// Objects are compared field by field, any other value, arrays included, as a whole.
+func diffContent(before, after *entity.Product) ([]entity.ProductChange, error) {
+	beforeMap, err := contentMap(before)
+	if err != nil {
+		return nil, err
+	}
+	afterMap, err := contentMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []entity.ProductChange{}
+	diffValues("", beforeMap, afterMap, &changes)
+
+	return changes, nil
+}
+
+func contentMap(product *entity.Product) (map[string]any, error) {
+	raw, err := json.Marshal(product)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]any
+	if err = json.Unmarshal(raw, &content); err != nil {
+		return nil, err
+	}
+	for _, field := range systemFields {
+		delete(content, field)
+	}
+
+	return content, nil
+}
+
+func diffValues(path string, before, after any, changes *[]entity.ProductChange) {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if !beforeIsMap || !afterIsMap {
+		if !reflect.DeepEqual(before, after) {
+			*changes = append(*changes, entity.ProductChange{Path: path, Before: before, After: after})
+		}
+		return
+	}
+
+	keys := make([]string, 0, len(beforeMap)+len(afterMap))
+	for key := range beforeMap {
+		keys = append(keys, key)
+	}
+	for key := range afterMap {
+		if _, ok := beforeMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		diffValues(childPath, beforeMap[key], afterMap[key], changes)
+	}
+}
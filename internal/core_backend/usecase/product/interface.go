@@ -3,6 +3,7 @@ package product
 import (
 	"backend-service/internal/core_backend/api/handler/request"
 	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/pagination"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -10,32 +11,60 @@ import (
 // Product interface
 type Product interface {
 	// Interface for repository
-	CreateProduct(*entity.Product) (*entity.Product, error)
+	CreateProduct(*entity.Product, *entity.ProductVersion) (*entity.Product, error)
 	CheckExistedProduct(*entity.Product) (bool, error)
-	GetAllProducts() (*[]entity.Product, error)
-	GetAllProductsInOrg(orgID *primitive.ObjectID) (*[]entity.Product, error)
+	GetProducts(scope *entity.AccessScope, orgID *primitive.ObjectID) (*[]entity.Product, error)
 	GetProductByID(*string) (*entity.Product, error)
-	UpdateProductDetail(*entity.Product, *string) (bool, error)
-	SoftDeleteProductByID(productID *string) (bool, error)
+	GetProductInScope(productID *string, scope *entity.AccessScope) (*entity.Product, error)
+	SoftDeleteProductByID(productID *string, scope *entity.AccessScope) (bool, error)
 	UpdateProductTotalItems(*string, int) (bool, error)
 	GetProductForAuthor(*string) (*[]entity.Product, error)
+	SearchProducts(scope *entity.AccessScope, filter *entity.ProductFilter, paging *pagination.Pagination) (*entity.ProductPage, error)
+}
+
+// ProductVersion interface
+type ProductVersion interface {
+	SaveProductDraft(*entity.ProductVersion) (*entity.ProductVersion, error)
+	DeleteProductDraft(productID primitive.ObjectID) (bool, error)
+	GetProductVersion(productID primitive.ObjectID, version int) (*entity.ProductVersion, error)
+	GetProductVersions(productID primitive.ObjectID) (*[]entity.ProductVersion, error)
+	PublishProductVersion(product *entity.Product, previousVersion int, version *entity.ProductVersion) (bool, error)
+}
+
+// Clone interface
+type Clone interface {
+	GetTemplateToClone(templateID primitive.ObjectID, scope *entity.AccessScope) (*entity.Template, error)
+	GetWebPagesToClone(pageIDs []primitive.ObjectID, scope *entity.AccessScope) (*[]entity.WebPage, error)
+	InsertProductClone(*entity.ProductClone) error
 }
 
 // Repository interface
 type Repository interface {
 	Product
+	ProductVersion
+	Clone
 }
 
 // UseCase interface
 type UseCase interface {
 	// Interface for usecase - service
 	CreateProduct(*entity.Product) (*entity.Product, int, error)
-	GetAllProductsInOrg(orgID *string) (*[]entity.Product, int, error)
+	GetProducts(scope *entity.AccessScope, orgID *string) (*[]entity.Product, int, error)
+	SearchProducts(scope *entity.AccessScope, filter *entity.ProductFilter, paging *pagination.Pagination) (*entity.ProductPage, int, error)
 	GetProductDetail(*request.InteractProductDetailRequest) (*entity.Product, int, error)
-	UpdateProductDetail(*entity.Product, *string) (bool, int, error)
-	DeteleProductByID(*request.InteractProductDetailRequest) (bool, int, error)
+	GetProductInScope(scope *entity.AccessScope, productID *string) (*entity.Product, int, error)
+	SaveProductDraft(scope *entity.AccessScope, product *entity.Product, productID *string, userID string) (*entity.ProductVersion, int, error)
+	GetProductDraft(scope *entity.AccessScope, productID *string) (*entity.ProductVersion, int, error)
+	DiscardProductDraft(scope *entity.AccessScope, productID *string) (bool, int, error)
+	PublishProductDraft(scope *entity.AccessScope, draft *entity.ProductVersion, userID string) (*entity.ProductVersion, int, error)
+	GetProductVersions(scope *entity.AccessScope, productID *string) (*[]entity.ProductVersion, int, error)
+	GetProductVersion(scope *entity.AccessScope, productID *string, version int) (*entity.ProductVersion, int, error)
+	DiffProductVersions(scope *entity.AccessScope, productID *string, from, to *int) (*entity.ProductDiff, int, error)
+	RollbackProduct(scope *entity.AccessScope, productID *string, version int, userID string) (*entity.ProductVersion, int, error)
+	DeteleProductByID(scope *entity.AccessScope, request *request.InteractProductDetailRequest) (bool, int, error)
 	GetProductByID(*string) (*entity.Product, int, error)
 	SyncTotalItems(*string, int) (bool, int, error)
 	GetProductForAuthor(*string) (*[]entity.Product, int, error)
-	CloneProductByID(*string) (*entity.Product, int, error)
+	CloneProductByID(scope *entity.AccessScope, productID *string) (*entity.Product, int, error)
+	DeepCloneProduct(scope *entity.AccessScope, productID *string, options *entity.ProductCloneOptions) (*entity.ProductCloneResult, int, error)
 }
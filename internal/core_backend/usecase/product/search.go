@@ -0,0 +1,37 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/pagination"
+)
+
+// SearchProducts a page of the products of the organizations in scope matching the filter.
+// Active products are searched unless a status is given, and products are ordered by relevance when there is a query.
+func (s *Service) SearchProducts(scope *entity.AccessScope, filter *entity.ProductFilter, paging *pagination.Pagination) (*entity.ProductPage, int, error) {
+	if paging.OrderBy == common.ProductOrderByRelevance && filter.Query == "" {
+		return nil, http.StatusBadRequest, errors.New(common.MessageErrorRelevanceWithoutQuery)
+	}
+	if paging.OrderBy == "" && filter.Query != "" {
+		paging.OrderBy = common.ProductOrderByRelevance
+	}
+	paging.Fulfill()
+	if filter.Status == "" {
+		filter.Status = common.StatusActive
+	}
+
+	page, err := s.repo.SearchProducts(scope, filter, paging)
+	if err != nil {
+		if errors.Is(err, pagination.ErrorInvalidLenCursor) {
+			return nil, http.StatusBadRequest, errors.New(common.MessageErrorInvalidCursor)
+		}
+		logger.LogError("Got error while searching products: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return page, http.StatusOK, nil
+}
@@ -1,10 +1,11 @@
 package product
 
 import (
-	"log"
+	"errors"
 	"net/http"
 
 	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/common/logger"
 	"backend-service/internal/core_backend/entity"
 
@@ -23,9 +24,10 @@ func NewService(r Repository) *Service {
 	}
 }
 
-// CreateProduct
+// CreateProduct creates the product live at version 1 of its content
 func (s *Service) CreateProduct(request *entity.Product) (*entity.Product, int, error) {
-	productInserted, err := s.repo.CreateProduct(request)
+	request.PublishedVersion = 1
+	productInserted, err := s.repo.CreateProduct(request, initialVersion(request))
 	if err != nil {
 		logger.LogError("Get error when creating product: " + err.Error())
 		return nil, http.StatusInternalServerError, err
@@ -34,64 +36,55 @@ func (s *Service) CreateProduct(request *entity.Product) (*entity.Product, int,
 	return productInserted, http.StatusOK, nil
 }
 
-func (s *Service) GetAllProductsInOrg(orgID *string) (*[]entity.Product, int, error) {
-	var products *[]entity.Product
+// GetProducts products of the organizations in scope, only of orgID when given
+func (s *Service) GetProducts(scope *entity.AccessScope, orgID *string) (*[]entity.Product, int, error) {
+	var oID *primitive.ObjectID
 	if len(*orgID) != 0 {
-		oID, err := primitive.ObjectIDFromHex(*orgID)
+		id, err := primitive.ObjectIDFromHex(*orgID)
 		if err != nil {
 			logger.LogError("Got error while parsing organization: " + err.Error())
-			return nil, http.StatusInternalServerError, err
-		}
-
-		result, err := s.repo.GetAllProductsInOrg(&oID)
-		if err != nil {
-			return nil, http.StatusInternalServerError, err
-		}
-
-		products = result
-	} else {
-		result, err := s.repo.GetAllProducts()
-		if err != nil {
-			return nil, http.StatusInternalServerError, err
+			return nil, http.StatusBadRequest, err
 		}
+		oID = &id
+	}
 
-		products = result
+	products, err := s.repo.GetProducts(scope, oID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
 
 	return products, http.StatusOK, nil
 }
 
-func (s *Service) GetProductDetail(request *request.InteractProductDetailRequest) (*entity.Product, int, error) {
-	product, err := s.repo.GetProductByID(&request.ProductID)
+// GetProductInScope products of organizations out of scope are reported as not found
+func (s *Service) GetProductInScope(scope *entity.AccessScope, productID *string) (*entity.Product, int, error) {
+	product, err := s.repo.GetProductInScope(productID, scope)
 	if err != nil {
 		logger.LogError("Got error while get product: " + err.Error())
 		return nil, http.StatusInternalServerError, err
 	}
+	if product == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorProductNotFound)
+	}
 
 	return product, http.StatusOK, nil
 }
 
-func (s *Service) UpdateProductDetail(request *entity.Product, productID *string) (bool, int, error) {
-	prod, err := s.repo.GetProductByID(productID)
-	if err != nil {
-		logger.LogError("Got error while getting product detail: " + err.Error())
-		return false, http.StatusInternalServerError, err
-	}
-	log.Println(request)
-	request.BaseModel = prod.BaseModel
-	request.SetTime()
-	request.ClearModel()
-	success, err := s.repo.UpdateProductDetail(request, productID)
+func (s *Service) GetProductDetail(request *request.InteractProductDetailRequest) (*entity.Product, int, error) {
+	product, err := s.repo.GetProductByID(&request.ProductID)
 	if err != nil {
-		logger.LogError("Got error while updating product detail: " + err.Error())
-		return false, http.StatusInternalServerError, err
+		logger.LogError("Got error while get product: " + err.Error())
+		return nil, http.StatusInternalServerError, err
 	}
 
-	return success, http.StatusOK, nil
+	return product, http.StatusOK, nil
 }
 
-func (s *Service) DeteleProductByID(request *request.InteractProductDetailRequest) (bool, int, error) {
-	success, err := s.repo.SoftDeleteProductByID(&request.ProductID)
+func (s *Service) DeteleProductByID(scope *entity.AccessScope, request *request.InteractProductDetailRequest) (bool, int, error) {
+	if _, code, err := s.GetProductInScope(scope, &request.ProductID); err != nil {
+		return false, code, err
+	}
+	success, err := s.repo.SoftDeleteProductByID(&request.ProductID, scope)
 	if err != nil {
 		logger.LogError("Got error while deleting product: " + err.Error())
 		return false, http.StatusInternalServerError, err
@@ -131,22 +124,15 @@ func (s *Service) GetProductForAuthor(authorID *string) (*[]entity.Product, int,
 }
 
 // CloneProductByID - clone product with specified product ID
-func (s *Service) CloneProductByID(productID *string) (*entity.Product, int, error) {
-	targetProduct, err := s.repo.GetProductByID(productID)
+func (s *Service) CloneProductByID(scope *entity.AccessScope, productID *string) (*entity.Product, int, error) {
+	targetProduct, code, err := s.GetProductInScope(scope, productID)
 	if err != nil {
-		logger.LogError("Error getting product: " + err.Error())
-		return nil, http.StatusInternalServerError, err
+		return nil, code, err
 	}
 
 	targetProduct.ProductName += "_Copy"
 	targetProduct.TotalItem = 0
 	targetProduct.Renew()
 
-	newProduct, err := s.repo.CreateProduct(targetProduct)
-	if err != nil {
-		logger.LogError("Get error when creating product: " + err.Error())
-		return nil, http.StatusInternalServerError, err
-	}
-
-	return newProduct, http.StatusOK, nil
+	return s.CreateProduct(targetProduct)
 }
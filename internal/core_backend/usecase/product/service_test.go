@@ -0,0 +1,372 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/pagination"
+)
+
+// productVersions the products of the publish and clone tests, versions by product then by number with the draft at 0,
+// and the templates and pages a clone copies
+type productVersions struct {
+	Repository
+	products  map[primitive.ObjectID]*entity.Product
+	versions  map[primitive.ObjectID]map[int]entity.ProductVersion
+	templates map[primitive.ObjectID]entity.Template
+	pages     map[primitive.ObjectID]entity.WebPage
+	clones    []entity.ProductClone
+	// publishErr fails the publications
+	publishErr error
+}
+
+func newMemoryRepository() *productVersions {
+	return &productVersions{
+		products:  map[primitive.ObjectID]*entity.Product{},
+		versions:  map[primitive.ObjectID]map[int]entity.ProductVersion{},
+		templates: map[primitive.ObjectID]entity.Template{},
+		pages:     map[primitive.ObjectID]entity.WebPage{},
+	}
+}
+
+func (r *productVersions) CreateProduct(product *entity.Product, version *entity.ProductVersion) (*entity.Product, error) {
+	product.ID = primitive.NewObjectID()
+	stored := *product
+	r.products[product.ID] = &stored
+	version.ProductID, version.Content.ID = product.ID, product.ID
+	r.versions[product.ID] = map[int]entity.ProductVersion{version.Version: *version}
+	return product, nil
+}
+
+func (r *productVersions) GetProductInScope(productID *string, scope *entity.AccessScope) (*entity.Product, error) {
+	id, err := primitive.ObjectIDFromHex(*productID)
+	if err != nil {
+		return nil, err
+	}
+	product, ok := r.products[id]
+	if !ok || !scope.CanAccess(product.OrganizationID) {
+		return nil, nil
+	}
+	found := *product
+	return &found, nil
+}
+
+func (r *productVersions) SaveProductDraft(draft *entity.ProductVersion) (*entity.ProductVersion, error) {
+	if r.versions[draft.ProductID] == nil {
+		r.versions[draft.ProductID] = map[int]entity.ProductVersion{}
+	}
+	r.versions[draft.ProductID][entity.DraftVersion] = *draft
+	return draft, nil
+}
+
+func (r *productVersions) DeleteProductDraft(productID primitive.ObjectID) (bool, error) {
+	_, ok := r.versions[productID][entity.DraftVersion]
+	delete(r.versions[productID], entity.DraftVersion)
+	return ok, nil
+}
+
+func (r *productVersions) GetProductVersion(productID primitive.ObjectID, version int) (*entity.ProductVersion, error) {
+	found, ok := r.versions[productID][version]
+	if !ok {
+		return nil, nil
+	}
+	return &found, nil
+}
+
+func (r *productVersions) GetProductVersions(productID primitive.ObjectID) (*[]entity.ProductVersion, error) {
+	versions := []entity.ProductVersion{}
+	for number := len(r.versions[productID]); number > 0; number-- {
+		if version, ok := r.versions[productID][number]; ok {
+			versions = append(versions, version)
+		}
+	}
+	return &versions, nil
+}
+
+// PublishProductVersion records nothing when it fails, like the transaction of the repository
+func (r *productVersions) PublishProductVersion(product *entity.Product, previousVersion int, version *entity.ProductVersion) (bool, error) {
+	if r.publishErr != nil {
+		return false, r.publishErr
+	}
+	if _, ok := r.versions[version.ProductID][version.Version]; ok {
+		return false, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+	}
+	stored, ok := r.products[product.ID]
+	if !ok || stored.PublishedVersion != previousVersion {
+		return false, nil
+	}
+	if r.versions[version.ProductID] == nil {
+		r.versions[version.ProductID] = map[int]entity.ProductVersion{}
+	}
+	version.ID = primitive.NewObjectID()
+	r.versions[version.ProductID][version.Version] = *version
+	*stored = *product
+	return true, nil
+}
+
+// sharedOrInScope documents without an organization are shared by every organization
+func sharedOrInScope(scope *entity.AccessScope, orgID primitive.ObjectID) bool {
+	return orgID.IsZero() || scope.CanAccess(orgID)
+}
+
+func (r *productVersions) GetTemplateToClone(templateID primitive.ObjectID, scope *entity.AccessScope) (*entity.Template, error) {
+	template, ok := r.templates[templateID]
+	if !ok || !sharedOrInScope(scope, template.OrganizationID) {
+		return nil, nil
+	}
+	template.Pages = append([]entity.TemplatePages{}, template.Pages...)
+	template.Menu = append([]entity.TemplateMenu{}, template.Menu...)
+	return &template, nil
+}
+
+func (r *productVersions) GetWebPagesToClone(pageIDs []primitive.ObjectID, scope *entity.AccessScope) (*[]entity.WebPage, error) {
+	pages := []entity.WebPage{}
+	for _, pageID := range pageIDs {
+		if page, ok := r.pages[pageID]; ok && sharedOrInScope(scope, page.OrganizationID) {
+			pages = append(pages, page)
+		}
+	}
+	return &pages, nil
+}
+
+func (r *productVersions) InsertProductClone(clone *entity.ProductClone) error {
+	r.clones = append(r.clones, *clone)
+	return nil
+}
+
+func TestProductDraftWorkflow(t *testing.T) {
+	repo := newMemoryRepository()
+	s := NewService(repo)
+	orgID := primitive.NewObjectID()
+	scope := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}}
+
+	product, _, err := s.CreateProduct(&entity.Product{Type: "coffee", ProductName: "Arabica", OrganizationID: orgID, TotalItem: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	productID := product.ID.Hex()
+	if versions, _, _ := s.GetProductVersions(scope, &productID); product.PublishedVersion != 1 || len(*versions) != 1 {
+		t.Fatalf("created at version %d with %d versions", product.PublishedVersion, len(*versions))
+	}
+
+	draft, _, err := s.SaveProductDraft(scope, &entity.Product{Type: "coffee", ProductName: "Arabica Reserve", Attribute: map[string]any{"farm_name": "Cau Dat"}}, &productID, "editor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live := repo.products[product.ID]; live.ProductName != "Arabica" {
+		t.Errorf("saving a draft changed the live product: %q", live.ProductName)
+	}
+	if draft.BaseVersion != 1 || draft.Content.TotalItem != 3 || draft.Content.OrganizationID != orgID {
+		t.Errorf("draft over version %d with %d items in %s", draft.BaseVersion, draft.Content.TotalItem, draft.Content.OrganizationID.Hex())
+	}
+
+	diff, _, err := s.DiffProductVersions(scope, &productID, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Changes) != 2 || diff.Changes[0].Path != "attribute" || diff.Changes[1].Path != "product_name" || diff.Changes[1].After != "Arabica Reserve" {
+		t.Errorf("published to draft: %+v", diff.Changes)
+	}
+
+	published, _, err := s.PublishProductDraft(scope, draft, "publisher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live := repo.products[product.ID]; published.Version != 2 || live.PublishedVersion != 2 || live.ProductName != "Arabica Reserve" {
+		t.Errorf("published version %d, live at %d named %q", published.Version, live.PublishedVersion, live.ProductName)
+	}
+	if _, code, _ := s.GetProductDraft(scope, &productID); code != http.StatusNotFound {
+		t.Errorf("draft after publishing: got %d", code)
+	}
+
+	// A draft saved before the rollback is over an outdated version
+	stale, _, _ := s.SaveProductDraft(scope, &entity.Product{Type: "coffee", ProductName: "Arabica Limited"}, &productID, "editor")
+	restored, _, err := s.RollbackProduct(scope, &productID, 1, "publisher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live := repo.products[product.ID]; restored.Version != 3 || restored.RestoredFrom != 1 || live.ProductName != "Arabica" {
+		t.Errorf("rolled back to version %d from %d, live named %q", restored.Version, restored.RestoredFrom, live.ProductName)
+	}
+	if _, code, _ := s.PublishProductDraft(scope, stale, "publisher"); code != http.StatusConflict {
+		t.Errorf("publish an outdated draft: got %d", code)
+	}
+	if _, code, _ := s.RollbackProduct(scope, &productID, 3, "publisher"); code != http.StatusBadRequest {
+		t.Errorf("roll back to the live version: got %d", code)
+	}
+
+	from, to := 1, 3
+	if diff, _, _ := s.DiffProductVersions(scope, &productID, &from, &to); len(diff.Changes) != 0 {
+		t.Errorf("a rollback differs from the version it restored: %+v", diff.Changes)
+	}
+}
+
+func TestProductVersionsOutOfScope(t *testing.T) {
+	repo := newMemoryRepository()
+	s := NewService(repo)
+	orgID := primitive.NewObjectID()
+	product, _, _ := s.CreateProduct(&entity.Product{Type: "coffee", ProductName: "Arabica", OrganizationID: orgID})
+	productID := product.ID.Hex()
+	other := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{primitive.NewObjectID()}}
+
+	checks := map[string]func() (int, error){
+		"save draft": func() (int, error) {
+			_, code, err := s.SaveProductDraft(other, &entity.Product{ProductName: "hijacked"}, &productID, "intruder")
+			return code, err
+		},
+		"versions": func() (int, error) {
+			_, code, err := s.GetProductVersions(other, &productID)
+			return code, err
+		},
+		"rollback": func() (int, error) {
+			_, code, err := s.RollbackProduct(other, &productID, 1, "intruder")
+			return code, err
+		},
+	}
+	for name, check := range checks {
+		if code, err := check(); code != http.StatusNotFound || err == nil {
+			t.Errorf("%s: got %d %v", name, code, err)
+		}
+	}
+
+	// Moving the product to an organization out of scope is refused too
+	scope := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}}
+	if _, code, _ := s.SaveProductDraft(scope, &entity.Product{ProductName: "moved", OrganizationID: primitive.NewObjectID()}, &productID, "editor"); code != http.StatusNotFound {
+		t.Errorf("move out of scope: got %d", code)
+	}
+}
+
+func TestPublishProductDraftFailure(t *testing.T) {
+	repo := newMemoryRepository()
+	s := NewService(repo)
+	orgID := primitive.NewObjectID()
+	scope := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}}
+	product, _, _ := s.CreateProduct(&entity.Product{Type: "coffee", ProductName: "Arabica", OrganizationID: orgID})
+	productID := product.ID.Hex()
+	draft, _, _ := s.SaveProductDraft(scope, &entity.Product{Type: "coffee", ProductName: "Arabica Reserve"}, &productID, "editor")
+
+	repo.publishErr = errors.New("connection reset")
+	if _, code, _ := s.PublishProductDraft(scope, draft, "publisher"); code != http.StatusInternalServerError {
+		t.Fatalf("failed publication: got %d", code)
+	}
+	if _, ok := repo.versions[product.ID][2]; ok {
+		t.Error("a failed publication recorded version 2")
+	}
+
+	// The version of the failed publication is still free
+	repo.publishErr = nil
+	published, _, err := s.PublishProductDraft(scope, draft, "publisher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live := repo.products[product.ID]; published.Version != 2 || live.ProductName != "Arabica Reserve" {
+		t.Errorf("published version %d, live named %q", published.Version, live.ProductName)
+	}
+}
+
+// searchRepository records the search the service asks for
+type searchRepository struct {
+	Repository
+	filter *entity.ProductFilter
+	paging *pagination.Pagination
+	err    error
+}
+
+func (r *searchRepository) SearchProducts(scope *entity.AccessScope, filter *entity.ProductFilter, paging *pagination.Pagination) (*entity.ProductPage, error) {
+	r.filter, r.paging = filter, paging
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &entity.ProductPage{Products: []entity.Product{}}, nil
+}
+
+func TestSearchProducts(t *testing.T) {
+	repo := &searchRepository{}
+	s := NewService(repo)
+	scope := &entity.AccessScope{AllOrganizations: true}
+
+	if _, _, err := s.SearchProducts(scope, &entity.ProductFilter{Query: "arabica"}, &pagination.Pagination{}); err != nil {
+		t.Fatal(err)
+	}
+	if repo.paging.OrderBy != common.ProductOrderByRelevance || repo.paging.Limit != pagination.PageSizeLimit || repo.filter.Status != common.StatusActive {
+		t.Errorf("query searched by %q, %d per page, status %q", repo.paging.OrderBy, repo.paging.Limit, repo.filter.Status)
+	}
+	if _, _, err := s.SearchProducts(scope, &entity.ProductFilter{Status: common.StatusInactive}, &pagination.Pagination{}); err != nil {
+		t.Fatal(err)
+	}
+	if repo.paging.OrderBy != pagination.DefaultOrderBy || repo.filter.Status != common.StatusInactive {
+		t.Errorf("no query searched by %q, status %q", repo.paging.OrderBy, repo.filter.Status)
+	}
+
+	if _, code, _ := s.SearchProducts(scope, &entity.ProductFilter{}, &pagination.Pagination{OrderBy: common.ProductOrderByRelevance}); code != http.StatusBadRequest {
+		t.Errorf("relevance without query: got %d", code)
+	}
+	repo.err = pagination.ErrorInvalidLenCursor
+	if _, code, _ := s.SearchProducts(scope, &entity.ProductFilter{}, &pagination.Pagination{Cursor: "stale"}); code != http.StatusBadRequest {
+		t.Errorf("invalid cursor: got %d", code)
+	}
+}
+
+func TestDeepCloneProduct(t *testing.T) {
+	repo := newMemoryRepository()
+	s := NewService(repo)
+	orgID, otherOrgID := primitive.NewObjectID(), primitive.NewObjectID()
+	scope := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID, otherOrgID}}
+
+	story := entity.WebPage{WebPageBase: entity.WebPageBase{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, OrganizationID: orgID, Name: "story"}}
+	gallery := entity.WebPage{WebPageBase: entity.WebPageBase{BaseModel: entity.BaseModel{ID: primitive.NewObjectID()}, Name: "gallery"}}
+	repo.pages[story.ID], repo.pages[gallery.ID] = story, gallery
+	template := entity.Template{
+		BaseModel:      entity.BaseModel{ID: primitive.NewObjectID()},
+		OrganizationID: orgID,
+		Name:           "coffee",
+		Pages:          []entity.TemplatePages{{PageID: story.ID}, {PageID: gallery.ID}},
+		Menu:           []entity.TemplateMenu{{PageID: story.ID}, {}},
+	}
+	repo.templates[template.ID] = template
+	source, _, _ := s.CreateProduct(&entity.Product{Type: "coffee", ProductName: "Arabica", OrganizationID: orgID, TemplateID: template.ID, TotalItem: 5})
+	productID := source.ID.Hex()
+
+	result, _, err := s.DeepCloneProduct(scope, &productID, &entity.ProductCloneOptions{OrganizationID: otherOrgID, CloneTemplate: true, TotalItem: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone := repo.clones[0]
+	if len(result.IDMap) != 4 || result.IDMap[productID] != clone.Product.ID.Hex() || result.IDMap[template.ID.Hex()] != clone.Template.ID.Hex() {
+		t.Errorf("id map %v", result.IDMap)
+	}
+	if clone.Product.TemplateID != clone.Template.ID || clone.Product.OrganizationID != otherOrgID || clone.Product.TotalItem != 2 || len(clone.Items) != 2 {
+		t.Errorf("cloned product %+v with %d items", clone.Product, len(clone.Items))
+	}
+	newStory, newGallery := result.IDMap[story.ID.Hex()], result.IDMap[gallery.ID.Hex()]
+	if clone.Template.Pages[0].PageID.Hex() != newStory || clone.Template.Pages[1].PageID.Hex() != newGallery || clone.Template.Menu[0].PageID.Hex() != newStory || !clone.Template.Menu[1].PageID.IsZero() {
+		t.Errorf("template references %+v %+v", clone.Template.Pages, clone.Template.Menu)
+	}
+	for _, page := range clone.WebPages {
+		if page.OrganizationID != otherOrgID {
+			t.Errorf("page %s cloned in %s", page.Name, page.OrganizationID.Hex())
+		}
+	}
+	if repo.templates[template.ID].Pages[0].PageID != story.ID {
+		t.Error("cloning changed the references of the original template")
+	}
+
+	// Keeping a template of another organization is refused
+	if _, code, _ := s.DeepCloneProduct(scope, &productID, &entity.ProductCloneOptions{OrganizationID: otherOrgID}); code != http.StatusBadRequest {
+		t.Errorf("keep the template in another organization: got %d", code)
+	}
+	delete(repo.pages, gallery.ID)
+	if _, code, _ := s.DeepCloneProduct(scope, &productID, &entity.ProductCloneOptions{CloneTemplate: true}); code != http.StatusNotFound {
+		t.Errorf("clone a template missing a page: got %d", code)
+	}
+	outOfScope := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}}
+	if _, code, _ := s.DeepCloneProduct(outOfScope, &productID, &entity.ProductCloneOptions{OrganizationID: otherOrgID}); code != http.StatusNotFound {
+		t.Errorf("clone into an organization out of scope: got %d", code)
+	}
+}
@@ -0,0 +1,289 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+)
+
+// SaveProductDraft replaces the draft of the product with the content of the request, the live product is unchanged.
+// The draft is written over the current published version, a draft written over an older one cannot be published.
+func (s *Service) SaveProductDraft(scope *entity.AccessScope, request *entity.Product, productID *string, userID string) (*entity.ProductVersion, int, error) {
+	product, code, err := s.versionedProduct(scope, productID)
+	if err != nil {
+		return nil, code, err
+	}
+	// A product can only be moved to another organization the admin manages
+	if request.OrganizationID.IsZero() {
+		request.OrganizationID = product.OrganizationID
+	}
+	if !scope.CanAccess(request.OrganizationID) {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorOrgNotFound)
+	}
+
+	existing, err := s.repo.GetProductVersion(product.ID, entity.DraftVersion)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	draft := &entity.ProductVersion{
+		ProductID:      product.ID,
+		OrganizationID: request.OrganizationID,
+		Version:        entity.DraftVersion,
+		BaseVersion:    product.PublishedVersion,
+		Content:        *withContent(product, *request, product.PublishedVersion),
+		EditedBy:       userID,
+	}
+	if existing != nil {
+		draft.CreatedAt = existing.CreatedAt
+	}
+	draft.SetTime()
+	draft.SetStatus(common.ProductVersionDraft)
+	draft, err = s.repo.SaveProductDraft(draft)
+	if err != nil {
+		logger.LogError("Got error while saving product draft: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return draft, http.StatusOK, nil
+}
+
+// GetProductDraft the draft of the product in scope
+func (s *Service) GetProductDraft(scope *entity.AccessScope, productID *string) (*entity.ProductVersion, int, error) {
+	return s.GetProductVersion(scope, productID, entity.DraftVersion)
+}
+
+// DiscardProductDraft deletes the draft of the product, the live product is unchanged
+func (s *Service) DiscardProductDraft(scope *entity.AccessScope, productID *string) (bool, int, error) {
+	product, code, err := s.GetProductInScope(scope, productID)
+	if err != nil {
+		return false, code, err
+	}
+	ok, err := s.repo.DeleteProductDraft(product.ID)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+	if !ok {
+		return false, http.StatusNotFound, errors.New(common.MessageErrorProductDraftNotFound)
+	}
+
+	return true, http.StatusOK, nil
+}
+
+// PublishProductDraft makes the content of the draft live as the next version and deletes the draft.
+// The attribute of the draft is expected to be validated against its product type by the caller.
+func (s *Service) PublishProductDraft(scope *entity.AccessScope, draft *entity.ProductVersion, userID string) (*entity.ProductVersion, int, error) {
+	productID := draft.ProductID.Hex()
+	product, code, err := s.versionedProduct(scope, &productID)
+	if err != nil {
+		return nil, code, err
+	}
+	if draft.BaseVersion != product.PublishedVersion {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorProductDraftOutdated)
+	}
+	if !scope.CanAccess(draft.Content.OrganizationID) {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorOrgNotFound)
+	}
+
+	version := &entity.ProductVersion{
+		BaseModel:   entity.BaseModel{CreatedAt: draft.CreatedAt},
+		ProductID:   product.ID,
+		BaseVersion: draft.BaseVersion,
+		Content:     draft.Content,
+		EditedBy:    draft.EditedBy,
+	}
+	version, code, err = s.publish(product, version, userID)
+	if err != nil {
+		return nil, code, err
+	}
+	if _, err = s.repo.DeleteProductDraft(product.ID); err != nil {
+		logger.LogError("Got error while deleting published draft of product " + productID + ": " + err.Error())
+	}
+
+	return version, http.StatusOK, nil
+}
+
+// GetProductVersions the published versions of the product in scope, newest first
+func (s *Service) GetProductVersions(scope *entity.AccessScope, productID *string) (*[]entity.ProductVersion, int, error) {
+	product, code, err := s.GetProductInScope(scope, productID)
+	if err != nil {
+		return nil, code, err
+	}
+	versions, err := s.repo.GetProductVersions(product.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return versions, http.StatusOK, nil
+}
+
+// GetProductVersion a published version of the product in scope, or its draft for entity.DraftVersion
+func (s *Service) GetProductVersion(scope *entity.AccessScope, productID *string, version int) (*entity.ProductVersion, int, error) {
+	product, code, err := s.GetProductInScope(scope, productID)
+	if err != nil {
+		return nil, code, err
+	}
+
+	return s.getVersion(product.ID, version)
+}
+
+// DiffProductVersions the changes of the content of the product from a version to another.
+// From defaults to the published version and to to the draft.
+func (s *Service) DiffProductVersions(scope *entity.AccessScope, productID *string, from, to *int) (*entity.ProductDiff, int, error) {
+	product, code, err := s.versionedProduct(scope, productID)
+	if err != nil {
+		return nil, code, err
+	}
+	fromVersion, toVersion := product.PublishedVersion, entity.DraftVersion
+	if from != nil {
+		fromVersion = *from
+	}
+	if to != nil {
+		toVersion = *to
+	}
+
+	before, code, err := s.getVersion(product.ID, fromVersion)
+	if err != nil {
+		return nil, code, err
+	}
+	after, code, err := s.getVersion(product.ID, toVersion)
+	if err != nil {
+		return nil, code, err
+	}
+	changes, err := diffContent(&before.Content, &after.Content)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &entity.ProductDiff{ProductID: product.ID, From: fromVersion, To: toVersion, Changes: changes}, http.StatusOK, nil
+}
+
+// RollbackProduct publishes the content of a previous version again, as the next version.
+// History is never rewritten, and the draft is kept but has to be saved again over the new version to be published.
+func (s *Service) RollbackProduct(scope *entity.AccessScope, productID *string, version int, userID string) (*entity.ProductVersion, int, error) {
+	product, code, err := s.versionedProduct(scope, productID)
+	if err != nil {
+		return nil, code, err
+	}
+	if version == entity.DraftVersion {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorProductVersionNotFound)
+	}
+	if version == product.PublishedVersion {
+		return nil, http.StatusBadRequest, errors.New(common.MessageErrorProductVersionIsLive)
+	}
+	target, code, err := s.getVersion(product.ID, version)
+	if err != nil {
+		return nil, code, err
+	}
+	if !scope.CanAccess(target.Content.OrganizationID) {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorOrgNotFound)
+	}
+
+	restored := &entity.ProductVersion{
+		ProductID:    product.ID,
+		BaseVersion:  product.PublishedVersion,
+		RestoredFrom: version,
+		Content:      target.Content,
+		EditedBy:     userID,
+	}
+
+	return s.publish(product, restored, userID)
+}
+
+// versionedProduct the product in scope. Products created before versioning have their live content recorded as version 1 first.
+func (s *Service) versionedProduct(scope *entity.AccessScope, productID *string) (*entity.Product, int, error) {
+	product, code, err := s.GetProductInScope(scope, productID)
+	if err != nil {
+		return nil, code, err
+	}
+	if product.PublishedVersion != 0 {
+		return product, http.StatusOK, nil
+	}
+
+	// Another request recording version 1 first is fine
+	product.PublishedVersion = 1
+	if _, err := s.repo.PublishProductVersion(product, 0, initialVersion(product)); err != nil && !mongo.IsDuplicateKeyError(err) {
+		logger.LogError("Got error while recording the first version of product " + product.ID.Hex() + ": " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return product, http.StatusOK, nil
+}
+
+// initialVersion the content of the product as version 1
+func initialVersion(product *entity.Product) *entity.ProductVersion {
+	version := &entity.ProductVersion{
+		BaseModel:      entity.BaseModel{CreatedAt: product.CreatedAt},
+		ProductID:      product.ID,
+		OrganizationID: product.OrganizationID,
+		Version:        1,
+		Content:        *product,
+		PublishedAt:    &product.UpdatedAt,
+	}
+	version.SetTime()
+	version.SetStatus(common.ProductVersionPublished)
+
+	return version
+}
+
+// publish records the content of the version as the version after the live one and makes it live, both or neither.
+// The unique index on product_id and version makes concurrent publications fail.
+func (s *Service) publish(product *entity.Product, version *entity.ProductVersion, userID string) (*entity.ProductVersion, int, error) {
+	previous := product.PublishedVersion
+	live := withContent(product, version.Content, previous+1)
+
+	now := time.Now()
+	version.ID = primitive.NilObjectID
+	version.OrganizationID = live.OrganizationID
+	version.Version = live.PublishedVersion
+	version.Content = *live
+	version.PublishedBy = userID
+	version.PublishedAt = &now
+	version.SetTime()
+	version.SetStatus(common.ProductVersionPublished)
+	ok, err := s.repo.PublishProductVersion(live, previous, version)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, http.StatusConflict, errors.New(common.MessageErrorProductPublishedMeanwhile)
+		}
+		logger.LogError("Got error while publishing product: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	if !ok {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorProductPublishedMeanwhile)
+	}
+
+	return version, http.StatusOK, nil
+}
+
+func (s *Service) getVersion(productID primitive.ObjectID, version int) (*entity.ProductVersion, int, error) {
+	productVersion, err := s.repo.GetProductVersion(productID, version)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if productVersion == nil {
+		if version == entity.DraftVersion {
+			return nil, http.StatusNotFound, errors.New(common.MessageErrorProductDraftNotFound)
+		}
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorProductVersionNotFound)
+	}
+
+	return productVersion, http.StatusOK, nil
+}
+
+// withContent the live product showing the content. Its identity, status and the counters maintained by the service are kept.
+func withContent(live *entity.Product, content entity.Product, version int) *entity.Product {
+	content.BaseModel = live.BaseModel
+	content.TotalItem = live.TotalItem
+	content.RatingScore = live.RatingScore
+	content.PublishedVersion = version
+	content.SetTime()
+
+	return &content
+}
@@ -15,11 +15,8 @@ type ProductItem interface {
 	GetAllProductItemByProductID(productID *primitive.ObjectID) (*[]entity.ProductItem, error)
 	GetAllProductItem() (*[]entity.ProductItem, error)
 	GetDetailProductItemByID(*string) (*entity.ProductItem, error)
-	IsAbleToClaim(productItemID *string) (bool, error)
-	SetOwner(productItemID, ownerID *string) (bool, error)
-	ToggleClaimable(productItemID *string) (bool, error)
 	GetDetailWithTagID(tagID *string) (*entity.ProductItem, error)
-	UpdateTotalLike(productItemID *string) (bool, error)
+	LikeProductItem(productItemID primitive.ObjectID, userID string) (bool, error)
 	GetOrganizationNameByProductItemID(productItemID *string) (string, error)
 	CountNumProductItems(*string) (int, error)
 	GetProductItemsInOrg(*string) (*[]entity.ProductItem, error)
@@ -38,10 +35,8 @@ type UseCase interface {
 	GetAllProductItem() (*[]entity.ProductItem, int, error)
 	GetAllProductItemInProduct(productID *string) (*[]entity.ProductItem, int, error)
 	GetDetailProductItem(*string) (*entity.ProductItem, int, error)
-	SetOnwerForItem(*request.SetOwnerRequest) (bool, int, error)
-	ToggleClaimable(*request.ProductItemInteractionRequest) (bool, int, error)
 	CheckProductItemMapped(productItemID *string) (bool, int, error)
-	UpdateTotalLike(productItemID *string) (bool, int, error)
+	LikeProductItem(*request.ProductItemLikeRequest) (bool, int, error)
 	CreateMultipleProductItems(*string, int, int) (bool, int, error)
 	CountNumProductItems(*string) (int, int, error)
 	GetProductItemsInOrg(*string) (*[]entity.ProductItem, int, error)
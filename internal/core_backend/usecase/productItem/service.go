@@ -86,27 +86,6 @@ func (s *Service) GetDetailProductItem(itemID *string) (*entity.ProductItem, int
 	return item, http.StatusOK, nil
 }
 
-func (s *Service) SetOnwerForItem(request *request.SetOwnerRequest) (bool, int, error) {
-	able, err := s.repo.IsAbleToClaim(&request.ProductItemID)
-
-	if err != nil {
-		logger.LogError("got error when setting onwer for item: " + err.Error())
-		return false, http.StatusInternalServerError, err
-	}
-	if !able {
-		logger.LogInfo(common.MessageErrorNotAbleToClaim)
-		return false, http.StatusBadRequest, errors.New(common.MessageErrorNotAbleToClaim)
-	}
-
-	success, err := s.repo.SetOwner(&request.ProductItemID, &request.OwnerID)
-	if err != nil {
-		logger.LogError("got error when setting onwer for item: " + err.Error())
-		return false, http.StatusInternalServerError, err
-	}
-
-	return success, http.StatusOK, nil
-}
-
 func (s *Service) GetDetailWithTagID(tagID *string) (*entity.ProductItem, int, error) {
 	item, err := s.repo.GetDetailWithTagID(tagID)
 	if err != nil {
@@ -124,23 +103,22 @@ func (s *Service) CheckProductItemMapped(productItemID *string) (bool, int, erro
 	return isMapped, http.StatusOK, err
 }
 
-func (s *Service) UpdateTotalLike(productItemID *string) (bool, int, error) {
-	ok, err := s.repo.UpdateTotalLike(productItemID)
+// LikeProductItem counts the like of the user, returns false when the user already liked the item
+func (s *Service) LikeProductItem(req *request.ProductItemLikeRequest) (bool, int, error) {
+	item, err := s.repo.GetDetailProductItemByID(&req.ProductItemID)
 	if err != nil {
-		return ok, http.StatusInternalServerError, err
+		return false, http.StatusBadRequest, err
 	}
-
-	return ok, http.StatusOK, nil
-}
-
-func (s *Service) ToggleClaimable(req *request.ProductItemInteractionRequest) (bool, int, error) {
-	success, err := s.repo.ToggleClaimable(&req.ProductItemID)
+	if item == nil {
+		return false, http.StatusNotFound, errors.New(common.MessageErrorProductItemNotFound)
+	}
+	ok, err := s.repo.LikeProductItem(item.ID, req.UserID)
 	if err != nil {
-		logger.LogError("Got error when setting claimable for item: " + err.Error())
+		logger.LogError("Got error when liking product item: " + err.Error())
 		return false, http.StatusInternalServerError, err
 	}
 
-	return success, http.StatusOK, nil
+	return ok, http.StatusOK, nil
 }
 
 func (s *Service) CreateMultipleProductItems(productID *string, totalItems int, startIndex int) (bool, int, error) {
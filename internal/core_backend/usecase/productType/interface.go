@@ -0,0 +1,35 @@
+package productType
+
+import (
+	"backend-service/internal/core_backend/entity"
+)
+
+// ProductType interface
+type ProductType interface {
+	// Interface for repository
+	CreateProductType(*entity.ProductType) (*entity.ProductType, error)
+	GetProductType(name *string) (*entity.ProductType, error)
+	GetProductTypes() (*[]entity.ProductType, error)
+	UpdateProductType(productType *entity.ProductType, previousVersion int) (bool, error)
+	DeleteProductType(name *string) (bool, error)
+	CreateProductTypeSchema(*entity.ProductTypeSchema) (*entity.ProductTypeSchema, error)
+	GetProductTypeSchemas(name *string) (*[]entity.ProductTypeSchema, error)
+	CountProductsOfType(name *string) (int64, error)
+}
+
+// Repository interface
+type Repository interface {
+	ProductType
+}
+
+// UseCase interface
+type UseCase interface {
+	// Interface for usecase - service
+	GetProductTypes() (*[]entity.ProductType, int, error)
+	GetProductType(name *string) (*entity.ProductType, int, error)
+	GetProductTypeSchemas(name *string) (*[]entity.ProductTypeSchema, int, error)
+	CreateProductType(scope *entity.AccessScope, productType *entity.ProductType) (*entity.ProductType, int, error)
+	UpdateProductType(scope *entity.AccessScope, name *string, update *entity.ProductType) (*entity.ProductType, int, error)
+	DeleteProductType(scope *entity.AccessScope, name *string) (bool, int, error)
+	ValidateProduct(*entity.Product) (int, error)
+}
@@ -0,0 +1,235 @@
+package productType
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/jsonschema"
+)
+
+// namePattern product type names are stored as the type of products and used in URLs
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+// Service struct
+type Service struct {
+	repo Repository
+}
+
+// NewService create service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// GetProductTypes every product type
+func (s *Service) GetProductTypes() (*[]entity.ProductType, int, error) {
+	productTypes, err := s.repo.GetProductTypes()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return productTypes, http.StatusOK, nil
+}
+
+// GetProductType the product type of the name
+func (s *Service) GetProductType(name *string) (*entity.ProductType, int, error) {
+	productType, err := s.repo.GetProductType(name)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if productType == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorProductTypeNotFound)
+	}
+
+	return productType, http.StatusOK, nil
+}
+
+// GetProductTypeSchemas every version of the schema of the product type, newest first
+func (s *Service) GetProductTypeSchemas(name *string) (*[]entity.ProductTypeSchema, int, error) {
+	if _, code, err := s.GetProductType(name); err != nil {
+		return nil, code, err
+	}
+	schemas, err := s.repo.GetProductTypeSchemas(name)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return schemas, http.StatusOK, nil
+}
+
+// CreateProductType creates the product type at the first version of its schema.
+// Product types are shared by every organization, only admins of all organizations manage them.
+func (s *Service) CreateProductType(scope *entity.AccessScope, productType *entity.ProductType) (*entity.ProductType, int, error) {
+	if !scope.AllOrganizations {
+		return nil, http.StatusForbidden, errors.New(common.MessageErrorSharedResource)
+	}
+	if !namePattern.MatchString(productType.Name) {
+		return nil, http.StatusBadRequest, errors.New(common.MessageErrorInvalidProductTypeName)
+	}
+	schema, err := compileSchema(productType.Schema)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	existing, err := s.repo.GetProductType(&productType.Name)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if existing != nil {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorProductTypeTaken)
+	}
+
+	productType.BaseModel = entity.BaseModel{}
+	productType.SetTime()
+	productType.SetStatus(common.StatusActive)
+	productType.Version = 1
+	productType.Schema = schema
+	productType, err = s.repo.CreateProductType(productType)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, http.StatusConflict, errors.New(common.MessageErrorProductTypeTaken)
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	if code, err := s.createSchemaVersion(productType); err != nil {
+		return nil, code, err
+	}
+
+	return productType, http.StatusOK, nil
+}
+
+// UpdateProductType changes the display name and the schema of the product type.
+// A new schema is a new version, the products validated against the previous ones keep their version until they are updated.
+func (s *Service) UpdateProductType(scope *entity.AccessScope, name *string, update *entity.ProductType) (*entity.ProductType, int, error) {
+	if !scope.AllOrganizations {
+		return nil, http.StatusForbidden, errors.New(common.MessageErrorSharedResource)
+	}
+	productType, code, err := s.GetProductType(name)
+	if err != nil {
+		return nil, code, err
+	}
+
+	previousVersion := productType.Version
+	if update.DisplayName != "" {
+		productType.DisplayName = update.DisplayName
+	}
+	if len(update.Schema) != 0 {
+		schema, err := compileSchema(update.Schema)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if !bytes.Equal(schema, productType.Schema) {
+			productType.Schema = schema
+			productType.Version++
+		}
+	}
+	productType.SetTime()
+
+	ok, err := s.repo.UpdateProductType(productType, previousVersion)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !ok {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorProductTypeChanged)
+	}
+	if productType.Version != previousVersion {
+		if code, err := s.createSchemaVersion(productType); err != nil {
+			return nil, code, err
+		}
+	}
+
+	return productType, http.StatusOK, nil
+}
+
+// DeleteProductType deletes the product type and its schema versions, unless products of the type exist
+func (s *Service) DeleteProductType(scope *entity.AccessScope, name *string) (bool, int, error) {
+	if !scope.AllOrganizations {
+		return false, http.StatusForbidden, errors.New(common.MessageErrorSharedResource)
+	}
+	if _, code, err := s.GetProductType(name); err != nil {
+		return false, code, err
+	}
+	count, err := s.repo.CountProductsOfType(name)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+	if count != 0 {
+		return false, http.StatusConflict, errors.New(common.MessageErrorProductTypeInUse)
+	}
+
+	ok, err := s.repo.DeleteProductType(name)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+
+	return ok, http.StatusOK, nil
+}
+
+// ValidateProduct checks the attribute of the product against the schema of its type
+// and records the version of the schema on the product. A missing attribute is validated as an empty object.
+func (s *Service) ValidateProduct(product *entity.Product) (int, error) {
+	productType, err := s.repo.GetProductType(&product.Type)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if productType == nil {
+		return http.StatusBadRequest, errors.New(common.MessageErrorUnknownProductType + ": " + product.Type)
+	}
+	schema, err := jsonschema.Compile(productType.Schema)
+	if err != nil {
+		logger.LogError("Stored schema of product type " + productType.Name + " does not compile: " + err.Error())
+		return http.StatusInternalServerError, err
+	}
+
+	if product.Attribute == nil {
+		product.Attribute = map[string]any{}
+	}
+	if err = schema.Validate(product.Attribute); err != nil {
+		var verr *jsonschema.ValidationError
+		if errors.As(err, &verr) {
+			return http.StatusBadRequest, errors.New(common.MessageErrorInvalidAttribute + ": " + verr.Error())
+		}
+		return http.StatusBadRequest, err
+	}
+	product.TypeVersion = productType.Version
+
+	return http.StatusOK, nil
+}
+
+func (s *Service) createSchemaVersion(productType *entity.ProductType) (int, error) {
+	version := &entity.ProductTypeSchema{
+		TypeName: productType.Name,
+		Version:  productType.Version,
+		Schema:   productType.Schema,
+	}
+	version.SetTime()
+	if _, err := s.repo.CreateProductTypeSchema(version); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// compileSchema checks the schema and returns it in a canonical form, so unchanged schemas compare equal
+func compileSchema(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return nil, errors.New(common.MessageErrorInvalidSchema + ": schema is required")
+	}
+	if _, err := jsonschema.Compile(raw); err != nil {
+		return nil, errors.New(common.MessageErrorInvalidSchema + ": " + err.Error())
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
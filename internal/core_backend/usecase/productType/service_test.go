@@ -0,0 +1,162 @@
+package productType
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// typeSchemas the product types with their schema history, products counts the products of each type
+type typeSchemas struct {
+	Repository
+	types    map[string]*entity.ProductType
+	schemas  []entity.ProductTypeSchema
+	products map[string]int64
+}
+
+func newMemoryRepository() *typeSchemas {
+	return &typeSchemas{types: map[string]*entity.ProductType{}, products: map[string]int64{}}
+}
+
+func (r *typeSchemas) CreateProductType(productType *entity.ProductType) (*entity.ProductType, error) {
+	productType.ID = primitive.NewObjectID()
+	stored := *productType
+	r.types[productType.Name] = &stored
+	return productType, nil
+}
+
+func (r *typeSchemas) GetProductType(name *string) (*entity.ProductType, error) {
+	productType, ok := r.types[*name]
+	if !ok {
+		return nil, nil
+	}
+	found := *productType
+	return &found, nil
+}
+
+func (r *typeSchemas) UpdateProductType(productType *entity.ProductType, previousVersion int) (bool, error) {
+	stored, ok := r.types[productType.Name]
+	if !ok || stored.Version != previousVersion {
+		return false, nil
+	}
+	*stored = *productType
+	return true, nil
+}
+
+func (r *typeSchemas) DeleteProductType(name *string) (bool, error) {
+	_, ok := r.types[*name]
+	delete(r.types, *name)
+	return ok, nil
+}
+
+func (r *typeSchemas) CreateProductTypeSchema(schema *entity.ProductTypeSchema) (*entity.ProductTypeSchema, error) {
+	r.schemas = append(r.schemas, *schema)
+	return schema, nil
+}
+
+func (r *typeSchemas) GetProductTypeSchemas(name *string) (*[]entity.ProductTypeSchema, error) {
+	schemas := []entity.ProductTypeSchema{}
+	for _, schema := range r.schemas {
+		if schema.TypeName == *name {
+			schemas = append(schemas, schema)
+		}
+	}
+	return &schemas, nil
+}
+
+func (r *typeSchemas) CountProductsOfType(name *string) (int64, error) {
+	return r.products[*name], nil
+}
+
+var global = &entity.AccessScope{AllOrganizations: true}
+
+const wineSchema = `{
+	"type": "object",
+	"required": ["vintage"],
+	"properties": {"vintage": {"type": "integer", "minimum": 1900}, "grape": {"type": "string"}}
+}`
+
+func TestProductTypeVersions(t *testing.T) {
+	s := NewService(newMemoryRepository())
+	name := "wine"
+
+	if _, code, _ := s.CreateProductType(&entity.AccessScope{OrganizationIDs: []primitive.ObjectID{primitive.NewObjectID()}}, &entity.ProductType{Name: name, Schema: json.RawMessage(wineSchema)}); code != http.StatusForbidden {
+		t.Errorf("create by an organization admin: got %d", code)
+	}
+	if _, code, _ := s.CreateProductType(global, &entity.ProductType{Name: "Wine!", Schema: json.RawMessage(wineSchema)}); code != http.StatusBadRequest {
+		t.Errorf("invalid name: got %d", code)
+	}
+	if _, code, _ := s.CreateProductType(global, &entity.ProductType{Name: name, Schema: json.RawMessage(`{"type": "date"}`)}); code != http.StatusBadRequest {
+		t.Errorf("invalid schema: got %d", code)
+	}
+
+	productType, _, err := s.CreateProductType(global, &entity.ProductType{Name: name, DisplayName: "Wine", Schema: json.RawMessage(wineSchema)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if productType.Version != 1 {
+		t.Errorf("created at version %d", productType.Version)
+	}
+	if _, code, _ := s.CreateProductType(global, &entity.ProductType{Name: name, Schema: json.RawMessage(wineSchema)}); code != http.StatusConflict {
+		t.Errorf("taken name: got %d", code)
+	}
+
+	// The same schema written differently is not a new version
+	productType, _, err = s.UpdateProductType(global, &name, &entity.ProductType{DisplayName: "Wines", Schema: json.RawMessage(`{"properties": {"grape": {"type": "string"}, "vintage": {"minimum": 1900, "type": "integer"}}, "required": ["vintage"], "type": "object"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if productType.Version != 1 || productType.DisplayName != "Wines" {
+		t.Errorf("got version %d named %q", productType.Version, productType.DisplayName)
+	}
+
+	productType, _, err = s.UpdateProductType(global, &name, &entity.ProductType{Schema: json.RawMessage(`{"type": "object", "required": ["vintage", "grape"]}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, _, _ := s.GetProductTypeSchemas(&name)
+	if productType.Version != 2 || len(*schemas) != 2 {
+		t.Errorf("got version %d with %d schema versions", productType.Version, len(*schemas))
+	}
+}
+
+func TestValidateProduct(t *testing.T) {
+	repo := newMemoryRepository()
+	s := NewService(repo)
+	if _, _, err := s.CreateProductType(global, &entity.ProductType{Name: "wine", Schema: json.RawMessage(wineSchema)}); err != nil {
+		t.Fatal(err)
+	}
+
+	product := &entity.Product{Type: "wine", Attribute: map[string]any{"vintage": 2019, "grape": "Merlot"}}
+	if _, err := s.ValidateProduct(product); err != nil {
+		t.Fatal(err)
+	}
+	if product.TypeVersion != 1 {
+		t.Errorf("got type version %d", product.TypeVersion)
+	}
+
+	for name, product := range map[string]*entity.Product{
+		"unknown type":       {Type: "watch", Attribute: map[string]any{}},
+		"missing attribute":  {Type: "wine"},
+		"invalid attribute":  {Type: "wine", Attribute: map[string]any{"vintage": 1850}},
+		"attribute not JSON": {Type: "wine", Attribute: "2019"},
+	} {
+		if code, _ := s.ValidateProduct(product); code != http.StatusBadRequest {
+			t.Errorf("%s: got %d", name, code)
+		}
+	}
+
+	name := "wine"
+	repo.products[name] = 1
+	if _, code, _ := s.DeleteProductType(global, &name); code != http.StatusConflict {
+		t.Errorf("delete a type in use: got %d", code)
+	}
+	repo.products[name] = 0
+	if ok, _, err := s.DeleteProductType(global, &name); err != nil || !ok {
+		t.Errorf("delete: got %v %v", ok, err)
+	}
+}
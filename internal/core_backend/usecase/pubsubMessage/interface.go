@@ -0,0 +1,26 @@
+package pubsubMessage
+
+import (
+	"google.golang.org/api/pubsub/v1"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// PubsubMessage interface
+type PubsubMessage interface {
+	// Interface for repository
+	CreatePubsubMessage(msg *entity.PubsubMessage) (bool, error)
+	DeletePubsubMessage(messageID *string) (bool, error)
+}
+
+// Repository interface
+type Repository interface {
+	PubsubMessage
+}
+
+// UseCase interface
+type UseCase interface {
+	// Interface for usecase - service
+	ClaimMessage(msg *pubsub.PubsubMessage) (bool, int, error)
+	ReleaseMessage(messageID *string) (bool, int, error)
+}
@@ -0,0 +1,78 @@
+package pubsubMessage
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/pubsub/v1"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+)
+
+// defaultReplayWindow used when PUBSUB_REPLAY_WINDOW_IN_SECOND is not set
+const defaultReplayWindow = 24 * time.Hour
+
+// publishClockSkew tolerated between Pub/Sub and this service for publish times in the future
+const publishClockSkew = time.Minute
+
+// Service struct
+type Service struct {
+	repo   Repository
+	window time.Duration
+	now    func() time.Time
+}
+
+// NewService create service
+func NewService(r Repository) *Service {
+	window := time.Duration(config.C.Pubsub.REPLAY_WINDOW_IN_SECOND) * time.Second
+	if window <= 0 {
+		window = defaultReplayWindow
+	}
+
+	return &Service{
+		repo:   r,
+		window: window,
+		now:    time.Now,
+	}
+}
+
+// ClaimMessage records the message as received and returns false when it was already received.
+// Messages published outside the replay window are refused, their IDs may be forgotten already.
+func (s *Service) ClaimMessage(msg *pubsub.PubsubMessage) (bool, int, error) {
+	if msg == nil || msg.MessageId == "" || msg.PublishTime == "" {
+		return false, http.StatusBadRequest, errors.New(common.MessageErrorPubsubMessageID)
+	}
+	publishTime, err := time.Parse(time.RFC3339Nano, msg.PublishTime)
+	if err != nil {
+		return false, http.StatusBadRequest, errors.New(common.MessageErrorPubsubMessageID)
+	}
+	now := s.now()
+	if publishTime.Before(now.Add(-s.window)) || publishTime.After(now.Add(publishClockSkew)) {
+		return false, http.StatusBadRequest, errors.New(common.MessageErrorPubsubReplayWindow)
+	}
+
+	created, err := s.repo.CreatePubsubMessage(&entity.PubsubMessage{
+		ID:          msg.MessageId,
+		PublishTime: publishTime,
+		ReceivedAt:  now,
+		ExpiresAt:   publishTime.Add(s.window),
+	})
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+
+	return created, http.StatusOK, nil
+}
+
+// ReleaseMessage forgets a message whose processing failed, so its redelivery is processed
+func (s *Service) ReleaseMessage(messageID *string) (bool, int, error) {
+	ok, err := s.repo.DeletePubsubMessage(messageID)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+
+	return ok, http.StatusOK, nil
+}
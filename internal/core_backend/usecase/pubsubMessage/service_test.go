@@ -0,0 +1,81 @@
+package pubsubMessage
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/pubsub/v1"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// receivedMessages the messages already handled, by message ID
+type receivedMessages struct {
+	Repository
+	messages map[string]entity.PubsubMessage
+}
+
+func (r *receivedMessages) CreatePubsubMessage(msg *entity.PubsubMessage) (bool, error) {
+	if _, ok := r.messages[msg.ID]; ok {
+		return false, nil
+	}
+	r.messages[msg.ID] = *msg
+	return true, nil
+}
+
+func (r *receivedMessages) DeletePubsubMessage(messageID *string) (bool, error) {
+	_, ok := r.messages[*messageID]
+	delete(r.messages, *messageID)
+	return ok, nil
+}
+
+func newTestService(now time.Time) *Service {
+	s := NewService(&receivedMessages{messages: map[string]entity.PubsubMessage{}})
+	s.window = time.Hour
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestClaimMessageOnce(t *testing.T) {
+	now := time.Now()
+	s := newTestService(now)
+	msg := &pubsub.PubsubMessage{MessageId: "1", PublishTime: now.Add(-time.Minute).Format(time.RFC3339Nano)}
+
+	first, _, err := s.ClaimMessage(msg)
+	if err != nil || !first {
+		t.Fatalf("first delivery: got %v, %v", first, err)
+	}
+	if again, _, err := s.ClaimMessage(msg); err != nil || again {
+		t.Fatalf("redelivery: got %v, %v", again, err)
+	}
+
+	// A released message is processed when redelivered
+	if _, _, err = s.ReleaseMessage(&msg.MessageId); err != nil {
+		t.Fatal(err)
+	}
+	if retried, _, err := s.ClaimMessage(msg); err != nil || !retried {
+		t.Fatalf("delivery after release: got %v, %v", retried, err)
+	}
+}
+
+func TestClaimMessageRefusesMessagesOutsideTheWindow(t *testing.T) {
+	now := time.Now()
+	s := newTestService(now)
+
+	cases := map[string]*pubsub.PubsubMessage{
+		"no message":     nil,
+		"no message ID":  {PublishTime: now.Format(time.RFC3339Nano)},
+		"no publish":     {MessageId: "1"},
+		"malformed time": {MessageId: "1", PublishTime: "yesterday"},
+		"too old":        {MessageId: "1", PublishTime: now.Add(-2 * time.Hour).Format(time.RFC3339Nano)},
+		"in the future":  {MessageId: "1", PublishTime: now.Add(time.Hour).Format(time.RFC3339Nano)},
+	}
+	for name, msg := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, code, _ := s.ClaimMessage(msg); code != http.StatusBadRequest {
+				t.Errorf("got status %d, want %d", code, http.StatusBadRequest)
+			}
+		})
+	}
+}
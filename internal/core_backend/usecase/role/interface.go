@@ -0,0 +1,38 @@
+package role
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// Role interface
+type Role interface {
+	// Interface for repository
+	GetRoleBindingsByUserID(userID *string) (*[]entity.RoleBinding, error)
+	GetRolesByOrgIDs(orgIDs []primitive.ObjectID) (*[]entity.Role, error)
+	GetRoleByID(roleID *string) (*entity.Role, error)
+	GetRoleByName(orgID primitive.ObjectID, roleName *string) (*entity.Role, error)
+	CreateRole(*entity.Role) (*entity.Role, error)
+	UpdateRolePermissions(*entity.Role) (bool, error)
+	UpsertRoleBinding(*entity.RoleBinding) (*entity.RoleBinding, error)
+	GetRoleBindingByID(bindingID *string) (*entity.RoleBinding, error)
+	DeleteRoleBinding(bindingID primitive.ObjectID) (bool, error)
+}
+
+// Repository interface
+type Repository interface {
+	Role
+}
+
+// UseCase interface
+type UseCase interface {
+	// Interface for usecase - service
+	GetUserGrants(user *entity.User) ([]entity.RoleGrant, int, error)
+	GetRoles(scope *entity.AccessScope) (*[]entity.Role, int, error)
+	CreateRole(grants []entity.RoleGrant, role *entity.Role) (*entity.Role, int, error)
+	UpdateRole(grants []entity.RoleGrant, scope *entity.AccessScope, roleID *string, description string, permissions []entity.Permission) (*entity.Role, int, error)
+	CanAssignRole(grants []entity.RoleGrant, binding *entity.RoleBinding) (int, error)
+	AssignRole(grants []entity.RoleGrant, binding *entity.RoleBinding) (*entity.RoleBinding, int, error)
+	RevokeRole(grants []entity.RoleGrant, bindingID *string) (*entity.RoleBinding, int, error)
+}
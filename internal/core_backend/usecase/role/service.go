@@ -0,0 +1,209 @@
+package role
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+)
+
+// Service struct
+type Service struct {
+	repo Repository
+}
+
+// NewService create service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// GetUserGrants resolves the role bindings of the user. Only stored bindings grant roles, the role claim
+// of the token is a copy of them and stays valid until the token expires, so it is not trusted.
+func (s *Service) GetUserGrants(user *entity.User) ([]entity.RoleGrant, int, error) {
+	bindings, err := s.repo.GetRoleBindingsByUserID(&user.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	var grants []entity.RoleGrant
+	for _, binding := range *bindings {
+		role, err := s.getRole(binding.OrganizationID, binding.RoleName)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		// Bindings to deleted roles grant nothing
+		if role == nil {
+			continue
+		}
+		grants = append(grants, entity.RoleGrant{OrganizationID: binding.OrganizationID, Role: *role})
+	}
+
+	return grants, http.StatusOK, nil
+}
+
+// GetRoles lists the system roles and the custom roles of the organizations in scope
+func (s *Service) GetRoles(scope *entity.AccessScope) (*[]entity.Role, int, error) {
+	roles := []entity.Role{entity.SystemRoles[string(entity.SUPER_ADMIN_ROLE)], entity.SystemRoles[string(entity.ORG_ADMIN_ROLE)]}
+	orgIDs := scope.OrganizationIDs
+	if scope.AllOrganizations {
+		orgIDs = nil
+	} else if orgIDs == nil {
+		orgIDs = []primitive.ObjectID{}
+	}
+	custom, err := s.repo.GetRolesByOrgIDs(orgIDs)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	roles = append(roles, *custom...)
+
+	return &roles, http.StatusOK, nil
+}
+
+// CreateRole creates a custom role. Its permissions must all be held by the creator in the role's organization.
+func (s *Service) CreateRole(grants []entity.RoleGrant, role *entity.Role) (*entity.Role, int, error) {
+	if _, ok := entity.SystemRoles[strings.ToUpper(role.RoleName)]; ok {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorRoleNameTaken)
+	}
+	if code, err := checkGrantable(grants, role.OrganizationID, entity.PermissionRoleWrite, role.Permissions); err != nil {
+		return nil, code, err
+	}
+
+	existing, err := s.repo.GetRoleByName(role.OrganizationID, &role.RoleName)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if existing != nil && existing.OrganizationID == role.OrganizationID {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorRoleNameTaken)
+	}
+
+	role.Status = common.StatusActive
+	role, err = s.repo.CreateRole(role)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return role, http.StatusOK, nil
+}
+
+// UpdateRole replaces the description and permissions of a custom role
+func (s *Service) UpdateRole(grants []entity.RoleGrant, scope *entity.AccessScope, roleID *string, description string, permissions []entity.Permission) (*entity.Role, int, error) {
+	role, err := s.repo.GetRoleByID(roleID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if role == nil || !(scope.AllOrganizations || scope.CanAccess(role.OrganizationID)) {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorRoleNotFound)
+	}
+	if code, err := checkGrantable(grants, role.OrganizationID, entity.PermissionRoleWrite, permissions); err != nil {
+		return nil, code, err
+	}
+
+	role.Description = description
+	role.Permissions = permissions
+	if _, err = s.repo.UpdateRolePermissions(role); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return role, http.StatusOK, nil
+}
+
+// AssignRole grants a role to a user in an organization, or in every organization when none is given.
+// The granter must hold every permission of the role there.
+func (s *Service) AssignRole(grants []entity.RoleGrant, binding *entity.RoleBinding) (*entity.RoleBinding, int, error) {
+	if code, err := s.CanAssignRole(grants, binding); err != nil {
+		return nil, code, err
+	}
+
+	binding, err := s.repo.UpsertRoleBinding(binding)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return binding, http.StatusOK, nil
+}
+
+// CanAssignRole checks the role exists and the granter holds every permission of the role in the organization
+func (s *Service) CanAssignRole(grants []entity.RoleGrant, binding *entity.RoleBinding) (int, error) {
+	role, err := s.getRole(binding.OrganizationID, binding.RoleName)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if role == nil {
+		return http.StatusNotFound, errors.New(common.MessageErrorRoleNotFound)
+	}
+	if code, err := checkGrantable(grants, binding.OrganizationID, entity.PermissionRoleWrite, role.Permissions); err != nil {
+		return code, err
+	}
+	// SUPER_ADMIN is only meaningful for every organization
+	if role.RoleName == string(entity.SUPER_ADMIN_ROLE) && !binding.OrganizationID.IsZero() {
+		return http.StatusBadRequest, errors.New(common.MessageErrorSuperAdminIsGlobal)
+	}
+
+	return http.StatusOK, nil
+}
+
+// RevokeRole deletes a role binding the revoker could have granted and returns it
+func (s *Service) RevokeRole(grants []entity.RoleGrant, bindingID *string) (*entity.RoleBinding, int, error) {
+	binding, err := s.repo.GetRoleBindingByID(bindingID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	// Bindings of organizations the revoker manages no roles in are not disclosed
+	scope := entity.NewAccessScope("", grants, entity.PermissionRoleWrite)
+	if binding == nil || !scope.CanAccess(binding.OrganizationID) {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorRoleBindingNotFound)
+	}
+
+	role, err := s.getRole(binding.OrganizationID, binding.RoleName)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	var permissions []entity.Permission
+	if role != nil {
+		permissions = role.Permissions
+	}
+	if code, err := checkGrantable(grants, binding.OrganizationID, entity.PermissionRoleWrite, permissions); err != nil {
+		return nil, code, err
+	}
+
+	if _, err = s.repo.DeleteRoleBinding(binding.ID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return binding, http.StatusOK, nil
+}
+
+// getRole resolves a role name bound in an organization, system roles first
+func (s *Service) getRole(orgID primitive.ObjectID, roleName string) (*entity.Role, error) {
+	if role, ok := entity.SystemRoles[roleName]; ok {
+		return &role, nil
+	}
+
+	return s.repo.GetRoleByName(orgID, &roleName)
+}
+
+// checkGrantable makes sure the caller holds the management permission and every given permission
+// in the organization, so roles can never be used to escalate privileges
+func checkGrantable(grants []entity.RoleGrant, orgID primitive.ObjectID, manage entity.Permission, permissions []entity.Permission) (int, error) {
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return http.StatusBadRequest, errors.New(common.MessageErrorInvalidPermission + ": " + string(permission))
+		}
+	}
+
+	scope := entity.NewAccessScope("", grants, append([]entity.Permission{manage}, permissions...)...)
+	if orgID.IsZero() && !scope.AllOrganizations {
+		return http.StatusForbidden, errors.New(common.MessageErrorGlobalRoleForbidden)
+	}
+	if !scope.CanAccess(orgID) {
+		return http.StatusForbidden, errors.New(common.MessageErrorPermissionNotHeld)
+	}
+
+	return http.StatusOK, nil
+}
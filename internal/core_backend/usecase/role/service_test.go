@@ -0,0 +1,121 @@
+package role
+
+import (
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// noCustomRoles a repository where only the system roles exist
+type noCustomRoles struct {
+	Repository
+}
+
+func (noCustomRoles) GetRoleByName(orgID primitive.ObjectID, roleName *string) (*entity.Role, error) {
+	return nil, nil
+}
+
+func TestCheckGrantable(t *testing.T) {
+	orgA, orgB := primitive.NewObjectID(), primitive.NewObjectID()
+	orgAdmin := []entity.RoleGrant{{OrganizationID: orgA, Role: entity.SystemRoles[string(entity.ORG_ADMIN_ROLE)]}}
+	superAdmin := []entity.RoleGrant{{Role: entity.SystemRoles[string(entity.SUPER_ADMIN_ROLE)]}}
+	editor := []entity.RoleGrant{{OrganizationID: orgA, Role: entity.Role{Permissions: []entity.Permission{entity.PermissionProductWrite}}}}
+
+	tests := []struct {
+		name        string
+		grants      []entity.RoleGrant
+		orgID       primitive.ObjectID
+		permissions []entity.Permission
+		want        int
+	}{
+		{"org admin in their organization", orgAdmin, orgA, []entity.Permission{entity.PermissionProductWrite}, http.StatusOK},
+		{"org admin in another organization", orgAdmin, orgB, []entity.Permission{entity.PermissionProductWrite}, http.StatusForbidden},
+		{"org admin globally", orgAdmin, primitive.NilObjectID, []entity.Permission{entity.PermissionProductWrite}, http.StatusForbidden},
+		{"org admin granting a permission they lack", orgAdmin, orgA, []entity.Permission{entity.PermissionNFTDeploy}, http.StatusForbidden},
+		{"org admin granting every permission", orgAdmin, orgA, entity.AllPermissions, http.StatusForbidden},
+		{"without the management permission", editor, orgA, []entity.Permission{entity.PermissionProductWrite}, http.StatusForbidden},
+		{"unknown permission", superAdmin, orgA, []entity.Permission{"product:delete"}, http.StatusBadRequest},
+		{"super admin anywhere", superAdmin, orgB, entity.AllPermissions, http.StatusOK},
+		{"super admin globally", superAdmin, primitive.NilObjectID, entity.AllPermissions, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, err := checkGrantable(tt.grants, tt.orgID, entity.PermissionRoleWrite, tt.permissions); code != tt.want {
+				t.Errorf("got %d (%v), want %d", code, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanAssignRole(t *testing.T) {
+	orgA, orgB := primitive.NewObjectID(), primitive.NewObjectID()
+	orgAdmin := []entity.RoleGrant{{OrganizationID: orgA, Role: entity.SystemRoles[string(entity.ORG_ADMIN_ROLE)]}}
+	superAdmin := []entity.RoleGrant{{Role: entity.SystemRoles[string(entity.SUPER_ADMIN_ROLE)]}}
+	s := NewService(noCustomRoles{})
+
+	tests := []struct {
+		name    string
+		grants  []entity.RoleGrant
+		binding entity.RoleBinding
+		want    int
+	}{
+		{"org admin grants org admin in their organization", orgAdmin, entity.RoleBinding{OrganizationID: orgA, RoleName: string(entity.ORG_ADMIN_ROLE)}, http.StatusOK},
+		{"org admin grants org admin in another organization", orgAdmin, entity.RoleBinding{OrganizationID: orgB, RoleName: string(entity.ORG_ADMIN_ROLE)}, http.StatusForbidden},
+		{"org admin grants super admin", orgAdmin, entity.RoleBinding{RoleName: string(entity.SUPER_ADMIN_ROLE)}, http.StatusForbidden},
+		{"org admin grants super admin in their organization", orgAdmin, entity.RoleBinding{OrganizationID: orgA, RoleName: string(entity.SUPER_ADMIN_ROLE)}, http.StatusForbidden},
+		{"super admin grants super admin", superAdmin, entity.RoleBinding{RoleName: string(entity.SUPER_ADMIN_ROLE)}, http.StatusOK},
+		{"super admin grants super admin in an organization", superAdmin, entity.RoleBinding{OrganizationID: orgA, RoleName: string(entity.SUPER_ADMIN_ROLE)}, http.StatusBadRequest},
+		{"unknown role", superAdmin, entity.RoleBinding{OrganizationID: orgA, RoleName: "auditor"}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, err := s.CanAssignRole(tt.grants, &tt.binding); code != tt.want {
+				t.Errorf("got %d (%v), want %d", code, err, tt.want)
+			}
+		})
+	}
+}
+
+// oneBinding a repository holding a single role binding of a system role
+type oneBinding struct {
+	noCustomRoles
+	binding entity.RoleBinding
+}
+
+func (r oneBinding) GetRoleBindingByID(bindingID *string) (*entity.RoleBinding, error) {
+	return &r.binding, nil
+}
+
+func (oneBinding) DeleteRoleBinding(bindingID primitive.ObjectID) (bool, error) {
+	return true, nil
+}
+
+func TestRevokeRole(t *testing.T) {
+	orgA, orgB := primitive.NewObjectID(), primitive.NewObjectID()
+	orgAdmin := []entity.RoleGrant{{OrganizationID: orgA, Role: entity.SystemRoles[string(entity.ORG_ADMIN_ROLE)]}}
+	editor := []entity.RoleGrant{{OrganizationID: orgA, Role: entity.Role{Permissions: []entity.Permission{entity.PermissionRoleWrite}}}}
+	bindingID := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name    string
+		grants  []entity.RoleGrant
+		binding entity.RoleBinding
+		want    int
+	}{
+		{"binding in their organization", orgAdmin, entity.RoleBinding{OrganizationID: orgA, RoleName: string(entity.ORG_ADMIN_ROLE)}, http.StatusOK},
+		{"binding in another organization", orgAdmin, entity.RoleBinding{OrganizationID: orgB, RoleName: string(entity.ORG_ADMIN_ROLE)}, http.StatusNotFound},
+		{"global binding", orgAdmin, entity.RoleBinding{RoleName: string(entity.SUPER_ADMIN_ROLE)}, http.StatusNotFound},
+		{"role with permissions they lack", editor, entity.RoleBinding{OrganizationID: orgA, RoleName: string(entity.ORG_ADMIN_ROLE)}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(oneBinding{binding: tt.binding})
+			if _, code, err := s.RevokeRole(tt.grants, &bindingID); code != tt.want {
+				t.Errorf("got %d (%v), want %d", code, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package site
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// Mapping interface
+type Mapping interface {
+	GetMappingWithTagID(tagID *string) (*entity.Mapping, error)
+}
+
+// Source interface
+type Source interface {
+	GetMetadataSource(productItemID primitive.ObjectID) (*entity.MetadataSource, error)
+}
+
+// Template interface
+type Template interface {
+	GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, error)
+}
+
+// UseCase interface
+type UseCase interface {
+	RenderItemSite(productItemID *string, chain translation.FallbackChain) (*entity.Site, int, error)
+	RenderTagSite(tagID *string, chain translation.FallbackChain) (*entity.Site, int, error)
+}
@@ -0,0 +1,82 @@
+package site
+
+import (
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// Service renders the sites of product items from the template of their product
+type Service struct {
+	mappings  Mapping
+	sources   Source
+	templates Template
+}
+
+// NewService create service
+func NewService(m Mapping, s Source, t Template) *Service {
+	return &Service{
+		mappings:  m,
+		sources:   s,
+		templates: t,
+	}
+}
+
+// RenderItemSite the site of the product item in the languages of the chain
+func (s *Service) RenderItemSite(productItemID *string, chain translation.FallbackChain) (*entity.Site, int, error) {
+	itemID, err := primitive.ObjectIDFromHex(*productItemID)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	source, err := s.sources.GetMetadataSource(itemID)
+	if err != nil {
+		logger.LogError("Got error while getting site source: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	if source == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorProductItemNotFound)
+	}
+	if source.Product.TemplateID.IsZero() {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorTemplateNotFound)
+	}
+
+	templateID := source.Product.TemplateID.Hex()
+	template, err := s.templates.GetTemplateWebpages(&templateID)
+	if err != nil {
+		logger.LogError("Got error while getting template webpages: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	// Overlay the translations of the documents before their texts are substituted
+	translation.Translate(source, chain)
+	site, err := template.Render(source, chain)
+	if err != nil {
+		if errors.Is(err, entity.ErrTemplateWithoutHome) {
+			logger.LogError("Template " + templateID + " has no home page")
+			return nil, http.StatusInternalServerError, errors.New(common.MessageErrorTemplateWithoutHome)
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return site, http.StatusOK, nil
+}
+
+// RenderTagSite the site of the product item the tag is mapped to
+func (s *Service) RenderTagSite(tagID *string, chain translation.FallbackChain) (*entity.Site, int, error) {
+	mapping, err := s.mappings.GetMappingWithTagID(tagID)
+	if err != nil {
+		logger.LogError("Got error while getting mapping: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	if mapping == nil || mapping.ProductItemID.IsZero() {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorMappingNotFound)
+	}
+	productItemID := mapping.ProductItemID.Hex()
+
+	return s.RenderItemSite(&productItemID, chain)
+}
@@ -0,0 +1,177 @@
+package site
+
+import (
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// siteSources what a site renders from: the mapping of a tag, the documents of its item and the template of its product
+type siteSources struct {
+	Mapping
+	mappings  map[string]entity.Mapping
+	sources   map[primitive.ObjectID]entity.MetadataSource
+	templates map[string]entity.TemplateWebpages
+}
+
+func (r *siteSources) GetMappingWithTagID(tagID *string) (*entity.Mapping, error) {
+	mapping, ok := r.mappings[*tagID]
+	if !ok {
+		return nil, nil
+	}
+	return &mapping, nil
+}
+
+func (r *siteSources) GetMetadataSource(productItemID primitive.ObjectID) (*entity.MetadataSource, error) {
+	source, ok := r.sources[productItemID]
+	if !ok {
+		return nil, nil
+	}
+	return &source, nil
+}
+
+func (r *siteSources) GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, error) {
+	template, ok := r.templates[*tID]
+	if !ok {
+		return nil, nil
+	}
+	return &template, nil
+}
+
+func newPage(name, pageType string, attributes map[string]any) entity.WebPage {
+	page := entity.WebPage{Attributes: attributes}
+	page.ID = primitive.NewObjectID()
+	page.Name = name
+	page.Type = pageType
+	return page
+}
+
+func newTitle(t *testing.T, texts map[string]string) translation.LocalizedString {
+	title, err := translation.NewLocalizedString(texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return title
+}
+
+// newSiteRepository a product item of a product whose template has a story page then a home page
+func newSiteRepository(t *testing.T) (*siteSources, primitive.ObjectID, *entity.TemplateWebpages) {
+	story := newPage("Story of {{product.product_name}}", "story", map[string]any{
+		"heading": "Item #{{item.index}}",
+		"blocks":  []any{map[string]any{"text": "By {{author.name}}"}},
+	})
+	home := newPage("Home", entity.WebPageTypeHome, map[string]any{"title": "{{product.product_name}}"})
+	story.Blocks = []entity.WebPageBlock{{
+		ID:       "intro",
+		Type:     entity.BlockTypeRichText,
+		RichText: &entity.RichTextBlock{Body: newTitle(t, map[string]string{"en": "Made by {{author.name}}", "fr": "Fait par {{author.name}}"})},
+	}}
+	template := entity.TemplateWebpages{
+		Languages: []string{"en", "fr"},
+		Pages:     []entity.WebPage{story, home},
+		Menu: []entity.TemplateWebpagesMenu{
+			{Title: newTitle(t, map[string]string{"en": "Home", "fr": "Accueil"}), WebPage: home},
+			{Title: newTitle(t, map[string]string{"en": "Story"}), WebPage: story},
+		},
+	}
+	template.ID = primitive.NewObjectID()
+
+	product := &entity.Product{ProductName: "Vase", TemplateID: template.ID}
+	product.ID = primitive.NewObjectID()
+	item := &entity.ProductItem{ProductID: product.ID, ItemIndex: 7}
+	item.ID = primitive.NewObjectID()
+	author := &entity.Author{Name: newTitle(t, map[string]string{"en": "Lan", "fr": "Lanne"})}
+
+	repo := &siteSources{
+		mappings:  map[string]entity.Mapping{"tag": {ProductItemID: item.ID}},
+		sources:   map[primitive.ObjectID]entity.MetadataSource{item.ID: {Product: product, ProductItem: item, Author: author}},
+		templates: map[string]entity.TemplateWebpages{template.ID.Hex(): template},
+	}
+	return repo, item.ID, &template
+}
+
+func TestRenderItemSite(t *testing.T) {
+	repo, itemID, template := newSiteRepository(t)
+	service := NewService(repo, repo, repo)
+
+	id := itemID.Hex()
+	site, code, err := service.RenderItemSite(&id, translation.NewFallbackChain("fr"))
+	if err != nil || code != http.StatusOK {
+		t.Fatalf("RenderItemSite() = %d, %v", code, err)
+	}
+	if site.Language != "fr" {
+		t.Errorf("Language = %q, want fr", site.Language)
+	}
+	if site.HomePageID != template.Pages[1].ID {
+		t.Errorf("HomePageID = %v, want the home page %v", site.HomePageID, template.Pages[1].ID)
+	}
+	if len(site.Pages) != 2 || site.Pages[0].ID != template.Pages[0].ID {
+		t.Fatalf("Pages = %+v, want the pages in the order of the template", site.Pages)
+	}
+
+	story := site.Pages[0]
+	if story.Name != "Story of Vase" {
+		t.Errorf("story name = %q", story.Name)
+	}
+	if story.Attributes["heading"] != "Item #7" {
+		t.Errorf("story heading = %v", story.Attributes["heading"])
+	}
+	blocks, ok := story.Attributes["blocks"].([]any)
+	if !ok || len(blocks) != 1 || blocks[0].(map[string]any)["text"] != "By Lanne" {
+		t.Errorf("story blocks = %v, want the author name in French", story.Attributes["blocks"])
+	}
+	if len(story.Blocks) != 1 {
+		t.Fatalf("story blocks = %v, want the block of the page", story.Blocks)
+	}
+	intro, ok := story.Blocks[0].(map[string]any)
+	if !ok || intro["id"] != "intro" || intro["rich_text"].(map[string]any)["body"] != "Fait par Lanne" {
+		t.Errorf("story blocks = %v, want the rich text in French with the author name", story.Blocks)
+	}
+	if site.Pages[1].Attributes["title"] != "Vase" {
+		t.Errorf("home title = %v", site.Pages[1].Attributes["title"])
+	}
+
+	if len(site.Menu) != 2 || site.Menu[0].Title != "Accueil" || site.Menu[0].PageID != template.Pages[1].ID {
+		t.Errorf("Menu = %+v, want the home page entry titled in French first", site.Menu)
+	}
+	if site.Menu[1].Title != "Story" {
+		t.Errorf("menu title = %q, want the English title when there is no French one", site.Menu[1].Title)
+	}
+}
+
+func TestRenderItemSiteWithoutHome(t *testing.T) {
+	repo, itemID, template := newSiteRepository(t)
+	template.Pages = template.Pages[:1]
+	repo.templates[template.ID.Hex()] = *template
+	service := NewService(repo, repo, repo)
+
+	id := itemID.Hex()
+	_, code, err := service.RenderItemSite(&id, translation.NewFallbackChain("en"))
+	if code != http.StatusInternalServerError || err == nil || err.Error() != common.MessageErrorTemplateWithoutHome {
+		t.Errorf("RenderItemSite() = %d, %v, want %d %q", code, err, http.StatusInternalServerError, common.MessageErrorTemplateWithoutHome)
+	}
+}
+
+func TestRenderTagSite(t *testing.T) {
+	repo, itemID, _ := newSiteRepository(t)
+	service := NewService(repo, repo, repo)
+
+	tagID := "tag"
+	site, code, err := service.RenderTagSite(&tagID, translation.NewFallbackChain("en"))
+	if err != nil || code != http.StatusOK {
+		t.Fatalf("RenderTagSite() = %d, %v", code, err)
+	}
+	if site.Menu[0].Title != "Home" || site.Pages[0].Attributes["heading"] != "Item #7" {
+		t.Errorf("site = %+v, want the site of item %v in English", site, itemID)
+	}
+
+	unknown := "unknown"
+	if _, code, err = service.RenderTagSite(&unknown, translation.NewFallbackChain("en")); code != http.StatusNotFound || err == nil {
+		t.Errorf("RenderTagSite() of an unmapped tag = %d, %v, want %d", code, err, http.StatusNotFound)
+	}
+}
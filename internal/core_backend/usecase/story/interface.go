@@ -0,0 +1,50 @@
+package story
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// Story interface
+type Story interface {
+	GetLastStoryQueuedAt() (time.Time, error)
+	QueueChangedStories(since time.Time) (int64, error)
+	QueueProductStories(productID primitive.ObjectID) (int64, error)
+	GetStaleStories(limit int) (*[]entity.StoryArtifact, error)
+	SaveStoryArtifact(artifact *entity.StoryArtifact) (bool, error)
+	GetStoryArtifact(productItemID primitive.ObjectID) (*entity.StoryArtifact, error)
+	GetStoryOwnership(productItemID primitive.ObjectID) (*entity.StoryOwnership, error)
+}
+
+// Source interface
+type Source interface {
+	GetMetadataSource(productItemID primitive.ObjectID) (*entity.MetadataSource, error)
+}
+
+// Template interface
+type Template interface {
+	GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, error)
+}
+
+// Storage the object storage stories are exported to
+type Storage interface {
+	UploadObject(objectPath string, content []byte, contentType, cacheControl string) (string, error)
+	DeleteObject(objectPath string) error
+}
+
+// Repository interface
+type Repository interface {
+	Story
+}
+
+// UseCase interface
+type UseCase interface {
+	PublishProductStories(productID primitive.ObjectID) (int64, int, error)
+	GetStoryArtifact(productItemID *string) (*entity.StoryArtifact, int, error)
+	GetStoryURL(productItemID primitive.ObjectID, chain translation.FallbackChain) (string, bool)
+	RunStoryPublisher()
+}
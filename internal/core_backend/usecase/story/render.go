@@ -0,0 +1,73 @@
+package story
+
+import (
+	"bytes"
+	"html/template"
+	"regexp"
+
+	"backend-service/internal/core_backend/entity"
+)
+
+// markup the tags of rich texts. The HTML export shows rich texts as plain text so markup written by organizations
+// is never served from the storage domain, the JSON export keeps it.
+var markup = regexp.MustCompile(`<[^>]*>`)
+
+var storyPage = template.Must(template.New("story").Funcs(template.FuncMap{
+	"plain": func(text any) string {
+		s, _ := text.(string)
+		return markup.ReplaceAllString(s, " ")
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Site.Language}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.ProductName}}</title>
+{{- range .Languages}}{{if ne . $.Site.Language}}
+<link rel="alternate" hreflang="{{.}}" href="{{.}}.html">{{end}}{{end}}
+</head>
+<body>
+<nav>
+{{- range .Site.Menu}}
+<a href="{{if .PageID.IsZero}}{{.URLLink}}{{else}}#page-{{.PageID.Hex}}{{end}}">{{.Title}}</a>
+{{- end}}
+</nav>
+<main>
+{{- range .Site.Pages}}
+<section id="page-{{.ID.Hex}}" data-type="{{.Type}}"{{if eq .ID $.Site.HomePageID}} data-home{{end}}>
+<h1>{{.Name}}</h1>
+{{- range .Blocks}}{{template "block" .}}{{end}}
+</section>
+{{- end}}
+</main>
+<footer>
+{{- if .Ownership.Claimed}}
+<p data-claim="claimed">{{.Ownership.OwnerName}}</p>
+{{- else if .Ownership.Claimable}}
+<p data-claim="claimable"></p>
+{{- end}}
+</footer>
+</body>
+</html>
+{{define "block"}}
+<div class="block block-{{.type}}">
+{{- with .rich_text}}{{with .title}}<h2>{{.}}</h2>{{end}}<p>{{plain .body}}</p>{{end}}
+{{- with .image_gallery}}{{with .title}}<h2>{{.}}</h2>{{end}}{{range .images}}<figure><img src="{{.url}}" alt="{{.caption}}">{{with .caption}}<figcaption>{{.}}</figcaption>{{end}}</figure>{{end}}{{end}}
+{{- with .video}}{{with .title}}<h2>{{.}}</h2>{{end}}<video controls src="{{.url}}"{{with .thumbnail_url}} poster="{{.}}"{{end}}></video>{{end}}
+{{- with .model_3d}}{{with .title}}<h2>{{.}}</h2>{{end}}<a href="{{.url}}">{{with .poster_url}}<img src="{{.}}" alt="">{{end}}</a>{{end}}
+{{- with .map_location}}{{with .title}}<h2>{{.}}</h2>{{end}}<p data-latitude="{{.latitude}}" data-longitude="{{.longitude}}" data-zoom="{{.zoom}}">{{.label}}</p>{{end}}
+{{- with .timeline}}{{with .title}}<h2>{{.}}</h2>{{end}}<ol>{{range .events}}<li><time>{{.date}}</time> <strong>{{.title}}</strong> {{.description}}</li>{{end}}</ol>{{end}}
+{{- with .quote}}<blockquote>{{.text}}{{with .author}}<cite>{{.}}</cite>{{end}}</blockquote>{{end}}
+{{- with .call_to_action}}<a class="call-to-action" href="{{.url}}">{{.label}}</a>{{end}}
+</div>
+{{- end}}`))
+
+// renderStoryHTML the static page of the story
+func renderStoryHTML(story *entity.Story) ([]byte, error) {
+	var page bytes.Buffer
+	if err := storyPage.Execute(&page, story); err != nil {
+		return nil, err
+	}
+
+	return page.Bytes(), nil
+}
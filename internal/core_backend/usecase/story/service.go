@@ -0,0 +1,296 @@
+package story
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// Service pre-renders the stories of product items, the site of the item in each language of its template,
+// to JSON and HTML files in object storage and rebuilds them when what they are rendered from changes
+type Service struct {
+	repo      Repository
+	sources   Source
+	templates Template
+	storage   Storage
+	// since the changes made after since are not queued yet
+	since time.Time
+}
+
+// NewService create service
+func NewService(r Repository, s Source, t Template, st Storage) *Service {
+	return &Service{
+		repo:      r,
+		sources:   s,
+		templates: t,
+		storage:   st,
+	}
+}
+
+// PublishProductStories queues the stories of every item of the product to be exported again, returns the number queued
+func (s *Service) PublishProductStories(productID primitive.ObjectID) (int64, int, error) {
+	queued, err := s.repo.QueueProductStories(productID)
+	if err != nil {
+		logger.LogError("Got error while queueing product stories: " + err.Error())
+		return 0, http.StatusInternalServerError, err
+	}
+
+	return queued, http.StatusOK, nil
+}
+
+// GetStoryArtifact the exported files of the story of the product item and the state of its export
+func (s *Service) GetStoryArtifact(productItemID *string) (*entity.StoryArtifact, int, error) {
+	itemID, err := primitive.ObjectIDFromHex(*productItemID)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	artifact, err := s.repo.GetStoryArtifact(itemID)
+	if err != nil {
+		logger.LogError("Got error while getting story artifact: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	if artifact == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorStoryNotFound)
+	}
+
+	return artifact, http.StatusOK, nil
+}
+
+// GetStoryURL the URL of the exported HTML story of the product item in the first language of the chain it has.
+// Stories waiting to be rebuilt are not up to date and have no URL.
+func (s *Service) GetStoryURL(productItemID primitive.ObjectID, chain translation.FallbackChain) (string, bool) {
+	artifact, err := s.repo.GetStoryArtifact(productItemID)
+	if err != nil {
+		logger.LogError("Got error while getting story artifact: " + err.Error())
+		return "", false
+	}
+	if artifact == nil || artifact.Stale || artifact.Status != common.StoryStatusPublished {
+		return "", false
+	}
+
+	var languages []string
+	for _, file := range artifact.Files {
+		if file.Format == entity.StoryFormatHTML {
+			languages = append(languages, file.Language)
+		}
+	}
+	language, ok := chain.Match(languages)
+	if !ok {
+		if len(languages) == 0 {
+			return "", false
+		}
+		language = languages[0]
+	}
+
+	return artifact.File(language, entity.StoryFormatHTML).URL, true
+}
+
+// RunStoryPublisher queues the stories changes made stale and rebuilds them every STORY_REBUILD_INTERVAL_IN_SECOND,
+// disabled when it is 0. Changes are looked for from the last time stories were queued, all stories the first time.
+func (s *Service) RunStoryPublisher() {
+	interval := time.Duration(config.C.Story.REBUILD_INTERVAL_IN_SECOND) * time.Second
+	if interval <= 0 {
+		return
+	}
+	since, err := s.repo.GetLastStoryQueuedAt()
+	if err != nil {
+		logger.LogError("Story publisher not started: " + err.Error())
+		return
+	}
+	s.since = since
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		queued, err := s.QueueChangedStories()
+		if err != nil {
+			logger.LogError("Story change detection failed: " + err.Error())
+		}
+		published, failed, err := s.BuildStaleStories(config.C.Story.BATCH_SIZE)
+		if err != nil {
+			logger.LogError("Story build failed: " + err.Error())
+			continue
+		}
+		if queued+int64(published+failed) != 0 {
+			logger.LogInfo(fmt.Sprintf("Story publisher: %d queued, %d built, %d failed", queued, published, failed))
+		}
+	}
+}
+
+// QueueChangedStories queues the stories of the items changed since the last detection, with an overlap
+func (s *Service) QueueChangedStories() (int64, error) {
+	// Changes written while looking for changes are looked for again next time
+	startedAt := time.Now()
+	since := s.since
+	if !since.IsZero() {
+		since = since.Add(-time.Duration(config.C.Story.CHANGE_OVERLAP_IN_SECOND) * time.Second)
+	}
+	queued, err := s.repo.QueueChangedStories(since)
+	if err != nil {
+		return 0, err
+	}
+	s.since = startedAt
+
+	return queued, nil
+}
+
+// BuildStaleStories exports at most limit stale stories, returns how many were built and how many failed
+func (s *Service) BuildStaleStories(limit int) (int, int, error) {
+	artifacts, err := s.repo.GetStaleStories(limit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	built, failed := 0, 0
+	for _, artifact := range *artifacts {
+		result := s.buildStory(artifact)
+		if result.Status == common.StoryStatusFailed {
+			failed++
+			logger.LogError("Story of product item " + artifact.ProductItemID.Hex() + " not exported: " + result.Error)
+		} else {
+			built++
+		}
+		if _, err := s.repo.SaveStoryArtifact(result); err != nil {
+			logger.LogError("Got error while saving story artifact: " + err.Error())
+		}
+	}
+
+	return built, failed, nil
+}
+
+// buildStory exports the story of the artifact in each language of the template of its product. Stories of items
+// whose product is no longer active are unpublished, the files of an earlier export that were not written again are removed.
+func (s *Service) buildStory(artifact entity.StoryArtifact) *entity.StoryArtifact {
+	result := &entity.StoryArtifact{
+		ProductItemID: artifact.ProductItemID,
+		Revision:      artifact.Revision,
+		Status:        common.StoryStatusPublished,
+		Files:         []entity.StoryFile{},
+		RenderedAt:    time.Now(),
+	}
+	files, published, err := s.exportStory(artifact.ProductItemID, result.RenderedAt)
+	switch {
+	case err != nil:
+		// The earlier export is still served
+		result.Status = common.StoryStatusFailed
+		result.Error = err.Error()
+		result.Files = artifact.Files
+		return result
+	case !published:
+		result.Status = common.StoryStatusUnpublished
+	default:
+		result.Files = files
+	}
+
+	for _, old := range artifact.Files {
+		if result.File(old.Language, old.Format) != nil {
+			continue
+		}
+		if err := s.storage.DeleteObject(old.Path); err != nil {
+			logger.LogError("Got error while removing exported story file: " + err.Error())
+		}
+	}
+
+	return result
+}
+
+// exportStory writes the files of the story of the product item, returns false when the item has no story to publish
+func (s *Service) exportStory(itemID primitive.ObjectID, renderedAt time.Time) ([]entity.StoryFile, bool, error) {
+	source, err := s.sources.GetMetadataSource(itemID)
+	if err != nil {
+		return nil, false, err
+	}
+	if source == nil || source.Product.Status != common.StatusActive || source.Product.TemplateID.IsZero() {
+		return nil, false, nil
+	}
+	templateID := source.Product.TemplateID.Hex()
+	template, err := s.templates.GetTemplateWebpages(&templateID)
+	if err != nil {
+		return nil, false, err
+	}
+	ownership, err := s.repo.GetStoryOwnership(itemID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	languages := template.Languages
+	if len(languages) == 0 {
+		languages = translation.DefaultLanguages[:1]
+	}
+	files := []entity.StoryFile{}
+	for i, language := range languages {
+		// Translations are overlaid on the source itself, each language starts from the stored documents
+		if i > 0 {
+			if source, err = s.sources.GetMetadataSource(itemID); err != nil {
+				return nil, false, err
+			}
+			if source == nil {
+				return nil, false, nil
+			}
+		}
+		chain := translation.NewFallbackChain(language)
+		translation.Translate(source, chain)
+		site, err := template.Render(source, chain)
+		if err != nil {
+			return nil, false, err
+		}
+		story := &entity.Story{
+			ProductItemID: itemID,
+			ProductName:   source.Product.ProductName,
+			ItemIndex:     source.ProductItem.ItemIndex,
+			Languages:     languages,
+			Site:          *site,
+			Ownership:     *ownership,
+			RenderedAt:    renderedAt,
+		}
+		written, err := s.writeStory(story, language)
+		if err != nil {
+			return nil, false, err
+		}
+		files = append(files, written...)
+	}
+
+	return files, true, nil
+}
+
+// writeStory uploads the JSON and HTML files of the story in the language
+func (s *Service) writeStory(story *entity.Story, language string) ([]entity.StoryFile, error) {
+	content, err := json.Marshal(story)
+	if err != nil {
+		return nil, err
+	}
+	page, err := renderStoryHTML(story)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheControl := fmt.Sprintf("public, max-age=%d", config.C.Story.CACHE_MAX_AGE)
+	files := []entity.StoryFile{}
+	for _, file := range []struct {
+		format      string
+		contentType string
+		content     []byte
+	}{
+		{entity.StoryFormatJSON, "application/json", content},
+		{entity.StoryFormatHTML, "text/html; charset=utf-8", page},
+	} {
+		path := entity.StoryFilePath(config.C.Story.STORAGE_PATH, story.ProductItemID, language, file.format)
+		url, err := s.storage.UploadObject(path, file.content, file.contentType, cacheControl)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, entity.StoryFile{Language: language, Format: file.format, Path: path, URL: url})
+	}
+
+	return files, nil
+}
@@ -0,0 +1,238 @@
+package story
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// exportedStories the story artifacts of a product and the documents they are rendered from,
+// objects is the storage they are exported to, by path
+type exportedStories struct {
+	Repository
+	artifacts map[primitive.ObjectID]*entity.StoryArtifact
+	sources   map[primitive.ObjectID]entity.MetadataSource
+	templates map[string]entity.TemplateWebpages
+	ownership entity.StoryOwnership
+	objects   map[string][]byte
+	// changedSince the time changes were last looked for after
+	changedSince time.Time
+}
+
+func (r *exportedStories) GetLastStoryQueuedAt() (time.Time, error) { return time.Time{}, nil }
+
+func (r *exportedStories) QueueChangedStories(since time.Time) (int64, error) {
+	r.changedSince = since
+	return 0, nil
+}
+
+func (r *exportedStories) QueueProductStories(productID primitive.ObjectID) (int64, error) {
+	var queued int64
+	for itemID, source := range r.sources {
+		if source.ProductItem.ProductID != productID {
+			continue
+		}
+		artifact, ok := r.artifacts[itemID]
+		if !ok {
+			artifact = &entity.StoryArtifact{ProductItemID: itemID}
+			r.artifacts[itemID] = artifact
+		}
+		artifact.Stale = true
+		artifact.Revision++
+		queued++
+	}
+	return queued, nil
+}
+
+func (r *exportedStories) GetStaleStories(limit int) (*[]entity.StoryArtifact, error) {
+	stale := []entity.StoryArtifact{}
+	for _, artifact := range r.artifacts {
+		if artifact.Stale && len(stale) < limit {
+			stale = append(stale, *artifact)
+		}
+	}
+	return &stale, nil
+}
+
+func (r *exportedStories) SaveStoryArtifact(artifact *entity.StoryArtifact) (bool, error) {
+	current, ok := r.artifacts[artifact.ProductItemID]
+	if !ok || current.Revision != artifact.Revision {
+		return false, nil
+	}
+	saved := *artifact
+	r.artifacts[artifact.ProductItemID] = &saved
+	return true, nil
+}
+
+func (r *exportedStories) GetStoryArtifact(productItemID primitive.ObjectID) (*entity.StoryArtifact, error) {
+	return r.artifacts[productItemID], nil
+}
+
+func (r *exportedStories) GetStoryOwnership(productItemID primitive.ObjectID) (*entity.StoryOwnership, error) {
+	ownership := r.ownership
+	return &ownership, nil
+}
+
+func (r *exportedStories) GetMetadataSource(productItemID primitive.ObjectID) (*entity.MetadataSource, error) {
+	source, ok := r.sources[productItemID]
+	if !ok {
+		return nil, nil
+	}
+	// Every read gets its own documents, as from the database
+	product, item, author := *source.Product, *source.ProductItem, *source.Author
+	return &entity.MetadataSource{Product: &product, ProductItem: &item, Author: &author}, nil
+}
+
+func (r *exportedStories) GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, error) {
+	template := r.templates[*tID]
+	return &template, nil
+}
+
+func (r *exportedStories) UploadObject(objectPath string, content []byte, contentType, cacheControl string) (string, error) {
+	r.objects[objectPath] = content
+	return "https://cdn.test/" + objectPath, nil
+}
+
+func (r *exportedStories) DeleteObject(objectPath string) error {
+	delete(r.objects, objectPath)
+	return nil
+}
+
+func localized(t *testing.T, texts map[string]string) translation.LocalizedString {
+	ls, err := translation.NewLocalizedString(texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ls
+}
+
+// newStoryRepository an item of an active product whose template in English and French has a home page with a rich text
+func newStoryRepository(t *testing.T) (*exportedStories, primitive.ObjectID, primitive.ObjectID) {
+	config.C.Story.STORAGE_PATH = "stories"
+	home := entity.WebPage{Blocks: []entity.WebPageBlock{{
+		ID:       "intro",
+		Type:     entity.BlockTypeRichText,
+		RichText: &entity.RichTextBlock{Body: localized(t, map[string]string{"en": "<b>Made</b> by {{author.name}}", "fr": "Fait par {{author.name}}<script>alert(1)</script>"})},
+	}}}
+	home.ID = primitive.NewObjectID()
+	home.Name = "{{product.product_name}}"
+	home.Type = entity.WebPageTypeHome
+	template := entity.TemplateWebpages{Languages: []string{"en", "fr"}, Pages: []entity.WebPage{home}}
+	template.ID = primitive.NewObjectID()
+
+	product := &entity.Product{ProductName: "Vase", TemplateID: template.ID}
+	product.ID = primitive.NewObjectID()
+	product.Status = common.StatusActive
+	item := &entity.ProductItem{ProductID: product.ID, ItemIndex: 3}
+	item.ID = primitive.NewObjectID()
+	author := &entity.Author{Name: localized(t, map[string]string{"en": "Lan", "fr": "Lanne"})}
+
+	repo := &exportedStories{
+		artifacts: map[primitive.ObjectID]*entity.StoryArtifact{},
+		sources:   map[primitive.ObjectID]entity.MetadataSource{item.ID: {Product: product, ProductItem: item, Author: author}},
+		templates: map[string]entity.TemplateWebpages{template.ID.Hex(): template},
+		ownership: entity.StoryOwnership{Claimed: true, OwnerName: "Mai"},
+		objects:   map[string][]byte{},
+	}
+	return repo, product.ID, item.ID
+}
+
+func TestBuildStaleStories(t *testing.T) {
+	repo, productID, itemID := newStoryRepository(t)
+	service := NewService(repo, repo, repo, repo)
+
+	if queued, _, err := service.PublishProductStories(productID); err != nil || queued != 1 {
+		t.Fatalf("PublishProductStories() = %d, %v, want 1 story queued", queued, err)
+	}
+	built, failed, err := service.BuildStaleStories(10)
+	if err != nil || built != 1 || failed != 0 {
+		t.Fatalf("BuildStaleStories() = %d, %d, %v, want 1 built", built, failed, err)
+	}
+
+	artifact := repo.artifacts[itemID]
+	if artifact.Stale || artifact.Status != common.StoryStatusPublished || len(artifact.Files) != 4 {
+		t.Fatalf("artifact = %+v, want a published artifact with JSON and HTML files in 2 languages", artifact)
+	}
+	frJSON := artifact.File("fr", entity.StoryFormatJSON)
+	if frJSON == nil || frJSON.Path != "stories/"+itemID.Hex()+"/fr.json" || frJSON.URL != "https://cdn.test/"+frJSON.Path {
+		t.Fatalf("French JSON file = %+v", frJSON)
+	}
+
+	var story entity.Story
+	if err := json.Unmarshal(repo.objects[frJSON.Path], &story); err != nil {
+		t.Fatal(err)
+	}
+	if story.Site.Language != "fr" || story.Site.Pages[0].Name != "Vase" || story.Ownership.OwnerName != "Mai" {
+		t.Errorf("French story = %+v", story)
+	}
+	body := story.Site.Pages[0].Blocks[0].(map[string]any)["rich_text"].(map[string]any)["body"]
+	if body != "Fait par Lanne<script>alert(1)</script>" {
+		t.Errorf("French JSON body = %v, want the rich text in French with its markup", body)
+	}
+
+	frHTML := string(repo.objects[artifact.File("fr", entity.StoryFormatHTML).Path])
+	if !strings.Contains(frHTML, "Fait par Lanne") || strings.Contains(frHTML, "<script>") {
+		t.Errorf("French HTML = %s, want the rich text in French without its markup", frHTML)
+	}
+	enHTML := string(repo.objects[artifact.File("en", entity.StoryFormatHTML).Path])
+	if !strings.Contains(enHTML, `lang="en"`) || !strings.Contains(enHTML, "Made  by Lan") {
+		t.Errorf("English HTML = %s, want the rich text in English", enHTML)
+	}
+
+	if url, ok := service.GetStoryURL(itemID, translation.NewFallbackChain("fr-CA")); !ok || !strings.HasSuffix(url, "/fr.html") {
+		t.Errorf("GetStoryURL(fr-CA) = %q, %v, want the French page", url, ok)
+	}
+}
+
+func TestBuildUnpublishedStory(t *testing.T) {
+	repo, productID, itemID := newStoryRepository(t)
+	service := NewService(repo, repo, repo, repo)
+	service.PublishProductStories(productID)
+	service.BuildStaleStories(10)
+
+	source := repo.sources[itemID]
+	source.Product.Status = common.StatusInactive
+	service.PublishProductStories(productID)
+	if url, ok := service.GetStoryURL(itemID, translation.NewFallbackChain("en")); ok {
+		t.Errorf("GetStoryURL() of a stale story = %q, want none", url)
+	}
+	if _, _, err := service.BuildStaleStories(10); err != nil {
+		t.Fatal(err)
+	}
+
+	artifact := repo.artifacts[itemID]
+	if artifact.Status != common.StoryStatusUnpublished || len(artifact.Files) != 0 {
+		t.Errorf("artifact = %+v, want an unpublished artifact without files", artifact)
+	}
+	if len(repo.objects) != 0 {
+		t.Errorf("objects = %v, want the files of the inactive product removed", repo.objects)
+	}
+}
+
+func TestQueueChangedStoriesOverlap(t *testing.T) {
+	previous := config.C
+	config.C.Story.CHANGE_OVERLAP_IN_SECOND = 30
+	t.Cleanup(func() { config.C = previous })
+	repo, _, _ := newStoryRepository(t)
+	service := NewService(repo, repo, repo, repo)
+
+	// Everything is looked for at the first detection
+	if _, err := service.QueueChangedStories(); err != nil || !repo.changedSince.IsZero() {
+		t.Fatalf("first detection since %v, %v, want the beginning", repo.changedSince, err)
+	}
+
+	// A write stamped before the last detection but committed after it is still found
+	last := service.since
+	service.QueueChangedStories()
+	if want := last.Add(-30 * time.Second); !repo.changedSince.Equal(want) {
+		t.Errorf("next detection since %v, want %v", repo.changedSince, want)
+	}
+}
@@ -3,16 +3,22 @@ package template
 import (
 	"backend-service/internal/core_backend/api/handler/request"
 	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Repository Interface For Template
 type Template interface {
 	CreateTemplate(*entity.Template) (*entity.Template, error)
-	UpdateTemplate(*entity.Template) error
+	UpdateTemplate(template *entity.Template, scope *entity.AccessScope) (bool, error)
 	GetTemplate(*string) (*entity.Template, error)
+	GetTemplateInScope(templateID *string, scope *entity.AccessScope) (*entity.Template, error)
 	CheckExistedTemplate(*string) (bool, error)
-	GetAllTemplates() (*[]entity.Template, error)
+	GetAllTemplates(scope *entity.AccessScope) (*[]entity.Template, error)
 	GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, error)
+	CountWebPagesInScope(pageIDs []primitive.ObjectID, scope *entity.AccessScope) (int64, error)
+	CountWebPagesOfType(pageIDs []primitive.ObjectID, pageType string) (int64, error)
+	GetDanglingReferences(scope *entity.AccessScope) (*[]entity.DanglingReference, error)
 }
 
 type Repository interface {
@@ -20,11 +26,14 @@ type Repository interface {
 }
 
 type Usecase interface {
-	CreateTemplate(*request.CreateTemplateRequest) (bool, int, error)
-	UpdateTemplate(*request.UpdateTemplateRequest) (bool, int, error)
+	CreateTemplate(scope *entity.AccessScope, orgID primitive.ObjectID, request *request.CreateTemplateRequest) (bool, int, error)
+	UpdateTemplate(scope *entity.AccessScope, request *request.UpdateTemplateRequest) (bool, int, error)
 	GetTemplate(*string) (*entity.Template, int, error)
+	GetTemplateInScope(scope *entity.AccessScope, templateID *string) (*entity.Template, int, error)
 	CheckExistedTemplate(*string) (bool, int, error)
-	GetAllTemplates() (*[]entity.Template, int, error)
+	GetAllTemplates(scope *entity.AccessScope) (*[]entity.Template, int, error)
 	GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, int, error)
-	CloneTemplate(templateID *string) (*entity.Template, int, error)
+	GetTemplateWebpagesInScope(scope *entity.AccessScope, tID *string) (*entity.TemplateWebpages, int, error)
+	CloneTemplate(scope *entity.AccessScope, templateID *string) (*entity.Template, int, error)
+	GetDanglingReferences(scope *entity.AccessScope) (*[]entity.DanglingReference, int, error)
 }
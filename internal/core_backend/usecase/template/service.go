@@ -1,9 +1,13 @@
 package template
 
 import (
+	"errors"
+
 	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/common/logger"
 	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
 	"net/http"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,39 +24,19 @@ func NewService(r Repository) *Service {
 	}
 }
 
-func (s *Service) CreateTemplate(request *request.CreateTemplateRequest) (bool, int, error) {
-	var pages []entity.TemplatePages
-	for _, page := range request.Pages {
-		pageID, err := primitive.ObjectIDFromHex(page.PageID)
-		if err != nil {
-			logger.LogError("Error convert pageID from string to ObjectID")
-			return false, http.StatusInternalServerError, err
-		}
-		pages = append(pages, entity.TemplatePages{
-			PageID: pageID,
-		})
-	}
-	var menus []entity.TemplateMenu
-	for _, menu := range request.Menu {
-		pageID, err := primitive.ObjectIDFromHex(menu.PageID)
-		if err != nil {
-			logger.LogError("Error convert pageID from string to ObjectID")
-			return false, http.StatusInternalServerError, err
-		}
-		menus = append(menus, entity.TemplateMenu{
-			Title: entity.TemplateMenuTitle{
-				VI: menu.Title.VI,
-				EN: menu.Title.EN,
-			},
-			PageID: pageID,
-		})
+// CreateTemplate creates a template of the organization, shared by every organization when orgID is zero
+func (s *Service) CreateTemplate(scope *entity.AccessScope, orgID primitive.ObjectID, request *request.CreateTemplateRequest) (bool, int, error) {
+	languages, pages, menus, code, err := s.validateTemplate(scope, request.Languages, request.Pages, request.Menu)
+	if err != nil {
+		return false, code, err
 	}
 	template := &entity.Template{
-		Name:      request.Name,
-		Category:  request.Category,
-		Languages: request.Languages,
-		Pages:     pages,
-		Menu:      menus,
+		OrganizationID: orgID,
+		Name:           request.Name,
+		Category:       request.Category,
+		Languages:      languages,
+		Pages:          pages,
+		Menu:           menus,
 	}
 	template.SetTime()
 	templateInserted, err := s.repo.CreateTemplate(template)
@@ -63,51 +47,28 @@ func (s *Service) CreateTemplate(request *request.CreateTemplateRequest) (bool,
 	return !templateInserted.ID.IsZero(), http.StatusOK, nil
 }
 
-func (s *Service) UpdateTemplate(request *request.UpdateTemplateRequest) (bool, int, error) {
-	template, err := s.repo.GetTemplate(&request.TemplateID)
+func (s *Service) UpdateTemplate(scope *entity.AccessScope, request *request.UpdateTemplateRequest) (bool, int, error) {
+	template, code, err := s.getWritableTemplate(scope, &request.TemplateID)
 	if err != nil {
-		logger.LogError("Error getting template: " + err.Error())
-		return false, http.StatusInternalServerError, err
-	}
-	var pages []entity.TemplatePages
-	for _, page := range request.Pages {
-		pageID, err := primitive.ObjectIDFromHex(page.PageID)
-		if err != nil {
-			logger.LogError("Error convert pageID from string to ObjectID")
-			return false, http.StatusInternalServerError, err
-		}
-		pages = append(pages, entity.TemplatePages{
-			PageID: pageID,
-		})
+		return false, code, err
 	}
-	var menus []entity.TemplateMenu
-	for _, menu := range request.Menu {
-		pageID, err := primitive.ObjectIDFromHex(menu.PageID)
-		if err != nil {
-			logger.LogError("Error convert pageID from string to ObjectID")
-			return false, http.StatusInternalServerError, err
-		}
-		menus = append(menus, entity.TemplateMenu{
-			Title: entity.TemplateMenuTitle{
-				VI: menu.Title.VI,
-				EN: menu.Title.EN,
-			},
-			PageID: pageID,
-		})
+	languages, pages, menus, code, err := s.validateTemplate(scope, request.Languages, request.Pages, request.Menu)
+	if err != nil {
+		return false, code, err
 	}
 
 	template.Name = request.Name
 	template.Category = request.Category
-	template.Languages = request.Languages
+	template.Languages = languages
 	template.Pages = pages
 	template.Menu = menus
 	template.SetTime()
-	err = s.repo.UpdateTemplate(template)
+	ok, err := s.repo.UpdateTemplate(template, scope)
 	if err != nil {
 		logger.LogError("Error updating template: " + err.Error())
 		return false, http.StatusInternalServerError, err
 	}
-	return true, http.StatusOK, nil
+	return ok, http.StatusOK, nil
 }
 
 func (s *Service) GetTemplate(templateID *string) (*entity.Template, int, error) {
@@ -128,8 +89,8 @@ func (s *Service) CheckExistedTemplate(templateID *string) (bool, int, error) {
 	return isExisted, http.StatusOK, err
 }
 
-func (s *Service) GetAllTemplates() (*[]entity.Template, int, error) {
-	templates, err := s.repo.GetAllTemplates()
+func (s *Service) GetAllTemplates(scope *entity.AccessScope) (*[]entity.Template, int, error) {
+	templates, err := s.repo.GetAllTemplates(scope)
 	if err != nil {
 		logger.LogError("Error getting all templates: " + err.Error())
 		return nil, http.StatusInternalServerError, err
@@ -137,6 +98,16 @@ func (s *Service) GetAllTemplates() (*[]entity.Template, int, error) {
 	return templates, http.StatusOK, nil
 }
 
+// GetDanglingReferences the references of the templates and products in scope to webpages and templates that no longer exist
+func (s *Service) GetDanglingReferences(scope *entity.AccessScope) (*[]entity.DanglingReference, int, error) {
+	references, err := s.repo.GetDanglingReferences(scope)
+	if err != nil {
+		logger.LogError("Error getting dangling references: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	return references, http.StatusOK, nil
+}
+
 func (s *Service) GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, int, error) {
 	templateWebpages, err := s.repo.GetTemplateWebpages(tID)
 	if err != nil {
@@ -147,11 +118,10 @@ func (s *Service) GetTemplateWebpages(tID *string) (*entity.TemplateWebpages, in
 }
 
 // CloneTemplate - Clone a template with given template ID
-func (s *Service) CloneTemplate(templateID *string) (*entity.Template, int, error) {
-	template, err := s.repo.GetTemplate(templateID)
+func (s *Service) CloneTemplate(scope *entity.AccessScope, templateID *string) (*entity.Template, int, error) {
+	template, code, err := s.getWritableTemplate(scope, templateID)
 	if err != nil {
-		logger.LogError("Error getting template: " + err.Error())
-		return nil, http.StatusInternalServerError, err
+		return nil, code, err
 	}
 	template.Renew()
 	template.Name += "_Copy"
@@ -163,3 +133,161 @@ func (s *Service) CloneTemplate(templateID *string) (*entity.Template, int, erro
 	}
 	return templateInserted, http.StatusOK, nil
 }
+
+// GetTemplateInScope templates of organizations out of scope are reported as not found
+func (s *Service) GetTemplateInScope(scope *entity.AccessScope, templateID *string) (*entity.Template, int, error) {
+	template, err := s.repo.GetTemplateInScope(templateID, scope)
+	if err != nil {
+		logger.LogError("Error getting template: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	if template == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorTemplateNotFound)
+	}
+	return template, http.StatusOK, nil
+}
+
+func (s *Service) GetTemplateWebpagesInScope(scope *entity.AccessScope, tID *string) (*entity.TemplateWebpages, int, error) {
+	if _, code, err := s.GetTemplateInScope(scope, tID); err != nil {
+		return nil, code, err
+	}
+	return s.GetTemplateWebpages(tID)
+}
+
+// getWritableTemplate shared templates can be read by every admin but only changed by admins of all organizations
+func (s *Service) getWritableTemplate(scope *entity.AccessScope, templateID *string) (*entity.Template, int, error) {
+	template, code, err := s.GetTemplateInScope(scope, templateID)
+	if err != nil {
+		return nil, code, err
+	}
+	if template.OrganizationID.IsZero() && !scope.AllOrganizations {
+		return nil, http.StatusForbidden, errors.New(common.MessageErrorSharedResource)
+	}
+	return template, http.StatusOK, nil
+}
+
+// checkPagesInScope a template can only use shared pages and pages of the organizations in scope
+func (s *Service) checkPagesInScope(scope *entity.AccessScope, pages []entity.TemplatePages, menus []entity.TemplateMenu) (int, error) {
+	unique := map[primitive.ObjectID]bool{}
+	for _, page := range pages {
+		unique[page.PageID] = true
+	}
+	for _, menu := range menus {
+		unique[menu.PageID] = true
+	}
+	if len(unique) == 0 {
+		return http.StatusOK, nil
+	}
+	pageIDs := make([]primitive.ObjectID, 0, len(unique))
+	for id := range unique {
+		pageIDs = append(pageIDs, id)
+	}
+
+	count, err := s.repo.CountWebPagesInScope(pageIDs, scope)
+	if err != nil {
+		logger.LogError("Error counting template webpages: " + err.Error())
+		return http.StatusInternalServerError, err
+	}
+	if count != int64(len(pageIDs)) {
+		return http.StatusBadRequest, errors.New(common.MessageErrorWebPageNotFound)
+	}
+	return http.StatusOK, nil
+}
+
+// parseLanguages the canonical BCP 47 tags of the languages of a template, in order
+// validateTemplate the languages, pages and menu of a template to save. Languages are BCP 47 tags each given once,
+// every page and menu entry targets an existing webpage the scope can use, one of the pages is the home page
+// and menu titles are only written in the languages of the template.
+func (s *Service) validateTemplate(scope *entity.AccessScope, requestLanguages []string, requestPages []request.TemplatePagesRequest, requestMenu []request.TemplateMenuRequest) ([]string, []entity.TemplatePages, []entity.TemplateMenu, int, error) {
+	languages, err := parseLanguages(requestLanguages)
+	if err != nil {
+		return nil, nil, nil, http.StatusBadRequest, err
+	}
+	var pages []entity.TemplatePages
+	for _, page := range requestPages {
+		pageID, err := primitive.ObjectIDFromHex(page.PageID)
+		if err != nil {
+			return nil, nil, nil, http.StatusBadRequest, errors.New(common.MessageErrorInvalidPageID + ": " + page.PageID)
+		}
+		pages = append(pages, entity.TemplatePages{
+			PageID: pageID,
+		})
+	}
+	var menus []entity.TemplateMenu
+	for _, menu := range requestMenu {
+		pageID, err := primitive.ObjectIDFromHex(menu.PageID)
+		if err != nil {
+			return nil, nil, nil, http.StatusBadRequest, errors.New(common.MessageErrorInvalidPageID + ": " + menu.PageID)
+		}
+		if language, ok := otherLanguage(menu.Title, languages); ok {
+			return nil, nil, nil, http.StatusBadRequest, errors.New(common.MessageErrorMenuLanguage + ": " + language)
+		}
+		menus = append(menus, entity.TemplateMenu{
+			Title:  menu.Title,
+			PageID: pageID,
+		})
+	}
+
+	if code, err := s.checkPagesInScope(scope, pages, menus); err != nil {
+		return nil, nil, nil, code, err
+	}
+	if code, err := s.checkHomePage(pages); err != nil {
+		return nil, nil, nil, code, err
+	}
+	return languages, pages, menus, http.StatusOK, nil
+}
+
+func parseLanguages(languages []string) ([]string, error) {
+	canonical := make([]string, 0, len(languages))
+	seen := map[string]bool{}
+	for _, language := range languages {
+		tag, err := translation.ParseLanguage(language)
+		if err != nil {
+			return nil, errors.New(common.MessageErrorInvalidLanguage + ": " + err.Error())
+		}
+		if seen[tag] {
+			return nil, errors.New(common.MessageErrorDuplicateLanguage + ": " + tag)
+		}
+		seen[tag] = true
+		canonical = append(canonical, tag)
+	}
+
+	return canonical, nil
+}
+
+// otherLanguage a language the title is written in that is not one of the languages, titles of templates
+// without languages can be in any language
+func otherLanguage(title translation.LocalizedString, languages []string) (string, bool) {
+	if len(languages) == 0 {
+		return "", false
+	}
+	allowed := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		allowed[language] = true
+	}
+	for language := range title.Texts() {
+		if !allowed[language] {
+			return language, true
+		}
+	}
+
+	return "", false
+}
+
+// checkHomePage a template is rendered from its home page, one of its pages must be of type home
+func (s *Service) checkHomePage(pages []entity.TemplatePages) (int, error) {
+	pageIDs := make([]primitive.ObjectID, 0, len(pages))
+	for _, page := range pages {
+		pageIDs = append(pageIDs, page.PageID)
+	}
+
+	count, err := s.repo.CountWebPagesOfType(pageIDs, entity.WebPageTypeHome)
+	if err != nil {
+		logger.LogError("Error counting template home pages: " + err.Error())
+		return http.StatusInternalServerError, err
+	}
+	if count == 0 {
+		return http.StatusBadRequest, errors.New(common.MessageErrorTemplateWithoutHome)
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,127 @@
+package template
+
+import (
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// templatePages the pages a new template may reference, the templates created are recorded
+type templatePages struct {
+	Repository
+	pages   map[primitive.ObjectID]entity.WebPage
+	created []entity.Template
+}
+
+func (r *templatePages) CountWebPagesInScope(pageIDs []primitive.ObjectID, scope *entity.AccessScope) (int64, error) {
+	var count int64
+	for _, id := range pageIDs {
+		if page, ok := r.pages[id]; ok && (page.OrganizationID.IsZero() || scope.CanAccess(page.OrganizationID)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *templatePages) CountWebPagesOfType(pageIDs []primitive.ObjectID, pageType string) (int64, error) {
+	var count int64
+	for _, id := range pageIDs {
+		if page, ok := r.pages[id]; ok && page.Type == pageType {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *templatePages) CreateTemplate(template *entity.Template) (*entity.Template, error) {
+	template.ID = primitive.NewObjectID()
+	r.created = append(r.created, *template)
+	return template, nil
+}
+
+func TestCreateTemplateValidation(t *testing.T) {
+	orgID := primitive.NewObjectID()
+	scope := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}}
+	newPage := func(pageOrgID primitive.ObjectID, pageType string) entity.WebPage {
+		page := entity.WebPage{}
+		page.ID = primitive.NewObjectID()
+		page.OrganizationID = pageOrgID
+		page.Type = pageType
+		return page
+	}
+	home, story, other := newPage(orgID, entity.WebPageTypeHome), newPage(primitive.NilObjectID, "story"), newPage(primitive.NewObjectID(), "story")
+	repo := &templatePages{pages: map[primitive.ObjectID]entity.WebPage{home.ID: home, story.ID: story, other.ID: other}}
+	service := NewService(repo)
+
+	french, _ := translation.NewLocalizedString(map[string]string{"fr": "Accueil"})
+	pages := func(ids ...primitive.ObjectID) []request.TemplatePagesRequest {
+		var requests []request.TemplatePagesRequest
+		for _, id := range ids {
+			requests = append(requests, request.TemplatePagesRequest{PageID: id.Hex()})
+		}
+		return requests
+	}
+	tests := []struct {
+		name    string
+		request request.CreateTemplateRequest
+		code    int
+		message string
+	}{
+		{
+			name:    "valid",
+			request: request.CreateTemplateRequest{Languages: []string{"en", "fr"}, Pages: pages(home.ID, story.ID), Menu: []request.TemplateMenuRequest{{Title: french, PageID: home.ID.Hex()}}},
+			code:    http.StatusOK,
+		},
+		{
+			name:    "invalid page id",
+			request: request.CreateTemplateRequest{Pages: []request.TemplatePagesRequest{{PageID: "home"}}},
+			code:    http.StatusBadRequest,
+			message: common.MessageErrorInvalidPageID + ": home",
+		},
+		{
+			name:    "missing webpage",
+			request: request.CreateTemplateRequest{Pages: pages(home.ID, primitive.NewObjectID())},
+			code:    http.StatusBadRequest,
+			message: common.MessageErrorWebPageNotFound,
+		},
+		{
+			name:    "webpage of another organization",
+			request: request.CreateTemplateRequest{Pages: pages(home.ID), Menu: []request.TemplateMenuRequest{{PageID: other.ID.Hex()}}},
+			code:    http.StatusBadRequest,
+			message: common.MessageErrorWebPageNotFound,
+		},
+		{
+			name:    "without home page",
+			request: request.CreateTemplateRequest{Pages: pages(story.ID)},
+			code:    http.StatusBadRequest,
+			message: common.MessageErrorTemplateWithoutHome,
+		},
+		{
+			name:    "duplicate language",
+			request: request.CreateTemplateRequest{Languages: []string{"en", "EN"}, Pages: pages(home.ID)},
+			code:    http.StatusBadRequest,
+			message: common.MessageErrorDuplicateLanguage + ": en",
+		},
+		{
+			name:    "menu title in another language",
+			request: request.CreateTemplateRequest{Languages: []string{"en"}, Pages: pages(home.ID), Menu: []request.TemplateMenuRequest{{Title: french, PageID: home.ID.Hex()}}},
+			code:    http.StatusBadRequest,
+			message: common.MessageErrorMenuLanguage + ": fr",
+		},
+	}
+	for _, test := range tests {
+		_, code, err := service.CreateTemplate(scope, orgID, &test.request)
+		if code != test.code || (test.message != "" && (err == nil || err.Error() != test.message)) {
+			t.Errorf("%s: CreateTemplate() = %d, %v, want %d %q", test.name, code, err, test.code, test.message)
+		}
+	}
+	if len(repo.created) != 1 {
+		t.Errorf("created %d templates, want only the valid one", len(repo.created))
+	}
+}
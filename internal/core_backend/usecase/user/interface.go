@@ -1,7 +1,10 @@
 package user
 
 import (
+	"time"
+
 	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/entity"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,10 +18,30 @@ type User interface {
 	GetUserByEmail(email *string) (*entity.User, error)
 	GetUserByID(userID *string) (*entity.User, error)
 	UpsertUser(*entity.User) error
-	UpdateRole(role *string, userID *string) (bool, error)
 	UpdateOrgID(orgID *primitive.ObjectID, userID *string) (bool, error)
 	UpdateUserDetails(*string, *request.UpdateUserDetailsRequest) (bool, error)
 	GetUserWithNoWallet() (*[]entity.User, error)
+	CreateSiweNonce(*entity.SiweNonce) error
+	ConsumeSiweNonce(userID *string, nonce *string) (*entity.SiweNonce, error)
+	GetUserByLinkedWallet(address *string) (*entity.User, error)
+	AddLinkedWallet(userID *string, wallet *entity.LinkedWallet) (bool, error)
+	RemoveLinkedWallet(userID *string, address *string) (bool, error)
+	SetMintWalletAddress(userID *string, address *string) (bool, error)
+	GetClaimedItems(userID *string) (*[]entity.ClaimedItem, error)
+	GetUserRoleBindings(userID *string) (*[]entity.RoleBinding, error)
+	GetUserWalletProvisionings(userID *string) (*[]entity.WalletProvisioning, error)
+	GetUserLikes(userID *string) (*[]entity.ProductItemLike, error)
+	GetUserClaimEvents(userID *string) (*[]entity.ClaimEvent, error)
+	CountOwnedOrganizations(userID *string) (int64, error)
+	ReleaseClaims(userID *string, orgID primitive.ObjectID) (int64, error)
+	ReassignClaims(userID *string, orgID primitive.ObjectID, ownerID *string) (int64, error)
+	AnonymizeUser(userID *string, at time.Time) (bool, error)
+	DeleteUserRecords(userID *string) error
+	SearchUsers(filter *entity.UserFilter, orgIDs []primitive.ObjectID, paging *common.Paging) (*[]entity.ManagedUser, int64, error)
+	SetUserStatus(userID *string, status string) (bool, error)
+	SetUserClaims(user *entity.User) (bool, error)
+	GetCollection(userID *string, paging *common.Paging) (*[]entity.CollectionItem, int64, error)
+	GetCollectionItem(userID *string, productItemID primitive.ObjectID) (*entity.CollectionItem, error)
 }
 
 // Repository interface
@@ -34,8 +57,20 @@ type UseCase interface {
 	GetUserByID(userID *string) (*entity.User, int, error)
 	GetUserByEmail(email *string) (*entity.User, int, error)
 	UpsertUserFromFireBase(*string) (*entity.User, int, error)
-	UpdateRole(*request.UpdateRoleRequest) (bool, int, error)
 	UpdateOrgID(*request.UpdateOrgRequest) (bool, int, error)
 	UpdateUserDetails(*string, *request.UpdateUserDetailsRequest) (bool, int, error)
 	GetUserWithNoWallet() (*[]entity.User, int, error)
+	IssueWalletNonce(userID *string) (*entity.SiweNonce, int, error)
+	LinkWallet(userID *string, req *request.LinkWalletRequest) (*entity.User, int, error)
+	UnlinkWallet(userID *string, address *string) (bool, int, error)
+	SetMintWallet(userID *string, address *string) (bool, int, error)
+	ExportUserData(userID *string) (*entity.UserDataExport, int, error)
+	DeleteAccount(userID *string) (*entity.AccountDeletion, int, error)
+	SearchUsers(scope *entity.AccessScope, filter *entity.UserFilter, paging *common.Paging) (*entity.UserPage, int, error)
+	InviteUser(req *request.InviteUserRequest, org *entity.Organization) (*entity.UserInvitation, int, error)
+	SetUserActive(scope *entity.AccessScope, userID *string, active bool) (*entity.User, int, error)
+	IsUserActive(userID *string) (bool, int, error)
+	SyncUserClaims(userID *string) (*entity.User, int, error)
+	GetCollection(userID *string, paging *common.Paging) (*entity.CollectionPage, int, error)
+	GetCollectionItem(userID *string, productItemID *string) (*entity.CollectionItem, int, error)
 }
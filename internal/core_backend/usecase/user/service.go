@@ -2,16 +2,21 @@ package user
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	config "backend-service/config/core_backend"
 	"backend-service/internal/core_backend/api/handler/request"
 	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/common/helper"
 	"backend-service/internal/core_backend/entity"
-	"backend-service/internal/core_backend/infrastructure/firebase"
+	"backend-service/internal/core_backend/infrastructure/identity"
+	"backend-service/internal/core_backend/infrastructure/siwe"
 	"backend-service/internal/core_backend/usecase/organization"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,23 +25,26 @@ import (
 // Service struct
 type Service struct {
 	repo     Repository
-	firebase *firebase.FirebaseClient
+	identity identity.Provider
 	orgRepo  organization.Repository
+	// claimPolicy what happens to the claims of deleted accounts, release or reassign
+	claimPolicy string
 }
 
 // NewService create service
-func NewService(fb *firebase.FirebaseClient, r Repository, or organization.Repository) *Service {
+func NewService(ip identity.Provider, r Repository, or organization.Repository) *Service {
 	return &Service{
-		repo:     r,
-		firebase: fb,
-		orgRepo:  or,
+		repo:        r,
+		identity:    ip,
+		orgRepo:     or,
+		claimPolicy: config.C.Account.DELETION_CLAIM_POLICY,
 	}
 }
 
 // CreateUser
 func (s *Service) RegisterUser(request *request.CreateUserRequest) (*entity.User, int, error) {
 	tokenStringArr := strings.Split(request.Token, " ")
-	tokenInfo, err := s.firebase.VerifyToken(tokenStringArr[1])
+	tokenInfo, err := s.identity.VerifyToken(tokenStringArr[1])
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -84,7 +92,7 @@ func (s *Service) GetUserByEmail(email *string) (*entity.User, int, error) {
 
 func (s *Service) TokenToUser(token *string) (*entity.User, int, error) {
 	tokenStringArr := strings.Split(*token, " ")
-	tokenInfo, err := s.firebase.VerifyToken(tokenStringArr[1])
+	tokenInfo, err := s.identity.VerifyToken(tokenStringArr[1])
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -109,7 +117,7 @@ func (s *Service) TokenToUser(token *string) (*entity.User, int, error) {
 }
 
 func (s *Service) UpsertUserFromFireBase(userID *string) (*entity.User, int, error) {
-	fbUser, err := s.firebase.GetFirebaseUser(*userID)
+	fbUser, err := s.identity.GetUser(*userID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -128,30 +136,21 @@ func (s *Service) UpsertUserFromFireBase(userID *string) (*entity.User, int, err
 		}
 	}
 
-	err = s.repo.UpsertUser(&newUser)
+	// Importing the account again does not reactivate a deactivated user
+	stored, err := s.repo.GetUserByID(userID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
-
-	return &newUser, http.StatusOK, nil
-}
-
-func (s *Service) UpdateRole(req *request.UpdateRoleRequest) (bool, int, error) {
-	ok, err := s.repo.UpdateRole(&req.NewRole, &req.UserID)
-	if err != nil || !ok {
-		return false, http.StatusInternalServerError, err
+	if stored != nil && stored.Status == common.StatusInactive {
+		newUser.Status = stored.Status
 	}
 
-	user, err := s.repo.GetUserByID(&req.UserID)
-	if err != nil {
-		return false, http.StatusInternalServerError, err
-	}
-	err = s.firebase.UpdateUserDetails(user)
+	err = s.repo.UpsertUser(&newUser)
 	if err != nil {
-		return false, http.StatusInternalServerError, err
+		return nil, http.StatusInternalServerError, err
 	}
 
-	return true, http.StatusOK, err
+	return &newUser, http.StatusOK, nil
 }
 
 func (s *Service) UpdateOrgID(req *request.UpdateOrgRequest) (bool, int, error) {
@@ -167,7 +166,7 @@ func (s *Service) UpdateOrgID(req *request.UpdateOrgRequest) (bool, int, error)
 
 	user.Organization = req.NewOrgTagName
 
-	err = s.firebase.UpdateUserDetails(user)
+	err = s.identity.UpdateUserClaims(user)
 	if err != nil {
 		return false, http.StatusInternalServerError, err
 	}
@@ -181,8 +180,8 @@ func (s *Service) UpdateUserDetails(userID *string, req *request.UpdateUserDetai
 		return false, http.StatusInternalServerError, err
 	}
 	if user == nil {
-		// Get user from Firebase
-		fbUser, err := s.firebase.GetFirebaseUser(*userID)
+		// Get user from the identity provider
+		fbUser, err := s.identity.GetUser(*userID)
 		if err != nil {
 			return false, http.StatusInternalServerError, err
 		}
@@ -222,3 +221,466 @@ func (s *Service) GetUserWithNoWallet() (*[]entity.User, int, error) {
 	}
 	return usersNoWallet, http.StatusOK, nil
 }
+
+// IssueWalletNonce issues the nonce the user has to sign in a SIWE message to link a wallet
+func (s *Service) IssueWalletNonce(userID *string) (*entity.SiweNonce, int, error) {
+	if config.C.Siwe.DOMAIN == "" || config.C.Siwe.URI == "" || len(config.C.Siwe.CHAIN_IDS) == 0 {
+		return nil, http.StatusInternalServerError, errors.New(common.MessageErrorSiweNotConfigured)
+	}
+
+	nonce, err := siwe.GenerateNonce()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	siweNonce := entity.SiweNonce{
+		Nonce:     nonce,
+		UserID:    *userID,
+		ExpiresAt: time.Now().Add(time.Duration(config.C.Siwe.NONCE_TTL_IN_SECOND) * time.Second),
+		CreatedAt: time.Now(),
+	}
+	if err = s.repo.CreateSiweNonce(&siweNonce); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &siweNonce, http.StatusOK, nil
+}
+
+// LinkWallet verifies the signed SIWE message and links its address to the user
+func (s *Service) LinkWallet(userID *string, req *request.LinkWalletRequest) (*entity.User, int, error) {
+	if config.C.Siwe.DOMAIN == "" || config.C.Siwe.URI == "" || len(config.C.Siwe.CHAIN_IDS) == 0 {
+		return nil, http.StatusInternalServerError, errors.New(common.MessageErrorSiweNotConfigured)
+	}
+
+	message, err := siwe.ParseMessage(req.Message)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err = message.Validate(siwe.Expected{Domain: config.C.Siwe.DOMAIN, URI: config.C.Siwe.URI, ChainIDs: config.C.Siwe.CHAIN_IDS}, time.Now()); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err = siwe.VerifySignature(req.Message, message, req.Signature); err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	// The nonce is consumed even if linking fails below, a signed message can only be used once
+	nonce, err := s.repo.ConsumeSiweNonce(userID, &message.Nonce)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if nonce == nil || time.Now().After(nonce.ExpiresAt) {
+		return nil, http.StatusBadRequest, errors.New(common.MessageErrorInvalidSiweNonce)
+	}
+
+	user, code, err := s.getOrCreateUser(userID)
+	if err != nil {
+		return nil, code, err
+	}
+
+	address := message.Address.Hex()
+	owner, err := s.repo.GetUserByLinkedWallet(&address)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if owner != nil && owner.ID != user.ID {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorWalletLinkedToOtherUser)
+	}
+	if owner == nil {
+		wallet := entity.LinkedWallet{Address: address, ChainID: message.ChainID, LinkedAt: time.Now()}
+		// The unique index on the linked addresses refuses a wallet linked to another user meanwhile
+		if _, err = s.repo.AddLinkedWallet(&user.ID, &wallet); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, http.StatusConflict, errors.New(common.MessageErrorWalletLinkedToOtherUser)
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+		user.LinkedWallets = append(user.LinkedWallets, wallet)
+	}
+
+	return user, http.StatusOK, nil
+}
+
+// UnlinkWallet removes a linked wallet, claimed items are minted to the custodial wallet again if it was chosen
+func (s *Service) UnlinkWallet(userID *string, address *string) (bool, int, error) {
+	checksum := gethcommon.HexToAddress(*address).Hex()
+	ok, err := s.repo.RemoveLinkedWallet(userID, &checksum)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+	if !ok {
+		return false, http.StatusNotFound, errors.New(common.MessageErrorWalletNotLinked)
+	}
+
+	return true, http.StatusOK, nil
+}
+
+// SetMintWallet chooses which of the user's wallets claimed items are minted to
+func (s *Service) SetMintWallet(userID *string, address *string) (bool, int, error) {
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+	if user == nil || !user.HasWallet(*address) {
+		return false, http.StatusBadRequest, errors.New(common.MessageErrorWalletNotLinked)
+	}
+
+	checksum := gethcommon.HexToAddress(*address).Hex()
+	ok, err := s.repo.SetMintWalletAddress(userID, &checksum)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+
+	return ok, http.StatusOK, nil
+}
+
+// ExportUserData everything stored about the user: profile and wallet addresses, claimed items and their ownership history,
+// likes, roles and wallet requests
+func (s *Service) ExportUserData(userID *string) (*entity.UserDataExport, int, error) {
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if user == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorNotFoundUser)
+	}
+
+	claims, err := s.repo.GetClaimedItems(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	bindings, err := s.repo.GetUserRoleBindings(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	provisionings, err := s.repo.GetUserWalletProvisionings(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	events, err := s.repo.GetUserClaimEvents(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	likes, err := s.repo.GetUserLikes(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &entity.UserDataExport{
+		ExportedAt:          time.Now(),
+		Profile:             *user,
+		ClaimedItems:        *claims,
+		OwnershipHistory:    *events,
+		Likes:               *likes,
+		RoleBindings:        *bindings,
+		WalletProvisionings: *provisionings,
+	}, http.StatusOK, nil
+}
+
+// DeleteAccount erases the personal data of the user, hands their claims over following the claim policy and
+// deletes the account of the identity provider. Every step can be run again and the user stays active until the identity
+// account is deleted, so a failed deletion is retried by calling it again.
+func (s *Service) DeleteAccount(userID *string) (*entity.AccountDeletion, int, error) {
+	owned, err := s.repo.CountOwnedOrganizations(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if owned > 0 {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorAccountOwnsOrganization)
+	}
+
+	deletion := &entity.AccountDeletion{UserID: *userID, ClaimPolicy: common.ClaimPolicyRelease, DeletedAt: time.Now()}
+	if s.claimPolicy == common.ClaimPolicyReassign {
+		deletion.ClaimPolicy = common.ClaimPolicyReassign
+	}
+	if code, err := s.handOverClaims(userID, deletion); err != nil {
+		return nil, code, err
+	}
+
+	if err = s.repo.DeleteUserRecords(userID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	// The identity account goes first: once the user is anonymized their tokens are refused and they could not retry
+	if err = s.identity.DeleteUser(*userID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if _, err = s.repo.AnonymizeUser(userID, deletion.DeletedAt); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return deletion, http.StatusOK, nil
+}
+
+// handOverClaims releases the claims of the user, or reassigns them to the owner of each organization under the reassign policy
+func (s *Service) handOverClaims(userID *string, deletion *entity.AccountDeletion) (int, error) {
+	claims, err := s.repo.GetClaimedItems(userID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	handled := map[primitive.ObjectID]bool{}
+	for _, claim := range *claims {
+		if handled[claim.OrganizationID] {
+			continue
+		}
+		handled[claim.OrganizationID] = true
+
+		owner, err := s.claimsOwner(claim.OrganizationID, deletion.ClaimPolicy)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if owner != "" && owner != *userID {
+			n, err := s.repo.ReassignClaims(userID, claim.OrganizationID, &owner)
+			if err != nil {
+				return http.StatusInternalServerError, err
+			}
+			deletion.ReassignedClaims += n
+			continue
+		}
+		n, err := s.repo.ReleaseClaims(userID, claim.OrganizationID)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		deletion.ReleasedClaims += n
+	}
+
+	return http.StatusOK, nil
+}
+
+// claimsOwner the user the claims in the organization are reassigned to, none when they are released
+func (s *Service) claimsOwner(orgID primitive.ObjectID, policy string) (string, error) {
+	if policy != common.ClaimPolicyReassign || orgID.IsZero() {
+		return "", nil
+	}
+	hex := orgID.Hex()
+	org, err := s.orgRepo.GetDetailOrganization(&hex)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return org.OwnerID, nil
+}
+
+// getOrCreateUser returns the stored user, importing it from the identity provider on its first call
+func (s *Service) getOrCreateUser(userID *string) (*entity.User, int, error) {
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if user != nil {
+		return user, http.StatusOK, nil
+	}
+
+	return s.UpsertUserFromFireBase(userID)
+}
+
+// SearchUsers a page of the users matching the filter, among the users of the organizations in scope
+func (s *Service) SearchUsers(scope *entity.AccessScope, filter *entity.UserFilter, paging *common.Paging) (*entity.UserPage, int, error) {
+	paging.Fullfill()
+	var orgIDs []primitive.ObjectID
+	if !scope.AllOrganizations {
+		orgIDs = append([]primitive.ObjectID{}, scope.OrganizationIDs...)
+	}
+
+	users, total, err := s.repo.SearchUsers(filter, orgIDs, paging)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &entity.UserPage{Users: *users, Page: paging.Page, Limit: paging.Limit, Total: total}, http.StatusOK, nil
+}
+
+// InviteUser creates the account of the email when there is none and makes the user a member of the organization.
+// The role is bound by the role service, the claims are synced afterwards.
+func (s *Service) InviteUser(req *request.InviteUserRequest, org *entity.Organization) (*entity.UserInvitation, int, error) {
+	account, link, err := s.identity.InviteUser(req.Email, req.Name)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	user, err := s.repo.GetUserByID(&account.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if user != nil && user.Status == common.StatusInactive {
+		return nil, http.StatusConflict, errors.New(common.MessageErrorUserDeactivated)
+	}
+	if user == nil {
+		newUser := entity.User{}.NewUserByFsUser(account)
+		newUser.OrganizationID = org.ID
+		newUser.Organization = org.NameTag
+		if err = s.repo.UpsertUser(&newUser); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		user = &newUser
+	}
+
+	return &entity.UserInvitation{User: *user, InviteLink: link}, http.StatusOK, nil
+}
+
+// SetUserActive deactivates, or reactivates, a user of the organizations in scope. The status holds in every
+// organization, so users of organizations out of scope or bound to a role in every organization can only be
+// managed by admins of every organization.
+func (s *Service) SetUserActive(scope *entity.AccessScope, userID *string, active bool) (*entity.User, int, error) {
+	if !active && scope.UserID == *userID {
+		return nil, http.StatusBadRequest, errors.New(common.MessageErrorDeactivateSelf)
+	}
+
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if user == nil || user.Status == common.StatusDeleted {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorNotFoundUser)
+	}
+	if !scope.AllOrganizations {
+		bindings, err := s.repo.GetUserRoleBindings(userID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		managed := entity.ManagedUser{User: *user, RoleBindings: *bindings}
+		if !managed.InOrganizations(scope.OrganizationIDs) {
+			return nil, http.StatusNotFound, errors.New(common.MessageErrorNotFoundUser)
+		}
+		if managed.HasGlobalBinding() || user.Role == string(entity.SUPER_ADMIN_ROLE) {
+			return nil, http.StatusForbidden, errors.New(common.MessageErrorForbidden + ": only global admins can manage global admins")
+		}
+		if !managed.OnlyInOrganizations(scope.OrganizationIDs) {
+			return nil, http.StatusForbidden, errors.New(common.MessageErrorForbidden + ": the user also belongs to organizations you do not manage")
+		}
+	}
+
+	// The status is checked by the authenticators, so the tokens of the user are refused from now on
+	user.Status = common.StatusActive
+	if !active {
+		user.Status = common.StatusInactive
+	}
+	if _, err = s.repo.SetUserStatus(userID, user.Status); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err = s.identity.SetUserDisabled(*userID, !active); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return user, http.StatusOK, nil
+}
+
+// IsUserActive reports whether the tokens of the user are accepted, users not imported yet are active
+func (s *Service) IsUserActive(userID *string) (bool, int, error) {
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		return false, http.StatusInternalServerError, err
+	}
+	if user == nil {
+		return true, http.StatusOK, nil
+	}
+
+	return user.Status != common.StatusInactive && user.Status != common.StatusDeleted, http.StatusOK, nil
+}
+
+// SyncUserClaims sets the role and organization claims of the user from the role bindings:
+// SUPER_ADMIN when it is bound for every organization, ORG_ADMIN and its organization when it is bound in one, no role otherwise
+func (s *Service) SyncUserClaims(userID *string) (*entity.User, int, error) {
+	user, code, err := s.getOrCreateUser(userID)
+	if err != nil {
+		return nil, code, err
+	}
+	if user.Status == common.StatusDeleted {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorNotFoundUser)
+	}
+	bindings, err := s.repo.GetUserRoleBindings(userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	role, orgID := "", primitive.NilObjectID
+	for _, binding := range *bindings {
+		if binding.RoleName == string(entity.SUPER_ADMIN_ROLE) && binding.OrganizationID.IsZero() {
+			role, orgID = binding.RoleName, primitive.NilObjectID
+			break
+		}
+		if binding.RoleName == string(entity.ORG_ADMIN_ROLE) && role == "" {
+			role, orgID = binding.RoleName, binding.OrganizationID
+		}
+	}
+	user.Role = role
+	if !orgID.IsZero() {
+		hex := orgID.Hex()
+		org, err := s.orgRepo.GetDetailOrganization(&hex)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, http.StatusInternalServerError, err
+		}
+		if org == nil {
+			user.Role = ""
+		} else {
+			user.OrganizationID = org.ID
+			user.Organization = org.NameTag
+		}
+	}
+
+	if _, err = s.repo.SetUserClaims(user); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err = s.identity.UpdateUserClaims(user); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return user, http.StatusOK, nil
+}
+
+// GetCollection a page of the items the user claimed, with their story link and NFT status
+func (s *Service) GetCollection(userID *string, paging *common.Paging) (*entity.CollectionPage, int, error) {
+	paging.Fullfill()
+	items, total, err := s.repo.GetCollection(userID, paging)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for i := range *items {
+		completeCollectionItem(&(*items)[i])
+	}
+
+	return &entity.CollectionPage{Items: *items, Page: paging.Page, Limit: paging.Limit, Total: total}, http.StatusOK, nil
+}
+
+// GetCollectionItem the item of the user's collection, not found when the user does not own it
+func (s *Service) GetCollectionItem(userID *string, productItemID *string) (*entity.CollectionItem, int, error) {
+	pItemID, err := primitive.ObjectIDFromHex(*productItemID)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	item, err := s.repo.GetCollectionItem(userID, pItemID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if item == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorCollectionItemNotFound)
+	}
+	completeCollectionItem(item)
+
+	return item, http.StatusOK, nil
+}
+
+// completeCollectionItem sets the story link, the one a scan of the tag redirects to, and the NFT status of the item
+func completeCollectionItem(item *entity.CollectionItem) {
+	lang := item.StoryLanguage
+	if lang == "" {
+		lang = "vi"
+	}
+	// Items of a deleted organization have no story to link to
+	if item.Organization.NameTag != "" {
+		item.StoryURL = fmt.Sprintf("%s/%s/%s/%s", config.C.Domains.WebpageDomain, lang, item.Organization.NameTag, item.TagID)
+	}
+
+	switch {
+	case item.NFT == nil:
+		item.NFTStatus = common.NFTStatusNotMinted
+	case item.NFT.Status == common.StatusTxSuccess:
+		item.NFTStatus = common.NFTStatusMinted
+	case item.NFT.Status == common.StatusTxFailure:
+		item.NFTStatus = common.NFTStatusFailed
+	default:
+		item.NFTStatus = common.NFTStatusPending
+	}
+}
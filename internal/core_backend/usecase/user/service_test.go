@@ -0,0 +1,521 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	config "backend-service/config/core_backend"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/identity"
+)
+
+// userRecords the records an account owns, what its export, deletion, deactivation and collection read and change
+type userRecords struct {
+	Repository
+	users    map[string]*entity.User
+	claims   []entity.Mapping
+	owners   map[string]int64
+	bindings map[string][]entity.RoleBinding
+	// assets the token minted for the item of a tag
+	assets map[string]*entity.CollectionAsset
+	// orgTags the tag names the collection joins to the organizations of the claims
+	orgTags map[primitive.ObjectID]string
+	likes   []entity.ProductItemLike
+	events  []entity.ClaimEvent
+}
+
+func (r *userRecords) GetUserByID(userID *string) (*entity.User, error) {
+	return r.users[*userID], nil
+}
+
+func (r *userRecords) UpsertUser(user *entity.User) error {
+	stored := *user
+	r.users[user.ID] = &stored
+	return nil
+}
+
+func (r *userRecords) CountOwnedOrganizations(userID *string) (int64, error) {
+	return r.owners[*userID], nil
+}
+
+func (r *userRecords) GetClaimedItems(userID *string) (*[]entity.ClaimedItem, error) {
+	items := []entity.ClaimedItem{}
+	for _, m := range r.claims {
+		if m.OwnerID == *userID {
+			items = append(items, entity.ClaimedItem{TagID: m.TagID, OrganizationID: m.OrganizationID})
+		}
+	}
+	return &items, nil
+}
+
+func (r *userRecords) ReleaseClaims(userID *string, orgID primitive.ObjectID) (int64, error) {
+	return r.ReassignClaims(userID, orgID, new(string))
+}
+
+func (r *userRecords) ReassignClaims(userID *string, orgID primitive.ObjectID, ownerID *string) (int64, error) {
+	var n int64
+	for i, m := range r.claims {
+		if m.OwnerID == *userID && m.OrganizationID == orgID {
+			r.claims[i].OwnerID = *ownerID
+			if *ownerID == "" {
+				r.claims[i].ClaimState = entity.ClaimStateClaimable
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *userRecords) AnonymizeUser(userID *string, at time.Time) (bool, error) {
+	user, ok := r.users[*userID]
+	if !ok {
+		return false, nil
+	}
+	*user = entity.User{ID: user.ID, Status: common.StatusDeleted, DeletedAt: &at}
+	return true, nil
+}
+
+func (r *userRecords) DeleteUserRecords(userID *string) error {
+	delete(r.bindings, *userID)
+	likes := r.likes[:0]
+	for _, like := range r.likes {
+		if like.UserID != *userID {
+			likes = append(likes, like)
+		}
+	}
+	r.likes = likes
+	return nil
+}
+
+func (r *userRecords) GetUserWalletProvisionings(userID *string) (*[]entity.WalletProvisioning, error) {
+	return &[]entity.WalletProvisioning{}, nil
+}
+
+func (r *userRecords) GetUserLikes(userID *string) (*[]entity.ProductItemLike, error) {
+	likes := []entity.ProductItemLike{}
+	for _, like := range r.likes {
+		if like.UserID == *userID {
+			likes = append(likes, like)
+		}
+	}
+	return &likes, nil
+}
+
+func (r *userRecords) GetUserClaimEvents(userID *string) (*[]entity.ClaimEvent, error) {
+	events := []entity.ClaimEvent{}
+	for _, event := range r.events {
+		if event.OwnerID == *userID || event.PreviousOwnerID == *userID || event.ActorID == *userID {
+			events = append(events, event)
+		}
+	}
+	return &events, nil
+}
+
+func (r *userRecords) GetUserRoleBindings(userID *string) (*[]entity.RoleBinding, error) {
+	bindings := append([]entity.RoleBinding{}, r.bindings[*userID]...)
+	return &bindings, nil
+}
+
+func (r *userRecords) SetUserStatus(userID *string, status string) (bool, error) {
+	user, ok := r.users[*userID]
+	if ok {
+		user.Status = status
+	}
+	return ok, nil
+}
+
+func (r *userRecords) SetUserClaims(user *entity.User) (bool, error) {
+	stored, ok := r.users[user.ID]
+	if ok {
+		stored.Role, stored.Organization, stored.OrganizationID = user.Role, user.Organization, user.OrganizationID
+	}
+	return ok, nil
+}
+
+func (r *userRecords) GetCollection(userID *string, paging *common.Paging) (*[]entity.CollectionItem, int64, error) {
+	items := []entity.CollectionItem{}
+	for _, m := range r.claims {
+		if m.OwnerID == *userID {
+			items = append(items, entity.CollectionItem{
+				TagID:         m.TagID,
+				ProductItemID: m.ProductItemID,
+				Organization:  entity.CollectionOrganization{ID: m.OrganizationID, NameTag: r.orgTags[m.OrganizationID]},
+				NFT:           r.assets[m.TagID],
+			})
+		}
+	}
+	total := int64(len(items))
+	start := (paging.Page - 1) * paging.Limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + paging.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	items = items[start:end]
+	return &items, total, nil
+}
+
+func (r *userRecords) GetCollectionItem(userID *string, productItemID primitive.ObjectID) (*entity.CollectionItem, error) {
+	items, _, _ := r.GetCollection(userID, &common.Paging{Page: 1, Limit: len(r.claims)})
+	for _, item := range *items {
+		if item.ProductItemID == productItemID {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
+// organizations resolves organizations by ID and tag name
+type organizations struct {
+	organizationRepository
+	orgs map[string]*entity.Organization
+}
+
+type organizationRepository interface {
+	CreateOrganization(*entity.Organization) (*entity.Organization, error)
+	UpdateOrganization(*entity.Organization) (bool, error)
+	GetAllOrganizations() (*[]entity.Organization, error)
+}
+
+func (o *organizations) GetOrgByTagName(tagName *string) (*entity.Organization, error) {
+	for _, org := range o.orgs {
+		if org.NameTag == *tagName {
+			return org, nil
+		}
+	}
+	return nil, nil
+}
+
+func (o *organizations) GetDetailOrganization(orgID *string) (*entity.Organization, error) {
+	if org, ok := o.orgs[*orgID]; ok {
+		return org, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+type userFixture struct {
+	service  *Service
+	repo     *userRecords
+	provider *identity.LocalProvider
+	token    string
+	ownedOrg primitive.ObjectID
+	freeOrg  primitive.ObjectID
+}
+
+func newUserFixture(t *testing.T, policy string) *userFixture {
+	t.Helper()
+	provider, err := identity.NewLocalProvider(identity.LocalConfig{Secret: []byte("0123456789abcdef0123456789abcdef")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _ := provider.MintToken(identity.Token{UserID: "user-1", Email: "a@example.com"}, time.Hour)
+	if _, err = provider.VerifyToken(token); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &userFixture{provider: provider, token: token, ownedOrg: primitive.NewObjectID(), freeOrg: primitive.NewObjectID()}
+	f.repo = &userRecords{
+		users: map[string]*entity.User{"user-1": {ID: "user-1", Email: "a@example.com", Name: "A", WalletAddress: "0xabc"}},
+		claims: []entity.Mapping{
+			{TagID: "tag-1", OrganizationID: f.ownedOrg, OwnerID: "user-1"},
+			{TagID: "tag-2", OrganizationID: f.ownedOrg, OwnerID: "user-1"},
+			{TagID: "tag-3", OrganizationID: f.freeOrg, OwnerID: "user-1"},
+			{TagID: "tag-4", OrganizationID: f.ownedOrg, OwnerID: "user-2"},
+		},
+		owners:   map[string]int64{"org-owner": 1},
+		bindings: map[string][]entity.RoleBinding{},
+	}
+	orgs := &organizations{orgs: map[string]*entity.Organization{
+		f.ownedOrg.Hex(): {BaseModel: entity.BaseModel{ID: f.ownedOrg}, NameTag: "owned", OwnerID: "org-owner"},
+		f.freeOrg.Hex():  {BaseModel: entity.BaseModel{ID: f.freeOrg}, NameTag: "free"},
+	}}
+	f.repo.bindings["user-1"] = []entity.RoleBinding{{UserID: "user-1", OrganizationID: f.ownedOrg, RoleName: string(entity.ORG_ADMIN_ROLE)}}
+	f.service = NewService(provider, f.repo, orgs)
+	f.service.claimPolicy = policy
+	return f
+}
+
+func (f *userFixture) owners() map[string]string {
+	owners := map[string]string{}
+	for _, m := range f.repo.claims {
+		owners[m.TagID] = m.OwnerID
+	}
+	return owners
+}
+
+func TestExportUserData(t *testing.T) {
+	f := newUserFixture(t, common.ClaimPolicyRelease)
+	userID := "user-1"
+	itemID := primitive.NewObjectID()
+	f.repo.likes = []entity.ProductItemLike{{ProductItemID: itemID, UserID: userID}, {ProductItemID: itemID, UserID: "user-2"}}
+	f.repo.events = []entity.ClaimEvent{
+		{ProductItemID: itemID, ToState: entity.ClaimStateClaimed, OwnerID: userID, ActorID: userID},
+		{ProductItemID: itemID, ToState: entity.ClaimStateClaimable, PreviousOwnerID: userID, ActorID: "admin"},
+		{ProductItemID: itemID, ToState: entity.ClaimStateLocked, ActorID: userID},
+		{ProductItemID: itemID, ToState: entity.ClaimStateClaimed, OwnerID: "user-2", ActorID: "user-2"},
+	}
+
+	export, _, err := f.service.ExportUserData(&userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.ClaimedItems) != 3 || len(export.RoleBindings) != 1 {
+		t.Errorf("exported %d claimed items and %d role bindings", len(export.ClaimedItems), len(export.RoleBindings))
+	}
+	if len(export.Likes) != 1 || export.Likes[0].ProductItemID != itemID {
+		t.Errorf("exported likes %+v", export.Likes)
+	}
+	if len(export.OwnershipHistory) != 3 {
+		t.Errorf("exported %d claim events of the user, want 3", len(export.OwnershipHistory))
+	}
+
+	if _, _, err = f.service.DeleteAccount(&userID); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.repo.likes) != 1 || f.repo.likes[0].UserID != "user-2" {
+		t.Errorf("likes after deleting the account: %+v", f.repo.likes)
+	}
+}
+
+func TestDeleteAccountReleasesClaims(t *testing.T) {
+	f := newUserFixture(t, common.ClaimPolicyRelease)
+	userID := "user-1"
+
+	deletion, _, err := f.service.DeleteAccount(&userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deletion.ReleasedClaims != 3 || deletion.ReassignedClaims != 0 {
+		t.Errorf("unexpected deletion %+v", deletion)
+	}
+	owners := f.owners()
+	if owners["tag-1"] != "" || owners["tag-3"] != "" || owners["tag-4"] != "user-2" {
+		t.Errorf("unexpected owners %v", owners)
+	}
+
+	user := f.repo.users[userID]
+	if user.Email != "" || user.Name != "" || user.WalletAddress != "" || user.Status != common.StatusDeleted || user.DeletedAt == nil {
+		t.Errorf("user not anonymized: %+v", user)
+	}
+	if _, ok := f.repo.bindings[userID]; ok {
+		t.Error("role bindings not deleted")
+	}
+	if _, err = f.provider.GetUser(userID); err == nil {
+		t.Error("identity account not deleted")
+	}
+
+	// Deleting again is a no-op
+	if _, code, err := f.service.DeleteAccount(&userID); err != nil || code != http.StatusOK {
+		t.Errorf("second deletion: got %d, %v", code, err)
+	}
+}
+
+// failingDeletion an identity provider whose account deletion fails
+type failingDeletion struct {
+	*identity.LocalProvider
+}
+
+func (failingDeletion) DeleteUser(userID string) error {
+	return errors.New("identity provider unavailable")
+}
+
+func TestDeleteAccountRetriedAfterIdentityFailure(t *testing.T) {
+	f := newUserFixture(t, common.ClaimPolicyRelease)
+	userID := "user-1"
+	f.service.identity = failingDeletion{f.provider}
+
+	if _, code, _ := f.service.DeleteAccount(&userID); code != http.StatusInternalServerError {
+		t.Fatalf("got status %d, want %d", code, http.StatusInternalServerError)
+	}
+	// The user can still call the deletion again
+	if active, _, _ := f.service.IsUserActive(&userID); !active {
+		t.Fatal("user was deactivated before the identity account was deleted")
+	}
+
+	f.service.identity = f.provider
+	if _, _, err := f.service.DeleteAccount(&userID); err != nil {
+		t.Fatal(err)
+	}
+	if active, _, _ := f.service.IsUserActive(&userID); active {
+		t.Error("deleted user is active")
+	}
+	if _, err := f.provider.GetUser(userID); err == nil {
+		t.Error("identity account not deleted")
+	}
+}
+
+func TestDeleteAccountReassignsClaimsToOrganizationOwners(t *testing.T) {
+	f := newUserFixture(t, common.ClaimPolicyReassign)
+	userID := "user-1"
+
+	deletion, _, err := f.service.DeleteAccount(&userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deletion.ReassignedClaims != 2 || deletion.ReleasedClaims != 1 {
+		t.Errorf("unexpected deletion %+v", deletion)
+	}
+	// Claims in an organization without owner are released
+	owners := f.owners()
+	if owners["tag-1"] != "org-owner" || owners["tag-2"] != "org-owner" || owners["tag-3"] != "" || owners["tag-4"] != "user-2" {
+		t.Errorf("unexpected owners %v", owners)
+	}
+}
+
+func TestDeleteAccountOfOrganizationOwner(t *testing.T) {
+	f := newUserFixture(t, common.ClaimPolicyRelease)
+	userID := "org-owner"
+
+	if _, code, _ := f.service.DeleteAccount(&userID); code != http.StatusConflict {
+		t.Fatalf("got status %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestSetUserActive(t *testing.T) {
+	f := newUserFixture(t, common.ClaimPolicyRelease)
+	userID := "user-1"
+	otherOrgAdmin := &entity.AccessScope{UserID: "admin", OrganizationIDs: []primitive.ObjectID{f.freeOrg}}
+	orgAdmin := &entity.AccessScope{UserID: "admin", OrganizationIDs: []primitive.ObjectID{f.ownedOrg}}
+
+	// Users of other organizations are not found
+	if _, code, _ := f.service.SetUserActive(otherOrgAdmin, &userID, false); code != http.StatusNotFound {
+		t.Fatalf("admin of another organization: got status %d, want %d", code, http.StatusNotFound)
+	}
+	if _, code, _ := f.service.SetUserActive(&entity.AccessScope{UserID: userID, AllOrganizations: true}, &userID, false); code != http.StatusBadRequest {
+		t.Fatalf("deactivating yourself: got status %d, want %d", code, http.StatusBadRequest)
+	}
+
+	if _, _, err := f.service.SetUserActive(orgAdmin, &userID, false); err != nil {
+		t.Fatal(err)
+	}
+	if active, _, _ := f.service.IsUserActive(&userID); active {
+		t.Error("deactivated user is active")
+	}
+	if _, err := f.provider.VerifyToken(f.token); err == nil {
+		t.Error("token of a deactivated user accepted by the identity provider")
+	}
+
+	// Importing the account again keeps it deactivated
+	if _, _, err := f.service.UpsertUserFromFireBase(&userID); err != nil {
+		t.Fatal(err)
+	}
+	if active, _, _ := f.service.IsUserActive(&userID); active {
+		t.Error("import reactivated the user")
+	}
+
+	if _, _, err := f.service.SetUserActive(orgAdmin, &userID, true); err != nil {
+		t.Fatal(err)
+	}
+	if active, _, _ := f.service.IsUserActive(&userID); !active {
+		t.Error("reactivated user is not active")
+	}
+	if _, err := f.provider.VerifyToken(f.token); err != nil {
+		t.Errorf("token of a reactivated user: %v", err)
+	}
+
+	// Members of another organization are only managed by admins of both
+	f.repo.bindings[userID] = append(f.repo.bindings[userID], entity.RoleBinding{UserID: userID, OrganizationID: f.freeOrg, RoleName: "editor"})
+	if _, code, _ := f.service.SetUserActive(orgAdmin, &userID, false); code != http.StatusForbidden {
+		t.Errorf("deactivating a member of another organization: got status %d, want %d", code, http.StatusForbidden)
+	}
+	bothOrgsAdmin := &entity.AccessScope{UserID: "admin", OrganizationIDs: []primitive.ObjectID{f.ownedOrg, f.freeOrg}}
+	if _, _, err := f.service.SetUserActive(bothOrgsAdmin, &userID, true); err != nil {
+		t.Errorf("admin of both organizations: %v", err)
+	}
+
+	// Admins of every organization are only managed by admins of every organization
+	f.repo.bindings[userID] = append(f.repo.bindings[userID], entity.RoleBinding{UserID: userID, RoleName: string(entity.SUPER_ADMIN_ROLE)})
+	if _, code, _ := f.service.SetUserActive(orgAdmin, &userID, false); code != http.StatusForbidden {
+		t.Errorf("deactivating a global admin: got status %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestSyncUserClaims(t *testing.T) {
+	f := newUserFixture(t, common.ClaimPolicyRelease)
+	userID := "user-1"
+
+	user, _, err := f.service.SyncUserClaims(&userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Role != string(entity.ORG_ADMIN_ROLE) || user.Organization != "owned" || user.OrganizationID != f.ownedOrg {
+		t.Errorf("ORG_ADMIN binding: got claims %q %q", user.Role, user.Organization)
+	}
+
+	f.repo.bindings[userID] = append(f.repo.bindings[userID], entity.RoleBinding{UserID: userID, RoleName: string(entity.SUPER_ADMIN_ROLE)})
+	if user, _, _ = f.service.SyncUserClaims(&userID); user.Role != string(entity.SUPER_ADMIN_ROLE) {
+		t.Errorf("SUPER_ADMIN binding: got role %q", user.Role)
+	}
+
+	// Revoking every role removes the role claim
+	delete(f.repo.bindings, userID)
+	if user, _, _ = f.service.SyncUserClaims(&userID); user.Role != "" {
+		t.Errorf("no binding: got role %q", user.Role)
+	}
+	account, err := f.provider.GetUser(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Role != "" {
+		t.Errorf("account claims not updated: %+v", account)
+	}
+}
+
+func TestGetCollection(t *testing.T) {
+	previous := config.C
+	t.Cleanup(func() { config.C = previous })
+	config.C.Domains.WebpageDomain = "https://story.example.com"
+	f := newUserFixture(t, common.ClaimPolicyRelease)
+	userID := "user-1"
+	for i := range f.repo.claims {
+		f.repo.claims[i].ProductItemID = primitive.NewObjectID()
+	}
+	f.repo.orgTags = map[primitive.ObjectID]string{f.ownedOrg: "owned", f.freeOrg: "free"}
+	f.repo.assets = map[string]*entity.CollectionAsset{
+		"tag-1": {Status: common.StatusTxSuccess, TokenID: 7},
+		"tag-2": {Status: common.StatusTxPending},
+	}
+
+	page, _, err := f.service.GetCollection(&userID, &common.Paging{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Page != 1 || page.Total != 3 || len(page.Items) != 2 {
+		t.Fatalf("got page %d with %d of %d items", page.Page, len(page.Items), page.Total)
+	}
+	if page.Items[0].NFTStatus != common.NFTStatusMinted || page.Items[1].NFTStatus != common.NFTStatusPending {
+		t.Errorf("got NFT statuses %q %q", page.Items[0].NFTStatus, page.Items[1].NFTStatus)
+	}
+	if page.Items[0].StoryURL != "https://story.example.com/vi/owned/tag-1" {
+		t.Errorf("got story link %q", page.Items[0].StoryURL)
+	}
+
+	pItemID := f.repo.claims[2].ProductItemID.Hex()
+	item, _, err := f.service.GetCollectionItem(&userID, &pItemID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.TagID != "tag-3" || item.NFTStatus != common.NFTStatusNotMinted || item.StoryURL != "https://story.example.com/vi/free/tag-3" {
+		t.Errorf("got item %q with NFT status %q and story link %q", item.TagID, item.NFTStatus, item.StoryURL)
+	}
+
+	// Without organization there is no story to link to
+	delete(f.repo.orgTags, f.freeOrg)
+	if item, _, _ = f.service.GetCollectionItem(&userID, &pItemID); item.StoryURL != "" {
+		t.Errorf("item without organization: got story link %q", item.StoryURL)
+	}
+
+	// Items of other users are not found
+	pItemID = f.repo.claims[3].ProductItemID.Hex()
+	if _, code, _ := f.service.GetCollectionItem(&userID, &pItemID); code != http.StatusNotFound {
+		t.Errorf("item of another user: got %d", code)
+	}
+}
@@ -0,0 +1,31 @@
+package wallet
+
+import (
+	"backend-service/internal/core_backend/entity"
+)
+
+// User interface
+type User interface {
+	GetUserByID(userID *string) (*entity.User, error)
+	GetUserWithNoWallet() (*[]entity.User, error)
+	SetWalletAddress(userID *string, walletAddress *string) (bool, error)
+}
+
+// Wallet interface
+type Wallet interface {
+	GetWalletProvisionings(userIDs []string) (*[]entity.WalletProvisioning, error)
+	RecordWalletProvisioning(*entity.WalletProvisioning) (*entity.WalletProvisioning, error)
+}
+
+// Repository interface
+type Repository interface {
+	Wallet
+}
+
+// UseCase interface
+type UseCase interface {
+	// Interface for usecase - service
+	ProvisionUserWallet(user *entity.User) (*entity.WalletProvisioning, int, error)
+	ReconcileWallets() (*entity.WalletReconciliation, int, error)
+	RunReconciliation()
+}
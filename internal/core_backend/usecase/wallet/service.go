@@ -0,0 +1,226 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	config "backend-service/config/core_backend"
+	constant "backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/common/logger"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/custodial"
+)
+
+// Service struct
+type Service struct {
+	repo     Repository
+	users    User
+	provider custodial.Provider
+}
+
+// NewService create service
+func NewService(r Repository, u User, p custodial.Provider) *Service {
+	return &Service{
+		repo:     r,
+		users:    u,
+		provider: p,
+	}
+}
+
+// ProvisionUserWallet creates the custodial wallet of a single user, unless the stored user already has one
+func (s *Service) ProvisionUserWallet(user *entity.User) (*entity.WalletProvisioning, int, error) {
+	if user.Email == "" {
+		return nil, http.StatusBadRequest, errors.New(constant.MessageErrorUserHasNoEmail)
+	}
+	stored, err := s.users.GetUserByID(&user.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if stored != nil && stored.WalletAddress != "" {
+		return nil, http.StatusConflict, errors.New(constant.MessageErrorUserHasWallet)
+	}
+
+	previous, err := s.repo.GetWalletProvisionings([]string{user.ID})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	attempt := 0
+	for _, provisioning := range *previous {
+		attempt = provisioning.Attempts
+	}
+
+	key := custodial.IdempotencyKey(custodial.AttemptedEmail{Email: user.Email, Attempt: attempt})
+	wallet, err := s.provider.CreateWallet(context.Background(), user.Email, key)
+	var address string
+	if wallet != nil {
+		address = wallet.WalletAddress
+	}
+	provisioning, err := s.record(user, key, address, err)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	switch provisioning.Status {
+	case constant.WalletProvisionFailed:
+		return provisioning, http.StatusBadGateway, errors.New(provisioning.Error)
+	case constant.WalletProvisionNotApplied:
+		return provisioning, http.StatusConflict, errors.New(provisioning.Error)
+	}
+
+	return provisioning, http.StatusOK, nil
+}
+
+// ReconcileWallets provisions the wallets of the users who have none, in batches of WALLET_RECONCILE_BATCH_SIZE.
+// Users without an email or who already failed WALLET_RECONCILE_MAX_ATTEMPTS times are skipped.
+func (s *Service) ReconcileWallets() (*entity.WalletReconciliation, int, error) {
+	users, err := s.users.GetUserWithNoWallet()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	report := entity.WalletReconciliation{Total: len(*users), Results: []entity.WalletProvisioning{}}
+	if len(*users) == 0 {
+		return &report, http.StatusOK, nil
+	}
+
+	var userIDs []string
+	for _, user := range *users {
+		userIDs = append(userIDs, user.ID)
+	}
+	previous, err := s.repo.GetWalletProvisionings(userIDs)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	attempts := map[string]int{}
+	for _, provisioning := range *previous {
+		attempts[provisioning.UserID] = provisioning.Attempts
+	}
+
+	var pending []entity.User
+	for _, user := range *users {
+		if user.Email == "" || attempts[user.ID] >= config.C.Wallet.WALLET_RECONCILE_MAX_ATTEMPTS {
+			report.Skipped++
+			continue
+		}
+		pending = append(pending, user)
+	}
+
+	batchSize := config.C.Wallet.WALLET_RECONCILE_BATCH_SIZE
+	if batchSize <= 0 {
+		batchSize = len(pending)
+	}
+	for start := 0; start < len(pending); start += batchSize {
+		end := start + batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		results, err := s.provisionBatch(pending[start:end], attempts)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		for _, result := range results {
+			switch result.Status {
+			case constant.WalletProvisionSucceeded:
+				report.Succeeded++
+			case constant.WalletProvisionNotApplied:
+				report.Skipped++
+			default:
+				report.Failed++
+			}
+			report.Results = append(report.Results, result)
+		}
+	}
+
+	return &report, http.StatusOK, nil
+}
+
+// RunReconciliation reconciles wallets every WALLET_RECONCILE_INTERVAL_IN_SECOND, disabled when it is 0
+func (s *Service) RunReconciliation() {
+	interval := time.Duration(config.C.Wallet.WALLET_RECONCILE_INTERVAL_IN_SECOND) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		report, _, err := s.ReconcileWallets()
+		if err != nil {
+			logger.LogError("Wallet reconciliation failed: " + err.Error())
+			continue
+		}
+		if report.Total != 0 {
+			logger.LogInfo(fmt.Sprintf("Wallet reconciliation: %d succeeded, %d failed, %d skipped", report.Succeeded, report.Failed, report.Skipped))
+		}
+	}
+}
+
+// provisionBatch creates the wallets of the users in one provider request and records the outcome of each user.
+// attempts are the attempts already made for each user, by user ID.
+func (s *Service) provisionBatch(users []entity.User, attempts map[string]int) ([]entity.WalletProvisioning, error) {
+	emails := make([]string, len(users))
+	attempted := make([]custodial.AttemptedEmail, len(users))
+	for i, user := range users {
+		emails[i] = user.Email
+		attempted[i] = custodial.AttemptedEmail{Email: user.Email, Attempt: attempts[user.ID]}
+	}
+	key := custodial.IdempotencyKey(attempted...)
+
+	wallets, providerErr := s.provider.CreateWallets(context.Background(), emails, key)
+	addresses := map[string]string{}
+	for _, wallet := range wallets {
+		addresses[strings.ToLower(wallet.Email)] = wallet.Wallet.WalletAddress
+	}
+
+	var results []entity.WalletProvisioning
+	for i := range users {
+		provisioning, err := s.record(&users[i], key, addresses[strings.ToLower(users[i].Email)], providerErr)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *provisioning)
+	}
+
+	return results, nil
+}
+
+// record stores the wallet address on the user then the outcome of the attempt. The address is not applied
+// to a user who got a wallet meanwhile.
+func (s *Service) record(user *entity.User, key string, address string, providerErr error) (*entity.WalletProvisioning, error) {
+	provisioning := entity.WalletProvisioning{
+		UserID:         user.ID,
+		Email:          user.Email,
+		IdempotencyKey: key,
+	}
+	switch {
+	case providerErr != nil:
+		provisioning.Error = providerErr.Error()
+	case address == "":
+		provisioning.Error = constant.MessageErrorWalletNotReturned
+	default:
+		applied, err := s.users.SetWalletAddress(&user.ID, &address)
+		switch {
+		case err != nil:
+			provisioning.Error = err.Error()
+		case !applied:
+			provisioning.Status = constant.WalletProvisionNotApplied
+			provisioning.Error = constant.MessageErrorWalletNotApplied
+		default:
+			provisioning.WalletAddress = address
+		}
+	}
+	switch {
+	case provisioning.Status == constant.WalletProvisionNotApplied:
+		logger.LogInfo(fmt.Sprintf("Wallet %s of user %s not applied: %s", address, user.ID, provisioning.Error))
+	case provisioning.Error != "":
+		provisioning.Status = constant.WalletProvisionFailed
+		logger.LogError(fmt.Sprintf("Cannot provision wallet of user %s: %s", user.ID, provisioning.Error))
+	default:
+		provisioning.Status = constant.WalletProvisionSucceeded
+	}
+
+	return s.repo.RecordWalletProvisioning(&provisioning)
+}
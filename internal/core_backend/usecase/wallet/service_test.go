@@ -0,0 +1,181 @@
+package wallet
+
+import (
+	"net/http"
+	"testing"
+
+	config "backend-service/config/core_backend"
+	constant "backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/internal/core_backend/infrastructure/custodial"
+)
+
+// walletUsers the users of the reconciliation, those without an address have no wallet.
+// walletsMeanwhile are the addresses users get while their wallet is being provisioned, by user ID.
+type walletUsers struct {
+	users            []entity.User
+	walletsMeanwhile map[string]string
+}
+
+func (u *walletUsers) GetUserByID(userID *string) (*entity.User, error) {
+	for i := range u.users {
+		if u.users[i].ID == *userID {
+			user := u.users[i]
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *walletUsers) GetUserWithNoWallet() (*[]entity.User, error) {
+	users := []entity.User{}
+	for _, user := range u.users {
+		if user.WalletAddress == "" {
+			users = append(users, user)
+		}
+	}
+	return &users, nil
+}
+
+func (u *walletUsers) SetWalletAddress(userID *string, walletAddress *string) (bool, error) {
+	for i := range u.users {
+		if u.users[i].ID != *userID {
+			continue
+		}
+		if address, ok := u.walletsMeanwhile[*userID]; ok {
+			u.users[i].WalletAddress = address
+		}
+		if u.users[i].WalletAddress != "" {
+			return false, nil
+		}
+		u.users[i].WalletAddress = *walletAddress
+		return true, nil
+	}
+	return false, nil
+}
+
+// provisionings keeps the last attempt of each user and counts the attempts
+type provisionings map[string]entity.WalletProvisioning
+
+func (p provisionings) GetWalletProvisionings(userIDs []string) (*[]entity.WalletProvisioning, error) {
+	found := []entity.WalletProvisioning{}
+	for _, userID := range userIDs {
+		if provisioning, ok := p[userID]; ok {
+			found = append(found, provisioning)
+		}
+	}
+	return &found, nil
+}
+
+func (p provisionings) RecordWalletProvisioning(provisioning *entity.WalletProvisioning) (*entity.WalletProvisioning, error) {
+	recorded := *provisioning
+	recorded.Attempts = p[provisioning.UserID].Attempts + 1
+	p[provisioning.UserID] = recorded
+	return &recorded, nil
+}
+
+func TestReconcileWallets(t *testing.T) {
+	previous := config.C
+	t.Cleanup(func() { config.C = previous })
+	config.C.Wallet.WALLET_RECONCILE_BATCH_SIZE = 2
+	config.C.Wallet.WALLET_RECONCILE_MAX_ATTEMPTS = 2
+
+	users := &walletUsers{users: []entity.User{
+		{ID: "user-1", Email: "a@example.com"},
+		{ID: "user-2", Email: "b@example.com"},
+		{ID: "user-3", Email: "c@example.com"},
+		{ID: "user-4"},
+		{ID: "user-5", Email: "e@example.com", WalletAddress: "0xabc"},
+	}}
+	repo := provisionings{}
+	provider := custodial.NewFake()
+	provider.FailEmails["b@example.com"] = true
+	s := NewService(repo, users, provider)
+
+	report, _, err := s.ReconcileWallets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 4 || report.Succeeded != 2 || report.Failed != 1 || report.Skipped != 1 || provider.Calls != 2 {
+		t.Errorf("first run: %+v in %d requests", report, provider.Calls)
+	}
+	if users.users[0].WalletAddress != custodial.FakeAddress("a@example.com") || users.users[2].WalletAddress != custodial.FakeAddress("c@example.com") {
+		t.Errorf("wallets of the first run: %+v", users.users)
+	}
+
+	// The retry is a new request for the provider, not a replay of the failed one
+	delete(provider.FailEmails, "b@example.com")
+	report, _, err = s.ReconcileWallets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Succeeded != 1 || report.Failed != 0 || users.users[1].WalletAddress != custodial.FakeAddress("b@example.com") {
+		t.Errorf("second run: %+v", report)
+	}
+	if repo["user-2"].Attempts != 2 || repo["user-2"].IdempotencyKey == repo["user-1"].IdempotencyKey {
+		t.Errorf("attempts of user-2: %+v", repo["user-2"])
+	}
+}
+
+func TestReconcileWalletsStopsAfterMaxAttempts(t *testing.T) {
+	previous := config.C
+	t.Cleanup(func() { config.C = previous })
+	config.C.Wallet.WALLET_RECONCILE_MAX_ATTEMPTS = 2
+
+	users := &walletUsers{users: []entity.User{{ID: "user-1", Email: "a@example.com"}}}
+	repo := provisionings{}
+	provider := custodial.NewFake()
+	provider.FailEmails["a@example.com"] = true
+	s := NewService(repo, users, provider)
+
+	var keys []string
+	for run := 0; run < 3; run++ {
+		if _, _, err := s.ReconcileWallets(); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, repo["user-1"].IdempotencyKey)
+	}
+	if provider.Calls != 2 || repo["user-1"].Attempts != 2 || repo["user-1"].Status != constant.WalletProvisionFailed {
+		t.Errorf("%d requests for %+v", provider.Calls, repo["user-1"])
+	}
+	if keys[0] == keys[1] {
+		t.Error("the second attempt reused the key of the first")
+	}
+
+	// A single provisioning continues from the attempts of the reconciliation
+	if _, _, err := s.ProvisionUserWallet(&users.users[0]); err == nil || repo["user-1"].Attempts != 3 || repo["user-1"].IdempotencyKey == keys[1] {
+		t.Errorf("single provisioning after the reconciliation: %v %+v", err, repo["user-1"])
+	}
+}
+
+func TestProvisionUserWalletKeepsExistingWallet(t *testing.T) {
+	previous := config.C
+	t.Cleanup(func() { config.C = previous })
+	config.C.Wallet.WALLET_RECONCILE_MAX_ATTEMPTS = 2
+
+	users := &walletUsers{
+		users:            []entity.User{{ID: "user-1", Email: "a@example.com"}, {ID: "user-2", Email: "b@example.com"}},
+		walletsMeanwhile: map[string]string{"user-2": "0xlinked"},
+	}
+	repo := provisionings{}
+	provider := custodial.NewFake()
+	s := NewService(repo, users, provider)
+
+	// The stored user has a wallet the caller did not see yet, the provider is not called
+	users.users[0].WalletAddress = "0xabc"
+	if _, code, err := s.ProvisionUserWallet(&entity.User{ID: "user-1", Email: "a@example.com"}); code != http.StatusConflict || err == nil || provider.Calls != 0 {
+		t.Errorf("user with a wallet: got %d, %v after %d requests", code, err, provider.Calls)
+	}
+
+	// A wallet linked while the provider was called is kept, the attempt is recorded as not applied
+	report, _, err := s.ReconcileWallets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Succeeded != 0 || report.Skipped != 1 || users.users[1].WalletAddress != "0xlinked" {
+		t.Errorf("reconciliation: %+v, wallet %q", report, users.users[1].WalletAddress)
+	}
+	if provisioning := repo["user-2"]; provisioning.Status != constant.WalletProvisionNotApplied || provisioning.WalletAddress != "" {
+		t.Errorf("provisioning of user-2: %+v", provisioning)
+	}
+}
@@ -3,16 +3,21 @@ package webpage
 import (
 	"backend-service/internal/core_backend/api/handler/request"
 	"backend-service/internal/core_backend/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // WebPage interface repository methods
 type WebPage interface {
 	// Interface for repository
 	CreateWebPage(page *entity.WebPage) (*entity.WebPage, error)
-	GetAllWebPages() (*[]entity.WebPage, error)
+	GetAllWebPages(scope *entity.AccessScope) (*[]entity.WebPage, error)
 	GetWebPage(pageId *string) (*entity.WebPage, error)
-	UpdateWebPage(page *entity.WebPage) (*entity.WebPage, error)
-	DeleteWebPage(Id *string) (bool, error)
+	GetWebPageInScope(pageId *string, scope *entity.AccessScope) (*entity.WebPage, error)
+	UpdateWebPage(page *entity.WebPage, scope *entity.AccessScope) (bool, error)
+	DeleteWebPage(Id *string, scope *entity.AccessScope) (bool, error)
+	GetTemplatesUsingWebPage(pageID primitive.ObjectID) (*[]entity.Template, error)
+	DeleteWebPageCascade(pageID primitive.ObjectID, scope *entity.AccessScope) (bool, error)
 }
 
 // Repository interface
@@ -23,9 +28,9 @@ type Repository interface {
 // UseCase interface
 type UseCase interface {
 	// Interface for usecase - service
-	CreateWebPage(*request.CreateWebpageRequest) (string, int, error)
-	GetAllWebPages() (*[]entity.WebPage, int, error)
+	CreateWebPage(orgID primitive.ObjectID, request *request.CreateWebpageRequest) (string, int, error)
+	GetAllWebPages(scope *entity.AccessScope) (*[]entity.WebPage, int, error)
 	GetWebPage(pageId *string) (*entity.WebPage, int, error)
-	UpdateWebPage(*request.UpdateWebpageRequest) (string, int, error)
-	DeleteWebPage(pageId *string) (bool, int, error)
+	UpdateWebPage(scope *entity.AccessScope, request *request.UpdateWebpageRequest) (string, int, error)
+	DeleteWebPage(scope *entity.AccessScope, pageId *string, cascade bool) (bool, int, error)
 }
@@ -1,9 +1,14 @@
 package webpage
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
 	"backend-service/internal/core_backend/common/logger"
 	"backend-service/internal/core_backend/entity"
 )
@@ -20,16 +25,23 @@ func NewService(r Repository) *Service {
 	}
 }
 
-// CreateWebpage
-func (s *Service) CreateWebPage(request *request.CreateWebpageRequest) (string, int, error) {
+// CreateWebpage creates a page of the organization, shared by every organization when orgID is zero
+func (s *Service) CreateWebPage(orgID primitive.ObjectID, request *request.CreateWebpageRequest) (string, int, error) {
+	blocks, err := prepareBlocks(request.Blocks)
+	if err != nil {
+		return "", http.StatusBadRequest, err
+	}
 	page := &entity.WebPage{
 		WebPageBase: entity.WebPageBase{
-			Name:    request.Name,
-			URLLink: request.URLLink,
-			Type:    request.Type,
+			OrganizationID: orgID,
+			Name:           request.Name,
+			URLLink:        request.URLLink,
+			Type:           request.Type,
 		},
 		Attributes: request.Attributes,
+		Blocks:     blocks,
 	}
+	page.SetTime()
 	webPage, err := s.repo.CreateWebPage(page)
 	if err != nil {
 		logger.LogError("Get error when getting webpage: " + err.Error())
@@ -47,46 +59,79 @@ func (s *Service) GetWebPage(pageId *string) (*entity.WebPage, int, error) {
 		logger.LogError("Get error when getting webpage: " + err.Error())
 		return nil, http.StatusInternalServerError, err
 	}
+	if webPage == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorWebPageNotFound)
+	}
 
 	return webPage, http.StatusOK, nil
 }
 
 // UpdateWebpage
-func (s *Service) UpdateWebPage(request *request.UpdateWebpageRequest) (string, int, error) {
-	page, err := s.repo.GetWebPage(&request.WebpageID)
+func (s *Service) UpdateWebPage(scope *entity.AccessScope, request *request.UpdateWebpageRequest) (string, int, error) {
+	page, code, err := s.getWritableWebPage(scope, &request.WebpageID)
 	if err != nil {
-		logger.LogError("Error getting webpage: " + err.Error())
-		return "", http.StatusInternalServerError, err
+		return "", code, err
+	}
+	blocks, err := prepareBlocks(request.Blocks)
+	if err != nil {
+		return "", http.StatusBadRequest, err
 	}
 	page.Name = request.Name
 	page.URLLink = request.URLLink
 	page.Type = request.Type
 	page.Attributes = request.Attributes
-	webPage, err := s.repo.UpdateWebPage(page)
-	if err != nil {
+	page.Blocks = blocks
+	page.SetTime()
+	if _, err = s.repo.UpdateWebPage(page, scope); err != nil {
 		logger.LogError("Get error when updating webpage: " + err.Error())
 		return "", http.StatusInternalServerError, err
 	}
 
-	return webPage.ID.Hex(), http.StatusOK, nil
+	return page.ID.Hex(), http.StatusOK, nil
 }
 
-// DeleteWebPage
-func (s *Service) DeleteWebPage(pageId *string) (bool, int, error) {
+// DeleteWebPage deletes a page no template uses. With cascade, a page templates use is deleted too and removed from
+// their pages and menus, unless it is a home page which would leave the templates without one.
+func (s *Service) DeleteWebPage(scope *entity.AccessScope, pageId *string, cascade bool) (bool, int, error) {
+	page, code, err := s.getWritableWebPage(scope, pageId)
+	if err != nil {
+		return false, code, err
+	}
 
-	result, err := s.repo.DeleteWebPage(pageId)
+	templates, err := s.repo.GetTemplatesUsingWebPage(page.ID)
 	if err != nil {
-		logger.LogError("Get error when deleting webpage: " + err.Error())
+		logger.LogError("Get error when getting templates using webpage: " + err.Error())
+		return false, http.StatusInternalServerError, err
+	}
+	if len(*templates) == 0 {
+		result, err := s.repo.DeleteWebPage(pageId, scope)
+		if err != nil {
+			logger.LogError("Get error when deleting webpage: " + err.Error())
+			return false, http.StatusInternalServerError, err
+		}
+		return result, http.StatusOK, nil
+	}
+
+	used := fmt.Sprintf(" (%d templates)", len(*templates))
+	if !cascade {
+		return false, http.StatusConflict, errors.New(common.MessageErrorWebPageInUse + used)
+	}
+	if page.Type == entity.WebPageTypeHome {
+		return false, http.StatusConflict, errors.New(common.MessageErrorHomePageInUse + used)
+	}
+	result, err := s.repo.DeleteWebPageCascade(page.ID, scope)
+	if err != nil {
+		logger.LogError("Get error when deleting webpage from templates: " + err.Error())
 		return false, http.StatusInternalServerError, err
 	}
 
 	return result, http.StatusOK, nil
 }
 
-// GetWebPage
-func (s *Service) GetAllWebPages() (*[]entity.WebPage, int, error) {
+// GetAllWebPages
+func (s *Service) GetAllWebPages(scope *entity.AccessScope) (*[]entity.WebPage, int, error) {
 
-	webPages, err := s.repo.GetAllWebPages()
+	webPages, err := s.repo.GetAllWebPages(scope)
 	if err != nil {
 		logger.LogError("Get error when getting all webpages: " + err.Error())
 		return nil, http.StatusInternalServerError, err
@@ -94,3 +139,40 @@ func (s *Service) GetAllWebPages() (*[]entity.WebPage, int, error) {
 
 	return webPages, http.StatusOK, nil
 }
+
+// getWritableWebPage pages of organizations out of scope are reported as not found,
+// shared pages can only be changed by admins of all organizations
+func (s *Service) getWritableWebPage(scope *entity.AccessScope, pageId *string) (*entity.WebPage, int, error) {
+	page, err := s.repo.GetWebPageInScope(pageId, scope)
+	if err != nil {
+		logger.LogError("Error getting webpage: " + err.Error())
+		return nil, http.StatusInternalServerError, err
+	}
+	if page == nil {
+		return nil, http.StatusNotFound, errors.New(common.MessageErrorWebPageNotFound)
+	}
+	if page.OrganizationID.IsZero() && !scope.AllOrganizations {
+		return nil, http.StatusForbidden, errors.New(common.MessageErrorSharedResource)
+	}
+
+	return page, http.StatusOK, nil
+}
+
+// prepareBlocks validates the blocks of a page and gives an ID to the new ones, the IDs of the blocks kept must be unique
+func prepareBlocks(blocks []entity.WebPageBlock) ([]entity.WebPageBlock, error) {
+	ids := map[string]bool{}
+	for i := range blocks {
+		if err := blocks[i].Validate(); err != nil {
+			return nil, fmt.Errorf("%s %d: %w", common.MessageErrorInvalidBlock, i+1, err)
+		}
+		if blocks[i].ID == "" {
+			blocks[i].ID = primitive.NewObjectID().Hex()
+		}
+		if ids[blocks[i].ID] {
+			return nil, fmt.Errorf("%s %d: duplicate block id %s", common.MessageErrorInvalidBlock, i+1, blocks[i].ID)
+		}
+		ids[blocks[i].ID] = true
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,205 @@
+package webpage
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"backend-service/internal/core_backend/api/handler/request"
+	"backend-service/internal/core_backend/common"
+	"backend-service/internal/core_backend/entity"
+	"backend-service/pkg/common/translation"
+)
+
+// pagesInUse the pages the tests create and delete, and the templates referencing them
+type pagesInUse struct {
+	Repository
+	pages     map[primitive.ObjectID]entity.WebPage
+	templates []entity.Template
+}
+
+// uses whether a page or a menu entry of the template targets the page
+func uses(template entity.Template, pageID primitive.ObjectID) bool {
+	for _, page := range template.Pages {
+		if page.PageID == pageID {
+			return true
+		}
+	}
+	for _, entry := range template.Menu {
+		if entry.PageID == pageID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *pagesInUse) CreateWebPage(page *entity.WebPage) (*entity.WebPage, error) {
+	page.ID = primitive.NewObjectID()
+	r.pages[page.ID] = *page
+	return page, nil
+}
+
+func (r *pagesInUse) GetWebPageInScope(pageId *string, scope *entity.AccessScope) (*entity.WebPage, error) {
+	id, err := primitive.ObjectIDFromHex(*pageId)
+	if err != nil {
+		return nil, err
+	}
+	page, ok := r.pages[id]
+	if !ok || !scope.CanAccess(page.OrganizationID) {
+		return nil, nil
+	}
+	return &page, nil
+}
+
+func (r *pagesInUse) GetTemplatesUsingWebPage(pageID primitive.ObjectID) (*[]entity.Template, error) {
+	templates := []entity.Template{}
+	for _, template := range r.templates {
+		if uses(template, pageID) {
+			templates = append(templates, template)
+		}
+	}
+	return &templates, nil
+}
+
+func (r *pagesInUse) DeleteWebPage(pageId *string, scope *entity.AccessScope) (bool, error) {
+	id, _ := primitive.ObjectIDFromHex(*pageId)
+	_, ok := r.pages[id]
+	delete(r.pages, id)
+	return ok, nil
+}
+
+func (r *pagesInUse) DeleteWebPageCascade(pageID primitive.ObjectID, scope *entity.AccessScope) (bool, error) {
+	for i := range r.templates {
+		pages := r.templates[i].Pages[:0]
+		for _, page := range r.templates[i].Pages {
+			if page.PageID != pageID {
+				pages = append(pages, page)
+			}
+		}
+		r.templates[i].Pages = pages
+		menu := r.templates[i].Menu[:0]
+		for _, entry := range r.templates[i].Menu {
+			if entry.PageID != pageID {
+				menu = append(menu, entry)
+			}
+		}
+		r.templates[i].Menu = menu
+	}
+	delete(r.pages, pageID)
+	return true, nil
+}
+
+func TestDeleteWebPage(t *testing.T) {
+	orgID := primitive.NewObjectID()
+	scope := &entity.AccessScope{OrganizationIDs: []primitive.ObjectID{orgID}}
+	newPage := func(pageType string) entity.WebPage {
+		page := entity.WebPage{}
+		page.ID = primitive.NewObjectID()
+		page.OrganizationID = orgID
+		page.Type = pageType
+		return page
+	}
+	home, story, unused := newPage(entity.WebPageTypeHome), newPage("story"), newPage("story")
+	repo := &pagesInUse{
+		pages: map[primitive.ObjectID]entity.WebPage{home.ID: home, story.ID: story, unused.ID: unused},
+		templates: []entity.Template{{
+			OrganizationID: orgID,
+			Pages:          []entity.TemplatePages{{PageID: home.ID}, {PageID: story.ID}},
+			Menu:           []entity.TemplateMenu{{PageID: story.ID}},
+		}},
+	}
+	service := NewService(repo)
+
+	tests := []struct {
+		name    string
+		page    entity.WebPage
+		cascade bool
+		code    int
+	}{
+		{"unused page", unused, false, http.StatusOK},
+		{"page used by a template", story, false, http.StatusConflict},
+		{"home page used by a template", home, true, http.StatusConflict},
+		{"page used by a template with cascade", story, true, http.StatusOK},
+	}
+	for _, test := range tests {
+		id := test.page.ID.Hex()
+		_, code, err := service.DeleteWebPage(scope, &id, test.cascade)
+		if code != test.code {
+			t.Errorf("%s: DeleteWebPage() = %d, %v, want %d", test.name, code, err, test.code)
+		}
+	}
+
+	if _, ok := repo.pages[story.ID]; ok {
+		t.Error("the page deleted with cascade is still there")
+	}
+	if _, ok := repo.pages[home.ID]; !ok {
+		t.Error("the home page of the template was deleted")
+	}
+	if uses(repo.templates[0], story.ID) {
+		t.Errorf("template = %+v, still uses the page deleted with cascade", repo.templates[0])
+	}
+}
+
+func TestCreateWebPageBlocks(t *testing.T) {
+	text := func(texts map[string]string) translation.LocalizedString {
+		ls, err := translation.NewLocalizedString(texts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ls
+	}
+	body := text(map[string]string{"en": "<p>Grown on the hills</p>", "vi": "<p>Trồng trên đồi</p>"})
+
+	tests := []struct {
+		name   string
+		blocks []entity.WebPageBlock
+		err    string
+	}{
+		{"no blocks", nil, ""},
+		{"every type", []entity.WebPageBlock{
+			{Type: entity.BlockTypeRichText, RichText: &entity.RichTextBlock{Body: body}},
+			{Type: entity.BlockTypeImageGallery, ImageGallery: &entity.ImageGalleryBlock{Images: []entity.GalleryImage{{URL: "https://cdn.example.com/a.jpg"}}}},
+			{Type: entity.BlockTypeVideo, Video: &entity.VideoBlock{URL: "{{product.video.url}}"}},
+			{Type: entity.BlockTypeModel3D, Model3D: &entity.Model3DBlock{URL: "https://cdn.example.com/vase.glb"}},
+			{Type: entity.BlockTypeMapLocation, MapLocation: &entity.MapLocationBlock{Latitude: 11.94, Longitude: 108.45, Zoom: 9}},
+			{Type: entity.BlockTypeTimeline, Timeline: &entity.TimelineBlock{Events: []entity.TimelineEvent{{Date: "1998", Title: body}}}},
+			{Type: entity.BlockTypeQuote, Quote: &entity.QuoteBlock{Text: body}},
+			{Type: entity.BlockTypeCallToAction, CallToAction: &entity.CallToActionBlock{Label: body, URL: "https://shop.example.com"}},
+		}, ""},
+		{"unknown type", []entity.WebPageBlock{{Type: "carousel"}}, "1: unknown block type"},
+		{"content of another type", []entity.WebPageBlock{{Type: entity.BlockTypeQuote, RichText: &entity.RichTextBlock{Body: body}}}, "1: a quote block needs its quote content"},
+		{"two contents", []entity.WebPageBlock{{Type: entity.BlockTypeQuote, Quote: &entity.QuoteBlock{Text: body}, RichText: &entity.RichTextBlock{Body: body}}}, "1: a quote block cannot have rich_text content"},
+		{"empty rich text", []entity.WebPageBlock{{Type: entity.BlockTypeRichText, RichText: &entity.RichTextBlock{}}}, "1: body is required"},
+		{"relative image", []entity.WebPageBlock{{Type: entity.BlockTypeImageGallery, ImageGallery: &entity.ImageGalleryBlock{Images: []entity.GalleryImage{{URL: "/a.jpg"}}}}}, "1: image 1: invalid url"},
+		{"placeholder after a scheme", []entity.WebPageBlock{{Type: entity.BlockTypeCallToAction, CallToAction: &entity.CallToActionBlock{Label: body, URL: "javascript:{{product.product_name}}"}}}, "1: invalid url"},
+		{"text after a placeholder", []entity.WebPageBlock{{Type: entity.BlockTypeVideo, Video: &entity.VideoBlock{URL: "{{product.video.url}}?autoplay=1"}}}, "1: invalid url"},
+		{"model format", []entity.WebPageBlock{{Type: entity.BlockTypeModel3D, Model3D: &entity.Model3DBlock{URL: "https://cdn.example.com/vase.obj"}}}, "1: 3D models must be one of"},
+		{"latitude", []entity.WebPageBlock{{Type: entity.BlockTypeMapLocation, MapLocation: &entity.MapLocationBlock{Latitude: 91}}}, "1: latitude"},
+		{"duplicate id", []entity.WebPageBlock{
+			{ID: "intro", Type: entity.BlockTypeQuote, Quote: &entity.QuoteBlock{Text: body}},
+			{ID: "intro", Type: entity.BlockTypeQuote, Quote: &entity.QuoteBlock{Text: body}},
+		}, "2: duplicate block id intro"},
+	}
+	for _, test := range tests {
+		repo := &pagesInUse{pages: map[primitive.ObjectID]entity.WebPage{}}
+		id, code, err := NewService(repo).CreateWebPage(primitive.NewObjectID(), &request.CreateWebpageRequest{Type: "story", Blocks: test.blocks})
+		if test.err != "" {
+			if code != http.StatusBadRequest || err == nil || !strings.HasPrefix(err.Error(), common.MessageErrorInvalidBlock+" "+test.err) {
+				t.Errorf("%s: CreateWebPage() = %d, %v, want %d %q", test.name, code, err, http.StatusBadRequest, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: CreateWebPage() = %d, %v", test.name, code, err)
+			continue
+		}
+		pageID, _ := primitive.ObjectIDFromHex(id)
+		for i, block := range repo.pages[pageID].Blocks {
+			if block.ID == "" {
+				t.Errorf("%s: block %d has no id", test.name, i+1)
+			}
+		}
+	}
+}
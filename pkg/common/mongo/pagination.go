@@ -51,3 +51,40 @@ func GetSortOperator(pagination *paginationpkg.Pagination) SortOperationType {
 
 	return sortOperator
 }
+
+// CursorPosition the position a page starts after, the value of the OrderBy field and the _id of the last item of the previous page
+type CursorPosition struct {
+	Value any
+	ID    any
+}
+
+// BuildCursorPaginationPipeline Pagination using Cursor, the items after the position sorted by OrderBy then _id.
+// A nil position is the first page. One more item than Limit is returned, it tells whether there is a next page.
+func BuildCursorPaginationPipeline(pagination *paginationpkg.Pagination, position *CursorPosition) mongo.Pipeline {
+	sortOperator := GetSortOperator(pagination)
+	pipeline := mongo.Pipeline{}
+
+	if position != nil {
+		compareOperator := string(GetCompareOperator(pagination))
+		matchStage := bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{pagination.OrderBy: bson.M{compareOperator: position.Value}},
+			bson.M{pagination.OrderBy: position.Value, "_id": bson.M{compareOperator: position.ID}},
+		}}}}
+		pipeline = append(pipeline, matchStage)
+	}
+
+	sortStage := bson.D{{Key: "$sort", Value: bson.D{{Key: pagination.OrderBy, Value: sortOperator}, {Key: "_id", Value: sortOperator}}}}
+
+	limitStage := bson.D{{Key: "$limit", Value: pagination.Limit + 1}}
+
+	return append(pipeline, sortStage, limitStage)
+}
+
+// GetCompareOperator the operator matching the items after a position in the order of the pagination
+func GetCompareOperator(pagination *paginationpkg.Pagination) CompareOperationType {
+	if pagination.IsAsc() {
+		return GtOperationMongo
+	}
+
+	return LtOperationMongo
+}
@@ -1,6 +1,8 @@
 package pagination
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"time"
 )
@@ -58,3 +60,26 @@ func (p *Pagination) Fulfill() {
 		p.OrderDirection = string(DescOrderDirection)
 	}
 }
+
+// EncodeCursor the cursor of a position, the JSON of the position in URL safe base64
+func EncodeCursor(position any) (string, error) {
+	raw, err := json.Marshal(position)
+	if err != nil {
+		return "", ErrorEncode
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor reads the position of a cursor made by EncodeCursor
+func DecodeCursor(cursor string, position any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) == 0 {
+		return ErrorInvalidLenCursor
+	}
+	if err = json.Unmarshal(raw, position); err != nil {
+		return ErrorInvalidLenCursor
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package translation
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguages the languages content falls back to when none of the requested ones is available
+var DefaultLanguages = []string{"vi", "en"}
+
+// FallbackChain the languages to look for, in order of preference
+type FallbackChain []language.Tag
+
+// NewFallbackChain the chain of the requested languages followed by DefaultLanguages.
+// Each request may be a single tag or an Accept-Language header, invalid tags are skipped.
+func NewFallbackChain(requested ...string) FallbackChain {
+	var chain FallbackChain
+	seen := map[language.Tag]bool{}
+	add := func(tag language.Tag) {
+		if tag != language.Und && !seen[tag] {
+			seen[tag] = true
+			chain = append(chain, tag)
+		}
+	}
+
+	for _, request := range append(requested, DefaultLanguages...) {
+		request = strings.TrimSpace(request)
+		if request == "" {
+			continue
+		}
+		tags, _, err := language.ParseAcceptLanguage(request)
+		if err != nil {
+			continue
+		}
+		for _, tag := range tags {
+			add(tag)
+		}
+	}
+
+	return chain
+}
+
+// Languages the tags of the chain
+func (c FallbackChain) Languages() []string {
+	tags := make([]string, len(c))
+	for i, tag := range c {
+		tags[i] = tag.String()
+	}
+
+	return tags
+}
+
+// Match the best of the available languages for the chain. For each language of the chain in turn,
+// the exact language is preferred, then its parents ("zh-Hant-TW", "zh-Hant", "zh"), then any variant of the same base language.
+// Available languages are compared in the order given, sort them for a stable result.
+func (c FallbackChain) Match(available []string) (string, bool) {
+	parsed := make(map[string]language.Tag, len(available))
+	for _, tag := range available {
+		if t, err := language.Parse(tag); err == nil {
+			parsed[tag] = t
+		}
+	}
+
+	for _, want := range c {
+		for parent := want; parent != language.Und; parent = parent.Parent() {
+			for _, tag := range available {
+				if t, ok := parsed[tag]; ok && t == parent {
+					return tag, true
+				}
+			}
+		}
+
+		base, _ := want.Base()
+		for _, tag := range available {
+			t, ok := parsed[tag]
+			if !ok {
+				continue
+			}
+			if b, _ := t.Base(); b == base {
+				return tag, true
+			}
+		}
+	}
+
+	return "", false
+}
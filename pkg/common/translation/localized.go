@@ -0,0 +1,172 @@
+package translation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"golang.org/x/text/language"
+)
+
+// LocalizedString is a text in any number of languages, keyed by BCP 47 tag.
+// It is stored as a document of tag to text, {"en": "...", "vi": "..."}, and written to JSON the same way
+// until it is resolved for a FallbackChain, after which it is written as the text of the best language.
+type LocalizedString struct {
+	texts    map[string]string
+	resolved *string
+}
+
+// NewLocalizedString the localized string of the texts by language, tags that are not BCP 47 are an error
+func NewLocalizedString(texts map[string]string) (LocalizedString, error) {
+	var ls LocalizedString
+	for tag, text := range texts {
+		if err := ls.Set(tag, text); err != nil {
+			return LocalizedString{}, err
+		}
+	}
+
+	return ls, nil
+}
+
+// ParseLanguage the canonical form of the BCP 47 tag, "EN-us" is "en-US"
+func ParseLanguage(tag string) (string, error) {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", fmt.Errorf("invalid language %q: %w", tag, err)
+	}
+
+	return parsed.String(), nil
+}
+
+// Set the text of the language, an empty text removes the language
+func (ls *LocalizedString) Set(tag, text string) error {
+	canonical, err := ParseLanguage(tag)
+	if err != nil {
+		return err
+	}
+	ls.resolved = nil
+	if text == "" {
+		delete(ls.texts, canonical)
+		return nil
+	}
+	if ls.texts == nil {
+		ls.texts = map[string]string{}
+	}
+	ls.texts[canonical] = text
+
+	return nil
+}
+
+// Text the text in exactly the language, without fallback
+func (ls LocalizedString) Text(tag string) string {
+	canonical, err := ParseLanguage(tag)
+	if err != nil {
+		return ""
+	}
+
+	return ls.texts[canonical]
+}
+
+// Texts a copy of the texts by language
+func (ls LocalizedString) Texts() map[string]string {
+	texts := make(map[string]string, len(ls.texts))
+	for tag, text := range ls.texts {
+		texts[tag] = text
+	}
+
+	return texts
+}
+
+// IsEmpty whether there is no text in any language
+func (ls LocalizedString) IsEmpty() bool {
+	return len(ls.texts) == 0
+}
+
+// Get the text in the first language of the chain it has, see FallbackChain.Match.
+// A text in none of the languages of the chain is still better than nothing, the first language in sorted order is used then.
+func (ls LocalizedString) Get(chain FallbackChain) string {
+	languages := ls.languages()
+	if len(languages) == 0 {
+		return ""
+	}
+	if tag, ok := chain.Match(languages); ok {
+		return ls.texts[tag]
+	}
+
+	return ls.texts[languages[0]]
+}
+
+// Resolve makes the localized string written to JSON as its text in the chain
+func (ls *LocalizedString) Resolve(chain FallbackChain) {
+	text := ls.Get(chain)
+	ls.resolved = &text
+}
+
+func (ls LocalizedString) languages() []string {
+	tags := make([]string, 0, len(ls.texts))
+	for tag := range ls.texts {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+func (ls LocalizedString) MarshalJSON() ([]byte, error) {
+	if ls.resolved != nil {
+		return json.Marshal(*ls.resolved)
+	}
+	if ls.texts == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(ls.texts)
+}
+
+func (ls *LocalizedString) UnmarshalJSON(data []byte) error {
+	var texts map[string]string
+	if err := json.Unmarshal(data, &texts); err != nil {
+		return errors.New("a localized text is an object of BCP 47 language to text")
+	}
+	parsed, err := NewLocalizedString(texts)
+	if err != nil {
+		return err
+	}
+	*ls = parsed
+
+	return nil
+}
+
+func (ls LocalizedString) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if ls.texts == nil {
+		return bson.MarshalValue(map[string]string{})
+	}
+
+	return bson.MarshalValue(ls.texts)
+}
+
+// UnmarshalBSONValue reads the stored texts as they are, documents written before the tags were canonical included
+func (ls *LocalizedString) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	*ls = LocalizedString{}
+	if t == bsontype.Null || t == bsontype.Undefined {
+		return nil
+	}
+	if t != bsontype.EmbeddedDocument {
+		return fmt.Errorf("cannot decode %s into a localized text", t)
+	}
+	var texts map[string]string
+	if err := bson.Unmarshal(data, &texts); err != nil {
+		return err
+	}
+	for tag, text := range texts {
+		if text == "" {
+			delete(texts, tag)
+		}
+	}
+	ls.texts = texts
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package translation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFallbackChain(t *testing.T) {
+	texts := map[string]string{"en": "Coffee", "vi": "Cà phê", "pt-PT": "Café", "zh-Hant": "咖啡"}
+	ls, err := NewLocalizedString(texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for requested, want := range map[string]string{
+		"":                 "Cà phê",
+		"en":               "Coffee",
+		"EN-us":            "Coffee",
+		"pt-BR":            "Café",
+		"zh-Hant-TW":       "咖啡",
+		"fr":               "Cà phê",
+		"fr;q=0.9, en-GB":  "Coffee",
+		"not a language!!": "Cà phê",
+	} {
+		if got := ls.Get(NewFallbackChain(requested)); got != want {
+			t.Errorf("%q: got %q, want %q", requested, got, want)
+		}
+	}
+
+	only, _ := NewLocalizedString(map[string]string{"ja": "コーヒー", "de": "Kaffee"})
+	if got := only.Get(NewFallbackChain("fr")); got != "Kaffee" {
+		t.Errorf("no language of the chain: got %q", got)
+	}
+
+	if _, err := NewLocalizedString(map[string]string{"english": "Coffee"}); err == nil {
+		t.Error("a tag that is not BCP 47 is accepted")
+	}
+}
+
+type story struct {
+	Title  LocalizedString  `json:"title"`
+	Menus  []menu           `json:"menus"`
+	Author *author          `json:"author"`
+	Extra  *LocalizedString `json:"extra"`
+}
+
+type menu struct {
+	Title LocalizedString `json:"title"`
+}
+
+type author struct {
+	Name        LocalizedString `json:"name"`
+	Phone       string          `json:"phone"`
+	Translation Translations    `json:"translation"`
+}
+
+func (a *author) GetTranslations() Translations {
+	return a.Translation
+}
+
+func TestTranslate(t *testing.T) {
+	title, _ := NewLocalizedString(map[string]string{"en": "Story", "vi": "Câu chuyện"})
+	home, _ := NewLocalizedString(map[string]string{"en": "Home"})
+	name, _ := NewLocalizedString(map[string]string{"vi": "Tuấn"})
+	s := &story{
+		Title: title,
+		Menus: []menu{{Title: home}},
+		Author: &author{
+			Name:        name,
+			Phone:       "0123",
+			Translation: Translations{"fr": {"name": "Tuan", "phone": "+84 123"}},
+		},
+	}
+
+	before, _ := json.Marshal(s)
+	if string(before) != `{"title":{"en":"Story","vi":"Câu chuyện"},"menus":[{"title":{"en":"Home"}}],"author":{"name":{"vi":"Tuấn"},"phone":"0123","translation":{"fr":{"name":"Tuan","phone":"+84 123"}}},"extra":null}` {
+		t.Errorf("unresolved: %s", before)
+	}
+
+	Translate(s, NewFallbackChain("fr"))
+	after, _ := json.Marshal(s)
+	if string(after) != `{"title":"Câu chuyện","menus":[{"title":"Home"}],"author":{"name":"Tuan","phone":"+84 123","translation":{"fr":{"name":"Tuan","phone":"+84 123"}}},"extra":null}` {
+		t.Errorf("resolved: %s", after)
+	}
+}
+
+func TestLocalizedStringEncoding(t *testing.T) {
+	var m menu
+	if err := json.Unmarshal([]byte(`{"title": {"EN": "Home", "vi": ""}}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if texts := m.Title.Texts(); len(texts) != 1 || texts["en"] != "Home" {
+		t.Errorf("got %v", texts)
+	}
+	if err := json.Unmarshal([]byte(`{"title": "Home"}`), &m); err == nil {
+		t.Error("a plain text is accepted")
+	}
+
+	// Stored as a document of language to text, as the {en, vi} texts were before
+	raw, err := bson.Marshal(bson.M{"title": m.Title})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Title map[string]string `bson:"title"`
+	}
+	if err = bson.Unmarshal(raw, &doc); err != nil || doc.Title["en"] != "Home" {
+		t.Errorf("stored as %v: %v", doc.Title, err)
+	}
+
+	var decoded struct {
+		Title LocalizedString `bson:"title"`
+	}
+	if err = bson.Unmarshal(raw, &decoded); err != nil || decoded.Title.Text("en") != "Home" {
+		t.Errorf("decoded %v: %v", decoded.Title.Texts(), err)
+	}
+}
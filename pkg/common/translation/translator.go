@@ -1,11 +1,11 @@
 package translation
 
 import (
-	"backend-service/pkg/common/logger"
-	structtraversal2 "backend-service/pkg/marketplace/structtraversal"
+	"reflect"
+	"sort"
+	"strings"
 
-	"github.com/jinzhu/copier"
-	"github.com/mitchellh/mapstructure"
+	structtraversal2 "backend-service/pkg/marketplace/structtraversal"
 )
 
 type Translatable interface {
@@ -14,55 +14,72 @@ type Translatable interface {
 
 type Translations map[string]map[string]string
 
+var localizedStringType = reflect.TypeOf(LocalizedString{})
+
 // TranslateCollection is for translating all elements in a slice.
-func TranslateCollection(coll any, lang string) {
-	structtraversal2.TraverseSlice(coll, translateFieldCallback(lang))
+func TranslateCollection(coll any, chain FallbackChain) {
+	structtraversal2.TraverseSlice(coll, translateFieldCallback(chain))
 }
 
-// Translate is for translating content of an object.
-func Translate(obj any, lang string) {
-	structtraversal2.TraverseObject(obj, translateFieldCallback(lang))
+// Translate writes every LocalizedString reachable from obj in the best language of the chain.
+// The translations of Translatable objects in that language are applied to their string fields,
+// matched by their JSON name, before the LocalizedString fields are resolved.
+func Translate(obj any, chain FallbackChain) {
+	structtraversal2.TraverseObject(obj, translateFieldCallback(chain))
 }
 
-func translateFieldCallback(lang string) func(args ...any) {
+func translateFieldCallback(chain FallbackChain) func(args ...any) {
 	return func(args ...any) {
 		if len(args) == 0 {
 			return
 		}
 
-		field := args[0]
-		fieldVal, ok := field.(Translatable)
-
-		if !ok {
-			return
+		switch field := args[0].(type) {
+		case *LocalizedString:
+			field.Resolve(chain)
+		case Translatable:
+			translations := field.GetTranslations()
+			languages := make([]string, 0, len(translations))
+			for tag := range translations {
+				languages = append(languages, tag)
+			}
+			sort.Strings(languages)
+			if tag, ok := chain.Match(languages); ok {
+				setTranslation(field, tag, translations[tag])
+			}
 		}
+	}
+}
 
-		translation, ok := fieldVal.GetTranslations()[lang]
-		if !ok {
-			return
+// setTranslation writes the texts of the language to the string and LocalizedString fields of obj
+func setTranslation(obj any, tag string, texts map[string]string) {
+	val := reflect.ValueOf(obj)
+	if val.Kind() != reflect.Pointer || val.Elem().Kind() != reflect.Struct {
+		return
+	}
+	val = val.Elem()
+	for i := 0; i < val.NumField(); i++ {
+		text := texts[jsonName(val.Type().Field(i))]
+		field := val.Field(i)
+		if text == "" || !field.CanSet() {
+			continue
 		}
 
-		err := mapstructure.Decode(translation, fieldVal)
-		if err != nil {
-			logger.Errorw(
-				err.Error(),
-				"field", fieldVal,
-				"translation", translation,
-			)
-
-			return
+		switch {
+		case field.Type() == localizedStringType:
+			ls := field.Addr().Interface().(*LocalizedString)
+			_ = ls.Set(tag, text)
+		case field.Kind() == reflect.String:
+			field.SetString(text)
 		}
+	}
+}
 
-		if err := copier.CopyWithOption(
-			fieldVal,
-			translation,
-			copier.Option{IgnoreEmpty: true},
-		); err != nil {
-			logger.Errorw(
-				err.Error(),
-				"field", fieldVal,
-				"translation", translation,
-			)
-		}
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
 	}
+
+	return name
 }
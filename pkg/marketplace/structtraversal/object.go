@@ -5,30 +5,42 @@ import (
 )
 
 // TraverseObject for traverse through an Object tree and execute callback function on each node
-// Only pointer nodes will be call by the callback.
+// Only pointer nodes will be call by the callback, struct values held by the object are passed by their address.
 func TraverseObject(obj any, callback func(args ...any)) {
-	if reflect.TypeOf(obj).Kind() != reflect.Pointer {
+	if obj == nil || reflect.TypeOf(obj).Kind() != reflect.Pointer {
+		return
+	}
+
+	val := reflect.ValueOf(obj)
+	if val.IsNil() {
 		return
 	}
 
 	callback(obj)
 
-	val := reflect.ValueOf(obj).Elem()
-	typ := reflect.TypeOf(val.Interface())
+	val = val.Elem()
+	if val.Kind() != reflect.Struct {
+		return
+	}
 
-	for i := 0; i < typ.NumField(); i++ {
+	for i := 0; i < val.NumField(); i++ {
 		field := val.Field(i)
 		if !field.CanInterface() {
 			continue
 		}
 
-		if field.Kind() == reflect.Pointer {
+		switch field.Kind() {
+		case reflect.Pointer:
 			realType := field.Elem().Kind()
 
 			if realType == reflect.Struct || realType == reflect.Map {
-				TraverseObject(field.Elem().Interface(), callback)
+				TraverseObject(field.Interface(), callback)
+			}
+		case reflect.Struct:
+			if field.CanAddr() {
+				TraverseObject(field.Addr().Interface(), callback)
 			}
-		} else if field.Kind() == reflect.Slice {
+		case reflect.Slice:
 			TraverseSlice(field.Interface(), callback)
 		}
 	}
@@ -6,6 +6,10 @@ import (
 
 // TraverseSlice traverse through each slice element and try to Traverse through their structure.
 func TraverseSlice(sl any, callback func(args ...any)) {
+	if sl == nil {
+		return
+	}
+
 	val := reflect.ValueOf(sl)
 	typ := reflect.TypeOf(sl)
 
@@ -26,6 +30,8 @@ func TraverseSlice(sl any, callback func(args ...any)) {
 			if realType == reflect.Struct || realType == reflect.Map {
 				TraverseObject(elemReflection.Interface(), callback)
 			}
+		} else if elemReflection.Kind() == reflect.Struct && elemReflection.CanAddr() {
+			TraverseObject(elemReflection.Addr().Interface(), callback)
 		} else if elemReflection.Kind() == reflect.Slice {
 			TraverseSlice(elemReflection.Interface(), callback)
 		}